@@ -23,7 +23,7 @@ Configure(cx *conte.Xt, ctx *cli.Context) {
 	initParams(cx)
 	initWalletFile(cx)
 	initListeners(cx, ctx)
-	initLogLevel(cfg)
+	initLogLevel(cx, ctx)
 	// Don't add peers from the config file when in regression test mode.
 	if ((*cfg.Network)[0] == 'r') && len(*cfg.AddPeers) > 0 {
 		*cfg.AddPeers = nil
@@ -40,6 +40,7 @@ Configure(cx *conte.Xt, ctx *cli.Context) {
 	validateUsers(cfg)
 	configRPC(cfg, cx.ActiveNet)
 	validatePolicies(cfg, cx.StateCfg)
+	validatePrune(cx.StateCfg)
 	validateOnions(cfg)
 	validateMiningStuff(cfg, cx.StateCfg, cx.ActiveNet)
 	setDiallers(cfg, cx.StateCfg)
@@ -186,14 +186,10 @@ GetApp(cx *conte.Xt) (a *cli.App) {
 			apputil.NewCommand("kopach",
 				"standalone miner for clusters",
 				kopachHandle(cx),
-				apputil.SubCommands(),
+				apputil.SubCommands(
+					kopachWorkerCommand(cx),
+				),
 				"k"),
-			//apputil.NewCommand("worker",
-			//	"single thread parallelcoin miner controlled with binary IPC"+
-			//		" interface on stdin/stdout",
-			//	workerHandle(cx),
-			//	apputil.SubCommands(),
-			//),
 		},
 		Flags: []cli.Flag{
 			cli.StringFlag{
@@ -228,6 +224,35 @@ GetApp(cx *conte.Xt) (a *cli.App) {
 				EnvVar:      "POD_LOGLEVEL",
 				Destination: cx.Config.LogLevel,
 			},
+			apputil.String(
+				"loglevels",
+				"levels for packages as package=level separated by"+
+					" commas, packages are named by path like pkg/chain and"+
+					" the level is also used for the packages below it",
+				"",
+				&cx.StateCfg.LogLevels),
+			apputil.Bool(
+				"logfile",
+				"write the log as lines of json to a file in the log"+
+					" directory",
+				&cx.StateCfg.LogFile),
+			apputil.Int(
+				"logmaxsize",
+				"size in megabytes the log file is rotated at, 0 for no"+
+					" limit",
+				log.DefaultMaxSize/1024/1024,
+				&cx.StateCfg.LogMaxSize),
+			apputil.Duration(
+				"logmaxage",
+				"how long the log file is written to before it is rotated,"+
+					" 0 for no limit",
+				log.DefaultMaxAge,
+				&cx.StateCfg.LogMaxAge),
+			apputil.Int(
+				"logmaxfiles",
+				"number of rotated log files to keep, 0 keeps them all",
+				log.DefaultMaxFiles,
+				&cx.StateCfg.LogMaxFiles),
 			apputil.String(
 				"network, n",
 				"connect to mainnet/testnet/regtest/simnet",
@@ -433,8 +458,8 @@ GetApp(cx *conte.Xt) (a *cli.App) {
 				cx.Config.DbType),
 			apputil.String(
 				"profile",
-				"Enable HTTP profiling on given port -- NOTE port"+
-					" must be between 1024 and 65536",
+				"Enable HTTP profiling on given port on localhost -- NOTE"+
+					" port must be between 1024 and 65536",
 				"",
 				cx.Config.Profile),
 			apputil.String(
@@ -463,6 +488,11 @@ GetApp(cx *conte.Xt) (a *cli.App) {
 				"Do not require free or low-fee transactions to have"+
 					" high priority for relaying",
 				cx.Config.NoRelayPriority),
+			apputil.Bool(
+				"rejectreplacement",
+				"Reject transactions that replace transactions in the"+
+					" mempool signaling replaceability (BIP125)",
+				&cx.StateCfg.RejectReplacement),
 			apputil.Duration(
 				"trickleinterval",
 				"Minimum time between attempts to send new"+
@@ -517,11 +547,73 @@ GetApp(cx *conte.Xt) (a *cli.App) {
 					" addresses to use for generated blocks, at least one is "+
 					"required if generate or minerlistener are set",
 				cx.Config.MiningAddrs),
+			apputil.String(
+				"miningxpub",
+				"extended public key, or pkh(xpub/0/*) descriptor, to"+
+					" derive a new payment address from for each generated"+
+					" block instead of using miningaddr",
+				"",
+				&cx.StateCfg.MiningXpub),
 			apputil.String(
 				"minerpass",
 				"password to authorise sending work to a miner",
 				genPassword(),
 				cx.Config.MinerPass),
+			apputil.Bool(
+				"minercompat",
+				"also send and accept the legacy kopach message format,"+
+					" which is not protected against replay, while old"+
+					" workers are upgraded",
+				&cx.StateCfg.MinerCompat),
+			apputil.StringSlice(
+				"minerinterfaces",
+				"network interfaces the miner controller and kopach send"+
+					" and receive work on, all multicast capable interfaces"+
+					" are used if none are given",
+				&cx.StateCfg.MinerInterfaces),
+			apputil.Bool(
+				"minerremote",
+				"let kopach workers outside of the local network connect to"+
+					" the miner controller address over TCP or unicast UDP",
+				&cx.StateCfg.MinerRemote),
+			apputil.String(
+				"minerconnect",
+				"address of a miner controller outside of the local network"+
+					" for kopach to connect to, as tcp://host:port or"+
+					" udp://host:port, work is received by multicast if empty",
+				"",
+				&cx.StateCfg.MinerConnect),
+			apputil.StringSlice(
+				"mineralgos",
+				"algorithms kopach workers mine, as name or name:weight"+
+					" where the weight is the share of time given to it,"+
+					" all algorithms are mined evenly if none are given",
+				&cx.StateCfg.MinerAlgos),
+			apputil.Bool(
+				"minerautotune",
+				"benchmark each algorithm when kopach starts and give"+
+					" them time by the reward they can be expected to earn"+
+					" per second at the current difficulty",
+				&cx.StateCfg.MinerAutoTune),
+			apputil.String(
+				"stratumlistener",
+				"address to listen on for Stratum v1 miners, which choose"+
+					" their algorithm with a password of the form"+
+					" algo=<name>, disabled if empty",
+				"",
+				&cx.StateCfg.StratumListener),
+			apputil.Float64(
+				"stratumdifficulty",
+				"starting share difficulty for Stratum miners, it is"+
+					" adjusted for each connection as shares are found",
+				1,
+				&cx.StateCfg.StratumDifficulty),
+			apputil.String(
+				"metricslistener",
+				"address to serve Prometheus metrics of the node on at"+
+					" /metrics, disabled if empty",
+				"",
+				&cx.StateCfg.MetricsListener),
 			apputil.Int(
 				"blockminsize",
 				"Minimum block size in bytes to be used when"+
@@ -586,6 +678,13 @@ GetApp(cx *conte.Xt) (a *cli.App) {
 					" makes the searchrawtransactions RPC available",
 				cx.Config.AddrIndex,
 			),
+			apputil.Int(
+				"prune",
+				"Delete the oldest blocks to keep the stored blocks under"+
+					" this many MiB, at least 550, 0 disables pruning;"+
+					" incompatible with the transaction and address indexes",
+				0,
+				&cx.StateCfg.Prune),
 			apputil.Bool(
 				"relaynonstd",
 				"Relay non-standard transactions regardless of the default"+
@@ -1,12 +1,19 @@
 package app
 
 import (
-	"github.com/p9c/pod/cmd/kopach"
-	"github.com/p9c/pod/pkg/log"
-	"github.com/p9c/cli"
+	"net/rpc"
 	"os"
 
+	"github.com/p9c/cli"
+
+	"github.com/p9c/pod/app/apputil"
+	"github.com/p9c/pod/cmd/kopach"
+	"github.com/p9c/pod/cmd/kopach/worker"
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/chain/fork"
 	"github.com/p9c/pod/pkg/conte"
+	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/sem"
 	"github.com/p9c/pod/pkg/util/interrupt"
 )
 
@@ -25,3 +32,60 @@ func kopachHandle(cx *conte.Xt) func(c *cli.Context) (err error) {
 		return
 	}
 }
+
+// kopachWorkerCommand is the subcommand kopach runs its workers with, it is
+// hidden as it is only useful when started by kopach
+func kopachWorkerCommand(cx *conte.Xt) cli.Command {
+	command := apputil.NewCommand(kopach.WorkerCommand,
+		"single thread parallelcoin miner controlled with binary IPC"+
+			" interface on stdin/stdout",
+		kopachWorkerHandle(cx),
+		apputil.SubCommands(),
+	)
+	command.Hidden = true
+	return command
+}
+
+// kopachWorkerHandle runs a single worker that is controlled by the kopach
+// that started it over stdin and stdout. The configuration is not touched,
+// the only parameter is the name of the network, as this does not change
+// during the lifecycle of the miner worker and is required to get the correct
+// hash functions due to differing hard fork heights.
+func kopachWorkerHandle(cx *conte.Xt) func(c *cli.Context) (err error) {
+	return func(c *cli.Context) (err error) {
+		if c.NArg() > 0 && c.Args().First() != netparams.MainNetParams.Name {
+			fork.IsTestnet = true
+		}
+		log.L.SetLevel(*cx.Config.LogLevel, true)
+		if cx.StateCfg.LogLevels != "" {
+			if err = log.SetLevels(cx.StateCfg.LogLevels); err != nil {
+				log.ERROR(err)
+			}
+		}
+		log.DEBUG("miner worker starting")
+		w, conn := worker.New(sem.New(1))
+		stop := func() {
+			select {
+			case <-w.Quit:
+			default:
+				close(w.Quit)
+			}
+		}
+		interrupt.AddHandler(stop)
+		err = rpc.Register(w)
+		if err != nil {
+			log.ERROR(err)
+			return
+		}
+		log.DEBUG("starting up worker IPC")
+		go func() {
+			rpc.ServeConn(conn)
+			// kopach has gone away so there is nobody to work for
+			log.DEBUG("worker IPC connection closed")
+			stop()
+		}()
+		<-w.Quit
+		log.DEBUG("miner worker finished")
+		return
+	}
+}
@@ -23,12 +23,14 @@ import (
 	blockchain "github.com/p9c/pod/pkg/chain"
 	"github.com/p9c/pod/pkg/chain/config/netparams"
 	"github.com/p9c/pod/pkg/chain/fork"
+	"github.com/p9c/pod/pkg/chain/mining"
 	"github.com/p9c/pod/pkg/conte"
 	"github.com/p9c/pod/pkg/log"
 	"github.com/p9c/pod/pkg/normalize"
 	"github.com/p9c/pod/pkg/peer/connmgr"
 	"github.com/p9c/pod/pkg/pod"
 	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/interrupt"
 )
 
 var funcName = "loadConfig"
@@ -209,7 +211,9 @@ func initTLSStuffs(cfg *pod.Config, st *state.Config) {
 	}
 }
 
-func initLogLevel(cfg *pod.Config) {
+func initLogLevel(cx *conte.Xt, ctx *cli.Context) {
+	cfg := cx.Config
+	st := cx.StateCfg
 	loglevel := *cfg.LogLevel
 	switch loglevel {
 	case "trace", "debug", "info", "warn", "error", "fatal", "off":
@@ -223,6 +227,25 @@ func initLogLevel(cfg *pod.Config) {
 		color = false
 	}
 	log.L.SetLevel(*cfg.LogLevel, color)
+	if st.LogLevels != "" {
+		if err := log.SetLevels(st.LogLevels); err != nil {
+			log.ERROR("ignoring package log levels:", err)
+		}
+	}
+	if st.LogFile {
+		// each command has its own file as they may run at the same time
+		name := "pod"
+		if ctx != nil && ctx.Command.Name != "" {
+			name += "-" + ctx.Command.Name
+		}
+		err := log.L.SetLogPaths(*cfg.LogDir, name+".jsonl",
+			int64(st.LogMaxSize)*1024*1024, st.LogMaxAge, st.LogMaxFiles)
+		if err != nil {
+			log.ERROR(err)
+			return
+		}
+		interrupt.AddHandler(log.L.Close)
+	}
 }
 
 func normalizeAddresses(cfg *pod.Config) {
@@ -515,6 +538,24 @@ func validatePolicies(cfg *pod.Config, stateConfig *state.Config) {
 		os.Exit(1)
 	}
 }
+func validatePrune(st *state.Config) {
+	// Don't allow a prune target that can't hold the blocks which are never
+	// pruned.
+	log.TRACE("validating prune target")
+	minPrune := blockchain.MinPruneTarget / (1024 * 1024)
+	if st.Prune < 0 {
+		err := fmt.Errorf("%s: The prune option may not be negative -- "+
+			"parsed [%d]", funcName, st.Prune)
+		log.ERROR(funcName, err)
+		st.Prune = 0
+	} else if st.Prune > 0 && st.Prune < minPrune {
+		err := fmt.Errorf("%s: The prune option may not be less than %d "+
+			"MiB -- parsed [%d]", funcName, minPrune, st.Prune)
+		log.ERROR(funcName, err)
+		st.Prune = minPrune
+	}
+}
+
 func validateOnions(cfg *pod.Config) {
 	// --onionproxy and not --onion are contradictory (TODO: this is kinda
 	//  stupid hm? switch *and* toggle by presence of flag value, one should be
@@ -563,8 +604,19 @@ func validateMiningStuff(cfg *pod.Config, state *state.Config,
 		}
 		state.ActiveMiningAddrs = append(state.ActiveMiningAddrs, addr)
 	}
+	// A mining extended public key replaces the list of addresses, check it
+	// can be derived from so the node doesn't fail when it starts mining.
+	if state.MiningXpub != "" {
+		if _, err := mining.ParsePayToXpub(state.MiningXpub, params); err != nil {
+			str := "%s: mining xpub '%s' is invalid: %v"
+			err := fmt.Errorf(str, funcName, state.MiningXpub, err)
+			fmt.Fprintln(os.Stderr, err)
+			state.MiningXpub = ""
+		}
+	}
 	// Ensure there is at least one mining address when the generate flag is set.
-	if (*cfg.Generate) && len(state.ActiveMiningAddrs) == 0 {
+	if (*cfg.Generate) && len(state.ActiveMiningAddrs) == 0 &&
+		state.MiningXpub == "" {
 		log.ERROR("the generate flag is set, " +
 			"but there are no mining addresses specified ")
 		log.SPEW(cfg)
@@ -53,7 +53,7 @@ func shellHandle(cx *conte.Xt) func(c *cli.Context) (err error) {
 		if !*cx.Config.WalletOff {
 			go func() {
 				err = walletmain.Main(cx.Config, cx.StateCfg,
-					cx.ActiveNet, walletChan, kill, &wg)
+					cx.ActiveNet, cx.RPCServer, walletChan, kill, &wg)
 				if err != nil {
 					log.Println("error running wallet:", err)
 				}
@@ -33,7 +33,7 @@ func walletHandle(cx *conte.Xt) func(c *cli.Context) (err error) {
 		cx.WalletKill = make(chan struct{})
 		go func() {
 			err = walletmain.Main(cx.Config, cx.StateCfg,
-				cx.ActiveNet, walletChan, cx.WalletKill, &wg)
+				cx.ActiveNet, nil, walletChan, cx.WalletKill, &wg)
 			if err != nil {
 				log.ERROR("failed to start up wallet", err)
 			}
@@ -24,7 +24,7 @@ func Services(cx *conte.Xt) error {
 			log.INFO("starting wallet")
 			//utils.GetBiosMessage(view, "starting wallet")
 			err = walletmain.Main(cx.Config, cx.StateCfg,
-				cx.ActiveNet, walletChan, cx.WalletKill, &wg)
+				cx.ActiveNet, cx.RPCServer, walletChan, cx.WalletKill, &wg)
 			if err != nil {
 				fmt.Println("error running wallet:", err)
 				os.Exit(1)
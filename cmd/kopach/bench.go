@@ -0,0 +1,144 @@
+package kopach
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	kopachworker "github.com/p9c/pod/cmd/kopach/worker"
+	"github.com/p9c/pod/pkg/chain/fork"
+	"github.com/p9c/pod/pkg/log"
+)
+
+const (
+	// BenchFile is the name of the file in the data directory the measured
+	// time per hash of each algorithm is written to
+	BenchFile = "kopachbench.json"
+	// BenchDuration is how long each algorithm is hashed for when
+	// benchmarking
+	BenchDuration = time.Second
+	// benchMinOps is the fewest hashes a benchmark is taken from, so the
+	// slowest algorithms are not measured by a single hash
+	benchMinOps = 3
+)
+
+// Bench measures how many nanoseconds one thread takes to compute a hash with
+// each of the algorithms of the latest hard fork
+func Bench() (nsPerOp map[string]int64) {
+	nsPerOp = make(map[string]int64)
+	// hashes below the latest hard fork are not repeated
+	height := fork.List[len(fork.List)-1].ActivationHeight
+	header := make([]byte, 80)
+	rand.Read(header)
+	var names []string
+	for name := range fork.List[len(fork.List)-1].Algos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var ops int64
+		start := time.Now()
+		for ops < benchMinOps || time.Since(start) < BenchDuration {
+			// change the nonce so no hash function can skip the work
+			header[76]++
+			_ = fork.Hash(header, name, height)
+			ops++
+		}
+		nsPerOp[name] = int64(time.Since(start)) / ops
+		log.INFO("benchmark", name, nsPerOp[name], "ns/op")
+	}
+	return
+}
+
+// WriteBench writes the benchmark results to a file
+func WriteBench(path string, nsPerOp map[string]int64) (err error) {
+	b, err := json.MarshalIndent(nsPerOp, "", "  ")
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	if err = ioutil.WriteFile(path, b, 0600); err != nil {
+		log.ERROR(err)
+	}
+	return
+}
+
+// ReadBench reads the benchmark results from a file
+func ReadBench(path string) (nsPerOp map[string]int64, err error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(b, &nsPerOp)
+	return
+}
+
+// ParseAlgoWeights reads the algorithms to mine and their weights given as
+// name or name:weight, an algorithm without a weight gets 1
+func ParseAlgoWeights(algos []string) (weights map[string]float64,
+	err error) {
+	for _, a := range algos {
+		name, weight := a, 1.0
+		if i := strings.LastIndex(a, ":"); i >= 0 {
+			name = a[:i]
+			if weight, err = strconv.ParseFloat(a[i+1:], 64); err != nil ||
+				weight <= 0 {
+				return nil, fmt.Errorf("invalid weight for algorithm %q", a)
+			}
+		}
+		if !knownAlgo(name) {
+			return nil, fmt.Errorf("unknown algorithm %q", name)
+		}
+		if weights == nil {
+			weights = make(map[string]float64)
+		}
+		weights[name] = weight
+	}
+	return
+}
+
+func knownAlgo(name string) bool {
+	for i := range fork.List {
+		if _, ok := fork.List[i].Algos[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// getSchedule returns the algorithms the workers mine from the configuration,
+// benchmarking them first if auto tuning is enabled
+func (w *Worker) getSchedule() (schedule *kopachworker.Schedule, err error) {
+	schedule = &kopachworker.Schedule{AutoTune: w.cx.StateCfg.MinerAutoTune}
+	schedule.Weights, err = ParseAlgoWeights(w.cx.StateCfg.MinerAlgos)
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	path := filepath.Join(*w.cx.Config.DataDir, BenchFile)
+	if schedule.AutoTune {
+		log.INFO("benchmarking algorithms for auto tuning")
+		schedule.NSperOp = Bench()
+		if err = os.MkdirAll(*w.cx.Config.DataDir, 0700); err != nil {
+			log.ERROR(err)
+			return
+		}
+		err = WriteBench(path, schedule.NSperOp)
+		return
+	}
+	if len(schedule.Weights) > 0 {
+		// measurements from an earlier auto tune make the shares of time
+		// more accurate than the values in the fork tables
+		if nsPerOp, e := ReadBench(path); e == nil {
+			schedule.NSperOp = nsPerOp
+		}
+	}
+	return
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/rpc"
 
+	"github.com/p9c/pod/cmd/kopach/worker"
 	"github.com/p9c/pod/pkg/controller/job"
 	"github.com/p9c/pod/pkg/log"
 )
@@ -82,3 +83,35 @@ func (c *Client) SendPass(pass string) (err error) {
 	}
 	return
 }
+
+// SetDispatch tells the worker where to send solutions instead of the
+// controller addresses in the jobs
+func (c *Client) SetDispatch(addresses []string) (err error) {
+	log.DEBUG("sending dispatch addresses")
+	var reply bool
+	err = c.Call("Worker.SetDispatch", addresses, &reply)
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	if reply != true {
+		err = errors.New("set dispatch command not acknowledged")
+	}
+	return
+}
+
+// SetSchedule tells the worker which algorithms to mine and how to divide its
+// time between them
+func (c *Client) SetSchedule(schedule *worker.Schedule) (err error) {
+	log.DEBUG("sending algorithm schedule")
+	var reply bool
+	err = c.Call("Worker.SetSchedule", schedule, &reply)
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	if reply != true {
+		err = errors.New("set schedule command not acknowledged")
+	}
+	return
+}
@@ -2,31 +2,49 @@ package kopach
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"os"
 	"sync"
 	"time"
 
 	"go.uber.org/atomic"
 
 	"github.com/p9c/pod/cmd/kopach/client"
+	kopachworker "github.com/p9c/pod/cmd/kopach/worker"
 	"github.com/p9c/pod/pkg/conte"
 	"github.com/p9c/pod/pkg/controller"
 	"github.com/p9c/pod/pkg/controller/job"
 	"github.com/p9c/pod/pkg/controller/pause"
+	"github.com/p9c/pod/pkg/controller/sol"
+	"github.com/p9c/pod/pkg/controller/stats"
 	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/routeable"
 	"github.com/p9c/pod/pkg/stdconn/worker"
 	"github.com/p9c/pod/pkg/transport"
 )
 
+const (
+	// WorkerCommand is the name of the kopach subcommand workers are run
+	// with
+	WorkerCommand = "worker"
+	// RestartDelay is how long after a worker process stops it is started
+	// again, so one that fails at startup doesn't spin
+	RestartDelay = time.Second
+)
+
 type Worker struct {
 	active        *atomic.Bool
 	conn          *transport.Connection
 	ctx           context.Context
 	cx            *conte.Xt
 	mx            *sync.Mutex
+	quit          chan struct{}
 	sendAddresses []*net.UDPAddr
 	workers       []*client.Client
+	dispatch      []string
+	schedule      *kopachworker.Schedule
 	firstSender   string
 	lastSent      time.Time
 }
@@ -34,41 +52,55 @@ type Worker struct {
 func Main(cx *conte.Xt, quit chan struct{}) {
 	log.DEBUG("miner controller starting")
 	ctx, cancel := context.WithCancel(context.Background())
-	conn, err := transport.NewConnection("", controller.UDP4MulticastAddress,
-		*cx.Config.MinerPass, controller.MaxDatagramSize, ctx, true)
+	var conn *transport.Connection
+	var err error
+	if cx.StateCfg.MinerConnect != "" {
+		conn, err = transport.Dial(cx.StateCfg.MinerConnect,
+			*cx.Config.MinerPass, controller.MaxDatagramSize, ctx,
+			cx.StateCfg.MinerCompat)
+	} else {
+		conn, err = transport.NewConnection(nil, controller.MulticastAddresses,
+			*cx.Config.MinerPass, controller.MaxDatagramSize, ctx,
+			routeable.GetMulticastInterfaces(cx.StateCfg.MinerInterfaces...),
+			cx.StateCfg.MinerCompat)
+	}
 	if err != nil {
 		log.ERROR(err)
 		cancel()
 		return
 	}
-	var workers []*client.Client
-	// start up the workers
-	for i := 0; i < *cx.Config.GenThreads; i++ {
-		// TODO: this needs to be made into a subcommand
-		log.DEBUG("starting worker", i)
-		cmd := worker.Spawn("go", "run", "-tags", "headless",
-			"cmd/kopach/kopach_worker/main.go",
-			cx.ActiveNet.Name)
-		workers = append(workers, client.New(cmd.StdConn))
-	}
 	w := &Worker{
 		conn:          conn,
 		active:        &atomic.Bool{},
 		ctx:           ctx,
 		cx:            cx,
 		mx:            &sync.Mutex{},
+		quit:          quit,
 		sendAddresses: []*net.UDPAddr{},
-		workers:       workers,
+		workers:       make([]*client.Client, *cx.Config.GenThreads),
 		lastSent:      time.Now(),
 	}
 	w.active.Store(false)
-	for i := range w.workers {
-		log.DEBUG("sending pass to worker", i)
-		err := w.workers[i].SendPass(*cx.Config.MinerPass)
+	w.schedule, err = w.getSchedule()
+	if err != nil {
+		log.ERROR(err)
+		cancel()
+		return
+	}
+	if cx.StateCfg.MinerConnect != "" {
+		// the workers can't reach a controller outside of the lan so their
+		// solutions are relayed over the session with it
+		err = w.relaySolutions()
 		if err != nil {
 			log.ERROR(err)
+			cancel()
+			return
 		}
 	}
+	// start up the workers
+	for i := range w.workers {
+		go w.supervise(i)
+	}
 	err = w.conn.Listen(handlers, w, &w.lastSent, &w.firstSender)
 	if err != nil {
 		log.ERROR(err)
@@ -96,27 +128,170 @@ func Main(cx *conte.Xt, quit chan struct{}) {
 					w.mx.Lock()
 					w.firstSender = ""
 					w.mx.Unlock()
-					// pause the workers
-					for i := range w.workers {
-						log.DEBUG("sending pause to worker", i)
-						err := w.workers[i].Pause()
-						if err != nil {
-							log.ERROR(err)
-						}
-					}
+					w.pause()
 				}
 			case <-quit:
 			}
 		}
 	}()
-	log.DEBUG("listening on", controller.UDP4MulticastAddress)
+	if cx.StateCfg.MinerConnect != "" {
+		log.DEBUG("receiving work from", cx.StateCfg.MinerConnect)
+	} else {
+		log.DEBUG("listening on", controller.MulticastAddresses)
+	}
 	<-quit
+	cancel()
 	log.INFO("kopach shutting down")
 }
 
+// startWorker runs this executable again with the worker subcommand to start
+// the worker for a slot and gives it what it needs to send solutions
+func (w *Worker) startWorker(i int) (cmd *worker.Worker, err error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return
+	}
+	log.DEBUG("starting worker", i)
+	cmd = worker.Spawn(exe, "--loglevel", *w.cx.Config.LogLevel,
+		"--loglevels", log.GetLevels(), "kopach", WorkerCommand,
+		w.cx.ActiveNet.Name)
+	if cmd == nil {
+		err = errors.New("could not start worker")
+		return
+	}
+	c := client.New(cmd.StdConn)
+	log.DEBUG("sending pass to worker", i)
+	if err = c.SendPass(*w.cx.Config.MinerPass); err == nil {
+		w.mx.Lock()
+		dispatch := w.dispatch
+		w.mx.Unlock()
+		if len(dispatch) > 0 {
+			log.DEBUG("sending dispatch addresses to worker", i)
+			err = c.SetDispatch(dispatch)
+		}
+	}
+	if err == nil && !w.schedule.Empty() {
+		log.DEBUG("sending algorithm schedule to worker", i)
+		err = c.SetSchedule(w.schedule)
+	}
+	if err != nil {
+		if e := cmd.Kill(); e != nil {
+			log.ERROR(e)
+		}
+		// collect the process so it doesn't linger
+		_ = cmd.Wait()
+		return
+	}
+	w.mx.Lock()
+	w.workers[i] = c
+	w.mx.Unlock()
+	return
+}
+
+// supervise starts the worker for a slot and starts it again whenever its
+// process stops, until kopach shuts down
+func (w *Worker) supervise(i int) {
+	for {
+		cmd, err := w.startWorker(i)
+		if err != nil {
+			log.ERROR("could not start worker", i, err)
+		} else {
+			err = cmd.Wait()
+			w.mx.Lock()
+			w.workers[i] = nil
+			w.mx.Unlock()
+		}
+		select {
+		case <-time.After(RestartDelay):
+		case <-w.ctx.Done():
+			return
+		case <-w.quit:
+			return
+		}
+		log.WARN("worker", i, "stopped", err, "restarting it")
+	}
+}
+
+// clients returns the workers that are running
+func (w *Worker) clients() (out []*client.Client) {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	for i := range w.workers {
+		if w.workers[i] != nil {
+			out = append(out, w.workers[i])
+		}
+	}
+	return
+}
+
+// pause sends a pause to all of the workers
+func (w *Worker) pause() {
+	for i, c := range w.clients() {
+		log.DEBUG("sending pause to worker", i)
+		err := c.Pause()
+		if err != nil {
+			log.ERROR(err)
+		}
+	}
+}
+
+// relaySolutions starts a listener on the loopback interface that the workers
+// send their solutions and statistics to, which are sent on to the controller
+func (w *Worker) relaySolutions() (err error) {
+	relay, err := transport.NewConnection(nil, []string{"127.0.0.1:0"},
+		*w.cx.Config.MinerPass, controller.MaxDatagramSize, w.ctx, nil, false)
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	err = relay.Listen(relayHandlers, w, nil, nil)
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	var addresses []string
+	for _, addr := range relay.LocalAddrs() {
+		addresses = append(addresses, addr.String())
+	}
+	// the workers are sent these when they start
+	w.mx.Lock()
+	w.dispatch = addresses
+	w.mx.Unlock()
+	return
+}
+
+// relayHandlers are the handlers for the messages the workers send to the
+// relay
+var relayHandlers = transport.HandleFunc{
+	string(sol.SolutionMagic): func(ctx interface{},
+		src net.Addr) func(b []byte) (err error) {
+		return func(b []byte) (err error) {
+			log.DEBUG("relaying solution to controller")
+			w := ctx.(*Worker)
+			err = w.conn.Send(b, sol.SolutionMagic)
+			if err != nil {
+				log.ERROR(err)
+			}
+			return
+		}
+	},
+	string(stats.StatsMagic): func(ctx interface{},
+		src net.Addr) func(b []byte) (err error) {
+		return func(b []byte) (err error) {
+			w := ctx.(*Worker)
+			err = w.conn.Send(b, stats.StatsMagic)
+			if err != nil {
+				log.ERROR(err)
+			}
+			return
+		}
+	},
+}
+
 // these are the handlers for specific message types.
 var handlers = transport.HandleFunc{
-	string(job.WorkMagic): func(ctx interface{}) func(b []byte) (err error) {
+	string(job.WorkMagic): func(ctx interface{},
+		src net.Addr) func(b []byte) (err error) {
 		return func(b []byte) (err error) {
 			log.DEBUG("received job")
 			w := ctx.(*Worker)
@@ -138,9 +313,9 @@ var handlers = transport.HandleFunc{
 				w.lastSent = time.Now()
 				w.mx.Unlock()
 			}
-			for i := range w.workers {
+			for i, c := range w.clients() {
 				log.DEBUG("sending job to worker", i)
-				err := w.workers[i].NewJob(&j)
+				err := c.NewJob(&j)
 				if err != nil {
 					log.ERROR(err)
 				}
@@ -148,17 +323,12 @@ var handlers = transport.HandleFunc{
 			return
 		}
 	},
-	string(pause.PauseMagic): func(ctx interface{}) func(b []byte) (err error) {
+	string(pause.PauseMagic): func(ctx interface{},
+		src net.Addr) func(b []byte) (err error) {
 		return func(b []byte) (err error) {
 			log.DEBUG("received pause")
 			w := ctx.(*Worker)
-			for i := range w.workers {
-				log.DEBUG("sending pause to worker", i)
-				err := w.workers[i].Pause()
-				if err != nil {
-					log.ERROR(err)
-				}
-			}
+			w.pause()
 			w.mx.Lock()
 			// clear the firstSender
 			w.firstSender = ""
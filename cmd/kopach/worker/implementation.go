@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"net"
 	"os"
+	"sync"
 	"time"
 
 	blockchain "github.com/p9c/pod/pkg/chain"
@@ -16,6 +17,7 @@ import (
 	"github.com/p9c/pod/pkg/controller"
 	"github.com/p9c/pod/pkg/controller/job"
 	"github.com/p9c/pod/pkg/controller/sol"
+	"github.com/p9c/pod/pkg/controller/stats"
 	"github.com/p9c/pod/pkg/log"
 	"github.com/p9c/pod/pkg/sem"
 	"github.com/p9c/pod/pkg/stdconn"
@@ -29,16 +31,27 @@ type Worker struct {
 	sem          sem.T
 	conn         net.Conn
 	dispatchConn *transport.Connection
-	ciph         cipher.AEAD
-	Quit         chan struct{}
-	run          sem.T
-	block        *util.Block
-	msgBlock     *wire.MsgBlock
-	bitses       map[int32]uint32
-	roller       *Counter
-	startNonce   uint32
-	startChan    chan struct{}
-	stopChan     chan struct{}
+	// mx protects dispatchTo and schedule, which are set by RPCs from
+	// kopach while jobs are arriving
+	mx         sync.Mutex
+	dispatchTo []string
+	schedule   *Schedule
+	ciph       cipher.AEAD
+	Quit       chan struct{}
+	run        sem.T
+	block      *util.Block
+	msgBlock   *wire.MsgBlock
+	bitses     map[int32]uint32
+	roller     *Counter
+	startNonce uint32
+	startChan  chan struct{}
+	stopChan   chan struct{}
+	// statistics sent to the controller every stats.Interval, they are
+	// only touched by the work loop
+	id        int32
+	hashes    map[int32]uint32
+	solutions map[int32]uint32
+	lastStats time.Time
 	//running    uint32
 }
 
@@ -51,6 +64,11 @@ type Counter struct {
 	C             int
 	Algos         []int32
 	RoundsPerAlgo int
+	// Weights is the share of hashes for each of Algos, they are rolled
+	// through evenly if it is empty
+	Weights []float64
+	credit  []float64
+	current int
 }
 
 // NewCounter returns an initialized algorithm rolling counter that ensures
@@ -68,11 +86,51 @@ func NewCounter(roundsPerAlgo int) (c *Counter) {
 	return
 }
 
+// SetAlgos changes the algorithm versions the counter rolls through and their
+// share of the rounds, which are even if weights is nil
+func (c *Counter) SetAlgos(algos []int32, weights []float64) {
+	c.Algos = algos
+	c.Weights = nil
+	c.credit = make([]float64, len(algos))
+	c.current = 0
+	if len(weights) != len(algos) {
+		return
+	}
+	var total float64
+	for i := range weights {
+		total += weights[i]
+	}
+	if total <= 0 {
+		return
+	}
+	c.Weights = make([]float64, len(weights))
+	for i := range weights {
+		c.Weights[i] = weights[i] / total
+	}
+}
+
 // GetAlgoVer returns the next algo version based on the current configuration
 func (c *Counter) GetAlgoVer() (ver int32) {
-	// the formula below rolls through versions with blocks roundsPerAlgo
-	// long for each algorithm by its index
-	ver = c.Algos[(c.C/c.RoundsPerAlgo)%len(c.Algos)]
+	if len(c.Weights) != len(c.Algos) {
+		// the formula below rolls through versions with blocks
+		// roundsPerAlgo long for each algorithm by its index
+		ver = c.Algos[(c.C/c.RoundsPerAlgo)%len(c.Algos)]
+		c.C++
+		return
+	}
+	// each block of rounds goes to the algorithm that is furthest behind
+	// its share, which spreads them out as evenly as the weights allow
+	if c.C%c.RoundsPerAlgo == 0 {
+		c.current = 0
+		for i := range c.credit {
+			c.credit[i] += c.Weights[i]
+			if c.credit[i] > c.credit[c.current] {
+				c.current = i
+			}
+		}
+		c.credit[c.current]--
+	}
+	ver = c.Algos[c.current]
 	c.C++
 	return
 }
@@ -97,6 +155,10 @@ func NewWithConnAndSemaphore(
 		roller:    NewCounter(RoundsPerAlgo),
 		startChan: make(chan struct{}),
 		stopChan:  make(chan struct{}),
+		id:        rand.Int31(),
+		hashes:    make(map[int32]uint32),
+		solutions: make(map[int32]uint32),
+		lastStats: time.Now(),
 	}
 	// with this we can report cumulative hash counts as well as using it to
 	// distribute algorithms evenly
@@ -134,8 +196,10 @@ func NewWithConnAndSemaphore(
 					// work
 					nH := w.block.Height()
 					hash := w.msgBlock.Header.BlockHashWithAlgos(nH)
+					w.hashes[w.msgBlock.Header.Version]++
 					bigHash := blockchain.HashToBig(&hash)
 					if bigHash.Cmp(fork.CompactToBig(w.msgBlock.Header.Bits)) <= 0 {
+						w.solutions[w.msgBlock.Header.Version]++
 						log.WARN("solution found", hash.String(),
 							fork.List[fork.GetCurrent(w.block.Height())].
 								AlgoVers[w.msgBlock.Header.Version],
@@ -161,7 +225,9 @@ func NewWithConnAndSemaphore(
 						total := w.roller.C - int(w.startNonce)
 						_, _ = fmt.Fprintf(os.Stderr,
 							"\r %9d hash/s        \r", total/since)
-
+						if time.Since(w.lastStats) >= stats.Interval {
+							w.sendStats()
+						}
 					}
 				}
 			}
@@ -172,6 +238,19 @@ func NewWithConnAndSemaphore(
 	return w
 }
 
+// sendStats sends the hashes and solutions counted since the last time to
+// the controller and starts counting again
+func (w *Worker) sendStats() {
+	interval := time.Since(w.lastStats)
+	sC := stats.GetStatsContainer(w.id, interval, w.hashes, w.solutions)
+	w.hashes = make(map[int32]uint32)
+	w.solutions = make(map[int32]uint32)
+	w.lastStats = time.Now()
+	if err := w.dispatchConn.Send(sC.Data, stats.StatsMagic); err != nil {
+		log.ERROR(err)
+	}
+}
+
 // New initialises the state for a worker,
 // loading the work function handler that runs a round of processing between
 // checking quit signal and work semaphore
@@ -196,13 +275,17 @@ func (w *Worker) NewJob(job *job.Container, reply *bool) (err error) {
 	// connection the existing dispatch connection is nilled and this
 	// will run. If there is no controllers on the network,
 	// the worker pauses
-	ips := job.GetIPs()
-	var addresses []string
-	for i := range ips {
-		// generally there is only one but if a server had two interfaces
-		// to different lans it would send both
-		addresses = append(addresses, ips[i].String()+":"+
-			fmt.Sprint(job.GetControllerListenerPort()))
+	w.mx.Lock()
+	addresses := append([]string(nil), w.dispatchTo...)
+	w.mx.Unlock()
+	if len(addresses) < 1 {
+		ips := job.GetIPs()
+		for i := range ips {
+			// generally there is only one but if a server had two
+			// interfaces to different lans it would send both
+			addresses = append(addresses, net.JoinHostPort(ips[i].String(),
+				fmt.Sprint(job.GetControllerListenerPort())))
+		}
 	}
 	err = w.dispatchConn.SetSendConn(addresses...)
 	if err != nil {
@@ -215,11 +298,11 @@ func (w *Worker) NewJob(job *job.Container, reply *bool) (err error) {
 	*reply = true
 	w.bitses = job.GetBitses()
 	newHeight := job.GetNewHeight()
-	w.roller.Algos = []int32{}
-	for i := range w.bitses {
-		// we don't need to know net params if version numbers come with jobs
-		w.roller.Algos = append(w.roller.Algos, i)
-	}
+	// we don't need to know net params if version numbers come with jobs
+	w.mx.Lock()
+	schedule := w.schedule
+	w.mx.Unlock()
+	w.roller.SetAlgos(schedule.Algos(w.bitses, newHeight))
 	w.block.SetHeight(newHeight)
 	w.msgBlock.Header.PrevBlock = *job.GetPrevBlockHash()
 	// TODO: ensure worker time sync - ntp? time wrapper with skew adjustment
@@ -270,7 +353,8 @@ func (w *Worker) Stop(_ int, reply *bool) (err error) {
 // pod) configuration to allow workers to dispatch their solutions
 func (w *Worker) SendPass(pass string, reply *bool) (err error) {
 	log.DEBUG("receiving dispatch password")
-	conn, err := transport.NewConnection("", "", pass, controller.MaxDatagramSize, nil, false)
+	conn, err := transport.NewConnection(nil, nil, pass,
+		controller.MaxDatagramSize, nil, nil, false)
 	if err != nil {
 		log.ERROR(err)
 	}
@@ -279,6 +363,30 @@ func (w *Worker) SendPass(pass string, reply *bool) (err error) {
 	return
 }
 
+// SetDispatch sets the addresses solutions are sent to in place of the
+// controller addresses in the jobs, for when kopach relays them to a
+// controller that the worker can't reach itself
+func (w *Worker) SetDispatch(addresses []string, reply *bool) (err error) {
+	log.DEBUG("setting dispatch addresses", addresses)
+	w.mx.Lock()
+	w.dispatchTo = addresses
+	w.mx.Unlock()
+	*reply = true
+	return
+}
+
+// SetSchedule sets which algorithms the worker mines and how much of its
+// time goes to each, it takes effect from the next job
+func (w *Worker) SetSchedule(schedule *Schedule, reply *bool) (err error) {
+	log.DEBUG("setting algorithm schedule", schedule.Weights,
+		schedule.AutoTune)
+	w.mx.Lock()
+	w.schedule = schedule
+	w.mx.Unlock()
+	*reply = true
+	return
+}
+
 // UpdateExtraNonce updates the extra nonce in the coinbase script of the
 // passed block by regenerating the coinbase script with the passed value and
 // block height.  It also recalculates and updates the new merkle root that
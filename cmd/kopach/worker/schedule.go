@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/p9c/pod/pkg/chain/fork"
+)
+
+// Schedule is which algorithms a worker mines and how it divides its time
+// between them
+type Schedule struct {
+	// Weights is the share of time given to each algorithm by name,
+	// algorithms not in it are not mined. All algorithms in the jobs are
+	// mined equally if it is empty
+	Weights map[string]float64
+	// NSperOp is the time a hash takes for each algorithm on this machine,
+	// the values in the fork tables are used for any that are missing
+	NSperOp map[string]int64
+	// AutoTune gives each algorithm time in proportion to the reward it can
+	// be expected to earn per second at the difficulty in the job
+	AutoTune bool
+}
+
+// Empty returns whether the schedule changes anything from rolling evenly
+// through every algorithm
+func (s *Schedule) Empty() bool {
+	return s == nil || (len(s.Weights) < 1 && !s.AutoTune)
+}
+
+// Algos returns the algorithm versions from the bits in a job that are to be
+// mined at the height and the share of hashes for each of them. The weights
+// are nil if the versions should be rolled through evenly
+func (s *Schedule) Algos(bitses map[int32]uint32, height int32) (
+	algos []int32, weights []float64) {
+	for ver := range bitses {
+		algos = append(algos, ver)
+	}
+	sort.Slice(algos, func(i, j int) bool { return algos[i] < algos[j] })
+	if s.Empty() {
+		return
+	}
+	params := fork.List[fork.GetCurrent(height)].Algos
+	var selected []int32
+	for _, ver := range algos {
+		name := fork.GetAlgoName(ver, height)
+		weight := 1.0
+		if len(s.Weights) > 0 {
+			var ok bool
+			if weight, ok = s.Weights[name]; !ok || weight <= 0 {
+				continue
+			}
+		}
+		nsPerOp := float64(params[name].NSperOp)
+		if measured, ok := s.NSperOp[name]; ok && measured > 0 {
+			nsPerOp = float64(measured)
+		}
+		if nsPerOp <= 0 {
+			nsPerOp = 1
+		}
+		if s.AutoTune {
+			// blocks found per second is the chance of a hash being under
+			// the target times the hashes done per second, and every
+			// algorithm earns the same block reward
+			target, _ := new(big.Float).SetInt(
+				fork.CompactToBig(bitses[ver])).Float64()
+			weight *= target / math.Exp2(256) * 1e9 / nsPerOp
+		}
+		// the counter picks algorithms by hashes, so the share of time is
+		// turned into a share of hashes by how long each takes
+		selected = append(selected, ver)
+		weights = append(weights, weight/nsPerOp)
+	}
+	if len(selected) < 1 {
+		// none of the configured algorithms are in the job
+		return algos, nil
+	}
+	return selected, weights
+}
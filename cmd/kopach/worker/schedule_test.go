@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"math"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/fork"
+)
+
+func TestCounterWeights(t *testing.T) {
+	c := NewCounter(1)
+	c.SetAlgos([]int32{5, 6, 7}, []float64{3, 1, 0})
+	counts := make(map[int32]int)
+	for i := 0; i < 400; i++ {
+		counts[c.GetAlgoVer()]++
+	}
+	if counts[5] != 300 || counts[6] != 100 || counts[7] != 0 {
+		t.Errorf("unexpected distribution %v", counts)
+	}
+	c.SetAlgos([]int32{5, 6}, nil)
+	counts = make(map[int32]int)
+	for i := 0; i < 10; i++ {
+		counts[c.GetAlgoVer()]++
+	}
+	if counts[5] != 5 || counts[6] != 5 {
+		t.Errorf("unweighted counter is not even %v", counts)
+	}
+}
+
+func TestScheduleAlgos(t *testing.T) {
+	height := fork.List[1].ActivationHeight
+	bitses := make(map[int32]uint32)
+	for ver := range fork.P9AlgoVers {
+		bitses[ver] = fork.FirstPowLimitBits
+	}
+	var s *Schedule
+	if algos, weights := s.Algos(bitses, height); len(algos) != len(bitses) ||
+		weights != nil {
+		t.Errorf("empty schedule gave %v %v", algos, weights)
+	}
+	s = &Schedule{
+		Weights: map[string]float64{"argon2i": 2, "keccak": 1},
+		NSperOp: map[string]int64{"argon2i": 100, "keccak": 100},
+	}
+	algos, weights := s.Algos(bitses, height)
+	if len(algos) != 2 || algos[0] != 6 || algos[1] != 8 ||
+		weights[0] != 2*weights[1] {
+		t.Errorf("restricted schedule gave %v %v", algos, weights)
+	}
+	// at the same difficulty an algorithm four times faster earns four times
+	// as much per second, so it gets four times the time and sixteen times
+	// the hashes
+	s = &Schedule{
+		NSperOp:  map[string]int64{"argon2i": 100, "keccak": 400},
+		AutoTune: true,
+	}
+	algos, weights = s.Algos(map[int32]uint32{6: fork.FirstPowLimitBits,
+		8: fork.FirstPowLimitBits}, height)
+	if len(algos) != 2 || math.Abs(weights[0]/weights[1]-16) > 1e-9 {
+		t.Errorf("auto tuned schedule gave %v %v", algos, weights)
+	}
+}
@@ -5,8 +5,7 @@ import (
 	"github.com/p9c/pod/pkg/controller"
 	"net"
 	"net/http"
-	//// This enables pprof
-	//_ "net/http/pprof"
+	httppprof "net/http/pprof"
 	"os"
 	"runtime/pprof"
 	"sync"
@@ -20,6 +19,8 @@ import (
 	"github.com/p9c/pod/pkg/conte"
 	database "github.com/p9c/pod/pkg/db"
 	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/metrics"
+	"github.com/p9c/pod/pkg/stratum"
 	"github.com/p9c/pod/pkg/util/interrupt"
 )
 
@@ -55,17 +56,25 @@ func Main(cx *conte.Xt, shutdownChan chan struct{},
 
 	// show version at startup
 	log.INFO("version", version.Version())
-	// enable http profiling server if requested
+	// enable http profiling server if requested, only on localhost as the
+	// profiles show the command line and can use up the cpu
 	if *cx.Config.Profile != "" {
 		log.DEBUG("profiling requested")
 		go func() {
-			listenAddr := net.JoinHostPort("",
+			listenAddr := net.JoinHostPort("127.0.0.1",
 				*cx.Config.Profile)
 			log.INFO("profile server listening on", listenAddr)
-			profileRedirect := http.RedirectHandler(
-				"/debug/pprof", http.StatusSeeOther)
-			http.Handle("/", profileRedirect)
-			log.ERROR("profile server", http.ListenAndServe(listenAddr, nil))
+			// The profiles are served on their own mux so they are not
+			// exposed by anything else using the default one.
+			mux := http.NewServeMux()
+			mux.HandleFunc("/debug/pprof/", httppprof.Index)
+			mux.HandleFunc("/debug/pprof/cmdline", httppprof.Cmdline)
+			mux.HandleFunc("/debug/pprof/profile", httppprof.Profile)
+			mux.HandleFunc("/debug/pprof/symbol", httppprof.Symbol)
+			mux.HandleFunc("/debug/pprof/trace", httppprof.Trace)
+			mux.Handle("/", http.RedirectHandler("/debug/pprof/",
+				http.StatusSeeOther))
+			log.ERROR("profile server", http.ListenAndServe(listenAddr, mux))
 		}()
 	}
 	// write cpu profile if requested
@@ -160,7 +169,7 @@ func Main(cx *conte.Xt, shutdownChan chan struct{},
 			*cx.Config.Listeners, err)
 		return err
 	}
-	var stopController context.CancelFunc
+	var stopController, stopStratum, stopMetrics context.CancelFunc
 	gracefulShutdown := func() {
 		log.DEBUG("shutting down node from interrupt")
 		log.INFO("gracefully shutting down the server...")
@@ -170,6 +179,14 @@ func Main(cx *conte.Xt, shutdownChan chan struct{},
 			log.DEBUG("stopping controller")
 			stopController()
 		}
+		if stopStratum != nil {
+			log.DEBUG("stopping stratum server")
+			stopStratum()
+		}
+		if stopMetrics != nil {
+			log.DEBUG("stopping metrics server")
+			stopMetrics()
+		}
 		e := server.Stop()
 		if e != nil {
 			log.WARN("failed to stop server", e)
@@ -192,6 +209,12 @@ func Main(cx *conte.Xt, shutdownChan chan struct{},
 	if *cx.Config.EnableController {
 		stopController = controller.Run(cx)
 	}
+	if cx.StateCfg.StratumListener != "" {
+		stopStratum = stratum.Run(cx)
+	}
+	if cx.StateCfg.MetricsListener != "" {
+		stopMetrics = metrics.Run(cx)
+	}
 	//interrupt.AddHandler(gracefulShutdown)
 
 	// Wait until the interrupt signal is received from an OS signal or
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -95,6 +96,10 @@ type Policy struct {
 	// MinRelayTxFee defines the minimum transaction fee in DUO/kB to be
 	// considered a non-zero fee.
 	MinRelayTxFee util.Amount
+	// RejectReplacement defines whether to reject transactions that spend
+	// outputs already spent by transactions in the pool even if those signal
+	// replaceability as described by BIP125.
+	RejectReplacement bool
 }
 
 type // Tag represents an identifier to use for tagging orphan transactions.
@@ -155,6 +160,14 @@ const (
 	// orphanExpireScanInterval is the minimum amount of time in between
 	// scans of the orphan pool to evict expired transactions.
 	orphanExpireScanInterval = time.Minute * 5
+	// MaxRBFSequence is the maximum sequence number an input can use to
+	// signal that the transaction spending it can be replaced, as described
+	// by BIP125.
+	MaxRBFSequence = wire.MaxTxInSequenceNum - 2
+	// MaxReplacementEvictions is the maximum number of transactions that can
+	// be evicted from the pool when accepting a replacement transaction,
+	// counting the conflicts and all of their descendants.
+	MaxReplacementEvictions = 100
 )
 
 var // Ensure the TxPool type implements the mining.TxSource interface.
@@ -254,6 +267,62 @@ func // MaybeAcceptTransaction is the main workhorse for handling insertion
 	return hashes, txD, err
 }
 
+func // MempoolEntry returns the details of a single transaction in the main
+// pool, including the totals for its in-pool ancestors and descendants.
+// As with other bitcoin-family nodes the counts, sizes and fees of the
+// ancestors and descendants include the transaction itself.
+// This function is safe for concurrent access.
+(mp *TxPool) MempoolEntry(txHash *chainhash.Hash) (*btcjson.GetMempoolEntryResult, error) {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+	desc, exists := mp.pool[*txHash]
+	if !exists {
+		return nil, fmt.Errorf("transaction is not in the pool")
+	}
+	tx := desc.Tx
+	var currentPriority float64
+	utxos, err := mp.fetchInputUtxos(tx)
+	if err == nil {
+		currentPriority = mining.CalcPriority(tx.MsgTx(), utxos,
+			mp.cfg.BestHeight()+1)
+	}
+	size := GetTxVirtualSize(tx)
+	fee := util.Amount(desc.Fee).ToDUO()
+	entry := &btcjson.GetMempoolEntryResult{
+		Size:             int32(size),
+		Fee:              fee,
+		ModifiedFee:      fee,
+		Time:             desc.Added.Unix(),
+		Height:           int64(desc.Height),
+		StartingPriority: desc.StartingPriority,
+		CurrentPriority:  currentPriority,
+		DescendantCount:  1,
+		DescendantSize:   size,
+		DescendantFees:   fee,
+		AncestorCount:    1,
+		AncestorSize:     size,
+		AncestorFees:     fee,
+		Depends:          make([]string, 0),
+	}
+	for _, txIn := range tx.MsgTx().TxIn {
+		hash := &txIn.PreviousOutPoint.Hash
+		if mp.haveTransaction(hash) {
+			entry.Depends = append(entry.Depends, hash.String())
+		}
+	}
+	for _, ancestor := range mp.txAncestors(tx) {
+		entry.AncestorCount++
+		entry.AncestorSize += GetTxVirtualSize(ancestor.Tx)
+		entry.AncestorFees += util.Amount(ancestor.Fee).ToDUO()
+	}
+	for _, descendant := range mp.txDescendants(tx) {
+		entry.DescendantCount++
+		entry.DescendantSize += GetTxVirtualSize(descendant.Tx)
+		entry.DescendantFees += util.Amount(descendant.Fee).ToDUO()
+	}
+	return entry, nil
+}
+
 func // MiningDescs returns a slice of mining descriptors for all the
 // transactions in the pool. This is part of the mining.
 // TxSource interface implementation and is safe for concurrent access as
@@ -380,6 +449,36 @@ func // RawMempoolVerbose returns all of the entries in the mempool as a fully
 	return result
 }
 
+func // EstimatePriority returns the priority a transaction needs to be
+// included in the priority space of the next numBlocks blocks, given the
+// space reserved for high priority transactions in each block. It is the
+// priority of the first transaction in the pool that doesn't fit in that
+// space, and never less than the priority for a transaction to be considered
+// high priority.
+// This function is safe for concurrent access.
+(mp *TxPool) EstimatePriority(numBlocks int64, blockPrioritySize uint32) float64 {
+	entries := mp.RawMempoolVerbose()
+	descs := make([]*btcjson.GetRawMempoolVerboseResult, 0, len(entries))
+	for _, entry := range entries {
+		descs = append(descs, entry)
+	}
+	sort.Slice(descs, func(i, j int) bool {
+		return descs[i].CurrentPriority > descs[j].CurrentPriority
+	})
+	space := numBlocks * int64(blockPrioritySize)
+	var used int64
+	for _, desc := range descs {
+		used += int64(desc.Size)
+		if used > space {
+			if desc.CurrentPriority > mining.MinHighPriority.ToDUO() {
+				return desc.CurrentPriority
+			}
+			break
+		}
+	}
+	return mining.MinHighPriority.ToDUO()
+}
+
 func // RemoveDoubleSpends removes all transactions which spend outputs spent by
 // the passed transaction from the memory pool.
 // Removing those transactions then leads to removing all transactions which
@@ -538,17 +637,166 @@ func // checkPoolDoubleSpend checks whether or not the passed transaction is
 // attempting to spend coins already spent by other transactions in the pool.
 // Note it does not check for double spends against transactions already in
 // the main chain.
+// Spending an output spent by a transaction that signals replacement is not
+// an error unless the policy rejects replacements, instead the returned bool
+// is true and the transaction must then pass validateReplacement.
 // This function MUST be called with the mempool lock held (for reads).
-(mp *TxPool) checkPoolDoubleSpend(tx *util.Tx) error {
+(mp *TxPool) checkPoolDoubleSpend(tx *util.Tx) (bool, error) {
+	var isReplacement bool
 	for _, txIn := range tx.MsgTx().TxIn {
-		if txR, exists := mp.outpoints[txIn.PreviousOutPoint]; exists {
-			str := fmt.Sprintf("output %v already spent by "+
-				"transaction %v in the memory pool",
-				txIn.PreviousOutPoint, txR.Hash())
-			return txRuleError(wire.RejectDuplicate, str)
+		conflict, exists := mp.outpoints[txIn.PreviousOutPoint]
+		if !exists {
+			continue
+		}
+		if !mp.cfg.Policy.RejectReplacement &&
+			mp.signalsReplacement(conflict, nil) {
+			isReplacement = true
+			continue
 		}
+		str := fmt.Sprintf("output %v already spent by "+
+			"transaction %v in the memory pool",
+			txIn.PreviousOutPoint, conflict.Hash())
+		return false, txRuleError(wire.RejectDuplicate, str)
 	}
-	return nil
+	return isReplacement, nil
+}
+
+func // signalsReplacement returns whether the passed transaction signals that
+// it can be replaced, either explicitly by an input with a sequence number no
+// higher than MaxRBFSequence or by spending an output of an unconfirmed
+// transaction that signals it.
+// The cache holds the answer for transactions already looked at and may be
+// nil.
+// This function MUST be called with the mempool lock held (for reads).
+(mp *TxPool) signalsReplacement(tx *util.Tx,
+	cache map[chainhash.Hash]bool) bool {
+	if cache == nil {
+		cache = make(map[chainhash.Hash]bool)
+	}
+	for _, txIn := range tx.MsgTx().TxIn {
+		if txIn.Sequence <= MaxRBFSequence {
+			return true
+		}
+	}
+	for _, txIn := range tx.MsgTx().TxIn {
+		hash := txIn.PreviousOutPoint.Hash
+		signals, seen := cache[hash]
+		if !seen {
+			desc, exists := mp.pool[hash]
+			if !exists {
+				continue
+			}
+			signals = mp.signalsReplacement(desc.Tx, cache)
+			cache[hash] = signals
+		}
+		if signals {
+			return true
+		}
+	}
+	return false
+}
+
+func // txConflicts returns the transactions in the main pool that spend any of
+// the outputs spent by the passed transaction.
+// This function MUST be called with the mempool lock held (for reads).
+(mp *TxPool) txConflicts(tx *util.Tx) map[chainhash.Hash]*util.Tx {
+	conflicts := make(map[chainhash.Hash]*util.Tx)
+	for _, txIn := range tx.MsgTx().TxIn {
+		conflict, exists := mp.outpoints[txIn.PreviousOutPoint]
+		if exists {
+			conflicts[*conflict.Hash()] = conflict
+		}
+	}
+	return conflicts
+}
+
+func // validateReplacement checks whether the passed transaction, which spends
+// outputs already spent in the pool by transactions that signal replacement,
+// can replace them as described by BIP125, and returns all the transactions
+// that must be evicted from the pool to accept it.
+// The replacement must pay a higher fee rate than each transaction it
+// conflicts with, and an absolute fee that covers all the evicted
+// transactions plus the relay fee for its own size.
+// It may not spend outputs of the transactions it replaces nor any other
+// unconfirmed outputs they did not spend, and no more than
+// MaxReplacementEvictions transactions can be evicted.
+// This function MUST be called with the mempool lock held (for reads).
+(mp *TxPool) validateReplacement(tx *util.Tx,
+	txFee int64) (map[chainhash.Hash]*util.Tx, error) {
+	txHash := tx.Hash()
+	conflicts := mp.txConflicts(tx)
+	evicted := make(map[chainhash.Hash]*util.Tx)
+	for hash, conflict := range conflicts {
+		evicted[hash] = conflict
+		for descHash, desc := range mp.txDescendants(conflict) {
+			evicted[descHash] = desc.Tx
+		}
+		if len(evicted) > MaxReplacementEvictions {
+			str := fmt.Sprintf("replacement transaction %v evicts more "+
+				"transactions than permitted: max is %v", txHash,
+				MaxReplacementEvictions)
+			return nil, txRuleError(wire.RejectNonstandard, str)
+		}
+	}
+	// The replacement can't depend on what it replaces, and may only spend
+	// unconfirmed outputs that were already spent by the conflicts.
+	conflictParents := make(map[chainhash.Hash]struct{})
+	for _, conflict := range conflicts {
+		for _, txIn := range conflict.MsgTx().TxIn {
+			conflictParents[txIn.PreviousOutPoint.Hash] = struct{}{}
+		}
+	}
+	for _, txIn := range tx.MsgTx().TxIn {
+		hash := txIn.PreviousOutPoint.Hash
+		if _, exists := evicted[hash]; exists {
+			str := fmt.Sprintf("replacement transaction %v spends "+
+				"output %v of a transaction it replaces", txHash,
+				txIn.PreviousOutPoint)
+			return nil, txRuleError(wire.RejectInvalid, str)
+		}
+		if _, exists := mp.pool[hash]; !exists {
+			continue
+		}
+		if _, exists := conflictParents[hash]; !exists {
+			str := fmt.Sprintf("replacement transaction %v spends new "+
+				"unconfirmed input %v", txHash, txIn.PreviousOutPoint)
+			return nil, txRuleError(wire.RejectNonstandard, str)
+		}
+	}
+	// The fee rate of the replacement must be higher than that of every
+	// transaction it directly conflicts with.
+	txSize := GetTxVirtualSize(tx)
+	txFeeRate := txFee * 1000 / txSize
+	for hash := range conflicts {
+		desc := mp.pool[hash]
+		if txFeeRate <= desc.FeePerKB {
+			str := fmt.Sprintf("replacement transaction %v has an "+
+				"insufficient fee rate: needs more than %v, has %v",
+				txHash, desc.FeePerKB, txFeeRate)
+			return nil, txRuleError(wire.RejectInsufficientFee, str)
+		}
+	}
+	// The replacement must also pay for all it evicts and for relaying
+	// itself.
+	var evictedFees int64
+	for hash := range evicted {
+		evictedFees += mp.pool[hash].Fee
+	}
+	if txFee < evictedFees {
+		str := fmt.Sprintf("replacement transaction %v has an "+
+			"insufficient absolute fee: needs %v, has %v", txHash,
+			evictedFees, txFee)
+		return nil, txRuleError(wire.RejectInsufficientFee, str)
+	}
+	relayFee := calcMinRequiredTxRelayFee(txSize,
+		mp.cfg.Policy.MinRelayTxFee)
+	if txFee-evictedFees < relayFee {
+		str := fmt.Sprintf("replacement transaction %v must pay at "+
+			"least %v more than the transactions it replaces, pays %v",
+			txHash, relayFee, txFee-evictedFees)
+		return nil, txRuleError(wire.RejectInsufficientFee, str)
+	}
+	return evicted, nil
 }
 
 func // fetchInputUtxos loads utxo details about the input transactions
@@ -742,7 +990,10 @@ func // maybeAcceptTransaction is the internal function which implements the
 	// There is a more in-depth check that happens later after fetching the
 	// referenced transaction inputs from the main chain which examines the
 	// actual spend data and prevents double spends.
-	err = mp.checkPoolDoubleSpend(tx)
+	// Transactions spending outputs spent by transactions that signal
+	// replacement are let through and checked further once their fee is
+	// known.
+	isReplacement, err := mp.checkPoolDoubleSpend(tx)
 	if err != nil {
 		log.ERROR(err)
 		return nil, nil, err
@@ -917,6 +1168,16 @@ func // maybeAcceptTransaction is the internal function which implements the
 			mp.cfg.Policy.FreeTxRelayLimit*10*1000,
 		)
 	}
+	// A replacement must pay enough to evict the transactions it conflicts
+	// with as described by BIP125.
+	var evicted map[chainhash.Hash]*util.Tx
+	if isReplacement {
+		evicted, err = mp.validateReplacement(tx, txFee)
+		if err != nil {
+			log.ERROR(err)
+			return nil, nil, err
+		}
+	}
 	// Verify crypto signatures for each input and reject the transaction if
 	// any don't verify.
 	err = blockchain.ValidateTransactionScripts(b, tx, utxoView,
@@ -929,6 +1190,12 @@ func // maybeAcceptTransaction is the internal function which implements the
 		}
 		return nil, nil, err
 	}
+	// Evict the replaced transactions and their descendants before adding
+	// the replacement so the outputs they spent are free again.
+	for hash, evictedTx := range evicted {
+		log.DEBUGF("replacing transaction %v with %v", hash, txHash)
+		mp.removeTransaction(evictedTx, false)
+	}
 	// Add to transaction pool.
 	txD := mp.addTransaction(utxoView, tx, bestHeight, txFee)
 	log.DEBUGF(
@@ -1120,6 +1387,58 @@ func // removeTransaction is the internal function which implements the public
 	}
 }
 
+func // txAncestors returns the descriptors of every transaction in the main
+// pool that the passed transaction depends on, directly or indirectly.
+// This function MUST be called with the mempool lock held (for reads).
+(mp *TxPool) txAncestors(tx *util.Tx) map[chainhash.Hash]*TxDesc {
+	ancestors := make(map[chainhash.Hash]*TxDesc)
+	queue := []*util.Tx{tx}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, txIn := range next.MsgTx().TxIn {
+			hash := txIn.PreviousOutPoint.Hash
+			if _, seen := ancestors[hash]; seen {
+				continue
+			}
+			if desc, exists := mp.pool[hash]; exists {
+				ancestors[hash] = desc
+				queue = append(queue, desc.Tx)
+			}
+		}
+	}
+	return ancestors
+}
+
+func // txDescendants returns the descriptors of every transaction in the main
+// pool that spends an output of the passed transaction, directly or
+// indirectly.
+// This function MUST be called with the mempool lock held (for reads).
+(mp *TxPool) txDescendants(tx *util.Tx) map[chainhash.Hash]*TxDesc {
+	descendants := make(map[chainhash.Hash]*TxDesc)
+	queue := []*util.Tx{tx}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		prevOut := wire.OutPoint{Hash: *next.Hash()}
+		for i := range next.MsgTx().TxOut {
+			prevOut.Index = uint32(i)
+			redeemer, exists := mp.outpoints[prevOut]
+			if !exists {
+				continue
+			}
+			if _, seen := descendants[*redeemer.Hash()]; seen {
+				continue
+			}
+			if desc, exists := mp.pool[*redeemer.Hash()]; exists {
+				descendants[*redeemer.Hash()] = desc
+				queue = append(queue, redeemer)
+			}
+		}
+	}
+	return descendants
+}
+
 func // New returns a new memory pool for validating and storing standalone
 // transactions until they are mined into a block.
 New(cfg *Config) *TxPool {
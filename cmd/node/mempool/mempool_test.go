@@ -13,8 +13,10 @@ import (
 	chaincfg "github.com/p9c/pod/pkg/chain/config"
 	"github.com/p9c/pod/pkg/chain/config/netparams"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/mining"
 	txscript "github.com/p9c/pod/pkg/chain/tx/script"
 	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
 	"github.com/p9c/pod/pkg/util"
 	ec "github.com/p9c/pod/pkg/util/elliptic"
 )
@@ -217,6 +219,38 @@ func (p *poolHarness) CreateSignedTx(inputs []spendableOutput, numOutputs uint32
 	return util.NewTx(tx), nil
 }
 
+// CreateReplaceableTx creates a new signed transaction that consumes the
+// provided inputs with the passed sequence number and pays the total input
+// amount less the fee to a single output to the payment script associated
+// with the harness.
+func (p *poolHarness) CreateReplaceableTx(inputs []spendableOutput, fee util.Amount, sequence uint32) (*util.Tx, error) {
+	var totalInput util.Amount
+	for _, input := range inputs {
+		totalInput += input.amount
+	}
+	tx := wire.NewMsgTx(wire.TxVersion)
+	for _, input := range inputs {
+		tx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: input.outPoint,
+			Sequence:         sequence,
+		})
+	}
+	tx.AddTxOut(&wire.TxOut{
+		PkScript: p.payScript,
+		Value:    int64(totalInput - fee),
+	})
+	for i := range tx.TxIn {
+		sigScript, err := txscript.SignatureScript(tx, i, p.payScript,
+			txscript.SigHashAll, p.signKey, true)
+		if err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+		tx.TxIn[i].SignatureScript = sigScript
+	}
+	return util.NewTx(tx), nil
+}
+
 // CreateTxChain creates a chain of zero-fee transactions (each subsequent
 // transaction spends the entire amount from the previous one) with the first
 // one spending the provided outpoint.
@@ -740,3 +774,218 @@ func TestCheckSpend(t *testing.T) {
 		t.Fatalf("Unexpeced spend found in pool: %v", spend)
 	}
 }
+
+// TestReplacement ensures transactions spending outputs already spent in the
+// pool replace the transactions spending them only when those signal it and
+// the replacement pays enough, and that replaced transactions are evicted
+// along with their descendants.
+func TestReplacement(t *testing.T) {
+	t.Parallel()
+	harness, outputs, err := newPoolHarness(&chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	tc := &testContext{t, harness}
+	// Split the spendable output so the replacements have several
+	// independent outputs to spend.
+	splitTx, err := harness.CreateSignedTx(outputs, 3)
+	if err != nil {
+		t.Fatalf("unable to create signed tx: %v", err)
+	}
+	_, err = harness.txPool.ProcessTransaction(nil, splitTx, true, false, 0)
+	if err != nil {
+		t.Fatalf("ProcessTransaction: failed to accept tx: %v", err)
+	}
+	accept := func(inputs []spendableOutput, fee util.Amount,
+		sequence uint32) *util.Tx {
+		tx, err := harness.CreateReplaceableTx(inputs, fee, sequence)
+		if err != nil {
+			t.Fatalf("unable to create replaceable tx: %v", err)
+		}
+		_, err = harness.txPool.ProcessTransaction(nil, tx, true, false, 0)
+		if err != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", err)
+		}
+		testPoolMembership(tc, tx, false, true)
+		return tx
+	}
+	reject := func(inputs []spendableOutput, fee util.Amount,
+		sequence uint32, code wire.RejectCode) {
+		tx, err := harness.CreateReplaceableTx(inputs, fee, sequence)
+		if err != nil {
+			t.Fatalf("unable to create replaceable tx: %v", err)
+		}
+		_, err = harness.txPool.ProcessTransaction(nil, tx, true, false, 0)
+		if err == nil {
+			t.Fatalf("ProcessTransaction: accepted invalid replacement")
+		}
+		gotCode, _ := extractRejectCode(err)
+		if gotCode != code {
+			t.Fatalf("ProcessTransaction: unexpected reject code -- "+
+				"got %v, want %v (%v)", gotCode, code, err)
+		}
+		testPoolMembership(tc, tx, false, false)
+	}
+	spendable := []spendableOutput{
+		txOutToSpendableOut(splitTx, 0),
+		txOutToSpendableOut(splitTx, 1),
+		txOutToSpendableOut(splitTx, 2),
+	}
+	// A transaction that does not signal replacement can't be replaced.
+	final := accept(spendable[:1], 1000, wire.MaxTxInSequenceNum)
+	reject(spendable[:1], 10000, MaxRBFSequence, wire.RejectDuplicate)
+	testPoolMembership(tc, final, false, true)
+	// A signaling transaction is replaced by one paying a higher fee rate and
+	// at least the relay fee more.
+	original := accept(spendable[1:2], 1000, MaxRBFSequence)
+	reject(spendable[1:2], 900, MaxRBFSequence,
+		wire.RejectInsufficientFee)
+	reject(spendable[1:2], 1100, MaxRBFSequence,
+		wire.RejectInsufficientFee)
+	replacement := accept(spendable[1:2], 2000, MaxRBFSequence)
+	testPoolMembership(tc, original, false, false)
+	// A replacement must pay for the descendants it evicts, which inherit
+	// the replaceability of their ancestors.
+	child := accept([]spendableOutput{txOutToSpendableOut(replacement, 0)},
+		5000, wire.MaxTxInSequenceNum)
+	reject(spendable[1:2], 4000, wire.MaxTxInSequenceNum,
+		wire.RejectInsufficientFee)
+	// It can't spend outputs of what it replaces, nor unconfirmed outputs
+	// the replaced transactions didn't spend.
+	reject([]spendableOutput{spendable[1],
+		txOutToSpendableOut(child, 0)}, 10000, wire.MaxTxInSequenceNum,
+		wire.RejectInvalid)
+	reject([]spendableOutput{spendable[1],
+		txOutToSpendableOut(final, 0)}, 10000, wire.MaxTxInSequenceNum,
+		wire.RejectNonstandard)
+	accept(spendable[1:2], 8000, wire.MaxTxInSequenceNum)
+	testPoolMembership(tc, replacement, false, false)
+	testPoolMembership(tc, child, false, false)
+	// Replacements are rejected outright when the policy says so.
+	harness.txPool.cfg.Policy.RejectReplacement = true
+	signaling := accept(spendable[2:], 1000, MaxRBFSequence)
+	reject(spendable[2:], 10000, MaxRBFSequence, wire.RejectDuplicate)
+	testPoolMembership(tc, signaling, false, true)
+}
+
+// TestMempoolEntry ensures the details of a transaction in the pool include
+// the totals for its in-pool ancestors and descendants.
+func TestMempoolEntry(t *testing.T) {
+	t.Parallel()
+	harness, outputs, err := newPoolHarness(&chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	parent, err := harness.CreateReplaceableTx(outputs, 1000,
+		wire.MaxTxInSequenceNum)
+	if err != nil {
+		t.Fatalf("unable to create tx: %v", err)
+	}
+	tx, err := harness.CreateReplaceableTx([]spendableOutput{
+		txOutToSpendableOut(parent, 0)}, 2000, wire.MaxTxInSequenceNum)
+	if err != nil {
+		t.Fatalf("unable to create tx: %v", err)
+	}
+	child, err := harness.CreateReplaceableTx([]spendableOutput{
+		txOutToSpendableOut(tx, 0)}, 3000, wire.MaxTxInSequenceNum)
+	if err != nil {
+		t.Fatalf("unable to create tx: %v", err)
+	}
+	for _, tx := range []*util.Tx{parent, tx, child} {
+		_, err = harness.txPool.ProcessTransaction(nil, tx, true, false, 0)
+		if err != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", err)
+		}
+	}
+	entry, err := harness.txPool.MempoolEntry(tx.Hash())
+	if err != nil {
+		t.Fatalf("MempoolEntry: unexpected error: %v", err)
+	}
+	size := GetTxVirtualSize(tx)
+	parentSize := GetTxVirtualSize(parent)
+	childSize := GetTxVirtualSize(child)
+	fee := func(amount util.Amount) float64 { return amount.ToDUO() }
+	want := &btcjson.GetMempoolEntryResult{
+		Size:             int32(size),
+		Fee:              fee(2000),
+		ModifiedFee:      fee(2000),
+		Time:             entry.Time,
+		Height:           int64(harness.chain.BestHeight()),
+		StartingPriority: entry.StartingPriority,
+		CurrentPriority:  entry.CurrentPriority,
+		DescendantCount:  2,
+		DescendantSize:   size + childSize,
+		DescendantFees:   fee(2000) + fee(3000),
+		AncestorCount:    2,
+		AncestorSize:     size + parentSize,
+		AncestorFees:     fee(2000) + fee(1000),
+		Depends:          []string{parent.Hash().String()},
+	}
+	if !reflect.DeepEqual(entry, want) {
+		t.Errorf("MempoolEntry: got %+v, want %+v", entry, want)
+	}
+	if _, err := harness.txPool.MempoolEntry(&chainhash.Hash{}); err == nil {
+		t.Errorf("MempoolEntry: no error for a transaction not in the pool")
+	}
+}
+
+// TestEstimatePriority ensures the estimated priority is that of the first
+// transaction that doesn't fit in the priority space of the blocks, and
+// never less than the minimum high priority.
+func TestEstimatePriority(t *testing.T) {
+	t.Parallel()
+	harness, outputs, err := newPoolHarness(&chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	minHighPriority := mining.MinHighPriority.ToDUO()
+	if priority := harness.txPool.EstimatePriority(1, 1); priority !=
+		minHighPriority {
+		t.Errorf("EstimatePriority: got %v for an empty pool, want %v",
+			priority, minHighPriority)
+	}
+	// The transaction spends an aged coinbase so it has a high priority,
+	// while the one spending its unconfirmed output has none.
+	tx, err := harness.CreateSignedTx(outputs, 1)
+	if err != nil {
+		t.Fatalf("unable to create tx: %v", err)
+	}
+	child, err := harness.CreateSignedTx([]spendableOutput{
+		txOutToSpendableOut(tx, 0)}, 1)
+	if err != nil {
+		t.Fatalf("unable to create tx: %v", err)
+	}
+	for _, tx := range []*util.Tx{tx, child} {
+		_, err = harness.txPool.ProcessTransaction(nil, tx, true, false, 0)
+		if err != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", err)
+		}
+	}
+	entry, err := harness.txPool.MempoolEntry(tx.Hash())
+	if err != nil {
+		t.Fatalf("MempoolEntry: unexpected error: %v", err)
+	}
+	if entry.CurrentPriority <= minHighPriority {
+		t.Fatalf("transaction priority %v is not high", entry.CurrentPriority)
+	}
+	size := uint32(tx.MsgTx().SerializeSize())
+	tests := []struct {
+		name      string
+		numBlocks int64
+		space     uint32
+		want      float64
+	}{
+		{"high priority tx doesn't fit", 1, size - 1, entry.CurrentPriority},
+		{"space over several blocks", 2, (size - 1) / 2,
+			entry.CurrentPriority},
+		{"low priority tx doesn't fit", 1, size, minHighPriority},
+		{"everything fits", 10, size, minHighPriority},
+	}
+	for _, test := range tests {
+		priority := harness.txPool.EstimatePriority(test.numBlocks, test.space)
+		if priority != test.want {
+			t.Errorf("EstimatePriority (%s): got %v, want %v", test.name,
+				priority, test.want)
+		}
+	}
+}
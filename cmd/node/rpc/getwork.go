@@ -6,7 +6,6 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
-	"math/rand"
 	"time"
 
 	"github.com/conformal/fastsha256"
@@ -66,10 +65,10 @@ func BigToLEUint256(n *big.Int) [Uint256Size]byte {
 // HandleGetWork handles the getwork call
 func HandleGetWork(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	c := cmd.(*btcjson.GetWorkCmd)
-	if len(s.StateCfg.ActiveMiningAddrs) == 0 {
+	if len(s.StateCfg.ActiveMiningAddrs) == 0 && s.StateCfg.MiningXpub == "" {
 		return nil, &btcjson.RPCError{
 			Code:    btcjson.ErrRPCInternal.Code,
-			Message: "No payment addresses specified via --miningaddr",
+			Message: "No payment addresses specified via --miningaddr or --miningxpub",
 		}
 	}
 	netwk := (*s.Config.Network)[0]
@@ -94,14 +93,16 @@ func HandleGetWork(s *Server, cmd interface{}, closeChan <-chan struct{}) (inter
 	if c.Data != nil {
 		return HandleGetWorkSubmission(s, *c.Data)
 	}
-	// Choose a payment address at random.
-	rand.Seed(time.Now().UnixNano())
-	payToAddr := s.StateCfg.ActiveMiningAddrs[rand.Intn(len(s.StateCfg.ActiveMiningAddrs))]
+	generator := s.Cfg.Generator
+	payToAddr, err := generator.PayToAddress()
+	if err != nil {
+		log.ERROR(err)
+		return nil, InternalRPCError(err.Error(),
+			"Failed to choose a payment address")
+	}
 	lastTxUpdate := s.GBTWorkState.LastTxUpdate
 	latestHash := &s.Cfg.Chain.BestSnapshot().Hash
-	generator := s.Cfg.Generator
 	if state.Template == nil {
-		var err error
 		state.Template, err = generator.NewBlockTemplate(0, payToAddr,
 			s.Cfg.Algo)
 		if err != nil {
@@ -126,7 +127,6 @@ func HandleGetWork(s *Server, cmd interface{}, closeChan <-chan struct{}) (inter
 		//	against so any errors below cause the next invocation to try
 		//	again.
 		state.prevHash = nil
-		var err error
 		state.Template, err = generator.NewBlockTemplate(0, payToAddr,
 			s.Cfg.Algo)
 		if err != nil {
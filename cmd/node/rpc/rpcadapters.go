@@ -1,14 +1,19 @@
 package rpc
 
 import (
+	"net"
 	"sync/atomic"
+	"time"
 
 	"github.com/p9c/pod/cmd/node/mempool"
 	blockchain "github.com/p9c/pod/pkg/chain"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	netsync "github.com/p9c/pod/pkg/chain/sync"
 	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/log"
 	"github.com/p9c/pod/pkg/peer"
+	"github.com/p9c/pod/pkg/peer/addrmgr"
+	"github.com/p9c/pod/pkg/peer/connmgr"
 	"github.com/p9c/pod/pkg/util"
 )
 
@@ -199,6 +204,93 @@ func (cm *ConnManager) RelayTransactions(txns []*mempool.TxDesc) {
 	cm.server.RelayTransactions(txns)
 }
 
+// LocalAddresses returns the local addresses that are advertised to peers
+// along with their priority. This function is safe for concurrent access and
+// is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) LocalAddresses() []addrmgr.LocalAddress {
+	return cm.server.AddrManager.LocalAddresses()
+}
+
+// Services returns the service flags that are advertised to peers. This
+// function is safe for concurrent access and is part of the
+// RPCServerConnManager interface implementation.
+func (cm *ConnManager) Services() wire.ServiceFlag {
+	return cm.server.LocalServices()
+}
+
+// SetPruned advertises to peers that only the most recent blocks are served.
+// This function is safe for concurrent access and is part of the
+// RPCServerConnManager interface implementation.
+func (cm *ConnManager) SetPruned() {
+	cm.server.SetPruned()
+}
+
+// BanSubnet bans the subnet until the passed time and disconnects the
+// connected peers within it. This function is safe for concurrent access and
+// is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) BanSubnet(subnet *net.IPNet, until time.Time,
+	reason string) error {
+	cm.server.BanList.Ban(subnet, until, reason)
+	if err := cm.server.SaveBanList(); err != nil {
+		log.ERROR(err)
+		return err
+	}
+	inSubnet := func(sp *NodePeer) bool {
+		host, _, err := net.SplitHostPort(sp.Addr())
+		if err != nil {
+			return false
+		}
+		return subnet.Contains(net.ParseIP(host))
+	}
+	// Each query disconnects one peer, so keep asking until none are left.
+	for {
+		replyChan := make(chan error)
+		cm.server.Query <- DisconnectNodeMsg{
+			Cmp:   inSubnet,
+			Reply: replyChan,
+		}
+		if err := <-replyChan; err != nil {
+			return nil
+		}
+	}
+}
+
+// UnbanSubnet lifts the ban of the subnet.
+// Attempting to unban a subnet which is not banned will return an error.
+// This function is safe for concurrent access and is part of the
+// RPCServerConnManager interface implementation.
+func (cm *ConnManager) UnbanSubnet(subnet *net.IPNet) error {
+	if err := cm.server.BanList.Unban(subnet); err != nil {
+		return err
+	}
+	return cm.server.SaveBanList()
+}
+
+// UnbanHost lifts the ban of a host whose address is not an IP address.
+// Attempting to unban a host which is not banned will return an error.
+// This function is safe for concurrent access and is part of the
+// RPCServerConnManager interface implementation.
+func (cm *ConnManager) UnbanHost(host string) error {
+	if err := cm.server.BanList.UnbanHost(host); err != nil {
+		return err
+	}
+	return cm.server.SaveBanList()
+}
+
+// BannedSubnets returns the bans which have not expired. This function is
+// safe for concurrent access and is part of the RPCServerConnManager
+// interface implementation.
+func (cm *ConnManager) BannedSubnets() []connmgr.BanEntry {
+	return cm.server.BanList.Entries()
+}
+
+// ClearBanned lifts all bans. This function is safe for concurrent access and
+// is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) ClearBanned() error {
+	cm.server.BanList.Clear()
+	return cm.server.SaveBanList()
+}
+
 // SyncManager provides a block manager for use with the RPC server and
 // implements the RPCServerSyncManager interface.
 type SyncManager struct {
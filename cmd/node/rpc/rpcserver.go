@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"bufio"
 	"bytes"
 	"crypto/sha256"
 	"crypto/subtle"
@@ -16,6 +17,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -37,9 +39,12 @@ import (
 	cpuminer "github.com/p9c/pod/pkg/chain/mining/cpu"
 	txscript "github.com/p9c/pod/pkg/chain/tx/script"
 	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/controller/stats"
 	database "github.com/p9c/pod/pkg/db"
 	"github.com/p9c/pod/pkg/log"
 	p "github.com/p9c/pod/pkg/peer"
+	"github.com/p9c/pod/pkg/peer/addrmgr"
+	"github.com/p9c/pod/pkg/peer/connmgr"
 	"github.com/p9c/pod/pkg/pod"
 	"github.com/p9c/pod/pkg/rpc/btcjson"
 	"github.com/p9c/pod/pkg/util"
@@ -51,6 +56,12 @@ const (
 	blockheightfail = "Failed to obtain block height"
 	scrypt          = "scrypt"
 	sha256d         = "sha256d"
+	// defaultAlgoStatsBlocks is the number of blocks the per algorithm
+	// statistics are calculated over unless requested otherwise.
+	defaultAlgoStatsBlocks = 120
+	// maxAlgoStatsBlocks is the most blocks the per algorithm statistics are
+	// calculated over, larger requests are capped to it.
+	maxAlgoStatsBlocks = 10000
 )
 
 type CommandHandler func(*Server, interface{}, <-chan struct{}) (interface{}, error)
@@ -155,6 +166,9 @@ type ServerConfig struct {
 	// The fee estimator keeps track of how long transactions are left in the
 	// mempool before they are mined into blocks.
 	FeeEstimator *mempool.FeeEstimator
+	// WorkerStats keeps the statistics kopach workers send to the miner
+	// controller.
+	WorkerStats *stats.Collector
 	// Algo sets the algorithm expected from the RPC endpoint. This allows
 	// multiple ports to serve multiple types of miners with one main node per
 	// algorithm. Currently 514 for scrypt and anything else passes for sha256d.
@@ -205,6 +219,28 @@ type ServerConnManager interface {
 	// RelayTransactions generates and relays inventory vectors for all of
 	// the passed transactions to all connected peers.
 	RelayTransactions(txns []*mempool.TxDesc)
+	// LocalAddresses returns the local addresses that are advertised to
+	// peers along with their priority.
+	LocalAddresses() []addrmgr.LocalAddress
+	// Services returns the service flags that are advertised to peers.
+	Services() wire.ServiceFlag
+	// SetPruned advertises to peers that only the most recent blocks are
+	// served, once the old ones are no longer stored.
+	SetPruned()
+	// BanSubnet bans the subnet until the passed time and disconnects the
+	// connected peers within it.
+	BanSubnet(subnet *net.IPNet, until time.Time, reason string) error
+	// UnbanSubnet lifts the ban of the subnet.
+	// Attempting to unban a subnet which is not banned will return an error.
+	UnbanSubnet(subnet *net.IPNet) error
+	// UnbanHost lifts the ban of a host whose address is not an IP address,
+	// such as an onion peer banned for misbehaving.
+	// Attempting to unban a host which is not banned will return an error.
+	UnbanHost(host string) error
+	// BannedSubnets returns the bans which have not expired.
+	BannedSubnets() []connmgr.BanEntry
+	// ClearBanned lifts all bans.
+	ClearBanned() error
 }
 
 // ServerPeer represents a peer for use with the RPC server.
@@ -324,7 +360,6 @@ var (
 		"getreceivedbyaccount":   {},
 		"getreceivedbyaddress":   {},
 		"gettransaction":         {},
-		"gettxoutsetinfo":        {},
 		"getunconfirmedbalance":  {},
 		"getwalletinfo":          {},
 		"importprivkey":          {},
@@ -357,14 +392,18 @@ var (
 	RPCHandlers map[string]CommandHandler
 	// RPCHandlersBeforeInit is
 	RPCHandlersBeforeInit = map[string]CommandHandler{
-		"addnode":              HandleAddNode,
-		"createrawtransaction": HandleCreateRawTransaction,
-		// "debuglevel":            handleDebugLevel,
+		"addnode":               HandleAddNode,
+		"clearbanned":           HandleClearBanned,
+		"createrawtransaction":  HandleCreateRawTransaction,
+		"debuglevel":            HandleDebugLevel,
 		"decoderawtransaction":  HandleDecodeRawTransaction,
 		"decodescript":          HandleDecodeScript,
+		"dumptxoutset":          HandleDumpTxOutSet,
 		"estimatefee":           HandleEstimateFee,
+		"estimatepriority":      HandleEstimatePriority,
 		"generate":              HandleGenerate,
 		"getaddednodeinfo":      HandleGetAddedNodeInfo,
+		"getalgostats":          HandleGetAlgoStats,
 		"getbestblock":          HandleGetBestBlock,
 		"getbestblockhash":      HandleGetBestBlockHash,
 		"getblock":              HandleGetBlock,
@@ -375,6 +414,7 @@ var (
 		"getblocktemplate":      HandleGetBlockTemplate,
 		"getcfilter":            HandleGetCFilter,
 		"getcfilterheader":      HandleGetCFilterHeader,
+		"getchaintips":          HandleGetChainTips,
 		"getconnectioncount":    HandleGetConnectionCount,
 		"getcurrentnet":         HandleGetCurrentNet,
 		"getdifficulty":         HandleGetDifficulty,
@@ -382,20 +422,30 @@ var (
 		"gethashespersec":       HandleGetHashesPerSec,
 		"getheaders":            HandleGetHeaders,
 		"getinfo":               HandleGetInfo,
+		"getmempoolentry":       HandleGetMempoolEntry,
 		"getmempoolinfo":        HandleGetMempoolInfo,
 		"getmininginfo":         HandleGetMiningInfo,
 		"getnettotals":          HandleGetNetTotals,
 		"getnetworkhashps":      HandleGetNetworkHashPS,
+		"getnetworkinfo":        HandleGetNetworkInfo,
 		"getpeerinfo":           HandleGetPeerInfo,
 		"getrawmempool":         HandleGetRawMempool,
 		"getrawtransaction":     HandleGetRawTransaction,
 		"gettxout":              HandleGetTxOut,
+		"gettxoutsetinfo":       HandleGetTxOutSetInfo,
 		"getwork":               HandleGetWork,
+		"getworkerstats":        HandleGetWorkerStats,
 		"help":                  HandleHelp,
+		"invalidateblock":       HandleInvalidateBlock,
+		"listbanned":            HandleListBanned,
+		"loadtxoutset":          HandleLoadTxOutSet,
 		"node":                  HandleNode,
 		"ping":                  HandlePing,
+		"preciousblock":         HandlePreciousBlock,
+		"reconsiderblock":       HandleReconsiderBlock,
 		"searchrawtransactions": HandleSearchRawTransactions,
 		"sendrawtransaction":    HandleSendRawTransaction,
+		"setban":                HandleSetBan,
 		"setgenerate":           HandleSetGenerate,
 		"stop":                  HandleStop,
 		"submitblock":           HandleSubmitBlock,
@@ -424,6 +474,8 @@ var (
 		"decoderawtransaction":  {},
 		"decodescript":          {},
 		"estimatefee":           {},
+		"estimatepriority":      {},
+		"getalgostats":          {},
 		"getbestblock":          {},
 		"getbestblockhash":      {},
 		"getblock":              {},
@@ -432,10 +484,12 @@ var (
 		"getblockheader":        {},
 		"getcfilter":            {},
 		"getcfilterheader":      {},
+		"getchaintips":          {},
 		"getcurrentnet":         {},
 		"getdifficulty":         {},
 		"getheaders":            {},
 		"getinfo":               {},
+		"getmempoolentry":       {},
 		"getnettotals":          {},
 		"getnetworkhashps":      {},
 		"getrawmempool":         {},
@@ -452,14 +506,7 @@ var (
 	// RPCUnimplemented is commands that are currently unimplemented,
 	// but should ultimately be.
 	RPCUnimplemented = map[string]struct{}{
-		"estimatepriority": {},
-		"getchaintips":     {},
-		"getmempoolentry":  {},
-		"getnetworkinfo":   {},
-		"getwork":          {},
-		"invalidateblock":  {},
-		"preciousblock":    {},
-		"reconsiderblock":  {},
+		"getwork": {},
 	}
 )
 
@@ -706,8 +753,8 @@ func (state *GBTWorkState) TemplateUpdateChan(prevHash *chainhash.Hash, lastGene
 // difficulty on testnet per the consesus rules).  Finally, if the
 // useCoinbaseValue flag is false and the existing block template does not
 // already contain a valid payment address, the block template will be updated
-// with a randomly selected payment address from the list of configured
-// addresses. This function MUST be called with the state locked.
+// with a payment address from the configured mining addresses or extended
+// public key. This function MUST be called with the state locked.
 func (state *GBTWorkState) UpdateBlockTemplate(s *Server,
 	useCoinbaseValue bool) error {
 	generator := s.Cfg.Generator
@@ -730,13 +777,18 @@ func (state *GBTWorkState) UpdateBlockTemplate(s *Server,
 		// Reset the previous best hash the block template was generated against
 		// so any errors below cause the next invocation to try again.
 		state.prevHash = nil
-		// Choose a payment address at random if the caller requests a full
-		// coinbase as opposed to only the pertinent details needed to create
-		// their own coinbase.
+		// Choose a payment address if the caller requests a full coinbase as
+		// opposed to only the pertinent details needed to create their own
+		// coinbase.
 		var payAddr util.Address
 		if !useCoinbaseValue {
-			payAddr = s.StateCfg.ActiveMiningAddrs[rand.Intn(len(s.StateCfg.
-				ActiveMiningAddrs))]
+			var err error
+			payAddr, err = generator.PayToAddress()
+			if err != nil {
+				log.ERROR(err)
+				return InternalRPCError(err.Error(),
+					"Failed to choose a payment address")
+			}
 		}
 		// Create a new block template that has a coinbase which anyone can
 		// redeem.  This is only acceptable because the returned block template
@@ -782,11 +834,14 @@ func (state *GBTWorkState) UpdateBlockTemplate(s *Server,
 		// one.  Since this requires mining addresses to be specified via the
 		// config, an error is returned if none have been specified.
 		if !useCoinbaseValue && !template.ValidPayAddress {
-			// Choose a payment address at random.
-			payToAddr := s.StateCfg.ActiveMiningAddrs[rand.Intn(len(s.
-				StateCfg.ActiveMiningAddrs))]
+			payToAddr, err := generator.PayToAddress()
+			if err != nil {
+				log.ERROR(err)
+				return InternalRPCError(err.Error(),
+					"Failed to choose a payment address")
+			}
 			// Update the block coinbase output of the template to pay to the
-			// randomly selected payment address.
+			// chosen payment address.
 			pkScript, err := txscript.PayToAddrScript(payToAddr)
 			if err != nil {
 				log.ERROR(err)
@@ -1798,6 +1853,19 @@ func HandleAskWallet(s *Server, cmd interface{},
 	return nil, ErrRPCNoWallet
 }
 
+// HandleClearBanned handles clearbanned commands.
+func HandleClearBanned(s *Server, cmd interface{},
+	closeChan <-chan struct{}) (interface{}, error) {
+	if err := s.Cfg.ConnMgr.ClearBanned(); err != nil {
+		log.ERROR(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDatabase,
+			Message: "Failed to save ban list: " + err.Error(),
+		}
+	}
+	return nil, nil
+}
+
 // HandleCreateRawTransaction handles createrawtransaction commands.
 func HandleCreateRawTransaction(s *Server, cmd interface{},
 	closeChan <-chan struct{}) (interface{}, error) {
@@ -1898,6 +1966,25 @@ func HandleCreateRawTransaction(s *Server, cmd interface{},
 	return mtxHex, nil
 }
 
+// HandleDebugLevel handles debuglevel commands.
+func HandleDebugLevel(s *Server, cmd interface{},
+	closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*btcjson.DebugLevelCmd)
+	// Special show command to list the current levels.
+	if c.LevelSpec == "show" {
+		return log.GetLevels(), nil
+	}
+	err := log.SetLevels(c.LevelSpec)
+	if err != nil {
+		log.ERROR(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParams.Code,
+			Message: err.Error(),
+		}
+	}
+	return "Done.", nil
+}
+
 // HandleDecodeRawTransaction handles decoderawtransaction commands.
 func HandleDecodeRawTransaction(s *Server, cmd interface{},
 	closeChan <-chan struct{}) (interface{}, error) {
@@ -1978,6 +2065,49 @@ func HandleDecodeScript(s *Server, cmd interface{},
 	return reply, nil
 }
 
+// HandleDumpTxOutSet implements the dumptxoutset command.
+func HandleDumpTxOutSet(s *Server, cmd interface{},
+	closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*btcjson.DumpTxOutSetCmd)
+	path := ResolveDataPath(s, c.Path)
+	// Write to a temporary file first so an interrupted dump never leaves a
+	// truncated snapshot under the requested name.
+	tmpPath := path + ".incomplete"
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		log.ERROR(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Failed to create snapshot file: " + err.Error(),
+		}
+	}
+	w := bufio.NewWriter(f)
+	info, err := s.Cfg.Chain.DumpTxOutSet(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		log.ERROR(err)
+		if rerr := os.Remove(tmpPath); rerr != nil && !os.IsNotExist(rerr) {
+			log.ERROR(rerr)
+		}
+		return nil, InternalRPCError(err.Error(), "Failed to dump utxo set")
+	}
+	return &btcjson.DumpTxOutSetResult{
+		CoinsWritten: info.TxOuts,
+		BaseHash:     info.BestHash.String(),
+		BaseHeight:   info.Height,
+		Path:         path,
+		TxOutSetHash: info.HashSerialized.String(),
+	}, nil
+}
+
 // HandleEstimateFee handles estimatefee commands.
 func HandleEstimateFee(s *Server, cmd interface{},
 	closeChan <-chan struct{}) (interface{}, error) {
@@ -1997,15 +2127,33 @@ func HandleEstimateFee(s *Server, cmd interface{},
 	return float64(feeRate), nil
 }
 
+// HandleEstimatePriority handles estimatepriority commands. The estimate is
+// the lowest current priority that would still fit into the high-priority
+// area of the given number of blocks if they were filled with the
+// transactions in the mempool, or the minimum high priority when there is
+// room to spare. -1 is returned when priority transactions are not relayed.
+func HandleEstimatePriority(s *Server, cmd interface{},
+	closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*btcjson.EstimatePriorityCmd)
+	if c.NumBlocks <= 0 {
+		return -1.0, errors.New("Parameter NumBlocks must be positive")
+	}
+	if *s.Config.NoRelayPriority || *s.Config.BlockPrioritySize <= 0 {
+		return -1.0, nil
+	}
+	return s.Cfg.TxMemPool.EstimatePriority(c.NumBlocks,
+		uint32(*s.Config.BlockPrioritySize)), nil
+}
+
 // HandleGenerate handles generate commands.
 func HandleGenerate(s *Server, cmd interface{},
 	closeChan <-chan struct{}) (interface{}, error) {
 	// Respond with an error if there are no addresses to pay the created blocks
 	// to.
-	if len(s.StateCfg.ActiveMiningAddrs) == 0 {
+	if len(s.StateCfg.ActiveMiningAddrs) == 0 && s.StateCfg.MiningXpub == "" {
 		return nil, &btcjson.RPCError{
 			Code:    btcjson.ErrRPCInternal.Code,
-			Message: "No payment addresses specified via --miningaddr",
+			Message: "No payment addresses specified via --miningaddr or --miningxpub",
 		}
 	}
 	// Respond with an error if there's virtually 0 chance of mining a block
@@ -2134,6 +2282,68 @@ func HandleGetAddedNodeInfo(s *Server, cmd interface{},
 	return results, nil
 }
 
+// HandleGetAlgoStats implements the getalgostats command.
+func HandleGetAlgoStats(s *Server, cmd interface{},
+	closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*btcjson.GetAlgoStatsCmd)
+	numBlocks := defaultAlgoStatsBlocks
+	if c.Blocks != nil {
+		if *c.Blocks <= 0 {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: "The number of blocks must be positive",
+			}
+		}
+		numBlocks = *c.Blocks
+		if numBlocks > maxAlgoStatsBlocks {
+			numBlocks = maxAlgoStatsBlocks
+		}
+	}
+	window, err := s.Cfg.Chain.FetchAlgoStats(int32(numBlocks))
+	if err != nil {
+		log.ERROR(err)
+		context := "Failed to calculate algorithm statistics"
+		return nil, InternalRPCError(err.Error(), context)
+	}
+	return &btcjson.GetAlgoStatsResult{
+		Height:   window.Height,
+		Blocks:   window.Blocks,
+		Timespan: window.Timespan,
+		Algos:    AlgoStatsResults(s, window),
+	}, nil
+}
+
+// RecentAlgoStats returns the statistics of each proof of work algorithm over
+// the default number of blocks, which are nil when they can't be calculated.
+func RecentAlgoStats(s *Server) []btcjson.AlgoStatsResult {
+	window := s.Cfg.Chain.RecentAlgoStats(defaultAlgoStatsBlocks)
+	if window == nil {
+		return nil
+	}
+	return AlgoStatsResults(s, window)
+}
+
+// AlgoStatsResults returns the difficulty and the estimated network hash rate
+// of each proof of work algorithm over the window.
+func AlgoStatsResults(s *Server,
+	window *blockchain.AlgoWindow) []btcjson.AlgoStatsResult {
+	algos := make([]btcjson.AlgoStatsResult, len(window.Algos))
+	for i, algo := range window.Algos {
+		algos[i] = btcjson.AlgoStatsResult{
+			Algo:    algo.Name,
+			Version: algo.Version,
+			Bits:    strconv.FormatInt(int64(algo.Bits), 16),
+			Difficulty: GetDifficultyRatio(algo.Bits, s.Cfg.ChainParams,
+				algo.Version),
+			NetworkHashPS:  algo.HashesPerSec,
+			Blocks:         algo.Blocks,
+			AvgInterval:    algo.AvgInterval,
+			TargetInterval: algo.TargetInterval,
+		}
+	}
+	return algos
+}
+
 // HandleGetBestBlock implements the getbestblock command.
 func HandleGetBestBlock(s *Server, cmd interface{},
 	closeChan <-chan struct{}) (interface{}, error) {
@@ -2276,9 +2486,13 @@ func HandleGetBlockChainInfo(s *Server, cmd interface{},
 		BestBlockHash: chainSnapshot.Hash.String(),
 		Difficulty:    GetDifficultyRatio(chainSnapshot.Bits, params, 2),
 		MedianTime:    chainSnapshot.MedianTime.Unix(),
-		Pruned:        false,
+		Pruned:        chain.IsPruned(),
 		Bip9SoftForks: make(map[string]*btcjson.Bip9SoftForkDescription),
 	}
+	if chainInfo.Pruned {
+		chainInfo.PruneHeight = chain.PruneHeight()
+	}
+	chainInfo.Algos = RecentAlgoStats(s)
 	// Next, populate the response with information describing the current
 	// status of soft-forks deployed via the super-majority block signalling
 	// mechanism.
@@ -2655,12 +2869,13 @@ func HandleGetBlockTemplateRequest(s *Server, request *btcjson.TemplateRequest,
 	}
 	// When a coinbase transaction has been requested, respond with an error if
 	// there are no addresses to pay the created block template to.
-	if !useCoinbaseValue && len(s.StateCfg.ActiveMiningAddrs) == 0 {
+	if !useCoinbaseValue && len(s.StateCfg.ActiveMiningAddrs) == 0 &&
+		s.StateCfg.MiningXpub == "" {
 		return nil, &btcjson.RPCError{
 			Code: btcjson.ErrRPCInternal.Code,
 			Message: "A coinbase transaction has been requested, " +
 				"but the server has not been configured with " +
-				"any payment addresses via --miningaddr",
+				"any payment addresses via --miningaddr or --miningxpub",
 		}
 	}
 	// Return an error if there are no peers connected since there is no way to
@@ -2777,6 +2992,22 @@ func HandleGetCFilterHeader(s *Server, cmd interface{},
 	return hash.String(), nil
 }
 
+// HandleGetChainTips implements the getchaintips command.
+func HandleGetChainTips(s *Server, cmd interface{},
+	closeChan <-chan struct{}) (interface{}, error) {
+	tips := s.Cfg.Chain.ChainTips()
+	result := make([]btcjson.GetChainTipsResult, len(tips))
+	for i := range tips {
+		result[i] = btcjson.GetChainTipsResult{
+			Height:    tips[i].Height,
+			Hash:      tips[i].Hash.String(),
+			BranchLen: tips[i].BranchLen,
+			Status:    string(tips[i].Status),
+		}
+	}
+	return result, nil
+}
+
 // HandleGetConnectionCount implements the getconnectioncount command.
 func HandleGetConnectionCount(s *Server, cmd interface{},
 	closeChan <-chan struct{}) (interface{}, error) {
@@ -3084,6 +3315,26 @@ func HandleGetInfo(s *Server, cmd interface{},
 	return ret, nil
 }
 
+// HandleGetMempoolEntry implements the getmempoolentry command.
+func HandleGetMempoolEntry(s *Server, cmd interface{},
+	closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*btcjson.GetMempoolEntryCmd)
+	txHash, err := chainhash.NewHashFromStr(c.TxID)
+	if err != nil {
+		log.ERROR(err)
+		return nil, DecodeHexError(c.TxID)
+	}
+	entry, err := s.Cfg.TxMemPool.MempoolEntry(txHash)
+	if err != nil {
+		log.DEBUG(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: "Transaction not in mempool",
+		}
+	}
+	return entry, nil
+}
+
 // HandleGetMempoolInfo implements the getmempoolinfo command.
 func HandleGetMempoolInfo(s *Server, cmd interface{},
 	closeChan <-chan struct{}) (interface{}, error) {
@@ -3120,6 +3371,7 @@ func HandleGetMiningInfo(s *Server, cmd interface{},
 			Message: "networkHashesPerSec is not an int64",
 		}
 	}
+	algos := RecentAlgoStats(s)
 	var Difficulty, dBlake2b, dBlake14lr, dBlake2s, dKeccak, dScrypt, dSHA256D,
 		dSkein, dStribog, dX11 float64
 	var lastbitsBlake2b, lastbitsBlake14lr, lastbitsBlake2s, lastbitsKeccak,
@@ -3174,6 +3426,7 @@ func HandleGetMiningInfo(s *Server, cmd interface{},
 			NetworkHashPS:      networkHashesPerSec,
 			PooledTx:           uint64(s.Cfg.TxMemPool.Count()),
 			TestNet:            (*s.Config.Network)[0] == 't',
+			Algos:              algos,
 		}
 	case 1:
 		foundcount, height := 0, best.Height
@@ -3292,6 +3545,7 @@ func HandleGetMiningInfo(s *Server, cmd interface{},
 			NetworkHashPS:       networkHashesPerSec,
 			PooledTx:            uint64(s.Cfg.TxMemPool.Count()),
 			TestNet:             (*s.Config.Network)[0] == 't',
+			Algos:               algos,
 		}
 	}
 	return ret, nil
@@ -3401,6 +3655,93 @@ func HandleGetNetworkHashPS(s *Server, cmd interface{},
 	return hashesPerSec.Int64(), nil
 }
 
+// HandleGetNetworkInfo implements the getnetworkinfo command.
+func HandleGetNetworkInfo(s *Server, cmd interface{},
+	closeChan <-chan struct{}) (interface{}, error) {
+	var comments []string
+	if s.Config.UserAgentComments != nil {
+		comments = *s.Config.UserAgentComments
+	}
+	msg := &wire.MsgVersion{UserAgent: wire.DefaultUserAgent}
+	if err := msg.AddUserAgent(UserAgentName, UserAgentVersion,
+		comments...); err != nil {
+		log.ERROR(err)
+	}
+	// Onion addresses are reached through the onion proxy when one is
+	// configured and through the main proxy otherwise.
+	proxy := *s.Config.Proxy
+	onionProxy := *s.Config.OnionProxy
+	if onionProxy == "" {
+		onionProxy = proxy
+	}
+	// The clearnet networks are reached through the proxy when one is
+	// configured and otherwise only those the node is listening on.
+	listening := make(map[string]bool)
+	if !*s.Config.DisableListen {
+		listeners, err := ParseListeners(*s.Config.Listeners)
+		if err != nil {
+			log.ERROR(err)
+		}
+		for _, l := range listeners {
+			listening[l.Network()] = true
+		}
+	}
+	networks := []btcjson.NetworksResult{
+		{
+			Name:                      "ipv4",
+			Reachable:                 proxy != "" || listening["tcp4"],
+			Proxy:                     proxy,
+			ProxyRandomizeCredentials: *s.Config.TorIsolation && proxy != "",
+		},
+		{
+			Name:                      "ipv6",
+			Reachable:                 proxy != "" || listening["tcp6"],
+			Proxy:                     proxy,
+			ProxyRandomizeCredentials: *s.Config.TorIsolation && proxy != "",
+		},
+		{
+			Name:                      "onion",
+			Limited:                   !*s.Config.Onion,
+			Reachable:                 *s.Config.Onion && onionProxy != "",
+			Proxy:                     onionProxy,
+			ProxyRandomizeCredentials: *s.Config.TorIsolation && onionProxy != "",
+		},
+	}
+	localAddrs := s.Cfg.ConnMgr.LocalAddresses()
+	addresses := make([]btcjson.LocalAddressesResult, 0, len(localAddrs))
+	for _, la := range localAddrs {
+		host, _, err := net.SplitHostPort(addrmgr.NetAddressKey(la.NetAddress))
+		if err != nil {
+			log.ERROR(err)
+			continue
+		}
+		addresses = append(addresses, btcjson.LocalAddressesResult{
+			Address: host,
+			Port:    la.NetAddress.Port,
+			Score:   int32(la.Score),
+		})
+	}
+	reply := &btcjson.GetNetworkInfoResult{
+		Version: int32(
+			1000000*version.AppMajor +
+				10000*version.AppMinor +
+				100*version.AppPatch),
+		SubVersion:      msg.UserAgent,
+		ProtocolVersion: int32(MaxProtocolVersion),
+		LocalServices:   fmt.Sprintf("%016x", uint64(s.Cfg.ConnMgr.Services())),
+		LocalRelay:      !*s.Config.BlocksOnly,
+		TimeOffset:      int64(s.Cfg.TimeSource.Offset().Seconds()),
+		Connections:     s.Cfg.ConnMgr.ConnectedCount(),
+		NetworkActive:   true,
+		Networks:        networks,
+		RelayFee:        s.StateCfg.ActiveMinRelayTxFee.ToDUO(),
+		IncrementalFee:  s.StateCfg.ActiveMinRelayTxFee.ToDUO(),
+		LocalAddresses:  addresses,
+		Warnings:        s.Cfg.Chain.Warnings(),
+	}
+	return reply, nil
+}
+
 // HandleGetPeerInfo implements the getpeerinfo command.
 func HandleGetPeerInfo(s *Server, cmd interface{},
 	closeChan <-chan struct{}) (interface{}, error) {
@@ -3673,6 +4014,35 @@ func HandleGetTxOut(s *Server, cmd interface{},
 	return txOutReply, nil
 }
 
+// HandleGetTxOutSetInfo handles gettxoutsetinfo commands.
+func HandleGetTxOutSetInfo(s *Server, cmd interface{},
+	closeChan <-chan struct{}) (interface{}, error) {
+	info, err := s.Cfg.Chain.FetchTxOutSetInfo()
+	if err != nil {
+		log.ERROR(err)
+		return nil, InternalRPCError(err.Error(), "Failed to scan utxo set")
+	}
+	coinbaseAmounts := make(map[string]float64, len(info.CoinbaseAmounts))
+	for algo, amount := range info.CoinbaseAmounts {
+		coinbaseAmounts[algo] = amount.ToDUO()
+	}
+	return &btcjson.GetTxOutSetInfoResult{
+		Height:          int64(info.Height),
+		BestBlock:       info.BestHash.String(),
+		Transactions:    info.Transactions,
+		TxOuts:          info.TxOuts,
+		HashSerialized:  info.HashSerialized.String(),
+		TotalAmount:     info.TotalAmount.ToDUO(),
+		CoinbaseAmounts: coinbaseAmounts,
+	}, nil
+}
+
+// HandleGetWorkerStats implements the getworkerstats command.
+func HandleGetWorkerStats(s *Server, cmd interface{},
+	closeChan <-chan struct{}) (interface{}, error) {
+	return s.Cfg.WorkerStats.Result(), nil
+}
+
 // HandleHelp implements the help command.
 func HandleHelp(s *Server, cmd interface{}, closeChan <-chan struct{}) (
 	interface{}, error) {
@@ -3711,6 +4081,78 @@ func HandleHelp(s *Server, cmd interface{}, closeChan <-chan struct{}) (
 	return help, nil
 }
 
+// HandleInvalidateBlock implements the invalidateblock command.
+func HandleInvalidateBlock(s *Server, cmd interface{},
+	closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*btcjson.InvalidateBlockCmd)
+	hash, err := LookupBlockIndexHash(s, c.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.Cfg.Chain.InvalidateBlock(hash); err != nil {
+		log.ERROR(err)
+		return nil, InternalRPCError(err.Error(), "Failed to invalidate block")
+	}
+	return nil, nil
+}
+
+// HandleListBanned handles listbanned commands.
+func HandleListBanned(s *Server, cmd interface{},
+	closeChan <-chan struct{}) (interface{}, error) {
+	bans := s.Cfg.ConnMgr.BannedSubnets()
+	result := make([]btcjson.ListBannedResult, 0, len(bans))
+	for _, ban := range bans {
+		result = append(result, btcjson.ListBannedResult{
+			Address:     ban.String(),
+			BanCreated:  ban.Created.Unix(),
+			BannedUntil: ban.Until.Unix(),
+			BanReason:   ban.Reason,
+		})
+	}
+	return result, nil
+}
+
+// HandleLoadTxOutSet implements the loadtxoutset command.
+func HandleLoadTxOutSet(s *Server, cmd interface{},
+	closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*btcjson.LoadTxOutSetCmd)
+	expected, err := chainhash.NewHashFromStr(c.Hash)
+	if err != nil {
+		log.ERROR(err)
+		return nil, DecodeHexError(c.Hash)
+	}
+	path := ResolveDataPath(s, c.Path)
+	f, err := os.Open(path)
+	if err != nil {
+		log.ERROR(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Failed to open snapshot file: " + err.Error(),
+		}
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.ERROR(err)
+		}
+	}()
+	info, err := s.Cfg.Chain.LoadTxOutSet(bufio.NewReader(f), expected)
+	if err != nil {
+		log.ERROR(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "Failed to load utxo set: " + err.Error(),
+		}
+	}
+	// The blocks below the snapshot can't be served to peers.
+	s.Cfg.ConnMgr.SetPruned()
+	return &btcjson.LoadTxOutSetResult{
+		CoinsLoaded: info.TxOuts,
+		BaseHash:    info.BestHash.String(),
+		BaseHeight:  info.Height,
+		Path:        path,
+	}, nil
+}
+
 // HandleNode handles node commands.
 func HandleNode(s *Server, cmd interface{}, closeChan <-chan struct{}) (
 	interface{}, error) {
@@ -3813,6 +4255,36 @@ func HandlePing(s *Server, cmd interface{}, closeChan <-chan struct{}) (
 	return nil, nil
 }
 
+// HandlePreciousBlock implements the preciousblock command.
+func HandlePreciousBlock(s *Server, cmd interface{},
+	closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*btcjson.PreciousBlockCmd)
+	hash, err := LookupBlockIndexHash(s, c.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.Cfg.Chain.PreciousBlock(hash); err != nil {
+		log.ERROR(err)
+		return nil, InternalRPCError(err.Error(), "Failed to prefer block")
+	}
+	return nil, nil
+}
+
+// HandleReconsiderBlock implements the reconsiderblock command.
+func HandleReconsiderBlock(s *Server, cmd interface{},
+	closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*btcjson.ReconsiderBlockCmd)
+	hash, err := LookupBlockIndexHash(s, c.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.Cfg.Chain.ReconsiderBlock(hash); err != nil {
+		log.ERROR(err)
+		return nil, InternalRPCError(err.Error(), "Failed to reconsider block")
+	}
+	return nil, nil
+}
+
 // HandleSearchRawTransactions implements the searchrawtransactions command.
 // TODO: simplify this, break it up
 func HandleSearchRawTransactions(s *Server, cmd interface{},
@@ -4125,6 +4597,73 @@ func HandleSendRawTransaction(s *Server, cmd interface{},
 	return tx.Hash().String(), nil
 }
 
+// HandleSetBan handles setban commands.
+func HandleSetBan(s *Server, cmd interface{},
+	closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*btcjson.SetBanCmd)
+	subnet, err := connmgr.ParseSubnet(c.Subnet)
+	// hosts that are not IP addresses are only banned for misbehaving, but
+	// they can be unbanned by their host
+	if err != nil && c.SubCmd == btcjson.SBRemove {
+		subnet, err = nil, nil
+	}
+	if err != nil {
+		log.ERROR(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: err.Error(),
+		}
+	}
+	switch c.SubCmd {
+	case btcjson.SBAdd:
+		until := time.Now().Add(*s.Config.BanDuration)
+		switch {
+		case c.BanTime != nil && *c.BanTime < 0:
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: "bantime must not be negative",
+			}
+		case c.BanTime != nil && c.Absolute != nil && *c.Absolute:
+			until = time.Unix(*c.BanTime, 0)
+			if !until.After(time.Now()) {
+				return nil, &btcjson.RPCError{
+					Code:    btcjson.ErrRPCInvalidParameter,
+					Message: "absolute bantime must be in the future",
+				}
+			}
+		case c.BanTime != nil && *c.BanTime > 0:
+			until = time.Now().Add(time.Duration(*c.BanTime) * time.Second)
+		}
+		err = s.Cfg.ConnMgr.BanSubnet(subnet, until, "manually added")
+	case btcjson.SBRemove:
+		if subnet != nil {
+			err = s.Cfg.ConnMgr.UnbanSubnet(subnet)
+		} else {
+			err = s.Cfg.ConnMgr.UnbanHost(c.Subnet)
+		}
+		if err == connmgr.ErrNotBanned {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCMisc,
+				Message: "Unban failed: " + err.Error(),
+			}
+		}
+	default:
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "invalid subcommand for setban",
+		}
+	}
+	if err != nil {
+		log.ERROR(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDatabase,
+			Message: "Failed to save ban list: " + err.Error(),
+		}
+	}
+	// no data returned unless an error.
+	return nil, nil
+}
+
 // HandleSetGenerate implements the setgenerate command.
 func HandleSetGenerate(s *Server, cmd interface{},
 	closeChan <-chan struct{}) (interface{}, error) {
@@ -4153,10 +4692,10 @@ func HandleSetGenerate(s *Server, cmd interface{},
 	} else {
 		// Respond with an error if there are no addresses to pay the created
 		// blocks to.
-		if len(s.StateCfg.ActiveMiningAddrs) == 0 {
+		if len(s.StateCfg.ActiveMiningAddrs) == 0 && s.StateCfg.MiningXpub == "" {
 			return nil, &btcjson.RPCError{
 				Code:    btcjson.ErrRPCInternal.Code,
-				Message: "no payment addresses specified via --miningaddr",
+				Message: "no payment addresses specified via --miningaddr or --miningxpub",
 			}
 		}
 		// It's safe to call start even if it's already started.
@@ -4369,6 +4908,25 @@ func JSONAuthFail(w http.ResponseWriter) {
 	http.Error(w, "401 Unauthorized.", http.StatusUnauthorized)
 }
 
+// LookupBlockIndexHash decodes a block hash given to an RPC and ensures the
+// block is present in the block index, on either the main or a side chain.
+func LookupBlockIndexHash(s *Server, blockHash string) (*chainhash.Hash,
+	error) {
+	hash, err := chainhash.NewHashFromStr(blockHash)
+	if err != nil {
+		log.ERROR(err)
+		return nil, DecodeHexError(blockHash)
+	}
+	if _, err = s.Cfg.Chain.HeaderByHash(hash); err != nil {
+		log.ERROR(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCBlockNotFound,
+			Message: "Block not found",
+		}
+	}
+	return hash, nil
+}
+
 // MessageToHex serializes a message to the wire protocol encoding using the
 // latest protocol version and returns a hex-encoded string of the result.
 func MessageToHex(msg wire.Message) (string, error) {
@@ -4477,6 +5035,15 @@ func NoTxInfoError(txHash *chainhash.Hash) *btcjson.RPCError {
 			txHash))
 }
 
+// ResolveDataPath returns the passed file path, with relative paths taken to
+// be inside the data directory of the active network.
+func ResolveDataPath(s *Server, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(*s.Config.DataDir, s.Cfg.ChainParams.Name, path)
+}
+
 // SoftForkStatus converts a ThresholdState state into a human readable string
 // corresponding to the particular state.
 func SoftForkStatus(state blockchain.ThresholdState) (string, error) {
@@ -4542,25 +5109,6 @@ func VerifyChain(s *Server, level, depth int32) error {
 	return nil
 }
 
-/*
-// handleDebugLevel handles debuglevel commands.
-func handleDebugLevel(	s *RPCServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
-	c := cmd.(*json.DebugLevelCmd)
-	// Special show command to list supported subsystems.
-	if c.LevelSpec == "show" {
-		return fmt.Sprintf("Supported subsystems %v",
-			supportedSubsystems()), nil
-	}
-	err := parseAndSetDebugLevels(c.LevelSpec)
-	if err != nil {
-		return nil, &json.RPCError{
-			Code:    json.ErrRPCInvalidParams.Code,
-			Message: err.Error(),
-		}
-	}
-	return "Done.", nil
-}
-*/
 // WitnessToHex formats the passed witness stack as a slice of hex-encoded
 // strings to be used in a JSON response.
 func WitnessToHex(witness wire.TxWitness) []string {
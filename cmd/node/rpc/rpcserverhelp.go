@@ -15,16 +15,16 @@ import (
 var HelpDescsEnUS = map[string]string{
 	// DebugLevelCmd help.
 	"debuglevel--synopsis": "Dynamically changes the debug logging level.\n" +
-		"The levelspec can either a debug level or of the form:\n" +
-		"<subsystem>=<level>,<subsystem2>=<level2>,...\n" +
-		"The valid debug levels are trace, debug, info, warn, error, and critical.\n" +
-		"The valid subsystems are AMGR, ADXR, BCDB, BMGR, NODE, CHAN, DISC, PEER, RPCS, SCRP, SRVR, and TXMP.\n" +
-		"Finally the keyword 'show' will return a list of the available subsystems.",
+		"The levelspec can either be a debug level or of the form:\n" +
+		"<level>,<package>=<level>,<package2>=<level2>,...\n" +
+		"The valid debug levels are off, trace, debug, info, warn, error, and fatal, and reset sets a package back to the level for all packages.\n" +
+		"Packages are given by path like pkg/chain, and the level is also used for the packages below it.\n" +
+		"Finally the keyword 'show' will return the current levels.",
 	"debuglevel-levelspec":   "The debug level(s) to use or the keyword 'show'",
 	"debuglevel--condition0": "levelspec!=show",
 	"debuglevel--condition1": "levelspec=show",
 	"debuglevel--result0":    "The string 'Done.'",
-	"debuglevel--result1":    "The list of subsystems",
+	"debuglevel--result1":    "The current levels in the form of a levelspec",
 	// AddNodeCmd help.
 	"addnode--synopsis": "Attempts to add or remove a persistent peer.",
 	"addnode-addr":      "IP address and port of the peer to operate on",
@@ -38,6 +38,8 @@ var HelpDescsEnUS = map[string]string{
 	"node-target": "Either the IP address and port of the peer to" +
 		" operate on, or a valid peer ID.",
 	"node-connectsubcmd": "'perm' to make the connected peer a permanent one, 'temp' to try a single connect to a peer",
+	// ClearBannedCmd help.
+	"clearbanned--synopsis": "Lifts the bans of all addresses and subnets.",
 	// TransactionInput help.
 	"transactioninput-txid": "The hash of the input transaction",
 	"transactioninput-vout": "The specific output of the input transaction to redeem",
@@ -113,6 +115,21 @@ var HelpDescsEnUS = map[string]string{
 	"decodescript--synopsis": "Returns a JSON object with information about" +
 		" the provided hex-encoded script.",
 	"decodescript-hexscript": "Hex-encoded script",
+	// DumpTxOutSetCmd help.
+	"dumptxoutset--synopsis": "Writes a snapshot of the unspent transaction" +
+		" output set as of the best block to a file, along with the headers" +
+		" of the main chain up to that block.\n" +
+		"A node which has not synced any blocks yet can start from the" +
+		" snapshot with loadtxoutset.",
+	"dumptxoutset-path": "The file to write, relative to the network data" +
+		" directory unless absolute; it must not exist yet",
+	// DumpTxOutSetResult help.
+	"dumptxoutsetresult-coins_written": "The number of unspent outputs written",
+	"dumptxoutsetresult-base_hash":     "The hash of the block the snapshot was taken at",
+	"dumptxoutsetresult-base_height":   "The height of the block the snapshot was taken at",
+	"dumptxoutsetresult-path":          "The full path of the written file",
+	"dumptxoutsetresult-txoutset_hash": "The hash of the snapshot, which the" +
+		" loading node must be given to trust it",
 	// EstimateFeeCmd help.
 	"estimatefee--synopsis": "Estimate the fee per kilobyte in satoshis " +
 		"required for a transaction to be mined before a certain number of " +
@@ -121,6 +138,14 @@ var HelpDescsEnUS = map[string]string{
 		"generated before the transaction is mined.",
 	"estimatefee--result0": "Estimated fee per kilobyte in satoshis for a block to " +
 		"be mined in the next NumBlocks blocks.",
+	// EstimatePriorityCmd help.
+	"estimatepriority--synopsis": "Estimate the priority a transaction needs" +
+		" to be mined without a fee before a certain number of blocks have" +
+		" been generated.",
+	"estimatepriority-numblocks": "The maximum number of blocks which can be" +
+		" generated before the transaction is mined.",
+	"estimatepriority--result0": "Estimated priority, or -1 if transactions" +
+		" are not relayed or mined based on their priority.",
 	// GenerateCmd help
 	"generate--synopsis": "Generates a set number of blocks (simnet or" +
 		" regtest only) and returns a JSON\n" +
@@ -143,6 +168,35 @@ var HelpDescsEnUS = map[string]string{
 	"getaddednodeinfo--condition0": "dns=false",
 	"getaddednodeinfo--condition1": "dns=true",
 	"getaddednodeinfo--result0":    "List of added peers",
+	// GetAlgoStatsCmd help.
+	"getalgostats--synopsis": "Returns the difficulty and the estimated" +
+		" network hashes per second of each proof of work algorithm, along" +
+		" with how often each found blocks over the most recent blocks.",
+	"getalgostats-blocks": "The number of most recent blocks to calculate" +
+		" the statistics over, at most 10000 with larger numbers capped to" +
+		" it",
+	// GetAlgoStatsResult help.
+	"getalgostatsresult-height": "Height of the latest best block",
+	"getalgostatsresult-blocks": "The number of blocks the statistics are" +
+		" calculated over, fewer than requested if the chain or the current" +
+		" hard fork does not have as many",
+	"getalgostatsresult-timespan": "The seconds from the block before the" +
+		" first one counted to the latest best block",
+	"getalgostatsresult-algos": "The statistics of each algorithm",
+	// AlgoStatsResult help.
+	"algostatsresult-algo":    "The name of the algorithm",
+	"algostatsresult-version": "The block version identifying the algorithm",
+	"algostatsresult-bits": "The hex-encoded target the next block found" +
+		" with the algorithm must meet",
+	"algostatsresult-difficulty": "The difficulty of the next block found" +
+		" with the algorithm",
+	"algostatsresult-networkhashps": "Estimated network hashes of the" +
+		" algorithm per second",
+	"algostatsresult-blocks": "The number of blocks found with the algorithm",
+	"algostatsresult-avginterval": "The average seconds between blocks found" +
+		" with the algorithm, or 0 if it found none",
+	"algostatsresult-targetinterval": "The seconds between blocks found with" +
+		" the algorithm the difficulty adjustment aims for",
 	// GetBestBlockResult help.
 	"getbestblockresult-hash":   "Hex-encoded bytes of the best block hash",
 	"getbestblockresult-height": "Height of the best block",
@@ -199,6 +253,8 @@ var HelpDescsEnUS = map[string]string{
 		" particular BIP009 deployment",
 	"getblockchaininforesult-bip9_softforks--desc": "The status of any" +
 		" defined BIP0009 soft-fork deployments",
+	"getblockchaininforesult-algos": "The difficulty and estimated network" +
+		" hashes per second of each algorithm over the most recent blocks",
 	// SoftForkDescription help.
 	"softforkdescription-reject": "The current activation status of the" +
 		" softfork",
@@ -370,6 +426,19 @@ var HelpDescsEnUS = map[string]string{
 	"getcfilterheader-filtertype": "The type of filter header to return (0=regular)",
 	"getcfilterheader-hash":       "The hash of the block",
 	"getcfilterheader--result0":   "The block's gcs filter header",
+	// GetChainTipsCmd help.
+	"getchaintips--synopsis": "Returns information about all known tips in" +
+		" the block tree, including the main chain and any side branches.",
+	// GetChainTipsResult help.
+	"getchaintipsresult-height": "Height of the chain tip",
+	"getchaintipsresult-hash":   "Block hash of the chain tip",
+	"getchaintipsresult-branchlen": "Number of blocks between the tip and" +
+		" the point where it forks from the main chain, zero for the main chain",
+	"getchaintipsresult-status": "Status of the branch: 'active' for the" +
+		" main chain, 'valid-fork' for a fully validated side branch," +
+		" 'valid-headers' for a side branch that has not been validated," +
+		" 'headers-only' for a branch missing block data, 'invalid' for a" +
+		" branch containing an invalid block",
 	// GetConnectionCountCmd help.
 	"getconnectioncount--synopsis": "Returns the number of active connections to other peers.",
 	"getconnectioncount--result0":  "The number of connections",
@@ -436,24 +505,57 @@ var HelpDescsEnUS = map[string]string{
 		" not guaranteed)",
 	// GetInfoCmd help.
 	"getinfo--synopsis": "Returns a JSON object containing various state info.",
+	// GetMempoolEntryCmd help.
+	"getmempoolentry--synopsis": "Returns mempool data for the given" +
+		" transaction.",
+	"getmempoolentry-txid": "The hash of the transaction",
+	// GetMempoolEntryResult help.
+	"getmempoolentryresult-size":             "Virtual transaction size in bytes",
+	"getmempoolentryresult-fee":              "Transaction fee in DUO",
+	"getmempoolentryresult-modifiedfee":      "Transaction fee in DUO used for mining priority",
+	"getmempoolentryresult-time":             "Local time transaction entered pool in seconds since 1 Jan 1970 GMT",
+	"getmempoolentryresult-height":           "Block height when transaction entered pool",
+	"getmempoolentryresult-startingpriority": "Priority when transaction entered pool",
+	"getmempoolentryresult-currentpriority":  "Current priority",
+	"getmempoolentryresult-descendantcount":  "Number of in-mempool descendant transactions, including this one",
+	"getmempoolentryresult-descendantsize":   "Virtual size of in-mempool descendants, including this one",
+	"getmempoolentryresult-descendantfees":   "Fees of in-mempool descendants in DUO, including this one",
+	"getmempoolentryresult-ancestorcount":    "Number of in-mempool ancestor transactions, including this one",
+	"getmempoolentryresult-ancestorsize":     "Virtual size of in-mempool ancestors, including this one",
+	"getmempoolentryresult-ancestorfees":     "Fees of in-mempool ancestors in DUO, including this one",
+	"getmempoolentryresult-depends":          "Unconfirmed transactions used as inputs for this transaction",
 	// GetMempoolInfoCmd help.
 	"getmempoolinfo--synopsis": "Returns memory pool information",
 	// GetMempoolInfoResult help.
 	"getmempoolinforesult-bytes": "Size in bytes of the mempool",
 	"getmempoolinforesult-size":  "Number of transactions in the mempool",
 	// GetMiningInfoResult help.
-	"getmininginforesult-blocks":             "Height of the latest best block",
-	"getmininginforesult-currentblocksize":   "Size of the latest best block",
-	"getmininginforesult-currentblockweight": "Weight of the latest best block",
-	"getmininginforesult-currentblocktx":     "Number of transactions in the latest best block",
-	"getmininginforesult-difficulty":         "Current target difficulty",
-	"getmininginforesult-errors":             "Any current errors",
-	"getmininginforesult-generate":           "Whether or not server is set to generate coins",
-	"getmininginforesult-genproclimit":       "Number of processors to use for coin generation (-1 when disabled)",
-	"getmininginforesult-hashespersec":       "Recent hashes per second performance measurement while generating coins",
-	"getmininginforesult-networkhashps":      "Estimated network hashes per second for the most recent blocks",
-	"getmininginforesult-pooledtx":           "Number of transactions in the memory pool",
-	"getmininginforesult-testnet":            "Whether or not server is using testnet",
+	"getmininginforesult-blocks":               "Height of the latest best block",
+	"getmininginforesult-currentblocksize":     "Size of the latest best block",
+	"getmininginforesult-currentblockweight":   "Weight of the latest best block",
+	"getmininginforesult-currentblocktx":       "Number of transactions in the latest best block",
+	"getmininginforesult-difficulty":           "Current target difficulty",
+	"getmininginforesult-pow_algo_id":          "The algorithm ID of the configured mining algorithm",
+	"getmininginforesult-pow_algo":             "The name of the configured mining algorithm",
+	"getmininginforesult-difficulty_blake2b":   "Current target difficulty of blake2b",
+	"getmininginforesult-difficulty_blake14lr": "Current target difficulty of blake14lr",
+	"getmininginforesult-difficulty_blake2s":   "Current target difficulty of blake2s",
+	"getmininginforesult-difficulty_keccak":    "Current target difficulty of keccak",
+	"getmininginforesult-difficulty_scrypt":    "Current target difficulty of scrypt",
+	"getmininginforesult-difficulty_sha256d":   "Current target difficulty of sha256d",
+	"getmininginforesult-difficulty_skein":     "Current target difficulty of skein",
+	"getmininginforesult-difficulty_stribog":   "Current target difficulty of stribog",
+	"getmininginforesult-difficulty_x11":       "Current target difficulty of x11",
+	"getmininginforesult-genalgo":              "The algorithm the CPU miner uses",
+	"getmininginforesult-errors":               "Any current errors",
+	"getmininginforesult-generate":             "Whether or not server is set to generate coins",
+	"getmininginforesult-genproclimit":         "Number of processors to use for coin generation (-1 when disabled)",
+	"getmininginforesult-hashespersec":         "Recent hashes per second performance measurement while generating coins",
+	"getmininginforesult-networkhashps":        "Estimated network hashes per second for the most recent blocks",
+	"getmininginforesult-pooledtx":             "Number of transactions in the memory pool",
+	"getmininginforesult-testnet":              "Whether or not server is using testnet",
+	"getmininginforesult-algos": "The difficulty and estimated network hashes" +
+		" per second of each algorithm over the most recent blocks",
 	// GetMiningInfoCmd help.
 	"getmininginfo--synopsis": "Returns a JSON object containing mining-related information.",
 	// GetNetworkHashPSCmd help.
@@ -471,6 +573,33 @@ var HelpDescsEnUS = map[string]string{
 	"getnettotalsresult-totalbytesrecv": "Total bytes received",
 	"getnettotalsresult-totalbytessent": "Total bytes sent",
 	"getnettotalsresult-timemillis":     "Number of milliseconds since 1 Jan 1970 GMT",
+	// GetNetworkInfoCmd help.
+	"getnetworkinfo--synopsis": "Returns a JSON object containing" +
+		" information about the peer-to-peer network.",
+	// GetNetworkInfoResult help.
+	"getnetworkinforesult-version":         "The version of the node as a numeric value",
+	"getnetworkinforesult-subversion":      "The user agent the node advertises to peers",
+	"getnetworkinforesult-protocolversion": "The latest supported protocol version",
+	"getnetworkinforesult-localservices":   "The services the node offers to the network, as hex",
+	"getnetworkinforesult-localrelay":      "Whether transactions are requested from peers",
+	"getnetworkinforesult-timeoffset":      "The time offset from the network in seconds",
+	"getnetworkinforesult-connections":     "The number of connected peers",
+	"getnetworkinforesult-networkactive":   "Whether peer-to-peer networking is enabled",
+	"getnetworkinforesult-networks":        "Information about each network",
+	"getnetworkinforesult-relayfee":        "Minimum relay fee for transactions in DUO/kB",
+	"getnetworkinforesult-incrementalfee":  "Minimum fee increment for mempool replacement in DUO/kB",
+	"getnetworkinforesult-localaddresses":  "The local addresses the node advertises",
+	"getnetworkinforesult-warnings":        "Any network or blockchain warnings",
+	// NetworksResult help.
+	"networksresult-name":                        "The network name (ipv4, ipv6 or onion)",
+	"networksresult-limited":                     "Whether connections to the network are disabled",
+	"networksresult-reachable":                   "Whether the network can be connected to",
+	"networksresult-proxy":                       "The proxy used for the network, if any",
+	"networksresult-proxy_randomize_credentials": "Whether random proxy credentials are used for each connection (Tor stream isolation)",
+	// LocalAddressesResult help.
+	"localaddressesresult-address": "The local address",
+	"localaddressesresult-port":    "The port the address is advertised with",
+	"localaddressesresult-score":   "The priority the address is advertised with",
 	// GetPeerInfoResult help.
 	"getpeerinforesult-id":        "A unique node ID",
 	"getpeerinforesult-addr":      "The ip address and port of the peer",
@@ -552,6 +681,45 @@ var HelpDescsEnUS = map[string]string{
 	"gettxout-txid":           "The hash of the transaction",
 	"gettxout-vout":           "The index of the output",
 	"gettxout-includemempool": "Include the mempool when true",
+	// GetTxOutSetInfoCmd help.
+	"gettxoutsetinfo--synopsis": "Returns statistics about the unspent" +
+		" transaction output set.\n" +
+		"This scans the whole set and may take some time.",
+	// GetTxOutSetInfoResult help.
+	"gettxoutsetinforesult-height":          "The height of the best block",
+	"gettxoutsetinforesult-bestblock":       "The hash of the best block",
+	"gettxoutsetinforesult-transactions":    "The number of transactions with unspent outputs",
+	"gettxoutsetinforesult-txouts":          "The number of unspent transaction outputs",
+	"gettxoutsetinforesult-hash_serialized": "The hash of the serialized set as of the best block",
+	"gettxoutsetinforesult-total_amount":    "The total amount of all unspent outputs in DUO",
+	"gettxoutsetinforesult-coinbase_amounts": "The amount in DUO of the" +
+		" unspent coinbase outputs by the algorithm of the block that" +
+		" created them",
+	"gettxoutsetinforesult-coinbase_amounts--key":   "algorithm",
+	"gettxoutsetinforesult-coinbase_amounts--value": "n.nnn",
+	"gettxoutsetinforesult-coinbase_amounts--desc": "The algorithm name as" +
+		" the key and the amount in DUO as the value",
+	// GetWorkerStatsCmd help.
+	"getworkerstats--synopsis": "Returns the hashrate, solutions and" +
+		" rejections of the kopach workers mining with the miner controller," +
+		" by the address they are at and by algorithm.",
+	// GetWorkerStatsResult help.
+	"getworkerstatsresult-sources": "The statistics of the workers at each address",
+	"getworkerstatsresult-algos":   "The statistics of all workers for each algorithm",
+	// WorkerSourceResult help.
+	"workersourceresult-address":      "The address the messages of the workers are received from",
+	"workersourceresult-workers":      "The number of workers that reported recently",
+	"workersourceresult-lastseen":     "The time of the last report or solution in seconds since 1 Jan 1970 GMT",
+	"workersourceresult-hashespersec": "The hashes per second of all algorithms",
+	"workersourceresult-algos":        "The statistics for each algorithm",
+	// WorkerAlgoResult help.
+	"workeralgoresult-algo":         "The name of the algorithm",
+	"workeralgoresult-hashespersec": "The hashes per second of the workers that reported recently",
+	"workeralgoresult-hashes":       "The number of hashes reported",
+	"workeralgoresult-found":        "The number of solutions the workers reported finding",
+	"workeralgoresult-accepted":     "The number of solutions that were accepted as blocks",
+	"workeralgoresult-stale":        "The number of solutions for a block that was no longer the best",
+	"workeralgoresult-rejected":     "The number of solutions that were rejected",
 	// HelpCmd help.
 	"help--synopsis": "Returns a list of all commands or help for a" +
 		" specified command.",
@@ -560,10 +728,54 @@ var HelpDescsEnUS = map[string]string{
 	"help--condition1": "command specified",
 	"help--result0":    "List of commands",
 	"help--result1":    "Help for specified command",
+	// InvalidateBlockCmd help.
+	"invalidateblock--synopsis": "Permanently marks a block and all of its" +
+		" descendants as invalid, as if it violated a consensus rule.\n" +
+		"If the block is part of the main chain the chain is rewound to its" +
+		" parent and the remaining branch with the most work becomes the" +
+		" main chain.",
+	"invalidateblock-blockhash": "The hash of the block to invalidate",
+	// ListBannedCmd help.
+	"listbanned--synopsis": "Returns the banned addresses and subnets whose" +
+		" bans have not expired.",
+	// ListBannedResult help.
+	"listbannedresult-address":      "The banned address, subnet or host",
+	"listbannedresult-ban_created":  "The time the ban was made in seconds since 1 Jan 1970 GMT",
+	"listbannedresult-banned_until": "The time the ban expires in seconds since 1 Jan 1970 GMT",
+	"listbannedresult-ban_reason":   "Why the address or subnet was banned",
+	// LoadTxOutSetCmd help.
+	"loadtxoutset--synopsis": "Replaces the chain state with a snapshot" +
+		" written by dumptxoutset, so the node continues syncing from the" +
+		" block the snapshot was taken at.\n" +
+		"This is only possible before any blocks are connected and with the" +
+		" optional indexes disabled, including the committed filter index" +
+		" which is enabled unless the node runs with --nocfilters.  The" +
+		" indexes can't be enabled afterwards.  The blocks below the" +
+		" snapshot are not downloaded and are reported as pruned.",
+	"loadtxoutset-path": "The file to read, relative to the network data" +
+		" directory unless absolute",
+	"loadtxoutset-hash": "The trusted hash of the snapshot as reported by" +
+		" the dumping node",
+	// LoadTxOutSetResult help.
+	"loadtxoutsetresult-coins_loaded": "The number of unspent outputs loaded",
+	"loadtxoutsetresult-base_hash":    "The hash of the block the snapshot was taken at",
+	"loadtxoutsetresult-base_height":  "The height of the block the snapshot was taken at",
+	"loadtxoutsetresult-path":         "The full path of the loaded file",
 	// PingCmd help.
 	"ping--synopsis": "Queues a ping to be sent to each connected peer.\n" +
 		"Ping times are provided by getpeerinfo via the pingtime and" +
 		" pingwait fields.",
+	// PreciousBlockCmd help.
+	"preciousblock--synopsis": "Treats a block as if it were received" +
+		" before others with the same work.\n" +
+		"If the branch ending in the block has at least as much work as the" +
+		" main chain, the node reorganizes onto it.",
+	"preciousblock-blockhash": "The hash of the block to mark as precious",
+	// ReconsiderBlockCmd help.
+	"reconsiderblock--synopsis": "Removes the invalid status from a block" +
+		" and its ancestors and descendants, reversing invalidateblock.\n" +
+		"The branch with the most work then becomes the main chain.",
+	"reconsiderblock-blockhash": "The hash of the block to reconsider",
 	// SearchRawTransactionsCmd help.
 	"searchrawtransactions--synopsis": "Returns raw data for transactions" +
 		" involving the passed address.\n" +
@@ -601,6 +813,19 @@ var HelpDescsEnUS = map[string]string{
 		" high fees (pod does not yet implement this parameter, so it has" +
 		" no effect)",
 	"sendrawtransaction--result0": "The hash of the transaction",
+	// SetBanCmd help.
+	"setban--synopsis": "Bans an address or subnet, disconnecting the" +
+		" connected peers within it, or lifts a ban.\n" +
+		"Bans are kept in the database and survive restarts.",
+	"setban-subnet": "The address or subnet in CIDR notation, a single" +
+		" address bans only that address, or to remove a ban the host of a" +
+		" peer banned for misbehaving whose address is not an IP address",
+	"setban-subcmd": "'add' to ban the address or subnet, 'remove' to lift" +
+		" the ban",
+	"setban-bantime": "The number of seconds the ban lasts, 0 for the" +
+		" configured ban duration",
+	"setban-absolute": "Whether bantime is the time the ban expires in" +
+		" seconds since 1 Jan 1970 GMT instead",
 	// SetGenerateCmd help.
 	"setgenerate--synopsis": "Set the server to generate coins (mine)" +
 		" or not.",
@@ -655,6 +880,13 @@ var HelpDescsEnUS = map[string]string{
 	// StopNotifyBlocksCmd help.
 	"stopnotifyblocks--synopsis": "Cancel registered notifications for" +
 		" whenever a block is connected or disconnected from the main (best) chain.",
+	// NotifyWorkerStatsCmd help.
+	"notifyworkerstats--synopsis": "Send a workerstats notification with" +
+		" the statistics of the kopach workers every few seconds while" +
+		" they are mining.",
+	// StopNotifyWorkerStatsCmd help.
+	"stopnotifyworkerstats--synopsis": "Cancel registered notifications" +
+		" of the statistics of the kopach workers.",
 	// NotifyNewTransactionsCmd help.
 	"notifynewtransactions--synopsis": "Send either a txaccepted or a" +
 		" txacceptedverbose notification when a new transaction is accepted into" +
@@ -747,13 +979,17 @@ var HelpDescsEnUS = map[string]string{
 //nolint
 var ResultTypes = map[string][]interface{}{
 	"addnode":               nil,
+	"clearbanned":           nil,
 	"createrawtransaction":  {(*string)(nil)},
 	"debuglevel":            {(*string)(nil), (*string)(nil)},
 	"decoderawtransaction":  {(*btcjson.TxRawDecodeResult)(nil)},
 	"decodescript":          {(*btcjson.DecodeScriptResult)(nil)},
+	"dumptxoutset":          {(*btcjson.DumpTxOutSetResult)(nil)},
 	"estimatefee":           {(*float64)(nil)},
+	"estimatepriority":      {(*float64)(nil)},
 	"generate":              {(*[]string)(nil)},
 	"getaddednodeinfo":      {(*[]string)(nil), (*[]btcjson.GetAddedNodeInfoResult)(nil)},
+	"getalgostats":          {(*btcjson.GetAlgoStatsResult)(nil)},
 	"getbestblock":          {(*btcjson.GetBestBlockResult)(nil)},
 	"getbestblockhash":      {(*string)(nil)},
 	"getblock":              {(*string)(nil), (*btcjson.GetBlockVerboseResult)(nil)},
@@ -764,6 +1000,7 @@ var ResultTypes = map[string][]interface{}{
 	"getblockchaininfo":     {(*btcjson.GetBlockChainInfoResult)(nil)},
 	"getcfilter":            {(*string)(nil)},
 	"getcfilterheader":      {(*string)(nil)},
+	"getchaintips":          {(*[]btcjson.GetChainTipsResult)(nil)},
 	"getconnectioncount":    {(*int32)(nil)},
 	"getcurrentnet":         {(*uint32)(nil)},
 	"getdifficulty":         {(*float64)(nil)},
@@ -771,19 +1008,29 @@ var ResultTypes = map[string][]interface{}{
 	"gethashespersec":       {(*float64)(nil)},
 	"getheaders":            {(*[]string)(nil)},
 	"getinfo":               {(*btcjson.InfoChainResult)(nil)},
+	"getmempoolentry":       {(*btcjson.GetMempoolEntryResult)(nil)},
 	"getmempoolinfo":        {(*btcjson.GetMempoolInfoResult)(nil)},
 	"getmininginfo":         {(*btcjson.GetMiningInfoResult)(nil)},
 	"getnettotals":          {(*btcjson.GetNetTotalsResult)(nil)},
 	"getnetworkhashps":      {(*int64)(nil)},
+	"getnetworkinfo":        {(*btcjson.GetNetworkInfoResult)(nil)},
 	"getpeerinfo":           {(*[]btcjson.GetPeerInfoResult)(nil)},
 	"getrawmempool":         {(*[]string)(nil), (*btcjson.GetRawMempoolVerboseResult)(nil)},
 	"getrawtransaction":     {(*string)(nil), (*btcjson.TxRawResult)(nil)},
 	"gettxout":              {(*btcjson.GetTxOutResult)(nil)},
+	"gettxoutsetinfo":       {(*btcjson.GetTxOutSetInfoResult)(nil)},
+	"getworkerstats":        {(*btcjson.GetWorkerStatsResult)(nil)},
 	"node":                  nil,
 	"help":                  {(*string)(nil), (*string)(nil)},
+	"invalidateblock":       nil,
+	"listbanned":            {(*[]btcjson.ListBannedResult)(nil)},
+	"loadtxoutset":          {(*btcjson.LoadTxOutSetResult)(nil)},
 	"ping":                  nil,
+	"preciousblock":         nil,
+	"reconsiderblock":       nil,
 	"searchrawtransactions": {(*string)(nil), (*[]btcjson.SearchRawTransactionsResult)(nil)},
 	"sendrawtransaction":    {(*string)(nil)},
+	"setban":                nil,
 	"setgenerate":           nil,
 	"stop":                  {(*string)(nil)},
 	"submitblock":           {nil, (*string)(nil)},
@@ -797,6 +1044,8 @@ var ResultTypes = map[string][]interface{}{
 	"session":                   {(*btcjson.SessionResult)(nil)},
 	"notifyblocks":              nil,
 	"stopnotifyblocks":          nil,
+	"notifyworkerstats":         nil,
+	"stopnotifyworkerstats":     nil,
 	"notifynewtransactions":     nil,
 	"stopnotifynewtransactions": nil,
 	"notifyreceived":            nil,
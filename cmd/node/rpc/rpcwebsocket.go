@@ -40,6 +40,7 @@ type NotificationRegisterBlocks WSClient
 
 // Notification control requests
 type NotificationRegisterClient WSClient
+type NotificationRegisterListener WSListener
 type NotificationRegisterNewMempoolTxs WSClient
 type NotificationRegisterSpent struct {
 	WSC *WSClient
@@ -55,11 +56,15 @@ type NotificationUnregisterAddr struct {
 }
 type NotificationUnregisterBlocks WSClient
 type NotificationUnregisterClient WSClient
+type NotificationUnregisterListener WSListener
 type NotificationUnregisterNewMempoolTxs WSClient
 type NotificationUnregisterSpent struct {
 	WSC *WSClient
 	OP  *wire.OutPoint
 }
+type NotificationRegisterWorkerStats WSClient
+type NotificationUnregisterWorkerStats WSClient
+type NotificationWorkerStats btcjson.GetWorkerStatsResult
 type RescanKeys struct {
 	Fallbacks           map[string]struct{}
 	PubKeyHashes        map[[ripemd160.Size]byte]struct{}
@@ -175,6 +180,17 @@ type WSNtfnMgr struct {
 	Quit chan struct{}
 }
 
+// WSListener receives the block and mempool notifications of the manager in
+// the same process, without the websocket connection and JSON encoding of a
+// WSClient. The notifications are sent as they are queued to the manager, as
+// *NotificationBlockConnected, *NotificationBlockDisconnected and
+// *NotificationTxAcceptedByMempool, so the channel must be read until Quit is
+// closed or the manager will stall.
+type WSListener struct {
+	Notifications chan<- interface{}
+	Quit          chan struct{}
+}
+
 // WSResponse houses a message to send to a connected websocket client as well
 // as a channel to reply on when the message is sent.
 type WSResponse struct {
@@ -220,11 +236,13 @@ var WSHandlersBeforeInit = map[string]WSCommandHandler{
 	"notifynewtransactions":     HandleNotifyNewTransactions,
 	"notifyreceived":            HandleNotifyReceived,
 	"notifyspent":               HandleNotifySpent,
+	"notifyworkerstats":         HandleNotifyWorkerStats,
 	"session":                   HandleSession,
 	"stopnotifyblocks":          HandleStopNotifyBlocks,
 	"stopnotifynewtransactions": HandleStopNotifyNewTransactions,
 	"stopnotifyspent":           HandleStopNotifySpent,
 	"stopnotifyreceived":        HandleStopNotifyReceived,
+	"stopnotifyworkerstats":     HandleStopNotifyWorkerStats,
 	"rescan":                    HandleRescan,
 	"rescanblocks":              HandleRescanBlocks,
 }
@@ -827,6 +845,19 @@ func (m *WSNtfnMgr) SendNotifyMempoolTx(tx *util.Tx, isNew bool) {
 	}
 }
 
+// SendNotifyWorkerStats passes the statistics of the kopach workers to the
+// notification manager to send to the clients that asked for them. The
+// statistics are sent again shortly, so they are dropped rather than holding
+// up the caller when the notification manager is busy.
+func (m *WSNtfnMgr) SendNotifyWorkerStats(stats btcjson.GetWorkerStatsResult) {
+	select {
+	case m.QueueNotification <- (*NotificationWorkerStats)(&stats):
+	case <-m.Quit:
+	default:
+		log.TRACE("notification manager is busy, dropping worker statistics")
+	}
+}
+
 // GetNumClients returns the number of clients actively being served.
 func (m *WSNtfnMgr) GetNumClients() (n int) {
 	select {
@@ -836,6 +867,12 @@ func (m *WSNtfnMgr) GetNumClients() (n int) {
 	return
 }
 
+// RegisterListener starts sending block and mempool notifications to the
+// passed listener.
+func (m *WSNtfnMgr) RegisterListener(l *WSListener) {
+	m.QueueNotification <- (*NotificationRegisterListener)(l)
+}
+
 // RegisterBlockUpdates requests block update notifications to the passed
 // websocket client.
 func (m *WSNtfnMgr) RegisterBlockUpdates(wsc *WSClient) {
@@ -848,6 +885,12 @@ func (m *WSNtfnMgr) RegisterNewMempoolTxsUpdates(wsc *WSClient) {
 	m.QueueNotification <- (*NotificationRegisterNewMempoolTxs)(wsc)
 }
 
+// RegisterWorkerStatsUpdates requests notifications of the statistics of the
+// kopach workers to the passed websocket client.
+func (m *WSNtfnMgr) RegisterWorkerStatsUpdates(wsc *WSClient) {
+	m.QueueNotification <- (*NotificationRegisterWorkerStats)(wsc)
+}
+
 // RegisterSpentRequests requests a notification when each of the passed
 // outpoints is confirmed spent (contained in a block connected to the main
 // chain) for the passed websocket client.  The request is automatically
@@ -890,6 +933,14 @@ func (m *WSNtfnMgr) Start() {
 	go m.NotificationHandler()
 }
 
+// UnregisterListener stops sending notifications to the passed listener.
+func (m *WSNtfnMgr) UnregisterListener(l *WSListener) {
+	select {
+	case m.QueueNotification <- (*NotificationUnregisterListener)(l):
+	case <-m.Quit:
+	}
+}
+
 // UnregisterBlockUpdates removes block update notifications for the passed
 // websocket client.
 func (m *WSNtfnMgr) UnregisterBlockUpdates(wsc *WSClient) {
@@ -902,6 +953,12 @@ func (m *WSNtfnMgr) UnregisterNewMempoolTxsUpdates(wsc *WSClient) {
 	m.QueueNotification <- (*NotificationUnregisterNewMempoolTxs)(wsc)
 }
 
+// UnregisterWorkerStatsUpdates removes notifications of the statistics of
+// the kopach workers for the passed websocket client.
+func (m *WSNtfnMgr) UnregisterWorkerStatsUpdates(wsc *WSClient) {
+	m.QueueNotification <- (*NotificationUnregisterWorkerStats)(wsc)
+}
+
 // UnregisterSpentRequest removes a request from the passed websocket client to
 // be notified when the passed outpoint is confirmed spent (contained in a
 // block connected to the main chain).
@@ -995,8 +1052,11 @@ func (m *WSNtfnMgr) NotificationHandler() {
 	// entire struct.
 	blockNotifications := make(map[chan struct{}]*WSClient)
 	txNotifications := make(map[chan struct{}]*WSClient)
+	workerStatsNotifications := make(map[chan struct{}]*WSClient)
 	watchedOutPoints := make(map[wire.OutPoint]map[chan struct{}]*WSClient)
 	watchedAddrs := make(map[string]map[chan struct{}]*WSClient)
+	// listeners in the same process get every block and mempool notification
+	listeners := make(map[chan struct{}]*WSListener)
 out:
 	for {
 		select {
@@ -1022,6 +1082,7 @@ out:
 					m.NotifyFilteredBlockConnected(blockNotifications,
 						block)
 				}
+				m.NotifyListeners(listeners, n)
 			case *NotificationBlockDisconnected:
 				block := (*util.Block)(n)
 				if len(blockNotifications) != 0 {
@@ -1030,12 +1091,19 @@ out:
 					m.NotifyFilteredBlockDisconnected(blockNotifications,
 						block)
 				}
+				m.NotifyListeners(listeners, n)
 			case *NotificationTxAcceptedByMempool:
 				if n.IsNew && len(txNotifications) != 0 {
 					m.NotifyForNewTx(txNotifications, n.Tx)
 				}
 				m.NotifyForTx(watchedOutPoints, watchedAddrs, n.Tx, nil)
 				m.NotifyRelevantTxAccepted(n.Tx, clients)
+				m.NotifyListeners(listeners, n)
+			case *NotificationWorkerStats:
+				if len(workerStatsNotifications) != 0 {
+					m.NotifyWorkerStats(workerStatsNotifications,
+						(*btcjson.GetWorkerStatsResult)(n))
+				}
 			case *NotificationRegisterBlocks:
 				wsc := (*WSClient)(n)
 				blockNotifications[wsc.Quit] = wsc
@@ -1051,6 +1119,7 @@ out:
 				// itself.
 				delete(blockNotifications, wsc.Quit)
 				delete(txNotifications, wsc.Quit)
+				delete(workerStatsNotifications, wsc.Quit)
 				for k := range wsc.SpentRequests {
 					op := k
 					m.RemoveSpentRequest(watchedOutPoints, wsc, &op)
@@ -1059,6 +1128,12 @@ out:
 					m.RemoveAddrRequest(watchedAddrs, wsc, addr)
 				}
 				delete(clients, wsc.Quit)
+			case *NotificationRegisterListener:
+				l := (*WSListener)(n)
+				listeners[l.Quit] = l
+			case *NotificationUnregisterListener:
+				l := (*WSListener)(n)
+				delete(listeners, l.Quit)
 			case *NotificationRegisterSpent:
 				m.AddSpentRequests(watchedOutPoints, n.WSC, n.OPs)
 			case *NotificationUnregisterSpent:
@@ -1073,6 +1148,12 @@ out:
 			case *NotificationUnregisterNewMempoolTxs:
 				wsc := (*WSClient)(n)
 				delete(txNotifications, wsc.Quit)
+			case *NotificationRegisterWorkerStats:
+				wsc := (*WSClient)(n)
+				workerStatsNotifications[wsc.Quit] = wsc
+			case *NotificationUnregisterWorkerStats:
+				wsc := (*WSClient)(n)
+				delete(workerStatsNotifications, wsc.Quit)
 			default:
 				log.WARN("unhandled notification type")
 			}
@@ -1108,6 +1189,37 @@ func (*WSNtfnMgr) NotifyBlockConnected(clients map[chan struct{}]*WSClient, bloc
 	}
 }
 
+// NotifyListeners passes a block or mempool notification to the listeners in
+// the same process, dropping the ones that have quit.
+func (*WSNtfnMgr) NotifyListeners(listeners map[chan struct{}]*WSListener,
+	n interface{}) {
+	for quit, l := range listeners {
+		select {
+		case l.Notifications <- n:
+		case <-quit:
+			delete(listeners, quit)
+		}
+	}
+}
+
+// NotifyWorkerStats notifies websocket clients that have registered for the
+// statistics of the kopach workers.
+func (*WSNtfnMgr) NotifyWorkerStats(clients map[chan struct{}]*WSClient,
+	stats *btcjson.GetWorkerStatsResult) {
+	ntfn := btcjson.NewWorkerStatsNtfn(*stats)
+	marshalledJSON, err := btcjson.MarshalCmd(nil, ntfn)
+	if err != nil {
+		log.ERROR("failed to marshal worker stats notification:", err)
+		return
+	}
+	for _, wsc := range clients {
+		err := wsc.QueueNotification(marshalledJSON)
+		if err != nil {
+			log.ERROR(err)
+		}
+	}
+}
+
 // NotifyBlockDisconnected notifies websocket clients that have registered for
 // block updates when a block is disconnected from the main chain (due to a
 // reorganize).
@@ -1631,6 +1743,14 @@ func HandleNotifyBlocks(wsc *WSClient, icmd interface{}) (interface{}, error) {
 	return nil, nil
 }
 
+// HandleNotifyWorkerStats implements the notifyworkerstats command extension
+// for websocket connections.
+func HandleNotifyWorkerStats(wsc *WSClient, icmd interface{}) (interface{},
+	error) {
+	wsc.Server.NtfnMgr.RegisterWorkerStatsUpdates(wsc)
+	return nil, nil
+}
+
 // handleNotifyNewTransations implements the notifynewtransactions command
 // extension for websocket connections.
 func HandleNotifyNewTransactions(wsc *WSClient,
@@ -2044,6 +2164,14 @@ func HandleStopNotifyBlocks(wsc *WSClient, icmd interface{}) (interface{},
 	return nil, nil
 }
 
+// HandleStopNotifyWorkerStats implements the stopnotifyworkerstats command
+// extension for websocket connections.
+func HandleStopNotifyWorkerStats(wsc *WSClient, icmd interface{}) (interface{},
+	error) {
+	wsc.Server.NtfnMgr.UnregisterWorkerStatsUpdates(wsc)
+	return nil, nil
+}
+
 // handleStopNotifyNewTransations implements the stopnotifynewtransactions
 // command extension for websocket connections.
 func HandleStopNotifyNewTransactions(wsc *WSClient, icmd interface{}) (interface{}, error) {
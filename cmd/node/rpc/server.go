@@ -32,6 +32,7 @@ import (
 	netsync "github.com/p9c/pod/pkg/chain/sync"
 	txscript "github.com/p9c/pod/pkg/chain/tx/script"
 	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/controller/stats"
 	database "github.com/p9c/pod/pkg/db"
 	"github.com/p9c/pod/pkg/log"
 	"github.com/p9c/pod/pkg/peer"
@@ -94,12 +95,11 @@ type (
 		Addr string
 	}
 	// PeerState maintains state of inbound, persistent,
-	// outbound peers as well as banned peers and outbound groups.
+	// outbound peers as well as outbound groups.
 	PeerState struct {
 		InboundPeers    map[int32]*NodePeer
 		OutboundPeers   map[int32]*NodePeer
 		PersistentPeers map[int32]*NodePeer
-		Banned          map[string]time.Time
 		OutboundGroups  map[string]int
 	}
 	// RelayMsg packages an inventory vector along with the newly discovered
@@ -117,8 +117,9 @@ type (
 	Node struct {
 		// The following variables must only be used atomically. Putting the
 		// uint64s first makes them 64-bit aligned for 32-bit systems.
-		BytesReceived        uint64 // Total bytes received from all peers since start.
-		BytesSent            uint64 // Total bytes sent by all peers since start.
+		BytesReceived        uint64           // Total bytes received from all peers since start.
+		BytesSent            uint64           // Total bytes sent by all peers since start.
+		Services             wire.ServiceFlag // Service flags advertised to peers.
 		StartupTime          int64
 		ChainParams          *netparams.Params
 		AddrManager          *addrmgr.AddrManager
@@ -143,7 +144,6 @@ type (
 		NAT                  upnp.NAT
 		DB                   database.DB
 		TimeSource           blockchain.MedianTimeSource
-		Services             wire.ServiceFlag
 		// The following fields are used for optional indexes.  They will be nil
 		// if the associated index is not enabled.  These fields are set during
 		// initial creation of the server and never changed afterwards, so they
@@ -154,6 +154,15 @@ type (
 		// The fee estimator keeps track of how long transactions are left in
 		// the mempool before they are mined into blocks.
 		FeeEstimator *mempool.FeeEstimator
+		// BanList keeps the banned subnets, it is saved in the database
+		// whenever it changes so bans survive restarts.
+		BanList *connmgr.BanList
+		// WorkerStats keeps the statistics kopach workers send to the miner
+		// controller.
+		WorkerStats *stats.Collector
+		// PayToAddrs gives the addresses the block templates made by the
+		// miners pay to.
+		PayToAddrs mining.PayToAddrSource
 		// CFCheckptCaches stores a cached slice of filter headers for
 		// cfcheckpt messages for each filter type.
 		CFCheckptCaches    map[wire.FilterType][]CFHeaderKV
@@ -234,6 +243,9 @@ var (
 	// nolint
 	UserAgentVersion = fmt.Sprintf("%d.%d.%d", version.AppMajor,
 		version.AppMinor, version.AppPatch)
+	// BanListDatabaseKey is the key the ban list is kept under in the
+	// metadata of the database.
+	BanListDatabaseKey = []byte("banlist")
 	// // zeroHash is the zero value hash (all zeros).  It is defined as a
 	// convenience.
 	// zeroHash chainhash.Hash
@@ -281,6 +293,27 @@ func (s *Node) AddBytesReceived(bytesReceived uint64) {
 	atomic.AddUint64(&s.BytesReceived, bytesReceived)
 }
 
+// LocalServices returns the service flags that are advertised to peers.  It is
+// safe for concurrent access.
+func (s *Node) LocalServices() wire.ServiceFlag {
+	return wire.ServiceFlag(atomic.LoadUint64((*uint64)(&s.Services)))
+}
+
+// SetPruned advertises to peers that only the most recent blocks are served,
+// for a node which pruned its blocks or loaded a utxo set snapshot.  It is safe
+// for concurrent access.
+func (s *Node) SetPruned() {
+	for {
+		services := atomic.LoadUint64((*uint64)(&s.Services))
+		pruned := (wire.ServiceFlag(services) &^ wire.SFNodeNetwork) |
+			wire.SFNodeNetworkLimited
+		if atomic.CompareAndSwapUint64((*uint64)(&s.Services), services,
+			uint64(pruned)) {
+			return
+		}
+	}
+}
+
 // AddBytesSent adds the passed number of bytes to the total bytes sent counter
 // for the server.  It is safe for concurrent access.
 func (s *Node) AddBytesSent(bytesSent uint64) {
@@ -323,6 +356,13 @@ func (s *Node) BanPeer(sp *NodePeer) {
 	s.BanPeers <- sp
 }
 
+// SaveBanList writes the ban list to the database.
+func (s *Node) SaveBanList() error {
+	return s.DB.Update(func(tx database.Tx) error {
+		return tx.Metadata().Put(BanListDatabaseKey, s.BanList.Save())
+	})
+}
+
 // BroadcastMessage sends msg to all peers currently connected to the server
 // except those in the passed peers to exclude.
 func (s *Node) BroadcastMessage(msg wire.Message, exclPeers ...*NodePeer) {
@@ -539,15 +579,11 @@ func (s *Node) HandleAddPeerMsg(state *PeerState, sp *NodePeer) bool {
 		sp.Disconnect()
 		return false
 	}
-	if banEnd, ok := state.Banned[host]; ok {
-		if time.Now().Before(banEnd) {
-			log.DEBUGF("peer %s is banned for another %v - disconnecting %s",
-				host, time.Until(banEnd))
-			sp.Disconnect()
-			return false
-		}
-		log.INFOF("peer %s is no longer banned", host)
-		delete(state.Banned, host)
+	if ban, ok := s.BanList.IsHostBanned(host); ok {
+		log.DEBUGF("peer %s is banned for another %v - disconnecting",
+			host, time.Until(ban.Until))
+		sp.Disconnect()
+		return false
 	}
 	// TODO: Check for max peers from a single IP.
 	//  Limit max number of total peers.
@@ -583,7 +619,18 @@ func (s *Node) HandleBanPeerMsg(state *PeerState, sp *NodePeer) {
 	}
 	direction := log.DirectionString(sp.Inbound())
 	log.INFOF("banned peer %s (%s) for %v", host, direction, *s.Config.BanDuration)
-	state.Banned[host] = time.Now().Add(*s.Config.BanDuration)
+	until := time.Now().Add(*s.Config.BanDuration)
+	reason := "misbehaving: " + sp.BanScore.Reasons()
+	// peers whose address is not an IP address, such as onion peers, are
+	// banned by their host
+	if subnet, err := connmgr.ParseSubnet(host); err == nil {
+		s.BanList.Ban(subnet, until, reason)
+	} else {
+		s.BanList.BanHost(host, until, reason)
+	}
+	if err := s.SaveBanList(); err != nil {
+		log.ERROR(err)
+	}
 }
 
 // HandleBroadcastMsg deals with broadcasting messages to peers.
@@ -885,7 +932,6 @@ func (s *Node) PeerHandler() {
 		InboundPeers:    make(map[int32]*NodePeer),
 		PersistentPeers: make(map[int32]*NodePeer),
 		OutboundPeers:   make(map[int32]*NodePeer),
-		Banned:          make(map[string]time.Time),
 		OutboundGroups:  make(map[string]int),
 	}
 	if !*s.Config.DisableDNSSeed || len(*s.Config.ConnectPeers) < 0 {
@@ -1192,7 +1238,7 @@ out:
 					continue out
 				}
 				na := wire.NewNetAddressIPPort(externalip, uint16(listenPort),
-					s.Services)
+					s.LocalServices())
 				err = s.AddrManager.AddLocalAddress(na, addrmgr.UpnpPrio)
 				if err != nil {
 					log.ERROR(err)
@@ -1865,7 +1911,7 @@ func (sp *NodePeer) OnInv(
 func (sp *NodePeer) OnMemPool(_ *peer.Peer,
 	msg *wire.MsgMemPool) {
 	// Only allow mempool requests if the server has bloom filtering enabled.
-	if sp.Server.Services&wire.SFNodeBloom != wire.SFNodeBloom {
+	if sp.Server.LocalServices()&wire.SFNodeBloom != wire.SFNodeBloom {
 		log.DEBUG("peer", sp, "sent mempool request with bloom filtering disabled"+
 			" -- disconnecting")
 		sp.Disconnect()
@@ -2058,7 +2104,7 @@ func (sp *NodePeer) AddBanScore(persistent, transient uint32, reason string) {
 		}
 		return
 	}
-	score := sp.BanScore.Increase(persistent, transient)
+	score := sp.BanScore.IncreaseWithReason(persistent, transient, reason)
 	if int(score) > warnThreshold {
 		log.WARNF("misbehaving peer %s: %s -- ban score increased to %d",
 			sp, reason, score)
@@ -2089,7 +2135,7 @@ func (sp *NodePeer) IsAddressKnown(na *wire.NetAddress) bool {
 // version  that is high enough to observe the bloom filter service support bit,
 // it will be banned since it is intentionally violating the protocol.
 func (sp *NodePeer) EnforceNodeBloomFlag(cmd string) bool {
-	if sp.Server.Services&wire.SFNodeBloom != wire.SFNodeBloom {
+	if sp.Server.LocalServices()&wire.SFNodeBloom != wire.SFNodeBloom {
 		// Ban the peer if the protocol version is high enough that the peer is
 		// knowingly violating the protocol and banning is enabled. NOTE: Even
 		// though the addBanScore function already examines whether or not
@@ -2498,7 +2544,7 @@ NewPeerConfig(sp *NodePeer) *peer.Config {
 		UserAgentVersion:  UserAgentVersion,
 		UserAgentComments: *sp.Server.Config.UserAgentComments,
 		ChainParams:       sp.Server.ChainParams,
-		Services:          sp.Server.Services,
+		Services:          sp.Server.LocalServices(),
 		DisableRelayTx:    *sp.Server.Config.BlocksOnly,
 		ProtocolVersion:   peer.MaxProtocolVersion,
 		TrickleInterval:   *sp.Server.Config.TrickleInterval,
@@ -2514,6 +2560,26 @@ NewNode(config *pod.Config, stateCfg *state.Config,
 	chainParams *netparams.Params, interruptChan <-chan struct{},
 	algo string) (*Node, error) {
 	log.TRACE("listenAddrs ", listenAddrs)
+	// The transaction and address indexes need every block to catch up
+	// and to serve lookups, which a pruned node no longer has.
+	if stateCfg.Prune != 0 && (*config.TxIndex || *config.AddrIndex) {
+		return nil, errors.New("pruning is incompatible with the " +
+			"transaction and address indexes")
+	}
+	// The blocks are just as missing when the chain was pruned by an earlier
+	// run or loaded from a utxo set snapshot.
+	if *config.TxIndex || *config.AddrIndex {
+		pruneHeight, err := blockchain.FetchPruneHeight(db)
+		if err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+		if pruneHeight > 0 {
+			return nil, fmt.Errorf("the transaction and address indexes "+
+				"can't be built, blocks below height %d are no longer "+
+				"stored", pruneHeight)
+		}
+	}
 	services := DefaultServices
 	if *config.NoPeerBloomFilters {
 		services &^= wire.SFNodeBloom
@@ -2521,6 +2587,11 @@ NewNode(config *pod.Config, stateCfg *state.Config,
 	if *config.NoCFilters {
 		services &^= wire.SFNodeCF
 	}
+	// A pruned node only has the most recent blocks to serve to peers.
+	if stateCfg.Prune != 0 {
+		services &^= wire.SFNodeNetwork
+		services |= wire.SFNodeNetworkLimited
+	}
 	aMgr := addrmgr.New(*config.DataDir+string(os.PathSeparator)+activeNet.
 		Name, Lookup(stateCfg))
 	var listeners []net.Listener
@@ -2564,6 +2635,7 @@ NewNode(config *pod.Config, stateCfg *state.Config,
 		SigCache:             txscript.NewSigCache(uint(*config.SigCacheMaxSize)),
 		HashCache:            txscript.NewHashCache(uint(*config.SigCacheMaxSize)),
 		CFCheckptCaches:      make(map[wire.FilterType][]CFHeaderKV),
+		WorkerStats:          stats.NewCollector(),
 		GenThreads:           uint32(thr),
 		Algo:                 algo,
 		Config:               config,
@@ -2623,6 +2695,7 @@ NewNode(config *pod.Config, stateCfg *state.Config,
 			SigCache:     s.SigCache,
 			IndexManager: indexManager,
 			HashCache:    s.HashCache,
+			PruneTarget:  uint64(stateCfg.Prune) * 1024 * 1024,
 		},
 	)
 	if err != nil {
@@ -2630,6 +2703,10 @@ NewNode(config *pod.Config, stateCfg *state.Config,
 		return nil, err
 	}
 	s.Chain.DifficultyAdjustments = make(map[string]float64)
+	// A chain loaded from a utxo snapshot is missing the old blocks as well.
+	if s.Chain.IsPruned() {
+		s.SetPruned()
+	}
 	// Search for a FeeEstimator state in the database.
 	// If none can be found or if it cannot be loaded, create a new one.
 	e := db.Update(func(tx database.Tx) error {
@@ -2664,6 +2741,23 @@ NewNode(config *pod.Config, stateCfg *state.Config,
 			mempool.DefaultEstimateFeeMinRegisteredBlocks,
 		)
 	}
+	// Restore the bans kept in the database, starting without any bans if
+	// they can't be loaded.
+	e = db.View(func(tx database.Tx) error {
+		banListData := tx.Metadata().Get(BanListDatabaseKey)
+		if banListData == nil {
+			return nil
+		}
+		var err error
+		s.BanList, err = connmgr.RestoreBanList(banListData)
+		return err
+	})
+	if e != nil {
+		log.ERROR("failed to restore ban list", e)
+	}
+	if s.BanList == nil {
+		s.BanList = connmgr.NewBanList()
+	}
 	txC := mempool.Config{
 		Policy: mempool.Policy{
 			DisableRelayPriority: *config.NoRelayPriority,
@@ -2674,6 +2768,7 @@ NewNode(config *pod.Config, stateCfg *state.Config,
 			MaxSigOpCostPerTx:    blockchain.MaxBlockSigOpsCost / 4,
 			MinRelayTxFee:        stateCfg.ActiveMinRelayTxFee,
 			MaxTxVersion:         2,
+			RejectReplacement:    stateCfg.RejectReplacement,
 		},
 		ChainParams:   chainParams,
 		FetchUtxoView: s.Chain.FetchUtxoView,
@@ -2725,11 +2820,25 @@ NewNode(config *pod.Config, stateCfg *state.Config,
 	blockTemplateGenerator := mining.NewBlkTmplGenerator(&policy,
 		s.ChainParams, s.TxMemPool, s.Chain, s.TimeSource,
 		s.SigCache, s.HashCache, s.Algo)
+	// Blocks pay to addresses derived from the mining extended public key
+	// if one is configured, otherwise to the list of mining addresses.
+	s.PayToAddrs = mining.AddrList{Addrs: &stateCfg.ActiveMiningAddrs}
+	if stateCfg.MiningXpub != "" {
+		var xpubAddrs *mining.XpubAddrs
+		xpubAddrs, err = mining.NewXpubAddrs(stateCfg.MiningXpub, db,
+			chainParams)
+		if err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+		s.Chain.Subscribe(xpubAddrs.HandleChainNotification)
+		s.PayToAddrs = xpubAddrs
+	}
+	blockTemplateGenerator.PayToAddrs = s.PayToAddrs
 	s.CPUMiner = cpuminer.New(&cpuminer.Config{
 		Blockchain:             s.Chain,
 		ChainParams:            chainParams,
 		BlockTemplateGenerator: blockTemplateGenerator,
-		MiningAddrs:            stateCfg.ActiveMiningAddrs,
 		ProcessBlock:           s.SyncManager.ProcessBlock,
 		ConnectedCount:         s.ConnectedCount,
 		IsCurrent:              s.SyncManager.IsCurrent,
@@ -2848,6 +2957,7 @@ NewNode(config *pod.Config, stateCfg *state.Config,
 				AddrIndex:    s.AddrIndex,
 				CfIndex:      s.CFIndex,
 				FeeEstimator: s.FeeEstimator,
+				WorkerStats:  s.WorkerStats,
 				Algo:         l,
 			}, stateCfg, config)
 			if err != nil {
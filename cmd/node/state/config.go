@@ -4,6 +4,8 @@ import (
 	"net"
 	"time"
 
+	"github.com/p9c/cli"
+
 	chaincfg "github.com/p9c/pod/pkg/chain/config"
 	"github.com/p9c/pod/pkg/util"
 )
@@ -15,11 +17,28 @@ type Config struct {
 	Dial                func(string, string, time.Duration) (net.Conn, error)
 	AddedCheckpoints    []chaincfg.Checkpoint
 	ActiveMiningAddrs   []util.Address
+	MiningXpub          string
 	ActiveMinerKey      []byte
 	ActiveMinRelayTxFee util.Amount
 	ActiveWhitelists    []*net.IPNet
 	DropAddrIndex       bool
 	DropTxIndex         bool
 	DropCfIndex         bool
+	Prune               int
 	Save                bool
+	MinerCompat         bool
+	MinerInterfaces     cli.StringSlice
+	MinerRemote         bool
+	MinerConnect        string
+	MinerAlgos          cli.StringSlice
+	MinerAutoTune       bool
+	LogLevels           string
+	LogFile             bool
+	LogMaxSize          int
+	LogMaxAge           time.Duration
+	LogMaxFiles         int
+	StratumListener     string
+	StratumDifficulty   float64
+	MetricsListener     string
+	RejectReplacement   bool
 }
@@ -8,6 +8,7 @@ import (
 	//_ "net/http/pprof"
 	"sync"
 
+	"github.com/p9c/pod/cmd/node/rpc"
 	"github.com/p9c/pod/cmd/node/state"
 	"github.com/p9c/pod/pkg/chain/config/netparams"
 	"github.com/p9c/pod/pkg/chain/fork"
@@ -24,8 +25,12 @@ import (
 // Instead, main runs this function and checks for a non-nil error, at point
 // any defers have already run, and if the error is non-nil, the program can be
 // exited with an error exit status.
+//
+// When node is not nil the wallet runs in the same process as the node and
+// uses it directly as its chain backend instead of connecting to its RPC
+// server.
 func Main(config *pod.Config, stateCfg *state.Config,
-	activeNet *netparams.Params,
+	activeNet *netparams.Params, node *rpc.Server,
 	walletChan chan *wallet.Wallet, killswitch chan struct{},
 	wg *sync.WaitGroup) error {
 	log.INFO("starting wallet")
@@ -59,6 +64,9 @@ func Main(config *pod.Config, stateCfg *state.Config,
 		log.TRACE("starting wallet RPC services", w != nil)
 		startWalletRPCServices(w, rpcS, legacyServer)
 	})
+	loader.RunAfterNamedLoad(func(name string, w *wallet.Wallet) {
+		go namedClientConnectLoop(config, activeNet, node, name, w)
+	})
 	if !*config.NoInitialLoad {
 		log.TRACE("starting rpc client connection handler")
 		// Create and start chain RPC client so it's ready to connect to
@@ -78,7 +86,7 @@ func Main(config *pod.Config, stateCfg *state.Config,
 			addresses.RefillMiningAddresses(w, config, stateCfg)
 
 		}()
-		go rpcClientConnectLoop(config, activeNet, legacyServer, loader)
+		go rpcClientConnectLoop(config, activeNet, node, legacyServer, loader)
 		loader.Wallet = w
 		log.TRACE("sending back wallet")
 		walletChan <- w
@@ -88,6 +96,7 @@ func Main(config *pod.Config, stateCfg *state.Config,
 	// before exiting.  Interrupt handlers run in LIFO order, so the wallet
 	// (which should be closed last) is added first.
 	interrupt.AddHandler(func() {
+		loader.UnloadNamedWallets()
 		err := loader.UnloadWallet()
 		if err != nil && err != wallet.ErrNotLoaded {
 			log.ERROR("failed to close wallet:", err)
@@ -126,6 +135,7 @@ func Main(config *pod.Config, stateCfg *state.Config,
 			rpcS.Stop()
 			log.INFO("RPC server shutdown")
 			log.INFO("unloading wallet")
+			loader.UnloadNamedWallets()
 			err := loader.UnloadWallet()
 			if err != nil && err != wallet.ErrNotLoaded {
 				log.ERROR("failed to close wallet:", err)
@@ -169,11 +179,16 @@ func ReadCAFile(config *pod.Config) []byte {
 // The legacy RPC is optional. If set,
 // the connected RPC client will be associated with the server for RPC
 // pass-through and to enable additional methods.
+//
+// If node is not nil the chain client reads from the node in this process
+// and the RPC connection settings are not used.
 func rpcClientConnectLoop(config *pod.Config, activeNet *netparams.Params,
-	legacyServer *legacy.Server, loader *wallet.Loader) {
-	// var certs []byte
+	node *rpc.Server, legacyServer *legacy.Server, loader *wallet.Loader) {
+	var certs []byte
 	// if !cx.PodConfig.UseSPV {
-	certs := ReadCAFile(config)
+	if node == nil {
+		certs = ReadCAFile(config)
+	}
 	// }
 	for {
 		var (
@@ -211,11 +226,19 @@ func rpcClientConnectLoop(config *pod.Config, activeNet *netparams.Params,
 		// 		log<-cl.Errorf{"couldn't start Neutrino client: %s", err)
 		// 	}
 		// } else {
-		chainClient, err = startChainRPC(config, activeNet, certs)
-		if err != nil {
-			log.ERROR(
-				"unable to open connection to consensus RPC server:", err)
-			continue
+		if node != nil {
+			chainClient, err = startChainNode(activeNet, node)
+			if err != nil {
+				log.ERROR("unable to start in-process chain client:", err)
+				return
+			}
+		} else {
+			chainClient, err = startChainRPC(config, activeNet, certs)
+			if err != nil {
+				log.ERROR(
+					"unable to open connection to consensus RPC server:", err)
+				continue
+			}
 		}
 		// }
 		// Rather than inlining this logic directly into the loader
@@ -261,6 +284,53 @@ func rpcClientConnectLoop(config *pod.Config, activeNet *netparams.Params,
 	}
 }
 
+// namedClientConnectLoop connects a wallet loaded by name to the chain and
+// reconnects it until the wallet is unloaded.  Each named wallet has a chain
+// client of its own as a client's notifications can only be handled by a single
+// wallet.
+func namedClientConnectLoop(config *pod.Config, activeNet *netparams.Params,
+	node *rpc.Server, name string, w *wallet.Wallet) {
+	var certs []byte
+	if node == nil {
+		certs = ReadCAFile(config)
+	}
+	for !w.ShuttingDown() {
+		var (
+			chainClient chain.Interface
+			err         error
+		)
+		if node != nil {
+			chainClient, err = startChainNode(activeNet, node)
+			if err != nil {
+				log.ERROR("unable to start in-process chain client for wallet",
+					name, ":", err)
+				return
+			}
+		} else {
+			chainClient, err = startChainRPC(config, activeNet, certs)
+			if err != nil {
+				log.ERROR("unable to open connection to consensus RPC server"+
+					" for wallet", name, ":", err)
+				continue
+			}
+		}
+		w.SynchronizeRPC(chainClient)
+		if w.ShuttingDown() {
+			// the wallet was unloaded before it took the client
+			chainClient.Stop()
+			return
+		}
+		chainClient.WaitForShutdown()
+		if w.ShuttingDown() {
+			return
+		}
+		w.SetChainSynced(false)
+		w.Stop()
+		w.WaitForShutdown()
+		w.Start()
+	}
+}
+
 // startChainRPC opens a RPC client connection to a pod server for blockchain
 // services.  This function uses the RPC options from the global config and
 // there is no recovery in case the server is not available or if there is an
@@ -279,3 +349,13 @@ func startChainRPC(config *pod.Config, activeNet *netparams.Params, certs []byte
 	err = rpcC.Start()
 	return rpcC, err
 }
+
+// startChainNode starts a chain client reading from the node running in this
+// process.
+func startChainNode(activeNet *netparams.Params,
+	node *rpc.Server) (*chain.NodeClient, error) {
+	log.TRACE("starting in-process chain client")
+	nodeC := chain.NewNodeClient(activeNet, node)
+	err := nodeC.Start()
+	return nodeC, err
+}
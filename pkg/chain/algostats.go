@@ -0,0 +1,144 @@
+package blockchain
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/p9c/pod/pkg/chain/fork"
+	"github.com/p9c/pod/pkg/log"
+)
+
+// AlgoStats describes the recent blocks of the main chain found with one of
+// the proof of work algorithms of the current hard fork.
+type AlgoStats struct {
+	Name    string
+	Version int32
+	// Bits is the target the next block found with the algorithm must meet.
+	Bits uint32
+	// Blocks is the number of blocks in the window found with the algorithm.
+	Blocks int32
+	// HashesPerSec estimates the hashes of the algorithm performed by the
+	// network per second over the window.
+	HashesPerSec int64
+	// AvgInterval is the average number of seconds between blocks of the
+	// algorithm over the window, or zero when it found no blocks.
+	AvgInterval float64
+	// TargetInterval is the number of seconds between blocks of the
+	// algorithm the difficulty adjustment aims for.
+	TargetInterval int64
+}
+
+// AlgoWindow holds the statistics of each algorithm over a window of the
+// most recent blocks of the main chain.
+type AlgoWindow struct {
+	// Height is the height of the last block of the window.
+	Height int32
+	// Blocks is the number of blocks in the window, which can be less than
+	// requested when the chain or the current hard fork is shorter.
+	Blocks int32
+	// Timespan is the number of seconds from the block before the window to
+	// the last block of the window.
+	Timespan int64
+	// Algos holds the statistics of each algorithm ordered by block version.
+	Algos []AlgoStats
+}
+
+func // calcAlgoWindow gathers the block counts, hash rates and average
+// intervals of each algorithm over the last numBlocks blocks ending at the
+// passed node.  The window never extends back past the activation of the
+// hard fork in effect for the block after the passed node, as the earlier
+// blocks use different algorithms.
+calcAlgoWindow(tip *BlockNode, numBlocks int32) *AlgoWindow {
+	current := fork.GetCurrent(tip.height + 1)
+	algoVers := fork.List[current].AlgoVers
+	window := &AlgoWindow{
+		Height: tip.height,
+		Algos:  make([]AlgoStats, 0, len(algoVers)),
+	}
+	targetInterval := fork.GetAlgoTargetTimePerBlock(tip.height + 1)
+	index := make(map[string]int, len(algoVers))
+	for version, name := range algoVers {
+		window.Algos = append(window.Algos, AlgoStats{
+			Name:           name,
+			Version:        version,
+			TargetInterval: targetInterval,
+		})
+	}
+	sort.Slice(window.Algos, func(i, j int) bool {
+		return window.Algos[i].Version < window.Algos[j].Version
+	})
+	hashes := make([]*big.Int, len(window.Algos))
+	for i := range window.Algos {
+		index[window.Algos[i].Name] = i
+		hashes[i] = new(big.Int)
+	}
+	start := tip
+	for window.Blocks < numBlocks && start.parent != nil &&
+		fork.GetCurrent(start.height) == current {
+		// Before the Plan 9 hard fork blocks of unknown versions are sha256d.
+		name := fork.GetAlgoName(start.version, start.height)
+		if i, ok := index[name]; ok {
+			window.Algos[i].Blocks++
+			// Unlike CalcWork this is not scaled by the relative cost of
+			// the algorithm, so it counts hashes of the algorithm itself.
+			target := CompactToBig(start.bits)
+			if target.Sign() > 0 {
+				hashes[i].Add(hashes[i], new(big.Int).Div(oneLsh256,
+					target.Add(target, bigOne)))
+			}
+		}
+		window.Blocks++
+		start = start.parent
+	}
+	window.Timespan = tip.timestamp - start.timestamp
+	for i := range window.Algos {
+		algo := &window.Algos[i]
+		if algo.Blocks == 0 {
+			continue
+		}
+		algo.AvgInterval = float64(window.Timespan) / float64(algo.Blocks)
+		if window.Timespan > 0 {
+			algo.HashesPerSec = new(big.Int).Div(hashes[i],
+				big.NewInt(window.Timespan)).Int64()
+		}
+	}
+	return window
+}
+
+func // FetchAlgoStats returns the statistics of each proof of work algorithm
+// over the last numBlocks blocks of the main chain along with the target the
+// next block found with each algorithm must meet.  It only reads the chain,
+// so it does not hold up blocks being processed.
+// This function is safe for concurrent access.
+(b *BlockChain) FetchAlgoStats(numBlocks int32) (*AlgoWindow, error) {
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+	tip := b.BestChain.Tip()
+	window := calcAlgoWindow(tip, numBlocks)
+	for i := range window.Algos {
+		algo := &window.Algos[i]
+		bits, err := b.calcNextRequiredDifficulty(0, tip, time.Now(),
+			algo.Name, false)
+		if err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+		algo.Bits = bits
+	}
+	return window, nil
+}
+
+func // RecentAlgoStats returns the statistics of each proof of work algorithm
+// over the last numBlocks blocks of the main chain, or nil when they can't be
+// calculated.  Reports which only include the statistics alongside others
+// leave them out then rather than failing as a whole.
+// This function is safe for concurrent access.
+(b *BlockChain) RecentAlgoStats(numBlocks int32) *AlgoWindow {
+	window, err := b.FetchAlgoStats(numBlocks)
+	if err != nil {
+		log.ERROR(err)
+		return nil
+	}
+	return window
+}
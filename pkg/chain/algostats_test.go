@@ -0,0 +1,89 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// TestCalcAlgoWindow ensures the per algorithm block counts, hash rates and
+// intervals are gathered over the requested window of blocks.
+func TestCalcAlgoWindow(t *testing.T) {
+	// Each block at this target takes 4295032833 hashes on average.
+	const bits = 0x1d00ffff
+	genesis := NewBlockNode(&wire.BlockHeader{Version: 2, Bits: bits,
+		Timestamp: time.Unix(1000, 0)}, nil)
+	tip := genesis
+	for _, block := range []struct {
+		version int32
+		time    int64
+	}{
+		{2, 1100},
+		{514, 1200},
+		{2, 1400},
+		// Unknown versions were accepted as sha256d before Plan 9.
+		{3, 1600},
+	} {
+		header := wire.BlockHeader{Version: block.version, Bits: bits,
+			PrevBlock: tip.hash, Timestamp: time.Unix(block.time, 0)}
+		tip = NewBlockNode(&header, tip)
+	}
+	tests := []struct {
+		name      string
+		numBlocks int32
+		blocks    int32
+		timespan  int64
+		// counts, hash rates and average intervals of sha256d and scrypt
+		counts    [2]int32
+		hashRates [2]int64
+		intervals [2]float64
+	}{
+		{
+			name:      "whole chain",
+			numBlocks: 120,
+			blocks:    4,
+			timespan:  600,
+			counts:    [2]int32{3, 1},
+			hashRates: [2]int64{21475164, 7158388},
+			intervals: [2]float64{200, 600},
+		},
+		{
+			name:      "last two blocks",
+			numBlocks: 2,
+			blocks:    2,
+			timespan:  400,
+			counts:    [2]int32{2, 0},
+			hashRates: [2]int64{21475164, 0},
+			intervals: [2]float64{200, 0},
+		},
+	}
+	for _, test := range tests {
+		window := calcAlgoWindow(tip, test.numBlocks)
+		if window.Height != 4 || window.Blocks != test.blocks ||
+			window.Timespan != test.timespan {
+			t.Errorf("%s: window at height %d of %d blocks over %d seconds, "+
+				"want 4, %d and %d", test.name, window.Height, window.Blocks,
+				window.Timespan, test.blocks, test.timespan)
+		}
+		if len(window.Algos) != 2 || window.Algos[0].Name != "sha256d" ||
+			window.Algos[1].Name != "scrypt" {
+			t.Errorf("%s: unexpected algorithms %v", test.name, window.Algos)
+			continue
+		}
+		for i, algo := range window.Algos {
+			if algo.Blocks != test.counts[i] ||
+				algo.HashesPerSec != test.hashRates[i] ||
+				algo.AvgInterval != test.intervals[i] {
+				t.Errorf("%s: %s found %d blocks at %d hashes/s every %v "+
+					"seconds, want %d at %d every %v", test.name, algo.Name,
+					algo.Blocks, algo.HashesPerSec, algo.AvgInterval,
+					test.counts[i], test.hashRates[i], test.intervals[i])
+			}
+			if algo.TargetInterval != 300 {
+				t.Errorf("%s: %s target interval %d, want 300", test.name,
+					algo.Name, algo.TargetInterval)
+			}
+		}
+	}
+}
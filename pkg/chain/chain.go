@@ -85,6 +85,11 @@ type // BlockChain provides functions for working with the bitcoin block chain.
 	// ensuring blocks follow all rules, orphan handling, checkpoint handling,
 	// and best chain selection with reorganization.
 	BlockChain struct {
+		// validatedBlocks and validationNanos count the blocks accepted by
+		// ProcessBlock and the time spent processing them.  They are accessed
+		// atomically and kept first for 64-bit alignment.
+		validatedBlocks uint64
+		validationNanos uint64
 		// The following fields are set when the instance is created and can't be
 		// changed afterwards so there is no need to protect them with a separate
 		// mutex.
@@ -96,6 +101,7 @@ type // BlockChain provides functions for working with the bitcoin block chain.
 		sigCache            *txscript.SigCache
 		indexManager        IndexManager
 		hashCache           *txscript.HashCache
+		pruneTarget         uint64
 		// The following fields are calculated based upon the provided chain
 		// parameters.  They are also set when the instance is created and can't
 		// be changed afterwards, so there is no need to protect them with
@@ -160,6 +166,12 @@ type // BlockChain provides functions for working with the bitcoin block chain.
 		// unknownVersionsWarned refers to warnings due to unknown versions being
 		// mined.
 		unknownRulesWarned bool
+		// warning is the last warning shown about unknown rules, reported to
+		// the rpc clients along with the network and chain state.
+		warning string
+		// pruneHeight is the height of the oldest main chain block whose data
+		// has not been pruned.
+		pruneHeight int32
 		// unknownVersionsWarned bool
 		// The notifications field stores a slice of callbacks to be executed on
 		// certain blockchain events.
@@ -627,6 +639,11 @@ func // connectBlock handles connecting the passed node/block to the end of the
 	b.stateLock.Lock()
 	b.stateSnapshot = state
 	b.stateLock.Unlock()
+	// Failing to prune only leaves more blocks on disk than wanted, so it
+	// does not stop the block from being connected.
+	if err := b.maybePruneBlocks(); err != nil {
+		log.WARN("unable to prune blocks:", err)
+	}
 	//
 	//// TODO: this should not run if the chain is syncing
 	//tN := time.Now()
@@ -1580,6 +1597,10 @@ type // Config is a descriptor which specifies the blockchain instance configura
 		// flag. This field can be nil if the caller is not interested in using a
 		// signature cache.
 		HashCache *txscript.HashCache
+		// PruneTarget is the number of bytes the stored blocks are limited to
+		// by deleting the data of the oldest ones.  It must be at least
+		// MinPruneTarget, or zero to keep every block.
+		PruneTarget uint64
 	}
 
 func // New returns a BlockChain instance using the provided configuration
@@ -1595,6 +1616,9 @@ New(config *Config) (*BlockChain, error) {
 	if config.TimeSource == nil {
 		return nil, AssertError("blockchain.New timesource is nil")
 	}
+	if config.PruneTarget != 0 && config.PruneTarget < MinPruneTarget {
+		return nil, AssertError("blockchain.New prune target is too small")
+	}
 	// Generate a checkpoint by height map from the provided checkpoints and
 	// assert the provided checkpoints are sorted by height as required.
 	var checkpointsByHeight map[int32]*chaincfg.Checkpoint
@@ -1628,6 +1652,7 @@ New(config *Config) (*BlockChain, error) {
 		blocksPerRetarget:     int32(targetTimespan / targetTimePerBlock),
 		Index:                 newBlockIndex(config.DB, params),
 		hashCache:             config.HashCache,
+		pruneTarget:           config.PruneTarget,
 		BestChain:             newChainView(nil),
 		orphans:               make(map[chainhash.Hash]*orphanBlock),
 		prevOrphans:           make(map[chainhash.Hash][]*orphanBlock),
@@ -1642,6 +1667,18 @@ New(config *Config) (*BlockChain, error) {
 	if err := b.initChainState(); err != nil {
 		return nil, err
 	}
+	if err := b.maybeDiscardTxOutSetLoad(); err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	err := b.db.View(func(dbTx database.Tx) error {
+		b.pruneHeight = dbFetchPruneHeight(dbTx)
+		return nil
+	})
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
 	// Perform any upgrades to the various chain-specific buckets as needed.
 	if err := b.maybeUpgradeDbBuckets(config.Interrupt); err != nil {
 		return nil, err
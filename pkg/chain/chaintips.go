@@ -0,0 +1,303 @@
+package blockchain
+
+import (
+	"container/list"
+	"fmt"
+	"math/big"
+	"sort"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/log"
+)
+
+// ChainTipStatus describes the validation state of the branch ending in a
+// chain tip, using the same names as the getchaintips RPC of other bitcoin
+// family nodes.
+type ChainTipStatus string
+
+const (
+	// ChainTipActive is the tip of the current best chain.
+	ChainTipActive ChainTipStatus = "active"
+	// ChainTipInvalid is a branch that contains at least one invalid block,
+	// either because it failed validation or it was invalidated manually.
+	ChainTipInvalid ChainTipStatus = "invalid"
+	// ChainTipHeadersOnly is a branch for which not all block data is stored.
+	ChainTipHeadersOnly ChainTipStatus = "headers-only"
+	// ChainTipValidHeaders is a branch whose blocks are all stored but which
+	// has never been fully validated because it was never the best chain.
+	ChainTipValidHeaders ChainTipStatus = "valid-headers"
+	// ChainTipValidFork is a fully validated branch that is not part of the
+	// current best chain, usually because it was reorganized away from.
+	ChainTipValidFork ChainTipStatus = "valid-fork"
+)
+
+// ChainTip describes one of the leaves of the block index tree.
+type ChainTip struct {
+	// Height is the height of the tip block.
+	Height int32
+	// Hash is the hash of the tip block.
+	Hash chainhash.Hash
+	// BranchLen is the number of blocks between the tip and the point where
+	// it forks from the best chain, zero for the active tip.
+	BranchLen int32
+	// Status is the validation state of the branch.
+	Status ChainTipStatus
+}
+
+// tips returns every block node in the index that has no children.
+// This function is safe for concurrent access.
+func (bi *blockIndex) tips() (tips []*BlockNode) {
+	bi.RLock()
+	parents := make(map[*BlockNode]struct{}, len(bi.index))
+	for _, node := range bi.index {
+		if node.parent != nil {
+			parents[node.parent] = struct{}{}
+		}
+	}
+	for _, node := range bi.index {
+		if _, ok := parents[node]; !ok {
+			tips = append(tips, node)
+		}
+	}
+	bi.RUnlock()
+	return
+}
+
+// ChainTips returns every known tip in the block index along with the length
+// of its branch and its validation state, sorted from the highest tip down.
+// This function is safe for concurrent access.
+func (b *BlockChain) ChainTips() []ChainTip {
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+	bestTip := b.BestChain.Tip()
+	nodes := b.Index.tips()
+	tips := make([]ChainTip, 0, len(nodes))
+	for _, node := range nodes {
+		tip := ChainTip{
+			Height: node.height,
+			Hash:   node.hash,
+		}
+		if fork := b.BestChain.FindFork(node); fork != nil {
+			tip.BranchLen = node.height - fork.height
+		}
+		tip.Status = b.branchStatus(node)
+		if node == bestTip {
+			tip.Status = ChainTipActive
+		}
+		tips = append(tips, tip)
+	}
+	sort.Slice(tips, func(i, j int) bool {
+		return tips[i].Height > tips[j].Height
+	})
+	return tips
+}
+
+// branchStatus returns the status of the branch ending at the given node.
+// This function MUST be called with the chain state lock held (for reads).
+func (b *BlockChain) branchStatus(node *BlockNode) ChainTipStatus {
+	status := b.Index.NodeStatus(node)
+	switch {
+	case status.KnownInvalid():
+		return ChainTipInvalid
+	case !status.HaveData():
+		return ChainTipHeadersOnly
+	case status.KnownValid():
+		return ChainTipValidFork
+	}
+	return ChainTipValidHeaders
+}
+
+// descendants returns all of the nodes in the index that have the given node
+// as an ancestor. It works back from the tips so that only the branches that
+// actually contain the node are walked.
+// This function MUST be called with the chain state lock held (for reads).
+func (b *BlockChain) descendants(node *BlockNode) (nodes []*BlockNode) {
+	seen := make(map[*BlockNode]struct{})
+	for _, tip := range b.Index.tips() {
+		if tip.height <= node.height || tip.Ancestor(node.height) != node {
+			continue
+		}
+		for n := tip; n != node; n = n.parent {
+			if _, ok := seen[n]; ok {
+				break
+			}
+			seen[n] = struct{}{}
+			nodes = append(nodes, n)
+		}
+	}
+	return
+}
+
+// branchWork returns the sum of the work of every block after the fork node up
+// to and including the tip node. Comparing branches from their common fork
+// point does not depend on the cumulative workSum stored in the nodes.
+func branchWork(fork, tip *BlockNode) *big.Int {
+	work := big.NewInt(0)
+	for n := tip; n != nil && n != fork; n = n.parent {
+		work.Add(work, CalcWork(n.bits, n.height, n.version))
+	}
+	return work
+}
+
+// activateBestChain finds the branch with the most work that is not known to
+// be invalid and reorganizes the chain onto it if it has more work than the
+// current best chain. Branches that fail to connect are marked invalid by the
+// reorganization and the next best one is tried.
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) activateBestChain() error {
+	tried := make(map[*BlockNode]struct{})
+	for {
+		tip := b.BestChain.Tip()
+		var best *BlockNode
+		var bestWork *big.Int
+		for _, candidate := range b.Index.tips() {
+			// Walk back from invalid or incomplete tips to the last block
+			// that could be connected.
+			for candidate != nil {
+				status := b.Index.NodeStatus(candidate)
+				if !status.KnownInvalid() && status.HaveData() {
+					break
+				}
+				candidate = candidate.parent
+			}
+			if candidate == nil || b.BestChain.Contains(candidate) {
+				continue
+			}
+			if _, ok := tried[candidate]; ok {
+				continue
+			}
+			fork := b.BestChain.FindFork(candidate)
+			work := branchWork(fork, candidate)
+			if work.Cmp(branchWork(fork, tip)) <= 0 {
+				continue
+			}
+			if best == nil || work.Cmp(bestWork) > 0 {
+				best, bestWork = candidate, work
+			}
+		}
+		if best == nil {
+			return nil
+		}
+		tried[best] = struct{}{}
+		detachNodes, attachNodes := b.getReorganizeNodes(best)
+		log.INFOF("REORGANIZE: activating branch ending in %v (height %d)",
+			best.hash, best.height)
+		err := b.reorganizeChain(detachNodes, attachNodes)
+		if err == nil {
+			return nil
+		}
+		if _, ok := err.(RuleError); !ok {
+			return err
+		}
+		// The failing block has been marked invalid, look for the next best
+		// branch.
+	}
+}
+
+// InvalidateBlock permanently marks the block with the given hash and all of
+// its descendants as invalid. If the block is part of the best chain, the chain
+// is disconnected back to its parent and the remaining branch with the most
+// work becomes the new best chain. The invalid state is kept in the block
+// index in the database so it persists across restarts until ReconsiderBlock
+// is called. This function is safe for concurrent access.
+func (b *BlockChain) InvalidateBlock(hash *chainhash.Hash) error {
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+	node := b.Index.LookupNode(hash)
+	if node == nil {
+		return fmt.Errorf("block %s is not known", hash)
+	}
+	if node.parent == nil {
+		return fmt.Errorf("the genesis block cannot be invalidated")
+	}
+	b.Index.SetStatusFlags(node, statusValidateFailed)
+	for _, n := range b.descendants(node) {
+		b.Index.SetStatusFlags(n, statusInvalidAncestor)
+	}
+	err := b.invalidateBestChain(node)
+	if err == nil {
+		err = b.activateBestChain()
+	}
+	if writeErr := b.Index.flushToDB(); writeErr != nil {
+		log.ERROR("error flushing block index changes to disk:", writeErr)
+	}
+	return err
+}
+
+// invalidateBestChain disconnects the blocks of the best chain back to the
+// parent of the given node, if the node is part of it.
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) invalidateBestChain(node *BlockNode) error {
+	if !b.BestChain.Contains(node) {
+		return nil
+	}
+	detachNodes := list.New()
+	for n := b.BestChain.Tip(); n != node.parent; n = n.parent {
+		detachNodes.PushBack(n)
+	}
+	log.INFOF("REORGANIZE: disconnecting %d blocks back to invalidated "+
+		"block %v (height %d)", detachNodes.Len(), node.hash, node.height)
+	return b.reorganizeChain(detachNodes, list.New())
+}
+
+// ReconsiderBlock removes the invalid status from the block with the given
+// hash, its ancestors and its descendants, undoing InvalidateBlock, and then
+// reorganizes onto the branch with the most work. Blocks that are actually
+// invalid are rejected again when the branch is connected.
+// This function is safe for concurrent access.
+func (b *BlockChain) ReconsiderBlock(hash *chainhash.Hash) error {
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+	node := b.Index.LookupNode(hash)
+	if node == nil {
+		return fmt.Errorf("block %s is not known", hash)
+	}
+	const invalid = statusValidateFailed | statusInvalidAncestor
+	for n := node; n != nil; n = n.parent {
+		if b.Index.NodeStatus(n)&invalid != 0 {
+			b.Index.UnsetStatusFlags(n, invalid)
+		}
+	}
+	for _, n := range b.descendants(node) {
+		if b.Index.NodeStatus(n)&invalid != 0 {
+			b.Index.UnsetStatusFlags(n, invalid)
+		}
+	}
+	err := b.activateBestChain()
+	if writeErr := b.Index.flushToDB(); writeErr != nil {
+		log.ERROR("error flushing block index changes to disk:", writeErr)
+	}
+	return err
+}
+
+// PreciousBlock treats the block with the given hash as if it was received
+// before any competing block with the same amount of work. If the branch
+// ending in the block has at least as much work as the current best chain the
+// chain is reorganized onto it, otherwise this has no effect.
+// This function is safe for concurrent access.
+func (b *BlockChain) PreciousBlock(hash *chainhash.Hash) error {
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+	node := b.Index.LookupNode(hash)
+	if node == nil {
+		return fmt.Errorf("block %s is not known", hash)
+	}
+	if b.Index.NodeStatus(node).KnownInvalid() {
+		return fmt.Errorf("block %s is invalid", hash)
+	}
+	if b.BestChain.Contains(node) {
+		return nil
+	}
+	fork := b.BestChain.FindFork(node)
+	if branchWork(fork, node).Cmp(branchWork(fork, b.BestChain.Tip())) < 0 {
+		return nil
+	}
+	detachNodes, attachNodes := b.getReorganizeNodes(node)
+	log.INFOF("REORGANIZE: precious block %v is causing a reorganize",
+		node.hash)
+	err := b.reorganizeChain(detachNodes, attachNodes)
+	if writeErr := b.Index.flushToDB(); writeErr != nil {
+		log.ERROR("error flushing block index changes to disk:", writeErr)
+	}
+	return err
+}
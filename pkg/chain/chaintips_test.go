@@ -0,0 +1,120 @@
+package blockchain
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestInvalidateReconsiderBlock ensures invalidating a block of the best chain
+// reorganizes onto the best valid branch, that reconsidering it reorganizes
+// back, and that the chain tips report the state of each branch.
+func TestInvalidateReconsiderBlock(t *testing.T) {
+	chain, teardown := newTestChain(t, "invalidatereconsider")
+	defer teardown()
+	genesis := chain.BestChain.Tip()
+	main := addTestBlocks(t, chain, genesis, 4, 0)
+	side := addTestBlocks(t, chain, genesis, 3, 1)
+	checkTips := func(desc string, want []ChainTip) {
+		t.Helper()
+		if tips := chain.ChainTips(); !reflect.DeepEqual(tips, want) {
+			t.Errorf("%s: chain tips %v, want %v", desc, tips, want)
+		}
+	}
+	if tip := chain.BestChain.Tip(); tip != main[3] {
+		t.Fatalf("best chain tip is at height %d, want the main branch",
+			tip.height)
+	}
+	// A side chain that was never connected only has valid headers.
+	checkTips("side chain", []ChainTip{
+		{Height: 4, Hash: main[3].hash, Status: ChainTipActive},
+		{Height: 3, Hash: side[2].hash, BranchLen: 3,
+			Status: ChainTipValidHeaders},
+	})
+	// Invalidating the second block leaves one block on the main branch,
+	// less work than the side chain, so the side chain becomes the best.
+	if err := chain.InvalidateBlock(&main[1].hash); err != nil {
+		t.Fatalf("InvalidateBlock: unexpected error: %v", err)
+	}
+	if tip := chain.BestChain.Tip(); tip != side[2] {
+		t.Fatalf("best chain tip after invalidating is at height %d, want "+
+			"the side chain", tip.height)
+	}
+	if status := chain.Index.NodeStatus(main[1]); !status.KnownInvalid() ||
+		status&statusValidateFailed == 0 {
+		t.Errorf("invalidated block has status %v", status)
+	}
+	for _, node := range main[2:] {
+		if status := chain.Index.NodeStatus(node); status&
+			statusInvalidAncestor == 0 {
+			t.Errorf("descendant at height %d of the invalidated block has "+
+				"status %v", node.height, status)
+		}
+	}
+	if status := chain.Index.NodeStatus(main[0]); status.KnownInvalid() {
+		t.Errorf("parent of the invalidated block has status %v", status)
+	}
+	checkTips("invalidated", []ChainTip{
+		{Height: 4, Hash: main[3].hash, BranchLen: 4, Status: ChainTipInvalid},
+		{Height: 3, Hash: side[2].hash, Status: ChainTipActive},
+	})
+	// Reconsidering the block makes the main branch the best again, and the
+	// side chain is now a fully validated fork.
+	if err := chain.ReconsiderBlock(&main[1].hash); err != nil {
+		t.Fatalf("ReconsiderBlock: unexpected error: %v", err)
+	}
+	if tip := chain.BestChain.Tip(); tip != main[3] {
+		t.Fatalf("best chain tip after reconsidering is at height %d, want "+
+			"the main branch", tip.height)
+	}
+	for _, node := range main {
+		if status := chain.Index.NodeStatus(node); status.KnownInvalid() {
+			t.Errorf("reconsidered block at height %d has status %v",
+				node.height, status)
+		}
+	}
+	checkTips("reconsidered", []ChainTip{
+		{Height: 4, Hash: main[3].hash, Status: ChainTipActive},
+		{Height: 3, Hash: side[2].hash, BranchLen: 3, Status: ChainTipValidFork},
+	})
+	if err := chain.InvalidateBlock(&genesis.hash); err == nil {
+		t.Errorf("InvalidateBlock: genesis block was invalidated")
+	}
+}
+
+// TestPreciousBlock ensures a precious block chooses between branches with
+// the same work and has no effect on a branch with less work.
+func TestPreciousBlock(t *testing.T) {
+	chain, teardown := newTestChain(t, "preciousblock")
+	defer teardown()
+	genesis := chain.BestChain.Tip()
+	a := addTestBlocks(t, chain, genesis, 2, 0)
+	b := addTestBlocks(t, chain, genesis, 2, 1)
+	if tip := chain.BestChain.Tip(); tip != a[1] {
+		t.Fatalf("best chain tip is not the first branch received")
+	}
+	if err := chain.PreciousBlock(&b[1].hash); err != nil {
+		t.Fatalf("PreciousBlock: unexpected error: %v", err)
+	}
+	if tip := chain.BestChain.Tip(); tip != b[1] {
+		t.Fatalf("best chain tip is not the precious block")
+	}
+	if err := chain.PreciousBlock(&a[1].hash); err != nil {
+		t.Fatalf("PreciousBlock: unexpected error: %v", err)
+	}
+	if tip := chain.BestChain.Tip(); tip != a[1] {
+		t.Fatalf("best chain tip did not return to the precious block")
+	}
+	// A branch with less work is not made the best chain.
+	if err := chain.PreciousBlock(&b[0].hash); err != nil {
+		t.Fatalf("PreciousBlock: unexpected error: %v", err)
+	}
+	if tip := chain.BestChain.Tip(); tip != a[1] {
+		t.Errorf("precious block with less work became the best chain tip")
+	}
+	if err := chain.InvalidateBlock(&b[1].hash); err != nil {
+		t.Fatalf("InvalidateBlock: unexpected error: %v", err)
+	}
+	if err := chain.PreciousBlock(&b[1].hash); err == nil {
+		t.Errorf("PreciousBlock: invalid block was accepted")
+	}
+}
@@ -3,12 +3,18 @@ package blockchain
 import (
 	"github.com/p9c/pod/pkg/chain/config/netparams"
 	"os"
+	"path/filepath"
+	"testing"
 	"time"
 
 	chaincfg "github.com/p9c/pod/pkg/chain/config"
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
 	"github.com/p9c/pod/pkg/chain/wire"
 	database "github.com/p9c/pod/pkg/db"
 	_ "github.com/p9c/pod/pkg/db/ffldb"
+	"github.com/p9c/pod/pkg/util"
 )
 
 const (
@@ -320,3 +326,81 @@ func newFakeNode(parent *BlockNode, blockVersion int32, bits uint32, timestamp t
 	}
 	return NewBlockNode(header, parent)
 }
+
+// newTestChain returns a chain on the regression test network backed by a new
+// database holding only the genesis block, along with a function removing
+// the database which the caller must invoke when done.
+func newTestChain(t *testing.T, dbName string) (*BlockChain, func()) {
+	params := &netparams.RegressionTestParams
+	dbPath := filepath.Join(os.TempDir(), dbName)
+	_ = os.RemoveAll(dbPath)
+	db, err := database.Create(testDbType, dbPath, params.Net)
+	if err != nil {
+		t.Fatalf("error creating db: %v", err)
+	}
+	teardown := func() {
+		db.Close()
+		os.RemoveAll(dbPath)
+	}
+	chain, err := New(&Config{
+		DB:          db,
+		ChainParams: params,
+		TimeSource:  NewMedianTime(),
+	})
+	if err != nil {
+		teardown()
+		t.Fatalf("failed to create chain instance: %v", err)
+	}
+	return chain, teardown
+}
+
+// addTestBlocks processes count blocks holding only a coinbase built on top of
+// the parent and returns their nodes.  The blocks are at the minimum
+// difficulty and the proof of work is not checked.  Branches from the same
+// parent must use a different branch number so their blocks differ.
+func addTestBlocks(t *testing.T, chain *BlockChain, parent *BlockNode,
+	count int, branch int64) []*BlockNode {
+	nodes := make([]*BlockNode, 0, count)
+	for i := 0; i < count; i++ {
+		height := parent.height + 1
+		script, err := txscript.NewScriptBuilder().AddInt64(int64(height)).
+			AddInt64(branch).Script()
+		if err != nil {
+			t.Fatalf("unable to create coinbase script: %v", err)
+		}
+		coinbase := wire.NewMsgTx(1)
+		coinbase.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: *wire.NewOutPoint(&chainhash.Hash{},
+				wire.MaxPrevOutIndex),
+			Sequence:        wire.MaxTxInSequenceNum,
+			SignatureScript: script,
+		})
+		coinbase.AddTxOut(&wire.TxOut{
+			Value:    CalcBlockSubsidy(height, chain.params),
+			PkScript: []byte{txscript.OP_TRUE},
+		})
+		merkles := BuildMerkleTreeStore([]*util.Tx{util.NewTx(coinbase)}, false)
+		block := &wire.MsgBlock{
+			Header: wire.BlockHeader{
+				Version:    parent.version,
+				PrevBlock:  parent.hash,
+				MerkleRoot: *merkles[len(merkles)-1],
+				Bits: fork.GetMinBits(fork.GetAlgoName(parent.version,
+					height), height),
+				Timestamp: time.Unix(parent.timestamp+60, 0),
+			},
+			Transactions: []*wire.MsgTx{coinbase},
+		}
+		_, _, err = chain.ProcessBlock(0, util.NewBlock(block),
+			BFFastAdd|BFNoPoWCheck, height)
+		if err != nil {
+			t.Fatalf("unable to process block at height %d: %v", height, err)
+		}
+		hash := block.BlockHash()
+		if parent = chain.Index.LookupNode(&hash); parent == nil {
+			t.Fatalf("block at height %d is not in the index", height)
+		}
+		nodes = append(nodes, parent)
+	}
+	return nodes
+}
@@ -205,6 +205,20 @@ func GetAlgoVer(name string, height int32) (version int32) {
 	return
 }
 
+// GetAlgoTargetTimePerBlock returns the target seconds between two blocks of
+// the same algorithm based on hard fork status. Before the Plan 9 hard fork
+// each algorithm retargets to the block interval on its own, afterwards the
+// algorithms take turns to make up the block interval, so each one targets
+// the block interval times the number of algorithms.
+func GetAlgoTargetTimePerBlock(height int32) (r int64) {
+	curr := GetCurrent(height)
+	r = int64(List[curr].TargetTimePerBlock)
+	if curr > 0 {
+		r *= int64(len(List[curr].Algos))
+	}
+	return
+}
+
 // GetAveragingInterval returns the active block interval target based on
 // hard fork status
 func GetAveragingInterval(height int32) (r int64) {
@@ -10,6 +10,10 @@ import (
 )
 
 func RefillMiningAddresses(w *wallet.Wallet, cfg *pod.Config, stateCfg *state.Config) {
+	// addresses are derived by the node from the mining xpub when one is set
+	if stateCfg.MiningXpub != "" {
+		return
+	}
 	// we make the list up to 1000 so the user does not have to attend to
 	// this too often
 	miningAddressLen := len(*cfg.MiningAddrs)
@@ -58,9 +58,6 @@ type Config struct {
 	// BlockTemplateGenerator identifies the instance to use in order to
 	// generate block templates that the miner will attempt to solve.
 	BlockTemplateGenerator *mining.BlkTmplGenerator
-	// MiningAddrs is a list of payment addresses to use for the generated
-	// blocks.  Each generated block will randomly choose one of them.
-	MiningAddrs []util.Address
 	// ProcessBlock defines the function to call with any solved blocks. It
 	// typically must run the provided block through the same set of rules and
 	// handling as any other block coming from the network.
@@ -136,6 +133,15 @@ func (m *CPUMiner) GenerateNBlocks(workerNumber uint32, n uint32,
 	// Start a ticker which is used to signal checks for stale work and updates to the speed monitor.
 	ticker := time.NewTicker(time.Second * hashUpdateSecs)
 	defer ticker.Stop()
+	// stop shuts down the speed monitor and lets the miner be started again
+	stop := func() {
+		m.Lock()
+		close(m.speedMonitorQuit)
+		m.wg.Wait()
+		m.started = false
+		m.discreteMining = false
+		m.Unlock()
+	}
 	for {
 		// Read updateNumWorkers in case someone tries a `setgenerate` while
 		// we're generating. We can ignore it as the `generate` RPC call only
@@ -149,9 +155,16 @@ func (m *CPUMiner) GenerateNBlocks(workerNumber uint32, n uint32,
 		// template on a block that is in the process of becoming stale.
 		m.submitBlockLock.Lock()
 		curHeight := m.g.BestSnapshot().Height
-		// Choose a payment address at random.
-		rand.Seed(time.Now().UnixNano())
-		payToAddr := m.cfg.MiningAddrs[rand.Intn(len(m.cfg.MiningAddrs))]
+		payToAddr, err := m.g.PayToAddress()
+		if err != nil {
+			m.submitBlockLock.Unlock()
+			log.ERROR(err)
+			if err == mining.ErrNoPayToAddrs {
+				stop()
+				return nil, err
+			}
+			continue
+		}
 		// Create a new block template using the available transactions in the
 		// memory pool as a source of transactions to potentially include in the
 		// block.
@@ -174,12 +187,7 @@ func (m *CPUMiner) GenerateNBlocks(workerNumber uint32, n uint32,
 			i++
 			if i == n {
 				log.WARNF("generated %d blocks", i)
-				m.Lock()
-				close(m.speedMonitorQuit)
-				m.wg.Wait()
-				m.started = false
-				m.discreteMining = false
-				m.Unlock()
+				stop()
 				return blockHashes, nil
 			}
 		}
@@ -255,7 +263,8 @@ func (m *CPUMiner) SetNumWorkers(numWorkers int32) {
 // been started will have no effect.
 // This function is safe for concurrent access.
 func (m *CPUMiner) Start() {
-	if len(m.cfg.MiningAddrs) < 1 {
+	if !m.g.HasPayToAddress() {
+		log.WARN("no mining addresses are configured, not starting cpu miner")
 		return
 	}
 	m.Lock()
@@ -370,9 +379,21 @@ out:
 		default:
 			// Non-blocking select to fall through
 		}
-		// Choose a payment address at random.
-		rand.Seed(time.Now().UnixNano())
-		payToAddr := m.cfg.MiningAddrs[rand.Intn(len(m.cfg.MiningAddrs))]
+		payToAddr, err := m.g.PayToAddress()
+		if err == mining.ErrNoPayToAddrs {
+			// the wallet may still add addresses to the list
+			log.WARN("no mining addresses are available, waiting")
+			select {
+			case <-quit:
+				break out
+			case <-time.After(time.Second * hashUpdateSecs):
+			}
+			continue
+		}
+		if err != nil {
+			log.ERROR(err)
+			continue
+		}
 		// Create a new block template using the available transactions in the
 		// memory pool as a source of transactions to potentially include in the
 		// block.
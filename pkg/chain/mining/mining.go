@@ -130,6 +130,9 @@ type (
 		SigCache    *txscript.SigCache
 		HashCache   *txscript.HashCache
 		Algo        string
+		// PayToAddrs gives the addresses templates pay to, it is set by the
+		// node after the generator is created.
+		PayToAddrs PayToAddrSource
 	}
 )
 
@@ -933,6 +936,21 @@ func // BestSnapshot returns information about the current best chain block and
 	return g.Chain.BestSnapshot()
 }
 
+func // PayToAddress returns the address a template for the block after the
+// current best block should pay to.
+(g *BlkTmplGenerator) PayToAddress() (util.Address, error) {
+	if g.PayToAddrs == nil {
+		return nil, ErrNoPayToAddrs
+	}
+	return g.PayToAddrs.PayToAddr(g.Chain.BestSnapshot().Height + 1)
+}
+
+func // HasPayToAddress returns whether there is an address templates can pay
+// to.
+(g *BlkTmplGenerator) HasPayToAddress() bool {
+	return g.PayToAddrs != nil && g.PayToAddrs.Available()
+}
+
 func // GetTxSource returns the associated transaction source.
 // This function is safe for concurrent access.
 (g *BlkTmplGenerator) GetTxSource() TxSource {
@@ -0,0 +1,275 @@
+package mining
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	database "github.com/p9c/pod/pkg/db"
+	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+)
+
+// ErrNoPayToAddrs is returned when a block template paying to a mining address
+// is asked for but no mining addresses are configured.
+var ErrNoPayToAddrs = errors.New("no mining addresses are configured")
+
+// payToXpubIndexKeyPrefix is the prefix of the database metadata keys storing
+// the index of the next address derived from a mining extended public key,
+// followed by the hash of the key addresses are derived from.
+var payToXpubIndexKeyPrefix = []byte("miningxpubidx")
+
+// PayToAddrSource gives the addresses the coinbases of block templates pay
+// to.
+type PayToAddrSource interface {
+	// PayToAddr returns the address for templates of the block at the
+	// height.
+	PayToAddr(height int32) (util.Address, error)
+	// Available returns whether there is an address to pay to, so mining can
+	// wait for one instead of failing to make templates.
+	Available() bool
+}
+
+// AddrList is a PayToAddrSource paying each template to an address chosen at
+// random from a list of configured addresses. It points to the list as the
+// wallet adds addresses to it when it runs low.
+type AddrList struct {
+	Addrs *[]util.Address
+}
+
+// PayToAddr returns one of the addresses of the list at random.
+func (l AddrList) PayToAddr(int32) (util.Address, error) {
+	if l.Addrs == nil || len(*l.Addrs) == 0 {
+		return nil, ErrNoPayToAddrs
+	}
+	return (*l.Addrs)[rand.Intn(len(*l.Addrs))], nil
+}
+
+// Available returns whether the list has any addresses.
+func (l AddrList) Available() bool {
+	return l.Addrs != nil && len(*l.Addrs) > 0
+}
+
+// XpubAddrs is a PayToAddrSource deriving addresses from an extended public
+// key, so mined coins don't all go to the same address and no list of
+// addresses has to be made by a wallet beforehand. Templates keep paying to
+// the same address until a block paying to it is connected, and only then is
+// the next one derived, so a wallet watching the key never finds more unused
+// addresses in a row than its gap limit. The index of the address being paid
+// to is kept in the node database so paid addresses are not used again after
+// a restart.
+type XpubAddrs struct {
+	mx     sync.Mutex
+	db     database.DB
+	params *netparams.Params
+	branch *hdkeychain.ExtendedKey
+	dbKey  []byte
+	index  uint32
+	addr   util.Address
+	script []byte
+}
+
+// NewXpubAddrs returns a PayToAddrSource deriving addresses from the extended
+// public key or descriptor desc as parsed by ParsePayToXpub, continuing from
+// the index stored in the database.
+func NewXpubAddrs(desc string, db database.DB,
+	params *netparams.Params) (*XpubAddrs, error) {
+	branch, err := ParsePayToXpub(desc, params)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	x := &XpubAddrs{
+		db:     db,
+		params: params,
+		branch: branch,
+		dbKey: append(append([]byte{}, payToXpubIndexKeyPrefix...),
+			chainhash.HashB([]byte(branch.String()))...),
+	}
+	var index uint32
+	err = db.View(func(tx database.Tx) error {
+		if v := tx.Metadata().Get(x.dbKey); len(v) == 4 {
+			index = binary.LittleEndian.Uint32(v)
+		}
+		return nil
+	})
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	if err = x.derive(index); err != nil {
+		return nil, err
+	}
+	log.DEBUGF("deriving mining addresses from %s starting at index %d",
+		desc, x.index)
+	return x, nil
+}
+
+// PayToAddr returns the address being mined to, which is the same for every
+// height until a block paying to it is connected.
+func (x *XpubAddrs) PayToAddr(int32) (util.Address, error) {
+	x.mx.Lock()
+	defer x.mx.Unlock()
+	return x.addr, nil
+}
+
+// HandleChainNotification moves on to the next address when a block paying
+// to the current one is connected to the main chain. It is to be subscribed
+// to the notifications of the chain.
+func (x *XpubAddrs) HandleChainNotification(n *blockchain.Notification) {
+	if n.Type != blockchain.NTBlockConnected {
+		return
+	}
+	block, ok := n.Data.(*util.Block)
+	if !ok {
+		return
+	}
+	x.mx.Lock()
+	defer x.mx.Unlock()
+	if !x.paidBy(block) {
+		return
+	}
+	// the index is saved before the next address is used so the paid one
+	// is never paid to again, even if the node stops right after
+	index, addr, script := x.index, x.addr, x.script
+	if err := x.derive(index + 1); err != nil {
+		return
+	}
+	v := make([]byte, 4)
+	binary.LittleEndian.PutUint32(v, x.index)
+	err := x.db.Update(func(tx database.Tx) error {
+		return tx.Metadata().Put(x.dbKey, v)
+	})
+	if err != nil {
+		log.ERROR(err)
+		x.index, x.addr, x.script = index, addr, script
+		return
+	}
+	log.TRACEF("block %d paid to mining address at index %d, now mining"+
+		" to %s at index %d", block.Height(), index, x.addr.EncodeAddress(),
+		x.index)
+}
+
+// paidBy returns whether the coinbase of the block pays to the current
+// address.
+func (x *XpubAddrs) paidBy(block *util.Block) bool {
+	txs := block.MsgBlock().Transactions
+	if len(txs) == 0 {
+		return false
+	}
+	for _, out := range txs[0].TxOut {
+		if bytes.Equal(out.PkScript, x.script) {
+			return true
+		}
+	}
+	return false
+}
+
+// derive makes the address at the index, or the first valid one after it,
+// the one being mined to.
+func (x *XpubAddrs) derive(index uint32) error {
+	var child *hdkeychain.ExtendedKey
+	for {
+		var err error
+		child, err = x.branch.Child(index)
+		if err == hdkeychain.ErrInvalidChild {
+			// there is an extremely small chance a child is invalid,
+			// the next one is used instead
+			index++
+			continue
+		}
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		break
+	}
+	addr, err := child.Address(x.params)
+	if err != nil {
+		log.ERROR(err)
+		return err
+	}
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		log.ERROR(err)
+		return err
+	}
+	x.index, x.addr, x.script = index, addr, script
+	return nil
+}
+
+// Available returns true as an address can always be derived.
+func (x *XpubAddrs) Available() bool {
+	return true
+}
+
+// ParsePayToXpub parses the extended public key mining addresses are derived
+// from, returning the key they are children of. A plain extended public key is
+// taken to be that of a wallet account so addresses are derived from its
+// external branch, /0/*. A descriptor of the form pkh(xpub/a/b/*) derives them
+// from the path given, which must end in /* and can't have hardened steps. An
+// origin such as [d34db33f/44'/0'/0'] before the key and a checksum after the
+// descriptor are ignored.
+func ParsePayToXpub(desc string, params *netparams.Params) (
+	*hdkeychain.ExtendedKey, error) {
+	desc = strings.TrimSpace(desc)
+	keyPath := desc + "/0/*"
+	if strings.HasPrefix(desc, "pkh(") {
+		if i := strings.IndexByte(desc, '#'); i >= 0 {
+			desc = desc[:i]
+		}
+		if !strings.HasSuffix(desc, ")") {
+			return nil, fmt.Errorf("descriptor %q is missing a closing"+
+				" parenthesis", desc)
+		}
+		keyPath = desc[len("pkh(") : len(desc)-1]
+		if strings.HasPrefix(keyPath, "[") {
+			i := strings.IndexByte(keyPath, ']')
+			if i < 0 {
+				return nil, fmt.Errorf("descriptor %q has an unterminated"+
+					" key origin", desc)
+			}
+			keyPath = keyPath[i+1:]
+		}
+	}
+	steps := strings.Split(keyPath, "/")
+	if len(steps) < 2 || steps[len(steps)-1] != "*" {
+		return nil, fmt.Errorf("descriptor %q must derive addresses with"+
+			" a path ending in /*", desc)
+	}
+	key, err := hdkeychain.NewKeyFromString(steps[0])
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	if key.IsPrivate() {
+		return nil, errors.New("mining addresses must be derived from an" +
+			" extended public key, not a private one")
+	}
+	if !key.IsForNet(params) {
+		return nil, fmt.Errorf("mining extended public key is not for %s",
+			params.Name)
+	}
+	for _, step := range steps[1 : len(steps)-1] {
+		index, err := strconv.ParseUint(step, 10, 32)
+		if err != nil || index >= hdkeychain.HardenedKeyStart {
+			return nil, fmt.Errorf("descriptor step %q is not an"+
+				" unhardened child index", step)
+		}
+		if key, err = key.Child(uint32(index)); err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+	}
+	return key, nil
+}
@@ -0,0 +1,198 @@
+package mining
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	database "github.com/p9c/pod/pkg/db"
+	_ "github.com/p9c/pod/pkg/db/ffldb"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+)
+
+// testAccountKey returns an extended private key to stand in for a wallet
+// account and its extended public key.
+func testAccountKey(t *testing.T) (*hdkeychain.ExtendedKey, string) {
+	seed := make([]byte, hdkeychain.RecommendedSeedLen)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	key, err := hdkeychain.NewMaster(seed, &netparams.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create master key: %v", err)
+	}
+	pub, err := key.Neuter()
+	if err != nil {
+		t.Fatalf("unable to neuter key: %v", err)
+	}
+	return key, pub.String()
+}
+
+// testChild returns the child of the key at the path.
+func testChild(t *testing.T, key *hdkeychain.ExtendedKey,
+	path ...uint32) *hdkeychain.ExtendedKey {
+	for _, index := range path {
+		var err error
+		if key, err = key.Child(index); err != nil {
+			t.Fatalf("unable to derive child %d: %v", index, err)
+		}
+	}
+	pub, err := key.Neuter()
+	if err != nil {
+		t.Fatalf("unable to neuter key: %v", err)
+	}
+	return pub
+}
+
+func TestParsePayToXpub(t *testing.T) {
+	key, xpub := testAccountKey(t)
+	tests := []struct {
+		name   string
+		desc   string
+		params *netparams.Params
+		want   *hdkeychain.ExtendedKey
+	}{
+		{"plain key", xpub, &netparams.MainNetParams,
+			testChild(t, key, 0)},
+		{"spaces", " " + xpub + "\n", &netparams.MainNetParams,
+			testChild(t, key, 0)},
+		{"descriptor", "pkh(" + xpub + "/1/*)", &netparams.MainNetParams,
+			testChild(t, key, 1)},
+		{"descriptor with deeper path", "pkh(" + xpub + "/1/2/*)",
+			&netparams.MainNetParams, testChild(t, key, 1, 2)},
+		{"descriptor with origin and checksum",
+			"pkh([d34db33f/44'/0'/0']" + xpub + "/0/*)#qwertyui",
+			&netparams.MainNetParams, testChild(t, key, 0)},
+		{"missing parenthesis", "pkh(" + xpub + "/0/*",
+			&netparams.MainNetParams, nil},
+		{"unterminated origin", "pkh([d34db33f/44'" + xpub + "/0/*)",
+			&netparams.MainNetParams, nil},
+		{"path not ending in /*", "pkh(" + xpub + "/0)",
+			&netparams.MainNetParams, nil},
+		{"no path", "pkh(" + xpub + ")", &netparams.MainNetParams, nil},
+		{"hardened step", "pkh(" + xpub + "/0'/*)",
+			&netparams.MainNetParams, nil},
+		{"private key", key.String(), &netparams.MainNetParams, nil},
+		{"network mismatch", xpub, &netparams.TestNet3Params, nil},
+		{"invalid key", "xpubnotakey", &netparams.MainNetParams, nil},
+	}
+	for _, test := range tests {
+		got, err := ParsePayToXpub(test.desc, test.params)
+		if test.want == nil {
+			if err == nil {
+				t.Errorf("%s: no error parsing %q", test.name, test.desc)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if got.String() != test.want.String() {
+			t.Errorf("%s: got key %s, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+// testCoinbaseBlock returns a block at the height whose coinbase pays to the
+// address.
+func testCoinbaseBlock(t *testing.T, height int32,
+	addr util.Address) *util.Block {
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+	coinbase := wire.NewMsgTx(1)
+	coinbase.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Index: wire.MaxPrevOutIndex},
+		SignatureScript:  []byte{byte(height)},
+	})
+	coinbase.AddTxOut(&wire.TxOut{Value: 1, PkScript: script})
+	block := util.NewBlock(&wire.MsgBlock{
+		Transactions: []*wire.MsgTx{coinbase},
+	})
+	block.SetHeight(height)
+	return block
+}
+
+func TestXpubAddrs(t *testing.T) {
+	params := &netparams.MainNetParams
+	dbPath := filepath.Join(os.TempDir(), "xpubaddrs")
+	_ = os.RemoveAll(dbPath)
+	db, err := database.Create("ffldb", dbPath, params.Net)
+	if err != nil {
+		t.Fatalf("error creating db: %v", err)
+	}
+	defer func() {
+		db.Close()
+		os.RemoveAll(dbPath)
+	}()
+	key, xpub := testAccountKey(t)
+	want := func(index uint32) util.Address {
+		addr, err := testChild(t, key, 0, index).Address(params)
+		if err != nil {
+			t.Fatalf("unable to derive address: %v", err)
+		}
+		return addr
+	}
+	check := func(x *XpubAddrs, height int32, want util.Address) {
+		t.Helper()
+		addr, err := x.PayToAddr(height)
+		if err != nil {
+			t.Fatalf("PayToAddr: unexpected error: %v", err)
+		}
+		if addr.EncodeAddress() != want.EncodeAddress() {
+			t.Errorf("PayToAddr(%d): got %s, want %s", height,
+				addr.EncodeAddress(), want.EncodeAddress())
+		}
+	}
+	connect := func(x *XpubAddrs, block *util.Block) {
+		x.HandleChainNotification(&blockchain.Notification{
+			Type: blockchain.NTBlockConnected, Data: block})
+	}
+	x, err := NewXpubAddrs(xpub, db, params)
+	if err != nil {
+		t.Fatalf("NewXpubAddrs: unexpected error: %v", err)
+	}
+	// Templates for the same and later heights keep the address until a
+	// block paying to it is connected.
+	check(x, 1, want(0))
+	check(x, 1, want(0))
+	check(x, 2, want(0))
+	connect(x, testCoinbaseBlock(t, 1, want(5)))
+	check(x, 2, want(0))
+	x.HandleChainNotification(&blockchain.Notification{
+		Type: blockchain.NTBlockDisconnected,
+		Data: testCoinbaseBlock(t, 1, want(0))})
+	check(x, 2, want(0))
+	connect(x, testCoinbaseBlock(t, 2, want(0)))
+	check(x, 3, want(1))
+	// The index persists when the database is opened again.
+	db.Close()
+	if db, err = database.Open("ffldb", dbPath, params.Net); err != nil {
+		t.Fatalf("error opening db: %v", err)
+	}
+	x, err = NewXpubAddrs(xpub, db, params)
+	if err != nil {
+		t.Fatalf("NewXpubAddrs: unexpected error: %v", err)
+	}
+	check(x, 3, want(1))
+	// Addresses of another key are counted separately.
+	other, err := NewXpubAddrs("pkh("+xpub+"/1/*)", db, params)
+	if err != nil {
+		t.Fatalf("NewXpubAddrs: unexpected error: %v", err)
+	}
+	internal, err := testChild(t, key, 1, 0).Address(params)
+	if err != nil {
+		t.Fatalf("unable to derive address: %v", err)
+	}
+	check(other, 3, internal)
+	if _, err = NewXpubAddrs(xpub, db, &netparams.TestNet3Params); err == nil {
+		t.Errorf("NewXpubAddrs: no error for a key of another network")
+	}
+}
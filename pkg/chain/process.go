@@ -2,6 +2,7 @@ package blockchain
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/p9c/pod/pkg/chain/fork"
@@ -47,6 +48,7 @@ func // ProcessBlock is the main workhorse for handling insertion of new blocks
 	}
 	b.chainLock.Lock()
 	defer b.chainLock.Unlock()
+	start := time.Now()
 	fastAdd := flags&BFFastAdd == BFFastAdd
 	blockHash := block.Hash()
 	hf := fork.GetCurrent(blockHeight)
@@ -188,9 +190,20 @@ func // ProcessBlock is the main workhorse for handling insertion of new blocks
 		blockHeight, blockHashWithAlgo, fork.GetAlgoName(block.MsgBlock().
 			Header.Version, blockHeight))
 	// log.WARN("finished blockchain.ProcessBlock")
+	atomic.AddUint64(&b.validatedBlocks, 1)
+	atomic.AddUint64(&b.validationNanos, uint64(time.Since(start)))
 	return isMainChain, false, nil
 }
 
+func // ValidationStats returns the number of blocks accepted by ProcessBlock
+// and the total time spent checking and connecting them, including the
+// orphans they made acceptable.
+// This function is safe for concurrent access.
+(b *BlockChain) ValidationStats() (blocks uint64, elapsed time.Duration) {
+	return atomic.LoadUint64(&b.validatedBlocks),
+		time.Duration(atomic.LoadUint64(&b.validationNanos))
+}
+
 func // blockExists determines whether a block with the given hash exists
 // either in the main chain or any side chains.
 // This function is safe for concurrent access.
@@ -0,0 +1,115 @@
+package blockchain
+
+import (
+	database "github.com/p9c/pod/pkg/db"
+	"github.com/p9c/pod/pkg/log"
+)
+
+const (
+	// MinBlocksToKeep is the number of blocks at the tip of the main chain
+	// which are never pruned, so reorganizations up to this depth can still be
+	// handled and peers catching up on recent blocks can still be served.
+	MinBlocksToKeep = 288
+	// MinPruneTarget is the smallest amount of disk space in bytes the stored
+	// blocks can be limited to.  It leaves room for MinBlocksToKeep blocks
+	// along with the block file currently being written.
+	MinPruneTarget = 550 * 1024 * 1024
+)
+
+// pruneHeightKeyName is the name of the db key used to store the height of
+// the oldest main chain block whose data has not been pruned.
+var pruneHeightKeyName = []byte("pruneheight")
+
+func // dbFetchPruneHeight uses an existing database transaction to retrieve the
+// height of the oldest main chain block which has not been pruned.
+// It returns zero when no blocks were ever pruned.
+dbFetchPruneHeight(dbTx database.Tx) int32 {
+	serialized := dbTx.Metadata().Get(pruneHeightKeyName)
+	if serialized == nil {
+		return 0
+	}
+	return int32(byteOrder.Uint32(serialized))
+}
+
+func // dbPutPruneHeight uses an existing database transaction to store the
+// height of the oldest main chain block which has not been pruned.
+dbPutPruneHeight(dbTx database.Tx, height int32) error {
+	var serialized [4]byte
+	byteOrder.PutUint32(serialized[:], uint32(height))
+	return dbTx.Metadata().Put(pruneHeightKeyName, serialized[:])
+}
+
+func // FetchPruneHeight returns the height of the oldest main chain block in the
+// database whose data has not been pruned, which is zero when no blocks were
+// ever pruned.  It tells whether old blocks are missing before the chain is
+// loaded.
+FetchPruneHeight(db database.DB) (height int32, err error) {
+	err = db.View(func(dbTx database.Tx) error {
+		height = dbFetchPruneHeight(dbTx)
+		return nil
+	})
+	return
+}
+
+func // maybePruneBlocks deletes the data of the oldest stored blocks when
+// pruning is enabled and the blocks take more space than the prune target.
+// The last MinBlocksToKeep blocks of the main chain are always kept.
+// The block index and the utxo set are not affected.
+// This function MUST be called with the chain state lock held (for writes).
+(b *BlockChain) maybePruneBlocks() error {
+	tip := b.BestChain.Tip()
+	if b.pruneTarget == 0 || tip.height < MinBlocksToKeep {
+		return nil
+	}
+	keep := b.BestChain.NodeByHeight(tip.height - MinBlocksToKeep + 1)
+	// The blocks deleted before an error still move the prune height, so
+	// the error is only returned once it is saved.
+	pruned, pruneErr := b.db.PruneBlocks(b.pruneTarget, &keep.hash)
+	if pruneErr != nil {
+		log.ERROR(pruneErr)
+	}
+	if len(pruned) == 0 {
+		return pruneErr
+	}
+	// Blocks are not necessarily stored in height order, so the prune height
+	// is one past the highest main chain block which was removed.
+	pruneHeight := b.pruneHeight
+	for i := range pruned {
+		node := b.Index.LookupNode(&pruned[i])
+		if node != nil && b.BestChain.Contains(node) &&
+			node.height >= pruneHeight {
+			pruneHeight = node.height + 1
+		}
+	}
+	err := b.db.Update(func(dbTx database.Tx) error {
+		return dbPutPruneHeight(dbTx, pruneHeight)
+	})
+	if err != nil {
+		log.ERROR(err)
+		return err
+	}
+	b.pruneHeight = pruneHeight
+	log.INFOF("pruned %d blocks, blocks below height %d are no longer stored",
+		len(pruned), pruneHeight)
+	return pruneErr
+}
+
+func // IsPruned returns whether or not the data of old blocks is missing,
+// either because pruning is enabled to limit disk usage, because blocks were
+// pruned before it was disabled or because the chain was loaded from a utxo set
+// snapshot.
+// This function is safe for concurrent access.
+(b *BlockChain) IsPruned() bool {
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+	return b.pruneTarget != 0 || b.pruneHeight > 0
+}
+
+func // PruneHeight returns the height of the oldest main chain block whose data
+// is still stored.  It is zero unless blocks have been pruned.
+// This function is safe for concurrent access.
+(b *BlockChain) PruneHeight() int32 {
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+	return b.pruneHeight
+}
@@ -51,7 +51,9 @@ var byteOrder = binary.BigEndian
 // change.
 const (
 	// LatestVersion is the most recent store version.
-	LatestVersion = 1
+	LatestVersion = 2
+	// replacedVersion is the version the replaced bucket was added in.
+	replacedVersion = 2
 )
 
 var (
@@ -69,6 +71,7 @@ var (
 	bucketUnmined        = []byte("m")
 	bucketUnminedCredits = []byte("mc")
 	bucketUnminedInputs  = []byte("mi")
+	bucketReplaced       = []byte("r")
 	// Root (namespace) bucket keys
 	rootCreateDate   = []byte("date")
 	rootVersion      = []byte("vers")
@@ -1193,6 +1196,34 @@ func deleteRawUnminedInput(ns walletdb.ReadWriteBucket, k []byte) error {
 	return nil
 }
 
+// Unmined transactions replaced by another transaction spending some of the
+// same outputs are recorded in the replaced bucket, keyed by the hash of the
+// replaced transaction.  The value is the hash of the replacement:
+//
+//   [0:32]  Replacement transaction hash (32 bytes)
+func putRawReplaced(ns walletdb.ReadWriteBucket, k []byte,
+	replacement *chainhash.Hash) error {
+	err := ns.NestedReadWriteBucket(bucketReplaced).Put(k, replacement[:])
+	if err != nil {
+		log.ERROR(err)
+		str := "failed to put replaced record"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+func existsRawReplaced(ns walletdb.ReadBucket, k []byte) (v []byte) {
+	return ns.NestedReadBucket(bucketReplaced).Get(k)
+}
+func deleteRawReplaced(ns walletdb.ReadWriteBucket, k []byte) error {
+	err := ns.NestedReadWriteBucket(bucketReplaced).Delete(k)
+	if err != nil {
+		log.ERROR(err)
+		str := "failed to delete replaced record"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
 // openStore opens an existing transaction store from the passed namespace.
 func openStore(ns walletdb.ReadBucket) error {
 	v := ns.Get(rootVersion)
@@ -1212,19 +1243,6 @@ func openStore(ns walletdb.ReadBucket) error {
 			"understood version %d", version, LatestVersion)
 		return storeError(ErrUnknownVersion, str, nil)
 	}
-	// Upgrade the tx store as needed, one version at a time, until
-	// LatestVersion is reached.  Versions are not skipped when performing
-	// database upgrades, and each upgrade is done in its own transaction.
-	//
-	// No upgrades yet.
-	//if version < LatestVersion {
-	//	err := scopedUpdate(namespace, func(ns walletdb.Bucket) error {
-	//	})
-	//	if err != nil {
-	//	log.ERROR(err)
-	//		// Handle err
-	//	}
-	//}
 	return nil
 }
 
@@ -1311,6 +1329,32 @@ func createStore(ns walletdb.ReadWriteBucket) error {
 		str := "failed to create unmined inputs bucket"
 		return storeError(ErrDatabase, str, err)
 	}
+	_, err = ns.CreateBucket(bucketReplaced)
+	if err != nil {
+		log.ERROR(err)
+		str := "failed to create replaced bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// upgradeToReplaced upgrades a version 1 store by adding the bucket of
+// replaced transactions.
+func upgradeToReplaced(ns walletdb.ReadWriteBucket) error {
+	_, err := ns.CreateBucketIfNotExists(bucketReplaced)
+	if err != nil {
+		log.ERROR(err)
+		str := "failed to create replaced bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	v := make([]byte, 4)
+	byteOrder.PutUint32(v, replacedVersion)
+	err = ns.Put(rootVersion, v)
+	if err != nil {
+		log.ERROR(err)
+		str := "failed to store database version"
+		return storeError(ErrDatabase, str, err)
+	}
 	return nil
 }
 
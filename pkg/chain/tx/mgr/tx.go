@@ -139,8 +139,25 @@ func NewTxRecordFromMsgTx(msgTx *wire.MsgTx, received time.Time) (*TxRecord, err
 // contained in the wallet database, namespaced by the top level bucket key
 // namespaceKey.
 func DoUpgrades(db walletdb.DB, namespaceKey []byte) error {
-	// No upgrades
-	return nil
+	// Upgrade the tx store as needed, one version at a time, until
+	// LatestVersion is reached.  Versions are not skipped when performing
+	// database upgrades, and each upgrade is done in its own transaction.
+	return walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(namespaceKey)
+		if ns == nil {
+			return nil
+		}
+		v := ns.Get(rootVersion)
+		if len(v) != 4 {
+			return nil
+		}
+		if byteOrder.Uint32(v) < replacedVersion {
+			log.INFO("upgrading transaction store to version",
+				replacedVersion)
+			return upgradeToReplaced(ns)
+		}
+		return nil
+	})
 }
 
 func // Open opens the wallet transaction store from a walletdb namespace.
@@ -359,6 +376,13 @@ func // insertMinedTx inserts a new transaction record for a mined
 			return err
 		}
 	}
+	// A transaction that was replaced while unmined may still be mined
+	// instead of its replacement.
+	if existsRawReplaced(ns, rec.Hash[:]) != nil {
+		if err := deleteRawReplaced(ns, rec.Hash[:]); err != nil {
+			return err
+		}
+	}
 	// As there may be unconfirmed transactions that are invalidated by this
 	// transaction (either being duplicates, or double spends), remove them
 	// from the unconfirmed set.  This also handles removing unconfirmed
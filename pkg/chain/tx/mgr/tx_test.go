@@ -1856,3 +1856,114 @@ func TestAddDuplicateCreditAfterConfirm(t *testing.T) {
 		}
 	})
 }
+
+// TestReplaceUnminedTx ensures that replacing an unmined transaction removes
+// it along with the transactions spending it, records its replacement, and
+// that the record is dropped if the replaced transaction is mined after all.
+func TestReplaceUnminedTx(t *testing.T) {
+	t.Parallel()
+	store, db, teardown, err := testStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+	b100 := &BlockMeta{
+		Block: Block{Height: 100},
+		Time:  time.Now(),
+	}
+	cb := newCoinBase(1e8)
+	cbRec, err := NewTxRecordFromMsgTx(cb, b100.Time)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		if err := store.InsertTx(ns, cbRec, b100); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.AddCredit(ns, cbRec, b100, 0, false); err != nil {
+			t.Fatal(err)
+		}
+	})
+	// Spend the coinbase with a transaction that pays change back to the
+	// wallet, and spend the change with another.
+	newRec := func(tx *wire.MsgTx) *TxRecord {
+		rec, err := NewTxRecordFromMsgTx(tx, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rec
+	}
+	original := newRec(spendOutput(&cbRec.Hash, 0, 5e7, 4e7))
+	child := newRec(spendOutput(&original.Hash, 1, 3e7))
+	replacement := newRec(spendOutput(&cbRec.Hash, 0, 5e7, 3e7))
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		for _, rec := range []*TxRecord{original, child} {
+			if err := store.InsertTx(ns, rec, nil); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.AddCredit(ns, rec, nil, 0, true); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+	checkUnmined := func(want ...*TxRecord) {
+		t.Helper()
+		commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+			t.Helper()
+			hashes, err := store.UnminedTxHashes(ns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(hashes) != len(want) {
+				t.Fatalf("expected %d unmined transactions, got %d",
+					len(want), len(hashes))
+			}
+			for i := range want {
+				if *hashes[i] != want[i].Hash {
+					t.Fatalf("expected unmined transaction %v, got %v",
+						want[i].Hash, hashes[i])
+				}
+			}
+		})
+	}
+	// Replacing the original removes it and its child, and the replacement
+	// then takes its place.
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		err := store.ReplaceUnminedTx(ns, original, &replacement.Hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := store.InsertTx(ns, replacement, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	checkUnmined(replacement)
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		replacedBy := store.ReplacedBy(ns, &original.Hash)
+		if replacedBy == nil || *replacedBy != replacement.Hash {
+			t.Fatalf("expected %v to be replaced by %v, got %v",
+				original.Hash, replacement.Hash, replacedBy)
+		}
+		if replacedBy := store.ReplacedBy(ns, &child.Hash); replacedBy != nil {
+			t.Fatalf("expected %v not to be replaced, got %v",
+				child.Hash, replacedBy)
+		}
+	})
+	// Mining the original instead removes the replacement and the record.
+	b101 := &BlockMeta{
+		Block: Block{Height: 101},
+		Time:  time.Now(),
+	}
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		if err := store.InsertTx(ns, original, b101); err != nil {
+			t.Fatal(err)
+		}
+	})
+	checkUnmined()
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		if replacedBy := store.ReplacedBy(ns, &original.Hash); replacedBy != nil {
+			t.Fatalf("expected mined %v not to be replaced, got %v",
+				original.Hash, replacedBy)
+		}
+	})
+}
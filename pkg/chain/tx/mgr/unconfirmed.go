@@ -47,6 +47,10 @@ func (s *Store) insertMemPoolTx(ns walletdb.ReadWriteBucket, rec *TxRecord) erro
 			return err
 		}
 	}
+	// A replaced transaction that is inserted again is no longer replaced.
+	if existsRawReplaced(ns, rec.Hash[:]) != nil {
+		return deleteRawReplaced(ns, rec.Hash[:])
+	}
 	// TODO: increment credit amount for each credit (but those are unknown
 	// here currently).
 	return nil
@@ -143,6 +147,32 @@ RemoveConflict(ns walletdb.ReadWriteBucket, rec *TxRecord) error {
 	return deleteRawUnmined(ns, rec.Hash[:])
 }
 
+func // ReplaceUnminedTx removes an unmined transaction that was replaced by
+// another transaction spending some of the same outputs, along with all
+// transactions that depend on it, and records the hash of the replacement.
+// This must be done before the replacement is inserted, as removing the
+// replaced transaction forgets which transactions spend its inputs.
+(s *Store) ReplaceUnminedTx(ns walletdb.ReadWriteBucket, rec *TxRecord,
+	replacement *chainhash.Hash) error {
+	if err := RemoveConflict(ns, rec); err != nil {
+		return err
+	}
+	return putRawReplaced(ns, rec.Hash[:], replacement)
+}
+
+func // ReplacedBy returns the hash of the transaction that replaced an unmined
+// transaction, or nil if it was not replaced.
+(s *Store) ReplacedBy(ns walletdb.ReadBucket,
+	txHash *chainhash.Hash) *chainhash.Hash {
+	v := existsRawReplaced(ns, txHash[:])
+	if len(v) != chainhash.HashSize {
+		return nil
+	}
+	var replacement chainhash.Hash
+	copy(replacement[:], v)
+	return &replacement
+}
+
 func // UnminedTxs returns the underlying transactions for all unmined
 // transactions which are not known to have been mined in a block.
 // Transactions are guaranteed to be sorted by their dependency order.
@@ -0,0 +1,510 @@
+package blockchain
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	database "github.com/p9c/pod/pkg/db"
+	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/util"
+)
+
+const (
+	// txOutSetSnapshotMagic identifies a utxo set snapshot file ("utxo").
+	txOutSetSnapshotMagic = 0x6f787475
+	// txOutSetSnapshotVersion is the version of the snapshot file format.
+	txOutSetSnapshotVersion = 1
+	// txOutSetLoadBatchSize is the number of utxo set entries written to the
+	// database in each transaction while a snapshot is loaded.
+	txOutSetLoadBatchSize = 50000
+	// maxTxOutSetKeySize is the largest serialized outpoint key accepted from
+	// a snapshot: the transaction hash and the VLQ encoded output index.
+	maxTxOutSetKeySize = chainhash.HashSize + 5
+)
+
+// txOutSetLoadingKeyName is the name of the db key which is present while a
+// utxo set snapshot is being loaded, so a load interrupted by a crash is
+// discarded on the next start.
+var txOutSetLoadingKeyName = []byte("txoutsetloading")
+
+// ErrTxOutSetHashMismatch is returned when a loaded utxo set snapshot does not
+// hash to the expected value.
+var ErrTxOutSetHashMismatch = errors.New("utxo snapshot hash does not match")
+
+// The utxo set snapshot file consists of a header, the headers of every main
+// chain block after genesis up to the snapshot block, each utxo set entry in
+// the order they are stored and the hash of the set.
+// The serialized format is:
+//   <header><block headers><entries><empty key><set hash>
+//   Field             Type             Size
+//   magic             uint32           4 bytes
+//   version           uint32           4 bytes
+//   network           uint32           4 bytes
+//   block hash        chainhash.Hash   chainhash.HashSize
+//   block height      int32            4 bytes
+//   total txns        uint64           8 bytes
+//   block headers     wire.BlockHeader 80 bytes * block height
+//   entry key         var bytes        variable
+//   entry value       var bytes        variable
+//   set hash          chainhash.Hash   chainhash.HashSize
+// -----------------------------------------------------------------------------
+
+// txOutSetSnapshotHeader is the fixed size header of a utxo set snapshot file.
+type txOutSetSnapshotHeader struct {
+	Magic      uint32
+	Version    uint32
+	Net        uint32
+	BaseHash   chainhash.Hash
+	BaseHeight int32
+	TotalTxns  uint64
+}
+
+// TxOutSetInfo describes the unspent transaction output set as of a block of
+// the main chain.
+type TxOutSetInfo struct {
+	Height int32
+	// BestHash is the hash of the block the set is as of.
+	BestHash chainhash.Hash
+	// Transactions is the number of transactions with unspent outputs.
+	Transactions int64
+	TxOuts       int64
+	TotalAmount  util.Amount
+	// CoinbaseAmounts totals the unspent coinbase outputs by the name of the
+	// algorithm of the block which created them.
+	CoinbaseAmounts map[string]util.Amount
+	// HashSerialized commits to the block hash and every serialized entry of
+	// the set in the order they are stored, so two nodes with the same set
+	// report the same hash.
+	HashSerialized chainhash.Hash
+}
+
+// txOutSetScan accumulates the statistics and hash of a utxo set as its
+// serialized entries are visited in order.
+type txOutSetScan struct {
+	info      *TxOutSetInfo
+	hasher    hash.Hash
+	mainChain []*BlockNode
+	lastTx    chainhash.Hash
+}
+
+func // newTxOutSetScan returns a scan of the utxo set as of the last of the
+// passed main chain nodes, which must start at the genesis block.
+newTxOutSetScan(mainChain []*BlockNode) *txOutSetScan {
+	tip := mainChain[len(mainChain)-1]
+	s := &txOutSetScan{
+		info: &TxOutSetInfo{
+			Height:          tip.height,
+			BestHash:        tip.hash,
+			CoinbaseAmounts: make(map[string]util.Amount),
+		},
+		hasher:    sha256.New(),
+		mainChain: mainChain,
+	}
+	_, _ = s.hasher.Write(tip.hash[:])
+	return s
+}
+
+func // add accumulates a serialized utxo set entry into the scan.
+(s *txOutSetScan) add(key, serialized []byte) error {
+	if len(key) <= chainhash.HashSize {
+		return fmt.Errorf("utxo set key %x is too short", key)
+	}
+	entry, err := deserializeUtxoEntry(serialized)
+	if err != nil {
+		log.ERROR(err)
+		return err
+	}
+	var txHash chainhash.Hash
+	copy(txHash[:], key[:chainhash.HashSize])
+	if s.info.TxOuts == 0 || txHash != s.lastTx {
+		s.info.Transactions++
+		s.lastTx = txHash
+	}
+	s.info.TxOuts++
+	amount := util.Amount(entry.Amount())
+	s.info.TotalAmount += amount
+	if entry.IsCoinBase() {
+		height := entry.BlockHeight()
+		if height < 0 || int(height) >= len(s.mainChain) {
+			return fmt.Errorf("utxo set entry %x is from block %d which"+
+				" is not in the main chain", key, height)
+		}
+		node := s.mainChain[height]
+		s.info.CoinbaseAmounts[fork.GetAlgoName(node.version, height)] +=
+			amount
+	}
+	_ = wire.WriteVarBytes(s.hasher, 0, key)
+	_ = wire.WriteVarBytes(s.hasher, 0, serialized)
+	return nil
+}
+
+func // finish returns the statistics of the scanned set.
+(s *txOutSetScan) finish() *TxOutSetInfo {
+	s.info.HashSerialized = chainhash.HashH(s.hasher.Sum(nil))
+	return s.info
+}
+
+func // mainChainNodes returns the nodes from the genesis block up to the passed
+// node indexed by height.
+mainChainNodes(tip *BlockNode) []*BlockNode {
+	nodes := make([]*BlockNode, tip.height+1)
+	for node := tip; node != nil; node = node.parent {
+		nodes[node.height] = node
+	}
+	return nodes
+}
+
+func // viewTxOutSet calls fn with the main chain nodes and the bucket of the
+// utxo set as of the best chain state stored in the same database
+// transaction, so the set and the block it belongs to always agree even while
+// blocks are being connected.
+(b *BlockChain) viewTxOutSet(fn func(state bestChainState,
+	mainChain []*BlockNode, utxoBucket database.Bucket) error) error {
+	return b.db.View(func(dbTx database.Tx) error {
+		state, err := deserializeBestChainState(
+			dbTx.Metadata().Get(chainStateKeyName))
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		tip := b.Index.LookupNode(&state.hash)
+		if tip == nil {
+			return AssertError(fmt.Sprintf("best block %v is not in the"+
+				" block index", state.hash))
+		}
+		return fn(state, mainChainNodes(tip),
+			dbTx.Metadata().Bucket(utxoSetBucketName))
+	})
+}
+
+func // FetchTxOutSetInfo scans the unspent transaction output set and returns
+// statistics about it as of the current best block.
+// This function is safe for concurrent access.
+(b *BlockChain) FetchTxOutSetInfo() (*TxOutSetInfo, error) {
+	var info *TxOutSetInfo
+	err := b.viewTxOutSet(func(state bestChainState,
+		mainChain []*BlockNode, utxoBucket database.Bucket) error {
+		scan := newTxOutSetScan(mainChain)
+		err := utxoBucket.ForEach(scan.add)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		info = scan.finish()
+		return nil
+	})
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	return info, nil
+}
+
+func // DumpTxOutSet writes a snapshot of the unspent transaction output set as
+// of the current best block to w, which can be used to bootstrap another node
+// with LoadTxOutSet.  The returned statistics include the hash of the set the
+// loading node has to be given to trust the snapshot.
+// This function is safe for concurrent access.
+(b *BlockChain) DumpTxOutSet(w io.Writer) (*TxOutSetInfo, error) {
+	var info *TxOutSetInfo
+	err := b.viewTxOutSet(func(state bestChainState,
+		mainChain []*BlockNode, utxoBucket database.Bucket) error {
+		header := txOutSetSnapshotHeader{
+			Magic:      txOutSetSnapshotMagic,
+			Version:    txOutSetSnapshotVersion,
+			Net:        uint32(b.params.Net),
+			BaseHash:   state.hash,
+			BaseHeight: int32(state.height),
+			TotalTxns:  state.totalTxns,
+		}
+		if err := binary.Write(w, byteOrder, &header); err != nil {
+			log.ERROR(err)
+			return err
+		}
+		for _, node := range mainChain[1:] {
+			blockHeader := node.Header()
+			if err := blockHeader.Serialize(w); err != nil {
+				log.ERROR(err)
+				return err
+			}
+		}
+		scan := newTxOutSetScan(mainChain)
+		err := utxoBucket.ForEach(func(key, serialized []byte) error {
+			if err := scan.add(key, serialized); err != nil {
+				return err
+			}
+			if err := wire.WriteVarBytes(w, 0, key); err != nil {
+				return err
+			}
+			return wire.WriteVarBytes(w, 0, serialized)
+		})
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		info = scan.finish()
+		// An empty key marks the end of the entries.
+		if err := wire.WriteVarBytes(w, 0, nil); err != nil {
+			log.ERROR(err)
+			return err
+		}
+		_, err = w.Write(info.HashSerialized[:])
+		return err
+	})
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	return info, nil
+}
+
+func // LoadTxOutSet replaces the chain state of a node which has not connected
+// any block since genesis with the utxo set snapshot read from r, which must
+// hash to the trusted expected hash.
+// The headers in the snapshot become the main chain without their block data,
+// so the node continues syncing from the snapshot block and reports the
+// blocks below it as pruned.  Optional indexes can't be caught up without the
+// block data, so loading is refused while any are enabled, the committed filter
+// index included, which is on unless the node runs with --nocfilters.
+// This function is safe for concurrent access.
+(b *BlockChain) LoadTxOutSet(r io.Reader, expected *chainhash.Hash) (*TxOutSetInfo, error) {
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+	if b.indexManager != nil {
+		return nil, errors.New("optional indexes must be disabled to load" +
+			" a utxo snapshot, which for the committed filter index that is" +
+			" on by default means running with --nocfilters")
+	}
+	genesis := b.BestChain.Tip()
+	if genesis.height != 0 {
+		return nil, errors.New("a utxo snapshot can only be loaded before" +
+			" any blocks are connected")
+	}
+	var header txOutSetSnapshotHeader
+	if err := binary.Read(r, byteOrder, &header); err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	switch {
+	case header.Magic != txOutSetSnapshotMagic:
+		return nil, errors.New("not a utxo snapshot file")
+	case header.Version != txOutSetSnapshotVersion:
+		return nil, fmt.Errorf("unsupported utxo snapshot version %d",
+			header.Version)
+	case header.Net != uint32(b.params.Net):
+		return nil, fmt.Errorf("utxo snapshot is for network %v",
+			wire.BitcoinNet(header.Net))
+	case header.BaseHeight <= 0:
+		return nil, errors.New("utxo snapshot has no blocks to load")
+	}
+	// The headers must form a chain from the genesis block to the snapshot
+	// block.  Proof of work is not checked as the trusted hash commits to
+	// the snapshot block, which commits to all of its ancestors.
+	mainChain := []*BlockNode{genesis}
+	for height := int32(1); height <= header.BaseHeight; height++ {
+		var blockHeader wire.BlockHeader
+		if err := blockHeader.Deserialize(r); err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+		parent := mainChain[height-1]
+		if blockHeader.PrevBlock != parent.hash {
+			return nil, fmt.Errorf("utxo snapshot block header %d does not"+
+				" connect to the previous one", height)
+		}
+		node := b.childNode(parent, &blockHeader)
+		if node == nil {
+			node = NewBlockNode(&blockHeader, parent)
+		}
+		mainChain = append(mainChain, node)
+	}
+	if mainChain[header.BaseHeight].hash != header.BaseHash {
+		return nil, errors.New("utxo snapshot block headers do not end at" +
+			" the snapshot block")
+	}
+	// Write the entries in batches, marking the load as in progress so it
+	// is discarded if the node stops before it completes.
+	err := b.db.Update(func(dbTx database.Tx) error {
+		return dbTx.Metadata().Put(txOutSetLoadingKeyName, []byte{1})
+	})
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	info, err := b.loadTxOutSetEntries(r, mainChain, expected)
+	if err != nil {
+		log.ERROR(err)
+		if err := b.discardTxOutSetLoad(); err != nil {
+			log.ERROR(err)
+		}
+		return nil, err
+	}
+	// Make the snapshot block the tip of the main chain.
+	for _, node := range mainChain[1:] {
+		if b.Index.LookupNode(&node.hash) == nil {
+			node.status = statusValid
+			b.Index.AddNode(node)
+		} else {
+			b.Index.SetStatusFlags(node, statusValid)
+		}
+	}
+	if err := b.Index.flushToDB(); err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	tip := mainChain[header.BaseHeight]
+	state := newBestState(tip, 0, 0, 0, header.TotalTxns,
+		tip.CalcPastMedianTime())
+	err = b.db.Update(func(dbTx database.Tx) error {
+		for _, node := range mainChain[1:] {
+			err := dbPutBlockIndex(dbTx, &node.hash, node.height)
+			if err != nil {
+				log.ERROR(err)
+				return err
+			}
+		}
+		if err := dbPutBestState(dbTx, state, tip.workSum); err != nil {
+			log.ERROR(err)
+			return err
+		}
+		if err := dbPutPruneHeight(dbTx, tip.height+1); err != nil {
+			log.ERROR(err)
+			return err
+		}
+		return dbTx.Metadata().Delete(txOutSetLoadingKeyName)
+	})
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	bitsMap, err := b.CalcNextRequiredDifficultyPlan9Controller(tip)
+	if err != nil {
+		log.ERROR(err)
+	}
+	tip.DiffMx.Lock()
+	tip.Diffs = bitsMap
+	tip.DiffMx.Unlock()
+	b.BestChain.SetTip(tip)
+	b.stateLock.Lock()
+	b.stateSnapshot = state
+	b.stateLock.Unlock()
+	b.pruneHeight = tip.height + 1
+	log.INFOF("loaded utxo snapshot with %d outputs at height %d (%v)",
+		info.TxOuts, tip.height, tip.hash)
+	return info, nil
+}
+
+func // childNode returns the node already in the block index for the passed
+// header when its parent is the passed node.
+(b *BlockChain) childNode(parent *BlockNode, blockHeader *wire.BlockHeader) *BlockNode {
+	hash := blockHeader.BlockHash()
+	node := b.Index.LookupNode(&hash)
+	if node == nil || node.parent != parent {
+		return nil
+	}
+	return node
+}
+
+func // loadTxOutSetEntries reads the utxo set entries and trailing hash of a
+// snapshot into the database and checks the set hashes to both the trailing
+// and the expected hash.
+(b *BlockChain) loadTxOutSetEntries(r io.Reader, mainChain []*BlockNode,
+	expected *chainhash.Hash) (*TxOutSetInfo, error) {
+	scan := newTxOutSetScan(mainChain)
+	var keys, values [][]byte
+	flush := func() error {
+		err := b.db.Update(func(dbTx database.Tx) error {
+			utxoBucket := dbTx.Metadata().Bucket(utxoSetBucketName)
+			for i := range keys {
+				if err := utxoBucket.Put(keys[i], values[i]); err != nil {
+					log.ERROR(err)
+					return err
+				}
+			}
+			return nil
+		})
+		keys, values = keys[:0], values[:0]
+		return err
+	}
+	for {
+		key, err := wire.ReadVarBytes(r, 0, maxTxOutSetKeySize, "key")
+		if err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+		if len(key) == 0 {
+			break
+		}
+		value, err := wire.ReadVarBytes(r, 0, wire.MaxBlockPayload, "value")
+		if err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+		if err := scan.add(key, value); err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+		if len(keys) == txOutSetLoadBatchSize {
+			if err := flush(); err != nil {
+				log.ERROR(err)
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	var setHash chainhash.Hash
+	if _, err := io.ReadFull(r, setHash[:]); err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	info := scan.finish()
+	if info.HashSerialized != setHash || info.HashSerialized != *expected {
+		return nil, ErrTxOutSetHashMismatch
+	}
+	return info, nil
+}
+
+func // discardTxOutSetLoad empties the utxo set after a snapshot failed to
+// load.  Loading is only allowed while the set holds nothing but the outputs
+// of the genesis block, which are not spendable and never stored.
+(b *BlockChain) discardTxOutSetLoad() error {
+	return b.db.Update(func(dbTx database.Tx) error {
+		meta := dbTx.Metadata()
+		if err := meta.DeleteBucket(utxoSetBucketName); err != nil {
+			log.ERROR(err)
+			return err
+		}
+		if _, err := meta.CreateBucket(utxoSetBucketName); err != nil {
+			log.ERROR(err)
+			return err
+		}
+		return meta.Delete(txOutSetLoadingKeyName)
+	})
+}
+
+func // maybeDiscardTxOutSetLoad discards a utxo set snapshot which the node
+// stopped loading before it was complete.
+(b *BlockChain) maybeDiscardTxOutSetLoad() error {
+	var loading bool
+	err := b.db.View(func(dbTx database.Tx) error {
+		loading = dbTx.Metadata().Get(txOutSetLoadingKeyName) != nil
+		return nil
+	})
+	if err != nil || !loading {
+		return err
+	}
+	log.WARN("discarding a utxo snapshot which was not completely loaded")
+	return b.discardTxOutSetLoad()
+}
@@ -0,0 +1,209 @@
+package blockchain
+
+import (
+	"bytes"
+	"testing"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	database "github.com/p9c/pod/pkg/db"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// TestTxOutSetScan ensures the utxo set statistics and hash are accumulated
+// correctly from serialized entries.
+func TestTxOutSetScan(t *testing.T) {
+	mainChain := chainedNodes(nil, 3)
+	mainChain[1].version = 2
+	mainChain[2].version = 514
+	pkScript := hexToBytes("76a914ee26c56fc1d942be8d7a24b2a1001dd89406947188ac")
+	txA := chainhash.Hash{0x01}
+	txB := chainhash.Hash{0x02}
+	entries := []struct {
+		outPoint wire.OutPoint
+		entry    *UtxoEntry
+	}{
+		{wire.OutPoint{Hash: txA, Index: 0}, &UtxoEntry{amount: 5000,
+			pkScript: pkScript, blockHeight: 1, packedFlags: tfCoinBase}},
+		{wire.OutPoint{Hash: txA, Index: 1}, &UtxoEntry{amount: 3000,
+			pkScript: pkScript, blockHeight: 2, packedFlags: tfCoinBase}},
+		{wire.OutPoint{Hash: txB, Index: 0}, &UtxoEntry{amount: 700,
+			pkScript: pkScript, blockHeight: 2}},
+	}
+	scanSet := func(mainChain []*BlockNode) *TxOutSetInfo {
+		scan := newTxOutSetScan(mainChain)
+		for _, e := range entries {
+			serialized, err := serializeUtxoEntry(e.entry)
+			if err != nil {
+				t.Fatalf("serializeUtxoEntry: unexpected error: %v", err)
+			}
+			if err := scan.add(*outpointKey(e.outPoint), serialized); err != nil {
+				t.Fatalf("add: unexpected error: %v", err)
+			}
+		}
+		return scan.finish()
+	}
+	info := scanSet(mainChain)
+	if info.Height != 2 || info.BestHash != mainChain[2].hash {
+		t.Errorf("scan is as of block %v (%d), want %v (2)", info.BestHash,
+			info.Height, mainChain[2].hash)
+	}
+	if info.Transactions != 2 || info.TxOuts != 3 {
+		t.Errorf("counted %d transactions with %d outputs, want 2 with 3",
+			info.Transactions, info.TxOuts)
+	}
+	if info.TotalAmount != 8700 {
+		t.Errorf("total amount %v, want 8700", int64(info.TotalAmount))
+	}
+	wantCoinbase := map[string]util.Amount{"sha256d": 5000, "scrypt": 3000}
+	if len(info.CoinbaseAmounts) != len(wantCoinbase) {
+		t.Errorf("coinbase amounts %v, want %v", info.CoinbaseAmounts,
+			wantCoinbase)
+	}
+	for algo, amount := range wantCoinbase {
+		if info.CoinbaseAmounts[algo] != amount {
+			t.Errorf("coinbase amount for %s is %v, want %v", algo,
+				int64(info.CoinbaseAmounts[algo]), int64(amount))
+		}
+	}
+	// The hash only depends on the entries and the block they are as of.
+	if again := scanSet(mainChain); again.HashSerialized != info.HashSerialized {
+		t.Errorf("rescanning the same set gave hash %v, want %v",
+			again.HashSerialized, info.HashSerialized)
+	}
+	forked := append(mainChain[:2:2], chainedNodes(mainChain[1], 1)...)
+	if other := scanSet(forked); other.HashSerialized == info.HashSerialized {
+		t.Errorf("sets as of different blocks have the same hash")
+	}
+	// A coinbase output from a block after the tip can't be part of the set.
+	entries = append(entries, struct {
+		outPoint wire.OutPoint
+		entry    *UtxoEntry
+	}{wire.OutPoint{Hash: txB, Index: 1}, &UtxoEntry{amount: 1,
+		pkScript: pkScript, blockHeight: 3, packedFlags: tfCoinBase}})
+	scan := newTxOutSetScan(mainChain)
+	serialized, _ := serializeUtxoEntry(entries[3].entry)
+	if err := scan.add(*outpointKey(entries[3].outPoint), serialized); err == nil {
+		t.Errorf("add: coinbase output from a future block was accepted")
+	}
+}
+
+// testTxOutSetEntries returns the number of entries in the utxo set bucket of
+// the chain.
+func testTxOutSetEntries(t *testing.T, chain *BlockChain) int {
+	var entries int
+	err := chain.db.View(func(dbTx database.Tx) error {
+		return dbTx.Metadata().Bucket(utxoSetBucketName).ForEach(
+			func(k, v []byte) error {
+				entries++
+				return nil
+			})
+	})
+	if err != nil {
+		t.Fatalf("unable to count utxo set entries: %v", err)
+	}
+	return entries
+}
+
+// TestTxOutSetRoundTrip ensures a utxo set snapshot dumped by one chain loads
+// into another which then has the same set as of the same block, and that
+// snapshots which are damaged, for another network or not the trusted one are
+// refused without leaving any of their entries behind.
+func TestTxOutSetRoundTrip(t *testing.T) {
+	src, teardownSrc := newTestChain(t, "txoutsetdump")
+	defer teardownSrc()
+	nodes := addTestBlocks(t, src, src.BestChain.Tip(), 5, 0)
+	tip := nodes[len(nodes)-1]
+	var snapshot bytes.Buffer
+	dumped, err := src.DumpTxOutSet(&snapshot)
+	if err != nil {
+		t.Fatalf("DumpTxOutSet: unexpected error: %v", err)
+	}
+	if dumped.Height != tip.height || dumped.BestHash != tip.hash ||
+		dumped.TxOuts != 5 {
+		t.Fatalf("dumped %d outputs as of %v (%d), want 5 as of %v (%d)",
+			dumped.TxOuts, dumped.BestHash, dumped.Height, tip.hash,
+			tip.height)
+	}
+	dst, teardownDst := newTestChain(t, "txoutsetload")
+	defer teardownDst()
+	// The set hash is written after the entries, and the header is followed
+	// by the block headers.
+	const headerSize = 56
+	damage := func(f func(b []byte) []byte) []byte {
+		return f(append([]byte{}, snapshot.Bytes()...))
+	}
+	otherHash := dumped.HashSerialized
+	otherHash[0] ^= 0xff
+	tests := []struct {
+		name     string
+		snapshot []byte
+		expected *chainhash.Hash
+	}{
+		{"truncated header", snapshot.Bytes()[:headerSize-1],
+			&dumped.HashSerialized},
+		{"truncated block headers", snapshot.Bytes()[:headerSize+100],
+			&dumped.HashSerialized},
+		{"truncated entries", snapshot.Bytes()[:snapshot.Len()-
+			chainhash.HashSize-10], &dumped.HashSerialized},
+		{"truncated set hash", snapshot.Bytes()[:snapshot.Len()-1],
+			&dumped.HashSerialized},
+		{"wrong network", damage(func(b []byte) []byte {
+			byteOrder.PutUint32(b[8:12], uint32(wire.MainNet))
+			return b
+		}), &dumped.HashSerialized},
+		{"block header not connecting", damage(func(b []byte) []byte {
+			// the previous block of the second block header
+			b[headerSize+80+4] ^= 0xff
+			return b
+		}), &dumped.HashSerialized},
+		{"wrong expected hash", snapshot.Bytes(), &otherHash},
+	}
+	for _, test := range tests {
+		_, err := dst.LoadTxOutSet(bytes.NewReader(test.snapshot),
+			test.expected)
+		if err == nil {
+			t.Fatalf("%s: snapshot was loaded", test.name)
+		}
+		if height := dst.BestSnapshot().Height; height != 0 {
+			t.Errorf("%s: best block moved to height %d", test.name, height)
+		}
+		if n := testTxOutSetEntries(t, dst); n != 0 {
+			t.Errorf("%s: %d utxo set entries were left behind", test.name, n)
+		}
+	}
+	loaded, err := dst.LoadTxOutSet(bytes.NewReader(snapshot.Bytes()),
+		&dumped.HashSerialized)
+	if err != nil {
+		t.Fatalf("LoadTxOutSet: unexpected error: %v", err)
+	}
+	if loaded.HashSerialized != dumped.HashSerialized ||
+		loaded.TxOuts != dumped.TxOuts {
+		t.Errorf("loaded %d outputs with hash %v, want %d with hash %v",
+			loaded.TxOuts, loaded.HashSerialized, dumped.TxOuts,
+			dumped.HashSerialized)
+	}
+	best := dst.BestSnapshot()
+	if best.Hash != tip.hash || best.Height != tip.height {
+		t.Errorf("best block is %v (%d), want %v (%d)", best.Hash,
+			best.Height, tip.hash, tip.height)
+	}
+	info, err := dst.FetchTxOutSetInfo()
+	if err != nil {
+		t.Fatalf("FetchTxOutSetInfo: unexpected error: %v", err)
+	}
+	if info.HashSerialized != dumped.HashSerialized {
+		t.Errorf("loaded set has hash %v, want %v", info.HashSerialized,
+			dumped.HashSerialized)
+	}
+	if !dst.IsPruned() || dst.PruneHeight() != tip.height+1 {
+		t.Errorf("chain is pruned %v below height %d, want pruned below %d",
+			dst.IsPruned(), dst.PruneHeight(), tip.height+1)
+	}
+	// Only a chain with nothing but the genesis block can load a snapshot.
+	_, err = dst.LoadTxOutSet(bytes.NewReader(snapshot.Bytes()),
+		&dumped.HashSerialized)
+	if err == nil {
+		t.Errorf("snapshot was loaded over connected blocks")
+	}
+}
@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"fmt"
 	"math"
 
 	chaincfg "github.com/p9c/pod/pkg/chain/config"
@@ -208,19 +209,31 @@ func // warnUnknownRuleActivations displays a warning when any unknown new rules
 		switch state {
 		case ThresholdActive:
 			if !b.unknownRulesWarned {
-				log.WARNF("unknown new rules activated (bit %d)", bit)
+				b.warning = fmt.Sprintf("unknown new rules activated (bit %d)",
+					bit)
+				log.WARN(b.warning)
 				b.unknownRulesWarned = true
 			}
 		case ThresholdLockedIn:
 			window := int32(checker.MinerConfirmationWindow())
 			activationHeight := window - (node.height % window)
-			log.WARNF("Unknown new rules are about to activate in %d blocks ("+
-				"bit %d)", activationHeight, bit)
+			b.warning = fmt.Sprintf("Unknown new rules are about to activate "+
+				"in %d blocks (bit %d)", activationHeight, bit)
+			log.WARN(b.warning)
 		}
 	}
 	return nil
 }
 
+func // Warnings returns the last warning about unknown rules being activated
+// or about to activate, or an empty string if there is none.
+// This function is safe for concurrent access.
+(b *BlockChain) Warnings() string {
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+	return b.warning
+}
+
 // warnUnknownVersions logs a warning if a high enough percentage of the last
 // blocks have unexpected versions.
 // This function MUST be called with the chain state lock held (for writes)
@@ -3,7 +3,6 @@ package controller
 import (
 	"context"
 	"errors"
-	"math/rand"
 	"net"
 	"sync"
 	"time"
@@ -19,7 +18,9 @@ import (
 	"github.com/p9c/pod/pkg/controller/job"
 	"github.com/p9c/pod/pkg/controller/pause"
 	"github.com/p9c/pod/pkg/controller/sol"
+	"github.com/p9c/pod/pkg/controller/stats"
 	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/routeable"
 	"github.com/p9c/pod/pkg/transport"
 	"github.com/p9c/pod/pkg/util"
 	"github.com/p9c/pod/pkg/util/interrupt"
@@ -34,11 +35,15 @@ const (
 	// has to puncture 6 of the 9 to fail.
 	// This protocol is connectionless and stateless so if one misses,
 	// the next one probably won't, usually a second or 3 later
-	MaxDatagramSize = blockchain.MaxBlockBaseSize / 3
-	//UDP6MulticastAddress = "ff02::1"
+	MaxDatagramSize      = blockchain.MaxBlockBaseSize / 3
 	UDP4MulticastAddress = "224.0.0.1:11049"
+	UDP6MulticastAddress = "[ff02::1]:11049"
 )
 
+// MulticastAddresses are the groups work is sent to, one for each address
+// family, so workers on IPv4 only and IPv6 only segments both receive it
+var MulticastAddresses = []string{UDP4MulticastAddress, UDP6MulticastAddress}
+
 type Controller struct {
 	conn                   *transport.Connection
 	active                 *atomic.Bool
@@ -57,7 +62,7 @@ type Controller struct {
 }
 
 func Run(cx *conte.Xt) (cancel context.CancelFunc) {
-	if len(cx.StateCfg.ActiveMiningAddrs) < 1 {
+	if len(cx.StateCfg.ActiveMiningAddrs) < 1 && cx.StateCfg.MiningXpub == "" {
 		log.WARN("no mining addresses, not starting controller")
 		return
 	}
@@ -70,14 +75,24 @@ func Run(cx *conte.Xt) (cancel context.CancelFunc) {
 		return
 	}
 	ctx, cancel := context.WithCancel(context.Background())
-	conn, err := transport.NewConnection(UDP4MulticastAddress,
-		*cx.Config.Controller, *cx.Config.MinerPass, MaxDatagramSize,
-		ctx, true)
+	conn, err := transport.NewConnection(MulticastAddresses,
+		[]string{*cx.Config.Controller}, *cx.Config.MinerPass,
+		MaxDatagramSize, ctx,
+		routeable.GetMulticastInterfaces(cx.StateCfg.MinerInterfaces...),
+		cx.StateCfg.MinerCompat)
 	if err != nil {
 		log.ERROR(err)
 		cancel()
 		return
 	}
+	if cx.StateCfg.MinerRemote {
+		// remote workers use the same port over TCP
+		if err = conn.AcceptRemote(*cx.Config.Controller); err != nil {
+			log.ERROR(err)
+			cancel()
+			return
+		}
+	}
 	ctrl := &Controller{
 		conn:                   conn,
 		active:                 &atomic.Bool{},
@@ -110,7 +125,7 @@ func Run(cx *conte.Xt) (cancel context.CancelFunc) {
 			log.ERROR(err)
 		}
 	}()
-	log.DEBUG("sending broadcasts to:", UDP4MulticastAddress)
+	log.DEBUG("sending broadcasts to:", MulticastAddresses)
 	err = ctrl.sendNewBlockTemplate()
 	if err != nil {
 		log.ERROR(err)
@@ -126,6 +141,7 @@ func Run(cx *conte.Xt) (cancel context.CancelFunc) {
 	cx.RealNode.Chain.Subscribe(ctrl.getNotifier())
 	go rebroadcaster(ctrl)
 	go submitter(ctrl)
+	go statsNotifier(ctrl)
 	select {
 	case <-ctx.Done():
 	case <-interrupt.HandlersDone:
@@ -136,19 +152,33 @@ func Run(cx *conte.Xt) (cancel context.CancelFunc) {
 }
 
 // these are the handlers for specific message types.
-// Controller only listens for submissions (currently)
+// Controller listens for submissions and the statistics of workers
 var handlers = transport.HandleFunc{
-	string(sol.SolutionMagic): func(ctx interface{}) func(b []byte) (
-		err error) {
+	string(stats.StatsMagic): func(ctx interface{},
+		src net.Addr) func(b []byte) (err error) {
+		return func(b []byte) (err error) {
+			c := ctx.(*Controller)
+			height := c.cx.RealNode.Chain.BestSnapshot().Height + 1
+			c.cx.RealNode.WorkerStats.Report(stats.SourceAddress(src),
+				stats.LoadStatsContainer(b), height)
+			return
+		}
+	},
+	string(sol.SolutionMagic): func(ctx interface{},
+		src net.Addr) func(b []byte) (err error) {
 		return func(b []byte) (err error) {
 			log.DEBUG("received solution")
 			c := ctx.(*Controller)
 			j := sol.LoadSolContainer(b)
 			msgBlock := j.GetMsgBlock()
 			log.SPEW(msgBlock)
-			if !msgBlock.Header.PrevBlock.IsEqual(&c.cx.RPCServer.Cfg.Chain.
-				BestSnapshot().Hash) {
+			best := c.cx.RPCServer.Cfg.Chain.BestSnapshot()
+			workerStats := c.cx.RealNode.WorkerStats
+			address := stats.SourceAddress(src)
+			algo := fork.GetAlgoName(msgBlock.Header.Version, best.Height+1)
+			if !msgBlock.Header.PrevBlock.IsEqual(&best.Hash) {
 				log.WARN("block submitted by kopach miner worker is stale")
+				workerStats.Solution(address, algo, stats.Stale)
 				return
 			}
 			// set old blocks to pause and send pause directly as block is
@@ -175,6 +205,7 @@ var handlers = transport.HandleFunc{
 				if isOrphan {
 					log.WARN("block is an orphan")
 				}
+				workerStats.Solution(address, algo, stats.Rejected)
 				// maybe something wrong with the network,
 				// send current work again
 				err = c.sendNewBlockTemplate()
@@ -184,6 +215,7 @@ var handlers = transport.HandleFunc{
 				return
 			}
 			log.DEBUG("the block was accepted")
+			workerStats.Solution(address, algo, stats.Accepted)
 			coinbaseTx := block.MsgBlock().Transactions[0].TxOut[0]
 			prevHeight := block.Height() - 1
 			prevBlock, _ := c.cx.RealNode.Chain.BlockByHeight(prevHeight)
@@ -226,14 +258,12 @@ func (c *Controller) sendNewBlockTemplate() (err error) {
 
 func getNewBlockTemplate(cx *conte.Xt, bTG *mining.BlkTmplGenerator,
 ) (template *mining.BlockTemplate) {
-	if len(*cx.Config.MiningAddrs) < 1 {
+	payToAddr, err := bTG.PayToAddress()
+	if err != nil {
+		log.ERROR(err)
 		return
 	}
-	// Choose a payment address at random.
-	rand.Seed(time.Now().UnixNano())
-	payToAddr := cx.StateCfg.ActiveMiningAddrs[rand.Intn(len(*cx.Config.
-		MiningAddrs))]
-	template, err := bTG.NewBlockTemplate(0, payToAddr,
+	template, err = bTG.NewBlockTemplate(0, payToAddr,
 		"sha256d")
 	if err != nil {
 		log.ERROR(err)
@@ -251,9 +281,11 @@ func getBlkTemplateGenerator(cx *conte.Xt) *mining.BlkTmplGenerator {
 		TxMinFreeFee:      cx.StateCfg.ActiveMinRelayTxFee,
 	}
 	s := cx.RealNode
-	return mining.NewBlkTmplGenerator(&policy,
+	g := mining.NewBlkTmplGenerator(&policy,
 		s.ChainParams, s.TxMemPool, s.Chain, s.TimeSource,
 		s.SigCache, s.HashCache, s.Algo)
+	g.PayToAddrs = s.PayToAddrs
+	return g
 }
 
 func rebroadcaster(ctrl *Controller) {
@@ -300,6 +332,28 @@ out:
 	}
 }
 
+// statsNotifier sends the statistics of the workers to the websocket clients
+// of the RPC servers that asked for them while workers are reporting
+func statsNotifier(ctrl *Controller) {
+	ticker := time.NewTicker(stats.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			workerStats := ctrl.cx.RealNode.WorkerStats
+			if !workerStats.Active() {
+				break
+			}
+			result := workerStats.Result()
+			for _, s := range ctrl.cx.RealNode.RPCServers {
+				s.NtfnMgr.SendNotifyWorkerStats(result)
+			}
+		case <-ctrl.ctx.Done():
+			return
+		}
+	}
+}
+
 func submitter(ctrl *Controller) {
 out:
 	for {
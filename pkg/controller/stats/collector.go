@@ -0,0 +1,223 @@
+package stats
+
+import (
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/pkg/chain/fork"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+)
+
+const (
+	// WorkerTimeout is how long after its last report a worker is no longer
+	// counted in the hashrate
+	WorkerTimeout = Interval * 3
+	// SourceExpiry is how long the totals of an address are kept after
+	// nothing more has been heard from it
+	SourceExpiry = time.Hour
+)
+
+// Result is what became of a solution sent to the controller
+type Result int
+
+const (
+	Accepted Result = iota
+	Stale
+	Rejected
+)
+
+// Collector keeps the statistics workers send by the address they were
+// received from and by algorithm, along with what became of the solutions
+// they sent
+type Collector struct {
+	mx      sync.Mutex
+	sources map[string]*source
+}
+
+// source is everything known about the workers sending from one address
+type source struct {
+	lastSeen time.Time
+	workers  map[int32]*report
+	algos    map[string]*counts
+}
+
+// report is the last statistics a worker sent
+type report struct {
+	received time.Time
+	interval time.Duration
+	hashes   map[string]uint32
+}
+
+// counts are the totals for an algorithm since the address was first seen
+type counts struct {
+	hashes, found, accepted, stale, rejected uint64
+}
+
+// SourceAddress returns the host messages were received from, which the
+// statistics are kept by. The port is left out so the workers on one machine,
+// or behind one relay, are counted together.
+func SourceAddress(src net.Addr) string {
+	if src == nil {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(src.String())
+	if err != nil {
+		return src.String()
+	}
+	return host
+}
+
+func NewCollector() *Collector {
+	return &Collector{sources: make(map[string]*source)}
+}
+
+// Report adds the statistics a worker sent from the address, the algorithm
+// versions are resolved to names at the height being mined
+func (c *Collector) Report(address string, sC *StatsContainer, height int32) {
+	now := time.Now()
+	r := &report{
+		received: now,
+		interval: sC.GetInterval(),
+		hashes:   make(map[string]uint32),
+	}
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	s := c.source(address, now)
+	for ver, n := range sC.GetHashes() {
+		algo := fork.GetAlgoName(ver, height)
+		r.hashes[algo] += n
+		s.algo(algo).hashes += uint64(n)
+	}
+	for ver, n := range sC.GetSolutions() {
+		s.algo(fork.GetAlgoName(ver, height)).found += uint64(n)
+	}
+	s.workers[sC.GetWorkerID()] = r
+}
+
+// Solution counts a solution from the address by what became of it
+func (c *Collector) Solution(address string, algo string, result Result) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	a := c.source(address, time.Now()).algo(algo)
+	switch result {
+	case Accepted:
+		a.accepted++
+	case Stale:
+		a.stale++
+	case Rejected:
+		a.rejected++
+	}
+}
+
+// Active returns whether any worker has reported recently
+func (c *Collector) Active() bool {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	for _, s := range c.sources {
+		for _, r := range s.workers {
+			if time.Since(r.received) < WorkerTimeout {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Result returns the statistics for each address and the totals for each
+// algorithm, workers that have stopped reporting are dropped from it
+func (c *Collector) Result() (out btcjson.GetWorkerStatsResult) {
+	now := time.Now()
+	totals := make(map[string]*btcjson.WorkerAlgoResult)
+	c.mx.Lock()
+	for address, s := range c.sources {
+		if now.Sub(s.lastSeen) > SourceExpiry {
+			delete(c.sources, address)
+			continue
+		}
+		rates := make(map[string]float64)
+		for id, r := range s.workers {
+			if now.Sub(r.received) > WorkerTimeout {
+				delete(s.workers, id)
+				continue
+			}
+			if r.interval <= 0 {
+				continue
+			}
+			for algo, n := range r.hashes {
+				rates[algo] += float64(n) / r.interval.Seconds()
+			}
+		}
+		sr := btcjson.WorkerSourceResult{
+			Address:  address,
+			Workers:  len(s.workers),
+			LastSeen: s.lastSeen.Unix(),
+		}
+		for algo, a := range s.algos {
+			ar := btcjson.WorkerAlgoResult{
+				Algo:         algo,
+				HashesPerSec: rates[algo],
+				Hashes:       a.hashes,
+				Found:        a.found,
+				Accepted:     a.accepted,
+				Stale:        a.stale,
+				Rejected:     a.rejected,
+			}
+			sr.HashesPerSec += ar.HashesPerSec
+			sr.Algos = append(sr.Algos, ar)
+			t, ok := totals[algo]
+			if !ok {
+				t = &btcjson.WorkerAlgoResult{Algo: algo}
+				totals[algo] = t
+			}
+			t.HashesPerSec += ar.HashesPerSec
+			t.Hashes += ar.Hashes
+			t.Found += ar.Found
+			t.Accepted += ar.Accepted
+			t.Stale += ar.Stale
+			t.Rejected += ar.Rejected
+		}
+		sortAlgos(sr.Algos)
+		out.Sources = append(out.Sources, sr)
+	}
+	c.mx.Unlock()
+	sort.Slice(out.Sources, func(i, j int) bool {
+		return out.Sources[i].Address < out.Sources[j].Address
+	})
+	for _, t := range totals {
+		out.Algos = append(out.Algos, *t)
+	}
+	sortAlgos(out.Algos)
+	return
+}
+
+// source returns the statistics for an address, creating them if it hasn't
+// been seen before
+func (c *Collector) source(address string, now time.Time) *source {
+	s, ok := c.sources[address]
+	if !ok {
+		s = &source{
+			workers: make(map[int32]*report),
+			algos:   make(map[string]*counts),
+		}
+		c.sources[address] = s
+	}
+	s.lastSeen = now
+	return s
+}
+
+func (s *source) algo(name string) *counts {
+	a, ok := s.algos[name]
+	if !ok {
+		a = &counts{}
+		s.algos[name] = a
+	}
+	return a
+}
+
+func sortAlgos(algos []btcjson.WorkerAlgoResult) {
+	sort.Slice(algos, func(i, j int) bool {
+		return algos[i].Algo < algos[j].Algo
+	})
+}
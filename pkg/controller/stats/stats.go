@@ -0,0 +1,64 @@
+// Package stats is the message kopach workers periodically send to the
+// miner controller with how many hashes and solutions they made for each
+// algorithm, and the collector the controller keeps them in
+package stats
+
+import (
+	"time"
+
+	"github.com/p9c/pod/pkg/simplebuffer"
+	"github.com/p9c/pod/pkg/simplebuffer/Bitses"
+	"github.com/p9c/pod/pkg/simplebuffer/Int32"
+)
+
+// Interval is how often workers send their statistics
+const Interval = time.Second * 5
+
+// StatsMagic is the marker for packets containing worker statistics
+var StatsMagic = []byte{'s', 't', 'a', 't'}
+
+type StatsContainer struct {
+	simplebuffer.Container
+}
+
+// GetStatsContainer returns the statistics of a worker for the interval,
+// the hashes and solutions are counted by algorithm version
+func GetStatsContainer(id int32, interval time.Duration, hashes,
+	solutions map[int32]uint32) *StatsContainer {
+	srs := simplebuffer.Serializers{
+		Int32.New().Put(id),
+		Int32.New().Put(int32(interval / time.Millisecond)),
+		Bitses.NewBitses().Put(hashes),
+		Bitses.NewBitses().Put(solutions),
+	}.CreateContainer(StatsMagic)
+	return &StatsContainer{*srs}
+}
+
+func LoadStatsContainer(b []byte) (out *StatsContainer) {
+	out = &StatsContainer{}
+	out.Data = b
+	return
+}
+
+// GetWorkerID returns the random number that tells apart workers on the same
+// machine
+func (sC *StatsContainer) GetWorkerID() int32 {
+	return Int32.New().DecodeOne(sC.Get(0)).Get()
+}
+
+// GetInterval returns the time the hashes and solutions were counted over
+func (sC *StatsContainer) GetInterval() time.Duration {
+	return time.Duration(Int32.New().DecodeOne(sC.Get(1)).Get()) *
+		time.Millisecond
+}
+
+// GetHashes returns the number of hashes done for each algorithm version
+func (sC *StatsContainer) GetHashes() map[int32]uint32 {
+	return Bitses.NewBitses().DecodeOne(sC.Get(2)).Get()
+}
+
+// GetSolutions returns the number of solutions found for each algorithm
+// version
+func (sC *StatsContainer) GetSolutions() map[int32]uint32 {
+	return Bitses.NewBitses().DecodeOne(sC.Get(3)).Get()
+}
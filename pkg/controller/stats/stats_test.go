@@ -0,0 +1,100 @@
+package stats
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chain/fork"
+)
+
+func TestStatsContainer(t *testing.T) {
+	hashes := map[int32]uint32{2: 1000, 514: 2000}
+	solutions := map[int32]uint32{514: 1}
+	sC := LoadStatsContainer(GetStatsContainer(42, time.Second*5, hashes,
+		solutions).Data)
+	if id := sC.GetWorkerID(); id != 42 {
+		t.Errorf("worker ID %d, want 42", id)
+	}
+	if interval := sC.GetInterval(); interval != time.Second*5 {
+		t.Errorf("interval %v, want 5s", interval)
+	}
+	if got := sC.GetHashes(); !reflect.DeepEqual(got, hashes) {
+		t.Errorf("hashes %v, want %v", got, hashes)
+	}
+	if got := sC.GetSolutions(); !reflect.DeepEqual(got, solutions) {
+		t.Errorf("solutions %v, want %v", got, solutions)
+	}
+}
+
+func TestCollector(t *testing.T) {
+	c := NewCollector()
+	if c.Active() {
+		t.Error("collector without reports is active")
+	}
+	ver := fork.GetAlgoVer("sha256d", 0)
+	for id := int32(1); id <= 2; id++ {
+		c.Report("192.168.0.5", LoadStatsContainer(GetStatsContainer(id,
+			time.Second*5, map[int32]uint32{ver: 500},
+			map[int32]uint32{ver: 1}).Data), 0)
+	}
+	if !c.Active() {
+		t.Error("collector with reports is not active")
+	}
+	c.Solution("192.168.0.5", "sha256d", Accepted)
+	c.Solution("192.168.0.5", "sha256d", Stale)
+	c.Solution("10.0.0.2", "scrypt", Rejected)
+	result := c.Result()
+	if len(result.Sources) != 2 {
+		t.Fatalf("%d sources, want 2", len(result.Sources))
+	}
+	var worker, rejecter bool
+	for _, s := range result.Sources {
+		switch {
+		case s.Address == "10.0.0.2":
+			rejecter = true
+			if s.Workers != 0 || len(s.Algos) != 1 || s.Algos[0].Rejected != 1 {
+				t.Errorf("unexpected statistics for %s: %+v", s.Address, s)
+			}
+		default:
+			worker = true
+			if s.Workers != 2 || s.HashesPerSec != 200 {
+				t.Errorf("%d workers at %v hashes/s, want 2 at 200",
+					s.Workers, s.HashesPerSec)
+			}
+			if len(s.Algos) != 1 {
+				t.Fatalf("%d algorithms, want 1", len(s.Algos))
+			}
+			a := s.Algos[0]
+			if a.Hashes != 1000 || a.Found != 2 || a.Accepted != 1 ||
+				a.Stale != 1 {
+				t.Errorf("unexpected statistics for %s: %+v", a.Algo, a)
+			}
+		}
+	}
+	if !worker || !rejecter {
+		t.Error("missing a source")
+	}
+	if len(result.Algos) != 2 || result.Algos[0].Algo != "scrypt" ||
+		result.Algos[1].HashesPerSec != 200 {
+		t.Errorf("unexpected algorithm totals %+v", result.Algos)
+	}
+}
+
+func TestSourceAddress(t *testing.T) {
+	for _, test := range []struct {
+		src  net.Addr
+		want string
+	}{
+		{&net.UDPAddr{IP: net.ParseIP("192.168.0.5"), Port: 11049},
+			"192.168.0.5"},
+		{&net.TCPAddr{IP: net.ParseIP("fe80::1"), Port: 11050}, "fe80::1"},
+		{nil, "unknown"},
+	} {
+		if got := SourceAddress(test.src); got != test.want {
+			t.Errorf("source address of %v is %s, want %s", test.src, got,
+				test.want)
+		}
+	}
+}
@@ -52,6 +52,8 @@ const (
 	ErrBlockExists
 	// ErrBlockRegionInvalid indicates a region that exceeds the bounds of the specified block was requested.  When the hash provided by the region does not correspond to an existing block, the error will be ErrBlockNotFound instead.
 	ErrBlockRegionInvalid
+	// ErrBlockPruned indicates the data of a block which is still in the block index was deleted to limit the disk space used by the database.
+	ErrBlockPruned
 	// Support for driver-specific errors.
 	// ErrDriverSpecific indicates the Err field is a driver-specific error. This provides a mechanism for drivers to plug-in their own custom errors for any situations which aren't already covered by the error codes provided by this package.
 	ErrDriverSpecific
@@ -81,6 +83,7 @@ var errorCodeStrings = map[ErrorCode]string{
 	ErrBlockNotFound:      "ErrBlockNotFound",
 	ErrBlockExists:        "ErrBlockExists",
 	ErrBlockRegionInvalid: "ErrBlockRegionInvalid",
+	ErrBlockPruned:        "ErrBlockPruned",
 	ErrDriverSpecific:     "ErrDriverSpecific",
 }
 
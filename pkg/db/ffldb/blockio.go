@@ -97,6 +97,8 @@ type (
 		openBlockFiles   map[uint32]*lockableFile
 		// writeCursor houses the state for the current file and location that new blocks are written to.
 		writeCursor *writeCursor
+		// firstFileNum is the oldest block file which has not been pruned.  It is protected by obfMutex.
+		firstFileNum uint32
 		// These functions are set to openFile, openWriteFile, and deleteFile by default, but are exposed here to allow the whitebox tests to replace them when working with mock files.
 		openFileFunc      func(fileNum uint32) (*lockableFile, error)
 		openWriteFileFunc func(fileNum uint32) (filer, error)
@@ -198,6 +200,42 @@ func (s *blockStore) deleteFile(fileNum uint32) error {
 	return nil
 }
 
+// pruneFiles closes and deletes every block file older than the passed file number.  Blocks stored in deleted files are reported as pruned from then on.  A file which fails to be deleted stops the prune and is retried by the next one.
+func (s *blockStore) pruneFiles(beforeFileNum uint32) error {
+	s.obfMutex.Lock()
+	defer s.obfMutex.Unlock()
+	for ; s.firstFileNum < beforeFileNum; s.firstFileNum++ {
+		fileNum := s.firstFileNum
+		// Close the file under the write lock for the file in case any readers are currently reading from it so it's not deleted out from under them.
+		if obf, ok := s.openBlockFiles[fileNum]; ok {
+			s.lruMutex.Lock()
+			s.openBlocksLRU.Remove(s.fileNumToLRUElem[fileNum])
+			delete(s.fileNumToLRUElem, fileNum)
+			s.lruMutex.Unlock()
+			obf.Lock()
+			_ = obf.file.Close()
+			obf.Unlock()
+			delete(s.openBlockFiles, fileNum)
+		}
+		if err := s.deleteFileFunc(fileNum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPruned returns ErrBlockPruned when the passed block file number was deleted by pruning.
+func (s *blockStore) checkPruned(hash *chainhash.Hash, fileNum uint32) error {
+	s.obfMutex.RLock()
+	pruned := fileNum < s.firstFileNum
+	s.obfMutex.RUnlock()
+	if pruned {
+		str := fmt.Sprintf("block %s has been pruned", hash)
+		return makeDbErr(database.ErrBlockPruned, str, nil)
+	}
+	return nil
+}
+
 // blockFile attempts to return an existing file handle for the passed flat file number if it is already open as well as marking it as most recently used.  It will also open the file when it's not already open subject to the rules described in openFile.
 // NOTE: The returned block file will already have the read lock acquired and the caller MUST call .RUnlock() to release it once it has finished all read operations.  This is necessary because otherwise it would be possible for a separate goroutine to close the file after it is returned from here, but before the caller has acquired a read lock.
 func (s *blockStore) blockFile(fileNum uint32) (*lockableFile, error) {
@@ -332,6 +370,9 @@ func (s *blockStore) writeBlock(rawBlock []byte) (blockLocation, error) {
 // readBlock reads the specified block record and returns the serialized block. It ensures the integrity of the block data by checking that the serialized network matches the current network associated with the block store and comparing the calculated checksum against the one stored in the flat file. This function also automatically handles all file management such as opening and closing files as necessary to stay within the maximum allowed open files limit.
 // Returns ErrDriverSpecific if the data fails to read for any reason and ErrCorruption if the checksum of the read data doesn't match the checksum read from the file. Format: <network><block length><serialized block><checksum>
 func (s *blockStore) readBlock(hash *chainhash.Hash, loc blockLocation) ([]byte, error) {
+	if err := s.checkPruned(hash, loc.blockFileNum); err != nil {
+		return nil, err
+	}
 	// Get the referenced block file handle opening the file as needed.  The function also handles closing files as needed to avoid going over the max allowed open files.
 	blockFile, err := s.blockFile(loc.blockFileNum)
 	if err != nil {
@@ -490,11 +531,35 @@ func (s *blockStore) handleRollback(oldBlockFileNum, oldBlockOffset uint32) {
 	}
 }
 
-// scanBlockFiles searches the database directory for all flat block files to find the end of the most recent file.  This position is considered the current write cursor which is also stored in the metadata.  Thus, it is used to detect unexpected shutdowns in the middle of writes so the block files can be reconciled.
+// firstBlockFile returns the number of the oldest flat block file in the database directory.  It is zero unless the database was pruned.
+func firstBlockFile(dbPath string) uint32 {
+	paths, err := filepath.Glob(filepath.Join(dbPath, "*.fdb"))
+	if err != nil {
+		log.TRACE(err)
+		return 0
+	}
+	var firstFile uint32
+	found := false
+	for _, path := range paths {
+		var fileNum uint32
+		_, err := fmt.Sscanf(filepath.Base(path), blockFilenameTemplate, &fileNum)
+		if err != nil {
+			log.TRACE(err)
+			continue
+		}
+		if !found || fileNum < firstFile {
+			firstFile = fileNum
+			found = true
+		}
+	}
+	return firstFile
+}
+
+// scanBlockFiles searches the database directory for all flat block files to find the end of the most recent file.  This position is considered the current write cursor which is also stored in the metadata.  Thus, it is used to detect unexpected shutdowns in the middle of writes so the block files can be reconciled.  The scan starts from the oldest file since a pruned database no longer holds the first ones.
 func scanBlockFiles(dbPath string) (int, uint32) {
 	lastFile := -1
 	fileLen := uint32(0)
-	for i := 0; ; i++ {
+	for i := int(firstBlockFile(dbPath)); ; i++ {
 		filePath := blockFilePath(dbPath, uint32(i))
 		st, err := os.Stat(filePath)
 		if err != nil {
@@ -521,6 +586,7 @@ func newBlockStore(basePath string, network wire.BitcoinNet) *blockStore {
 		network:          network,
 		basePath:         basePath,
 		maxBlockFileSize: maxBlockFileSize,
+		firstFileNum:     firstBlockFile(basePath),
 		openBlockFiles:   make(map[uint32]*lockableFile),
 		openBlocksLRU:    list.New(),
 		fileNumToLRUElem: make(map[uint32]*list.Element),
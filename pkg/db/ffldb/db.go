@@ -1310,6 +1310,9 @@ func // FetchBlockRegion returns the raw serialized bytes for the given block
 			region.Offset, region.Len, location.blockLen)
 		return nil, makeDbErr(database.ErrBlockRegionInvalid, str, nil)
 	}
+	if err := tx.db.store.checkPruned(region.Hash, location.blockFileNum); err != nil {
+		return nil, err
+	}
 	// Read the region from the appropriate disk block file.
 	regionBytes, err := tx.db.store.readBlockRegion(location, region.Offset,
 		region.Len)
@@ -1385,6 +1388,9 @@ func // FetchBlockRegions returns the raw serialized bytes for the given
 				region.Offset, region.Len, location.blockLen)
 			return nil, makeDbErr(database.ErrBlockRegionInvalid, str, nil)
 		}
+		if err := tx.db.store.checkPruned(region.Hash, location.blockFileNum); err != nil {
+			return nil, err
+		}
 		fetchList = append(fetchList, bulkFetchData{&location, i})
 	}
 	sort.Sort(bulkFetchDataSorter(fetchList))
@@ -1698,6 +1704,103 @@ func // Update invokes the passed function in the context of a managed read
 	return tx.Commit()
 }
 
+func // CacheStats returns the number of key lookups which were answered by the
+// database cache and the number which had to consult leveldb.
+// This function is safe for concurrent access and is part of the database.DB
+// interface implementation.
+(db *db) CacheStats() (hits, misses uint64) {
+	return db.cache.Stats()
+}
+
+func // PruneBlocks deletes the flat files holding the oldest blocks until the
+// remaining files take no more than targetSize bytes.
+// The file holding the block identified by keep and all newer files are
+// never deleted, so the target is exceeded rather than deleting them.
+// It returns the hashes of the blocks which were stored in the deleted files,
+// also when a file fails to be deleted, along with the error.
+// This function is part of the database.DB interface implementation.
+(db *db) PruneBlocks(targetSize uint64, keep *chainhash.Hash) ([]chainhash.Hash, error) {
+	// Hold the write lock for the duration so no blocks are written while the
+	// files are being deleted.
+	db.writeLock.Lock()
+	defer db.writeLock.Unlock()
+	tx, err := db.begin(false)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	keepRow, err := tx.fetchBlockRow(keep)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	keepFileNum := deserializeBlockLoc(keepRow).blockFileNum
+	// Work out how many of the oldest files have to go to get under the
+	// target size.
+	store := db.store
+	store.obfMutex.RLock()
+	firstFileNum := store.firstFileNum
+	store.obfMutex.RUnlock()
+	store.writeCursor.RLock()
+	curFileNum := store.writeCursor.curFileNum
+	store.writeCursor.RUnlock()
+	fileSizes := make([]uint64, 0, curFileNum-firstFileNum+1)
+	var totalSize uint64
+	for fileNum := firstFileNum; fileNum <= curFileNum; fileNum++ {
+		var size uint64
+		if st, err := os.Stat(blockFilePath(store.basePath, fileNum)); err == nil {
+			size = uint64(st.Size())
+		}
+		fileSizes = append(fileSizes, size)
+		totalSize += size
+	}
+	pruneFileNum := firstFileNum
+	for pruneFileNum < keepFileNum && totalSize > targetSize {
+		totalSize -= fileSizes[pruneFileNum-firstFileNum]
+		pruneFileNum++
+	}
+	if pruneFileNum == firstFileNum {
+		return nil, nil
+	}
+	// Collect the blocks stored in the files about to be deleted.
+	var pruned []chainhash.Hash
+	var prunedFileNums []uint32
+	err = tx.blockIdxBucket.ForEach(func(k, v []byte) error {
+		loc := deserializeBlockLoc(v)
+		if loc.blockFileNum >= firstFileNum && loc.blockFileNum < pruneFileNum {
+			var hash chainhash.Hash
+			copy(hash[:], k)
+			pruned = append(pruned, hash)
+			prunedFileNums = append(prunedFileNums, loc.blockFileNum)
+		}
+		return nil
+	})
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	if err = store.pruneFiles(pruneFileNum); err != nil {
+		log.ERROR(err)
+		// The files before the one which failed to be deleted are gone, so
+		// their blocks are pruned all the same.
+		store.obfMutex.RLock()
+		deletedFileNum := store.firstFileNum
+		store.obfMutex.RUnlock()
+		deleted := pruned[:0]
+		for i := range pruned {
+			if prunedFileNums[i] < deletedFileNum {
+				deleted = append(deleted, pruned[i])
+			}
+		}
+		return deleted, err
+	}
+	log.DEBUGF("pruned block files %d to %d", firstFileNum, pruneFileNum-1)
+	return pruned, nil
+}
+
 func // Close cleanly shuts down the database and syncs all data.
 // It will block until all database transactions have been finalized (
 // rolled back or committed). This function is part of the database.
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/btcsuite/goleveldb/leveldb"
@@ -263,18 +264,24 @@ type dbCacheSnapshot struct {
 	dbSnapshot    *leveldb.Snapshot
 	pendingKeys   *treap.Immutable
 	pendingRemove *treap.Immutable
+	// cache is the database cache the snapshot was taken from, its lookup
+	// counters are updated by the snapshot.
+	cache *dbCache
 }
 
 // Has returns whether or not the passed key exists.
 func (snap *dbCacheSnapshot) Has(key []byte) bool {
 	// Check the cached entries first.
 	if snap.pendingRemove.Has(key) {
+		atomic.AddUint64(&snap.cache.hits, 1)
 		return false
 	}
 	if snap.pendingKeys.Has(key) {
+		atomic.AddUint64(&snap.cache.hits, 1)
 		return true
 	}
 	// Consult the database.
+	atomic.AddUint64(&snap.cache.misses, 1)
 	hasKey, _ := snap.dbSnapshot.Has(key, nil)
 	return hasKey
 }
@@ -284,12 +291,15 @@ func (snap *dbCacheSnapshot) Has(key []byte) bool {
 func (snap *dbCacheSnapshot) Get(key []byte) []byte {
 	// Check the cached entries first.
 	if snap.pendingRemove.Has(key) {
+		atomic.AddUint64(&snap.cache.hits, 1)
 		return nil
 	}
 	if value := snap.pendingKeys.Get(key); value != nil {
+		atomic.AddUint64(&snap.cache.hits, 1)
 		return value
 	}
 	// Consult the database.
+	atomic.AddUint64(&snap.cache.misses, 1)
 	value, err := snap.dbSnapshot.Get(key, nil)
 	if err != nil {
 		//log.TRACE(err)
@@ -327,6 +337,11 @@ func (snap *dbCacheSnapshot) NewIterator(slice *util.Range) *dbCacheIterator {
 // callers can commit transactions at will without incurring large performance
 // hits due to frequent disk syncs.
 type dbCache struct {
+	// hits and misses count the snapshot lookups which were answered by the
+	// cache and those which consulted the underlying database.  They are
+	// accessed atomically and kept first for 64-bit alignment.
+	hits   uint64
+	misses uint64
 	// ldb is the underlying leveldb DB for metadata.
 	ldb *leveldb.DB
 	// store is used to sync blocks to flat files.
@@ -376,6 +391,7 @@ func (c *dbCache) Snapshot() (*dbCacheSnapshot, error) {
 		dbSnapshot:    dbSnapshot,
 		pendingKeys:   c.cachedKeys,
 		pendingRemove: c.cachedRemove,
+		cache:         c,
 	}
 	c.cacheLock.RUnlock()
 	return cacheSnapshot, nil
@@ -568,6 +584,13 @@ func (c *dbCache) commitTx(tx *transaction) error {
 	return nil
 }
 
+// Stats returns the number of snapshot lookups which were answered by the
+// cache and the number which consulted the underlying database.
+// This function is safe for concurrent access.
+func (c *dbCache) Stats() (hits, misses uint64) {
+	return atomic.LoadUint64(&c.hits), atomic.LoadUint64(&c.misses)
+}
+
 // Close cleanly shuts down the database cache by syncing all data and
 // closing the underlying leveldb database.
 // This function MUST be called with the database write lock held.
@@ -15,6 +15,7 @@ import (
 	ldberrors "github.com/btcsuite/goleveldb/leveldb/errors"
 
 	chaincfg "github.com/p9c/pod/pkg/chain/config"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	"github.com/p9c/pod/pkg/chain/wire"
 	database "github.com/p9c/pod/pkg/db"
 	"github.com/p9c/pod/pkg/util"
@@ -608,3 +609,164 @@ func testCorruption(tc *testContext) bool {
 // 	// Test various corruption scenarios.
 // 	testCorruption(tc)
 // }
+
+// storePruneTestBlocks stores ten blocks in the database, two to a block file,
+// and returns their hashes.
+func storePruneTestBlocks(t *testing.T, idb database.DB) []chainhash.Hash {
+	// Use a small file size so each file holds two blocks.
+	idb.(*db).store.maxBlockFileSize = 256
+	var hashes []chainhash.Hash
+	for i := uint32(0); i < 10; i++ {
+		msgBlock := wire.NewMsgBlock(wire.NewBlockHeader(1, &chainhash.Hash{},
+			&chainhash.Hash{}, 0, i))
+		tx := wire.NewMsgTx(1)
+		tx.AddTxOut(wire.NewTxOut(int64(i), nil))
+		msgBlock.AddTransaction(tx)
+		block := util.NewBlock(msgBlock)
+		err := idb.Update(func(tx database.Tx) error {
+			return tx.StoreBlock(block)
+		})
+		if err != nil {
+			t.Fatalf("StoreBlock #%d: unexpected error: %v", i, err)
+		}
+		hashes = append(hashes, *block.Hash())
+	}
+	return hashes
+}
+
+// TestPruneBlocks ensures pruning deletes the oldest block files while keeping the block index, and that a pruned database can be reopened.
+func TestPruneBlocks(t *testing.T) {
+	t.Parallel()
+	dbPath := filepath.Join(os.TempDir(), "ffldb-prune")
+	_ = os.RemoveAll(dbPath)
+	idb, err := openDB(dbPath, blockDataNet, true)
+	if err != nil {
+		t.Fatalf("openDB: unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dbPath)
+	hashes := storePruneTestBlocks(t, idb)
+	// Nothing is pruned while the files fit in the target.
+	pruned, err := idb.PruneBlocks(1<<20, &hashes[6])
+	if err != nil || len(pruned) != 0 {
+		t.Fatalf("PruneBlocks: pruned %d blocks, err %v", len(pruned), err)
+	}
+	// The files older than the one holding the kept block are deleted.
+	pruned, err = idb.PruneBlocks(0, &hashes[6])
+	if err != nil {
+		t.Fatalf("PruneBlocks: unexpected error: %v", err)
+	}
+	if len(pruned) != 6 {
+		t.Fatalf("PruneBlocks: pruned %d blocks, want 6", len(pruned))
+	}
+	if _, err := os.Stat(blockFilePath(dbPath, 2)); !os.IsNotExist(err) {
+		t.Fatalf("block file 2 was not deleted: %v", err)
+	}
+	checkPruned := func(idb database.DB) {
+		err := idb.View(func(tx database.Tx) error {
+			if has, _ := tx.HasBlock(&hashes[0]); !has {
+				t.Errorf("HasBlock: pruned block is no longer indexed")
+			}
+			_, err := tx.FetchBlock(&hashes[0])
+			checkDbError(t, "FetchBlock", err, database.ErrBlockPruned)
+			_, err = tx.FetchBlockHeader(&hashes[5])
+			checkDbError(t, "FetchBlockHeader", err, database.ErrBlockPruned)
+			if _, err := tx.FetchBlock(&hashes[6]); err != nil {
+				t.Errorf("FetchBlock: unexpected error: %v", err)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("View: unexpected error: %v", err)
+		}
+	}
+	checkPruned(idb)
+	// Ensure the database still opens with the oldest files missing.
+	if err := idb.Close(); err != nil {
+		t.Fatalf("Close: unexpected error: %v", err)
+	}
+	idb, err = openDB(dbPath, blockDataNet, false)
+	if err != nil {
+		t.Fatalf("openDB: unexpected error: %v", err)
+	}
+	defer idb.Close()
+	checkPruned(idb)
+}
+
+// TestPruneBlocksFailure ensures the blocks of the files deleted before one
+// fails to be deleted are still reported as pruned, and that the next prune
+// carries on from the file which failed.
+func TestPruneBlocksFailure(t *testing.T) {
+	t.Parallel()
+	dbPath := filepath.Join(os.TempDir(), "ffldb-prunefailure")
+	_ = os.RemoveAll(dbPath)
+	idb, err := openDB(dbPath, blockDataNet, true)
+	if err != nil {
+		t.Fatalf("openDB: unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dbPath)
+	defer idb.Close()
+	hashes := storePruneTestBlocks(t, idb)
+	store := idb.(*db).store
+	deleteFile := store.deleteFileFunc
+	store.deleteFileFunc = func(fileNum uint32) error {
+		if fileNum == 1 {
+			return makeDbErr(database.ErrDriverSpecific, "injected", nil)
+		}
+		return deleteFile(fileNum)
+	}
+	pruned, err := idb.PruneBlocks(0, &hashes[6])
+	checkDbError(t, "PruneBlocks", err, database.ErrDriverSpecific)
+	if len(pruned) != 2 || !(pruned[0] == hashes[0] && pruned[1] == hashes[1] ||
+		pruned[0] == hashes[1] && pruned[1] == hashes[0]) {
+		t.Fatalf("PruneBlocks: pruned %v, want the blocks of file 0", pruned)
+	}
+	store.deleteFileFunc = deleteFile
+	pruned, err = idb.PruneBlocks(0, &hashes[6])
+	if err != nil {
+		t.Fatalf("PruneBlocks: unexpected error: %v", err)
+	}
+	if len(pruned) != 4 {
+		t.Fatalf("PruneBlocks: pruned %d blocks, want 4", len(pruned))
+	}
+}
+
+// TestCacheStats ensures lookups of keys held by the database cache are
+// counted as hits and lookups which consult leveldb as misses.
+func TestCacheStats(t *testing.T) {
+	t.Parallel()
+	dbPath := filepath.Join(os.TempDir(), "ffldb-cachestats")
+	_ = os.RemoveAll(dbPath)
+	idb, err := openDB(dbPath, blockDataNet, true)
+	if err != nil {
+		t.Fatalf("openDB: unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dbPath)
+	defer idb.Close()
+	// The committed key stays in the cache until it is flushed.
+	err = idb.Update(func(tx database.Tx) error {
+		return tx.Metadata().Put([]byte("cachedkey"), []byte("value"))
+	})
+	if err != nil {
+		t.Fatalf("Update: unexpected error: %v", err)
+	}
+	lookup := func(key string) (hits, misses uint64) {
+		hits, misses = idb.CacheStats()
+		err := idb.View(func(tx database.Tx) error {
+			tx.Metadata().Get([]byte(key))
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("View: unexpected error: %v", err)
+		}
+		newHits, newMisses := idb.CacheStats()
+		return newHits - hits, newMisses - misses
+	}
+	if hits, misses := lookup("cachedkey"); hits != 1 || misses != 0 {
+		t.Errorf("cached key: %d hits and %d misses, want 1 and 0", hits,
+			misses)
+	}
+	if hits, misses := lookup("missingkey"); hits != 0 || misses != 1 {
+		t.Errorf("missing key: %d hits and %d misses, want 0 and 1", hits,
+			misses)
+	}
+}
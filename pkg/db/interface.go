@@ -413,6 +413,19 @@ type DB interface {
 	// Calling Rollback or Commit on the transaction passed to the
 	// user-supplied function will result in a panic.
 	Update(fn func(tx Tx) error) error
+	// PruneBlocks deletes the data of the oldest stored blocks until the
+	// remaining blocks take no more than targetSize bytes.  The block
+	// identified by keep and every block stored after it are never
+	// deleted.  It returns the hashes of the blocks which were deleted,
+	// including when an error stops the prune partway.
+	//
+	// Pruned blocks stay in the block index, so HasBlock still reports
+	// them while attempts to fetch their data return ErrBlockPruned.
+	PruneBlocks(targetSize uint64, keep *chainhash.Hash) ([]chainhash.Hash, error)
+	// CacheStats returns the number of key lookups which were answered by
+	// the database cache and the number which had to consult the
+	// underlying storage since the database was opened.
+	CacheStats() (hits, misses uint64)
 	// Close cleanly shuts down the database and syncs all data.  It will
 	// block until all database transactions have been finalized (rolled
 	// back or committed).
@@ -4,16 +4,68 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/sha1"
+	"crypto/sha256"
+	"io"
 
 	"github.com/btcsuite/golangcrypto/pbkdf2"
+	"golang.org/x/crypto/hkdf"
 
 	"github.com/p9c/pod/pkg/log"
 )
 
+const (
+	// KeyIterations is the number of PBKDF2 rounds used to derive the master
+	// key from the pre shared password
+	KeyIterations = 100000
+	// KeyLen is the length of the master and session keys, AES-256
+	KeyLen = 32
+)
+
+// keySalt is the salt for the master key derivation. The password is the only
+// shared secret so the salt cannot be random, instead it is unique to this
+// protocol so that keys derived from the same password for other purposes
+// are unrelated.
+var keySalt = []byte("p9c/pod/transport/v1")
+
+// DeriveKey stretches a pre shared password into a master key. It is slow on
+// purpose and should only be called once per password.
+func DeriveKey(password string) []byte {
+	return pbkdf2.Key([]byte(password), keySalt, KeyIterations, KeyLen,
+		sha256.New)
+}
+
+// GetSessionCipher returns a GCM cipher keyed from the master key and the
+// salt that identifies a sending session. Every session uses a different key,
+// so each sender can count its nonces up from zero without colliding with
+// another sender using the same password.
+func GetSessionCipher(masterKey, sessionSalt []byte) (gcm cipher.AEAD,
+	err error) {
+	key := make([]byte, KeyLen)
+	kdf := hkdf.New(sha256.New, masterKey, sessionSalt, keySalt)
+	if _, err = io.ReadFull(kdf, key); err != nil {
+		log.ERROR(err)
+		return
+	}
+	var c cipher.Block
+	if c, err = aes.NewCipher(key); err != nil {
+		log.ERROR(err)
+		return
+	}
+	if gcm, err = cipher.NewGCM(c); err != nil {
+		log.ERROR(err)
+	}
+	return
+}
+
 // GetCipher returns a GCM cipher given a password string. Note that this cipher
 // must be renewed every 4gb of encrypted data
+//
+// Deprecated: the key is derived with a salt made from the password itself and
+// is the same for everyone using the password. It is kept so the transport can
+// still talk to peers using the legacy framing, use DeriveKey and
+// GetSessionCipher instead.
 func GetCipher(password string) cipher.AEAD {
-	key := pbkdf2.Key(reverse([]byte(password)), []byte(password),
+	key := pbkdf2.Key(legacyKeyInput([]byte(password)), []byte(password),
 		4096, 32,
 		sha1.New)
 	c, err := aes.NewCipher(key)
@@ -27,7 +79,11 @@ func GetCipher(password string) cipher.AEAD {
 	return gcm
 }
 
-func reverse(b []byte) []byte {
+// legacyKeyInput reproduces the input the legacy key derivation used. It was
+// meant to be the reversed password but the function that made it filled
+// every position with the last byte instead, legacy peers can only decrypt
+// with a key made the same way.
+func legacyKeyInput(b []byte) []byte {
 	out := make([]byte, len(b))
 	for i := range b {
 		out[i] = b[len(b)-1]
@@ -0,0 +1,201 @@
+package log
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// modulePath is trimmed from package paths so subsystems are named the same
+// as by pkgs.Name
+const modulePath = "github.com/p9c/pod/"
+
+var (
+	// baseLevel is the index in Levels of the level for packages that don't
+	// have their own
+	baseLevel = int32(levelIndex(Info))
+	// subsystemCount is the number of packages with their own level, which
+	// is checked first so the callers don't need to be looked up without
+	// any
+	subsystemCount int32
+	subsystemMx    sync.RWMutex
+	subsystems     = make(map[string]int)
+	// callerPackages caches the package path of the functions calling the
+	// logger by program counter
+	callerPackages sync.Map
+)
+
+// levelIndex returns the position of a level in Levels, or -1 if it is not a
+// level
+func levelIndex(level string) int {
+	for i := range Levels {
+		if level == Levels[i] {
+			return i
+		}
+	}
+	return -1
+}
+
+// levelAbbreviations are the level names printed in log lines
+var levelAbbreviations = map[string]string{
+	"FTL": Fatal,
+	"ERR": Error,
+	"WRN": Warn,
+	"INF": Info,
+	"DBG": Debug,
+	"TRC": Trace,
+}
+
+// Reset is the level that sets a package back to the level for all packages
+// in SetLevels
+const Reset = "reset"
+
+// ParseLevels reads a level specification like
+// info,pkg/chain=trace,pkg/peer=debug where a level on its own is the level
+// for all packages and package=level gives a package and those below it their
+// own level. Packages are named by path without the module, as by pkgs.Name
+func ParseLevels(spec string) (base string, packages map[string]string,
+	err error) {
+	packages = make(map[string]string)
+	for _, field := range strings.Split(spec, ",") {
+		var pkg, level string
+		if pkg, level, err = parseLevel(field); err != nil {
+			return "", nil, err
+		}
+		switch {
+		case level == "":
+		case levelIndex(level) < 0:
+			return "", nil, fmt.Errorf("invalid log level %q", level)
+		case pkg == "":
+			base = level
+		default:
+			packages[pkg] = level
+		}
+	}
+	return
+}
+
+// parseLevel splits a field of a level specification into the package and
+// level, the package is empty if the level is for all packages
+func parseLevel(field string) (pkg, level string, err error) {
+	level = strings.TrimSpace(field)
+	if i := strings.Index(field, "="); i >= 0 {
+		pkg, level = strings.Trim(field[:i], "/ "),
+			strings.TrimSpace(field[i+1:])
+		if pkg == "" {
+			err = fmt.Errorf("missing package in log level %q", field)
+		}
+	}
+	return
+}
+
+// SetLevels changes the levels from a specification read by ParseLevels,
+// the levels of packages not in it are left as they were. A package given the
+// level Reset goes back to the level for all packages
+func SetLevels(spec string) (err error) {
+	var resets []string
+	var fields []string
+	for _, field := range strings.Split(spec, ",") {
+		pkg, level, e := parseLevel(field)
+		if e == nil && pkg != "" && level == Reset {
+			resets = append(resets, pkg)
+			continue
+		}
+		fields = append(fields, field)
+	}
+	base, packages, err := ParseLevels(strings.Join(fields, ","))
+	if err != nil {
+		return
+	}
+	if base != "" {
+		atomic.StoreInt32(&baseLevel, int32(levelIndex(base)))
+	}
+	subsystemMx.Lock()
+	for _, pkg := range resets {
+		delete(subsystems, pkg)
+	}
+	for pkg, level := range packages {
+		subsystems[pkg] = levelIndex(level)
+	}
+	atomic.StoreInt32(&subsystemCount, int32(len(subsystems)))
+	subsystemMx.Unlock()
+	return
+}
+
+// GetLevels returns the level for all packages and the packages with their
+// own level in the form read by SetLevels
+func GetLevels() string {
+	out := []string{Levels[atomic.LoadInt32(&baseLevel)]}
+	subsystemMx.RLock()
+	var pkgs []string
+	for pkg := range subsystems {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+	for _, pkg := range pkgs {
+		out = append(out, pkg+"="+Levels[subsystems[pkg]])
+	}
+	subsystemMx.RUnlock()
+	return strings.Join(out, ",")
+}
+
+// enabled returns whether a message at the level should be printed, the
+// package it is from is only looked up if any package has a level of its own
+func enabled(level string) bool {
+	l := levelIndex(levelAbbreviations[level])
+	if atomic.LoadInt32(&subsystemCount) < 1 {
+		return l <= int(atomic.LoadInt32(&baseLevel))
+	}
+	// the caller of the function that called the print function
+	pc, _, _, ok := runtime.Caller(3)
+	if !ok {
+		return l <= int(atomic.LoadInt32(&baseLevel))
+	}
+	return l <= packageLevel(callerPackage(pc))
+}
+
+// packageLevel returns the level of the package, which is that of the
+// nearest package above it with a level of its own or the level for all
+// packages if there isn't one
+func packageLevel(pkg string) int {
+	subsystemMx.RLock()
+	defer subsystemMx.RUnlock()
+	for {
+		if l, ok := subsystems[pkg]; ok {
+			return l
+		}
+		i := strings.LastIndex(pkg, "/")
+		if i < 0 {
+			break
+		}
+		pkg = pkg[:i]
+	}
+	return int(atomic.LoadInt32(&baseLevel))
+}
+
+// callerPackage returns the path of the package of the function at the
+// program counter
+func callerPackage(pc uintptr) string {
+	if pkg, ok := callerPackages.Load(pc); ok {
+		return pkg.(string)
+	}
+	var pkg string
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		pkg = packageOf(fn.Name())
+	}
+	callerPackages.Store(pc, pkg)
+	return pkg
+}
+
+// packageOf returns the package path of a function name as given by the
+// runtime, trimmed like by pkgs.Name
+func packageOf(name string) string {
+	slash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[slash+1:], "."); dot >= 0 {
+		name = name[:slash+1+dot]
+	}
+	return strings.TrimPrefix(name, modulePath)
+}
@@ -0,0 +1,110 @@
+package log
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLevels(t *testing.T) {
+	base, packages, err := ParseLevels("debug, pkg/chain=trace,pkg/peer/=warn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base != Debug || len(packages) != 2 || packages["pkg/chain"] != Trace ||
+		packages["pkg/peer"] != Warn {
+		t.Errorf("got %s %v", base, packages)
+	}
+	for _, spec := range []string{"loud", "pkg/chain=loud", "=info"} {
+		if _, _, err := ParseLevels(spec); err == nil {
+			t.Errorf("%q was accepted", spec)
+		}
+	}
+}
+
+func TestPackageLevels(t *testing.T) {
+	defer func() {
+		_ = SetLevels("info,pkg/chain=reset,pkg/chain/sync=reset")
+	}()
+	if err := SetLevels("warn,pkg/chain=trace,pkg/chain/sync=error"); err != nil {
+		t.Fatal(err)
+	}
+	for pkg, want := range map[string]string{
+		"pkg/chain":         Trace,
+		"pkg/chain/fork":    Trace,
+		"pkg/chain/sync":    Error,
+		"pkg/chain/syncing": Trace,
+		"pkg/peer":          Warn,
+	} {
+		if got := Levels[packageLevel(pkg)]; got != want {
+			t.Errorf("%s: level %s, want %s", pkg, got, want)
+		}
+	}
+	if got := GetLevels(); got != "warn,pkg/chain=trace,pkg/chain/sync=error" {
+		t.Errorf("levels %s", got)
+	}
+	if err := SetLevels("pkg/chain/sync=reset"); err != nil {
+		t.Fatal(err)
+	}
+	if got := Levels[packageLevel("pkg/chain/sync")]; got != Trace {
+		t.Errorf("reset package has level %s", got)
+	}
+}
+
+func TestPackageOf(t *testing.T) {
+	for name, want := range map[string]string{
+		"github.com/p9c/pod/pkg/chain/sync.(*SyncManager).handle": "pkg/chain/sync",
+		"github.com/p9c/pod/pkg/log.TestPackageOf":                "pkg/log",
+		"main.main": "main",
+	} {
+		if got := packageOf(name); got != want {
+			t.Errorf("%s: got %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestRotator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "pod.jsonl")
+	r, err := NewRotator(path, 100, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		writeEntry(r, "INF", strings.Repeat("x", 20))
+		// rotated files are named by the time so they need to differ
+		time.Sleep(time.Millisecond * 2)
+	}
+	if err = r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if rotated := r.rotated(); len(rotated) != 2 {
+		t.Errorf("%d rotated files, want 2", len(rotated))
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	var lines int
+	for scanner.Scan() {
+		var e Entry
+		if err = json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Errorf("invalid json line %q: %v", scanner.Text(), err)
+		}
+		lines++
+	}
+	if lines != 1 {
+		t.Errorf("%d lines in the log file, want 1", lines)
+	}
+}
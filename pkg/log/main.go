@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	gt "github.com/buger/goterm"
@@ -99,15 +100,16 @@ type Logger struct {
 	Infoc         PrintcFunc
 	Debugc        PrintcFunc
 	Tracec        PrintcFunc
-	LogFileHandle *os.File
+	LogFileHandle *Rotator
 	Writer        LogWriter
 	Color         bool
 }
 
-// Entry is a log entry to be printed as json to the log file
+// Entry is a log entry to be printed as a line of json to the log file
 type Entry struct {
 	Time         time.Time
 	Level        string
+	Package      string
 	CodeLocation string
 	Text         string
 }
@@ -170,71 +172,61 @@ func (w *LogWriter) Printf(format string, a ...interface{}) {
 	_, _ = fmt.Fprintf(wr, format, a...)
 }
 
-// SetLogPaths sets a file path to write logs
-func (l *Logger) SetLogPaths(logPath, logFileName string) {
-	const timeFormat = "2006-01-02_15-04-05"
-	path := filepath.Join(logFileName, logPath)
-	var logFileHandle *os.File
-	if FileExists(path) {
-		err := os.Rename(path, filepath.Join(logPath,
-			time.Now().Format(timeFormat)+".json"))
-		if err != nil {
-			wr.Println("error rotating log", err)
-			return
-		}
-	}
-	logFileHandle, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0755)
+// SetLogPaths sets a file path to write logs to as lines of json, the file is
+// rotated when it grows past maxSize bytes or has been written for maxAge, and
+// maxFiles of the rotated files are kept
+func (l *Logger) SetLogPaths(logPath, logFileName string, maxSize int64,
+	maxAge time.Duration, maxFiles int) (err error) {
+	r, err := NewRotator(filepath.Join(logPath, logFileName), maxSize, maxAge,
+		maxFiles)
 	if err != nil {
-		wr.Println("error opening log file", logFileName)
+		wr.Println("error opening log file", logFileName, err)
+		return
+	}
+	if l.LogFileHandle != nil {
+		_ = l.LogFileHandle.Close()
+	}
+	l.LogFileHandle = r
+	// the print functions are given the new file
+	l.SetLevel(Levels[atomic.LoadInt32(&baseLevel)], l.Color)
+	return
+}
+
+// Close closes the log file, if there is one
+func (l *Logger) Close() {
+	if l.LogFileHandle != nil {
+		if err := l.LogFileHandle.Close(); err != nil {
+			wr.Println("error closing log file", err)
+		}
 	}
-	l.LogFileHandle = logFileHandle
-	_, _ = fmt.Fprintln(logFileHandle, "{")
 }
 
-// SetLevel enables or disables the various print functions
+// SetLevel sets the level for all packages and enables the print functions.
+// As packages can have their own level set with SetLevels the functions for
+// every level are enabled and the messages are filtered when printed
 func (l *Logger) SetLevel(level string, color bool) *Logger {
-	//*l = *Empty()
 	level = sanitizeLoglevel(level)
-	var fallen bool
-	switch {
-	case level == Trace || fallen:
-		TRACE("trace testing")
-		l.Trace = printlnFunc("TRC", color, l.LogFileHandle)
-		l.Tracef = printfFunc("TRC", color, l.LogFileHandle)
-		l.Tracec = printcFunc("TRC", color, l.LogFileHandle)
-		l.Traces = ps("TRC", color, l.LogFileHandle)
-		fallen = true
-		fallthrough
-	case level == Debug || fallen:
-		l.Debug = printlnFunc("DBG", color, l.LogFileHandle)
-		l.Debugf = printfFunc("DBG", color, l.LogFileHandle)
-		l.Debugc = printcFunc("DBG", color, l.LogFileHandle)
-		fallen = true
-		fallthrough
-	case level == Info || fallen:
-		l.Info = printlnFunc("INF", color, l.LogFileHandle)
-		l.Infof = printfFunc("INF", color, l.LogFileHandle)
-		l.Infoc = printcFunc("INF", color, l.LogFileHandle)
-		fallen = true
-		fallthrough
-	case level == Warn || fallen:
-		l.Warn = printlnFunc("WRN", color, l.LogFileHandle)
-		l.Warnf = printfFunc("WRN", color, l.LogFileHandle)
-		l.Warnc = printcFunc("WRN", color, l.LogFileHandle)
-		fallen = true
-		fallthrough
-	case level == Error || fallen:
-		l.Error = printlnFunc("ERR", color, l.LogFileHandle)
-		l.Errorf = printfFunc("ERR", color, l.LogFileHandle)
-		l.Errorc = printcFunc("ERR", color, l.LogFileHandle)
-		fallen = true
-		fallthrough
-	case level == Fatal:
-		l.Fatal = printlnFunc("FTL", color, l.LogFileHandle)
-		l.Fatalf = printfFunc("FTL", color, l.LogFileHandle)
-		l.Fatalc = printcFunc("FTL", color, l.LogFileHandle)
-		fallen = true
-	}
+	atomic.StoreInt32(&baseLevel, int32(levelIndex(level)))
+	l.Color = color
+	l.Trace = printlnFunc("TRC", color, l.LogFileHandle)
+	l.Tracef = printfFunc("TRC", color, l.LogFileHandle)
+	l.Tracec = printcFunc("TRC", color, l.LogFileHandle)
+	l.Traces = ps("TRC", color, l.LogFileHandle)
+	l.Debug = printlnFunc("DBG", color, l.LogFileHandle)
+	l.Debugf = printfFunc("DBG", color, l.LogFileHandle)
+	l.Debugc = printcFunc("DBG", color, l.LogFileHandle)
+	l.Info = printlnFunc("INF", color, l.LogFileHandle)
+	l.Infof = printfFunc("INF", color, l.LogFileHandle)
+	l.Infoc = printcFunc("INF", color, l.LogFileHandle)
+	l.Warn = printlnFunc("WRN", color, l.LogFileHandle)
+	l.Warnf = printfFunc("WRN", color, l.LogFileHandle)
+	l.Warnc = printcFunc("WRN", color, l.LogFileHandle)
+	l.Error = printlnFunc("ERR", color, l.LogFileHandle)
+	l.Errorf = printfFunc("ERR", color, l.LogFileHandle)
+	l.Errorc = printcFunc("ERR", color, l.LogFileHandle)
+	l.Fatal = printlnFunc("FTL", color, l.LogFileHandle)
+	l.Fatalf = printfFunc("FTL", color, l.LogFileHandle)
+	l.Fatalc = printcFunc("FTL", color, l.LogFileHandle)
 	return l
 }
 
@@ -427,80 +419,78 @@ func Composite(text, level string, color bool) string {
 }
 
 // printlnFunc prints a log entry like Println
-func printlnFunc(level string, color bool, fh *os.File) PrintlnFunc {
+func printlnFunc(level string, color bool, fh *Rotator) PrintlnFunc {
 	f := func(a ...interface{}) {
+		if !enabled(level) {
+			return
+		}
 		text := trimReturn(fmt.Sprintln(a...))
 		wr.Println(Composite(text, level, color))
-		if fh != nil {
-			_, loc, line, _ := runtime.Caller(2)
-			out := Entry{time.Now(), level, fmt.Sprint(loc, ":", line), text}
-			j, err := json.Marshal(out)
-			if err != nil {
-				wr.Println("logging error:", err)
-			}
-			_, _ = fmt.Fprint(fh, string(j)+",")
-		}
+		writeEntry(fh, level, text)
 	}
 	return &f
 }
 
 // printfFunc prints a log entry with formatting
-func printfFunc(level string, color bool, fh *os.File) PrintfFunc {
+func printfFunc(level string, color bool, fh *Rotator) PrintfFunc {
 	f := func(format string, a ...interface{}) {
+		if !enabled(level) {
+			return
+		}
 		text := fmt.Sprintf(format, a...)
 		wr.Println(Composite(text, level, color))
-		if fh != nil {
-			_, loc, line, _ := runtime.Caller(2)
-			out := Entry{time.Now(), level, fmt.Sprint(loc, ":", line), text}
-			j, err := json.Marshal(out)
-			if err != nil {
-				wr.Println("logging error:", err)
-			}
-			_, _ = fmt.Fprint(fh, string(j)+",")
-		}
+		writeEntry(fh, level, text)
 	}
 	return &f
 }
 
 // printcFunc prints from a closure returning a string
-func printcFunc(level string, color bool, fh *os.File) PrintcFunc {
+func printcFunc(level string, color bool, fh *Rotator) PrintcFunc {
 	f := func(fn func() string) {
+		if !enabled(level) {
+			return
+		}
 		t := fn()
 		text := trimReturn(t)
 		wr.Println(Composite(text, level, color))
-		if fh != nil {
-			_, loc, line, _ := runtime.Caller(2)
-			out := Entry{time.Now(), level, fmt.Sprint(loc, ":", line), text}
-			j, err := json.Marshal(out)
-			if err != nil {
-				wr.Println("logging error:", err)
-			}
-			_, _ = fmt.Fprint(fh, string(j)+",")
-		}
+		writeEntry(fh, level, text)
 	}
 	return &f
 }
 
 // ps spews a variable
-func ps(level string, color bool, fh *os.File) SpewFunc {
+func ps(level string, color bool, fh *Rotator) SpewFunc {
 	f := func(a interface{}) {
+		if !enabled(level) {
+			return
+		}
 		text := trimReturn(spew.Sdump(a))
 		o := "" + Composite("spew:", level, color)
 		o += "\n" + text + "\n"
 		wr.Print(o)
-		if fh != nil {
-			_, loc, line, _ := runtime.Caller(2)
-			out := Entry{time.Now(), level, fmt.Sprint(loc, ":", line), text}
-			j, err := json.Marshal(out)
-			if err != nil {
-				wr.Println("logging error:", err)
-			}
-			_, _ = fmt.Fprint(fh, string(j)+",")
-		}
+		writeEntry(fh, level, text)
 	}
 	return &f
 }
 
+// writeEntry writes a log entry to the log file as a line of json, if there
+// is a log file
+func writeEntry(fh *Rotator, level, text string) {
+	if fh == nil {
+		return
+	}
+	// the caller of the function that called the print function
+	pc, loc, line, _ := runtime.Caller(3)
+	out := Entry{time.Now(), level, callerPackage(pc),
+		fmt.Sprint(loc, ":", line), text}
+	j, err := json.Marshal(out)
+	if err != nil {
+		wr.Println("logging error:", err)
+		return
+	}
+	_, _ = fh.Write(append(j, '\n'))
+}
+
 // FileExists reports whether the named file or directory exists.
 func FileExists(filePath string) bool {
 	_, err := os.Stat(filePath)
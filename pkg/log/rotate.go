@@ -0,0 +1,141 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxSize is the size in bytes a log file is rotated at
+	DefaultMaxSize = 10 * 1024 * 1024
+	// DefaultMaxAge is how long a log file is written before it is rotated
+	DefaultMaxAge = time.Hour * 24
+	// DefaultMaxFiles is how many rotated log files are kept
+	DefaultMaxFiles = 10
+	// rotatedTimeFormat is added to the names of rotated files, it sorts in
+	// the order the files were rotated
+	rotatedTimeFormat = "2006-01-02_15-04-05.000"
+)
+
+// Rotator is a log file that is moved aside and started again when it grows
+// past a size or has been written for too long, keeping a number of the old
+// files. A size, age or count of zero turns off that limit
+type Rotator struct {
+	mx       sync.Mutex
+	path     string
+	maxSize  int64
+	maxAge   time.Duration
+	maxFiles int
+	file     *os.File
+	size     int64
+	opened   time.Time
+}
+
+// NewRotator opens the log file at the path, rotating away what is already
+// there so each run starts a new file
+func NewRotator(path string, maxSize int64, maxAge time.Duration,
+	maxFiles int) (r *Rotator, err error) {
+	r = &Rotator{
+		path:     path,
+		maxSize:  maxSize,
+		maxAge:   maxAge,
+		maxFiles: maxFiles,
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	if err = r.rotate(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Write writes to the log file, rotating it first if the write would take it
+// past the size limit or the file is past the age limit
+func (r *Rotator) Write(p []byte) (n int, err error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+	if (r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize) ||
+		(r.maxAge > 0 && time.Since(r.opened) > r.maxAge) {
+		if err = r.rotate(); err != nil {
+			return
+		}
+	}
+	n, err = r.file.Write(p)
+	r.size += int64(n)
+	return
+}
+
+// Close closes the log file, later writes return an error
+func (r *Rotator) Close() (err error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	if r.file == nil {
+		return
+	}
+	err = r.file.Close()
+	r.file = nil
+	return
+}
+
+// rotate closes the current file and moves it aside, if there is one, opens
+// a new one and removes the oldest rotated files past the limit
+func (r *Rotator) rotate() (err error) {
+	if r.file != nil {
+		if err = r.file.Close(); err != nil {
+			return
+		}
+		r.file = nil
+	}
+	if FileExists(r.path) {
+		if err = os.Rename(r.path, r.rotatedName(time.Now())); err != nil {
+			return
+		}
+	}
+	if r.file, err = os.OpenFile(r.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC,
+		0600); err != nil {
+		return
+	}
+	r.size = 0
+	r.opened = time.Now()
+	r.prune()
+	return
+}
+
+// rotatedName returns the name a file rotated at the time is moved to
+func (r *Rotator) rotatedName(t time.Time) string {
+	ext := filepath.Ext(r.path)
+	return strings.TrimSuffix(r.path, ext) + "-" +
+		t.Format(rotatedTimeFormat) + ext
+}
+
+// rotated returns the rotated files, oldest first
+func (r *Rotator) rotated() (files []string) {
+	ext := filepath.Ext(r.path)
+	files, _ = filepath.Glob(strings.TrimSuffix(r.path, ext) + "-[0-9]*" +
+		ext)
+	sort.Strings(files)
+	return
+}
+
+// prune removes the oldest rotated files until there are no more than the
+// limit
+func (r *Rotator) prune() {
+	if r.maxFiles <= 0 {
+		return
+	}
+	files := r.rotated()
+	for len(files) > r.maxFiles {
+		if err := os.Remove(files[0]); err != nil {
+			wr.Println("error removing rotated log", err)
+		}
+		files = files[1:]
+	}
+}
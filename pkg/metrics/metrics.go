@@ -0,0 +1,181 @@
+// Package metrics serves statistics of the running node over HTTP in the
+// Prometheus text exposition format, so the node can be scraped by
+// Prometheus or anything else that reads the format. The statistics are
+// gathered from the node each time they are requested.
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/p9c/pod/cmd/node/rpc"
+	"github.com/p9c/pod/pkg/conte"
+	"github.com/p9c/pod/pkg/log"
+)
+
+const (
+	// Path is where the metrics are served
+	Path = "/metrics"
+	// AlgoWindow is the number of recent blocks the block counts and hash
+	// rates of each algorithm are calculated over
+	AlgoWindow = 120
+	// ContentType is the content type of the text exposition format
+	ContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// errShutdown is returned when the node shuts down while the metrics are
+// gathered
+var errShutdown = errors.New("node is shutting down")
+
+// Server serves the metrics of the node
+type Server struct {
+	node *rpc.Node
+}
+
+// Run starts serving the metrics on the configured listener, it stops when
+// the returned cancel function is called
+func Run(cx *conte.Xt) (cancel context.CancelFunc) {
+	if cx.RealNode == nil {
+		log.WARN("node is not running, not starting metrics server")
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	l, err := net.Listen("tcp", cx.StateCfg.MetricsListener)
+	if err != nil {
+		log.ERROR(err)
+		cancel()
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle(Path, &Server{node: cx.RealNode})
+	srv := &http.Server{Handler: mux}
+	log.INFO("metrics server listening on", l.Addr())
+	go func() {
+		<-ctx.Done()
+		if err := srv.Close(); err != nil {
+			log.ERROR(err)
+		}
+	}()
+	go func() {
+		if err := srv.Serve(l); err != http.ErrServerClosed {
+			log.ERROR(err)
+		}
+	}()
+	return
+}
+
+// ServeHTTP writes the current metrics of the node
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", ContentType)
+	bw := bufio.NewWriter(w)
+	if err := s.write(bw); err != nil {
+		log.ERROR(err)
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if err := bw.Flush(); err != nil {
+		log.DEBUG(err)
+	}
+}
+
+// write gathers the metrics of the node and writes them in the text
+// exposition format
+func (s *Server) write(out *bufio.Writer) error {
+	n := s.node
+	w := &writer{w: out}
+	// Asking the peer handler for the peers waits for it, so give up if the
+	// node stops in the meantime.
+	replyChan := make(chan []*rpc.NodePeer, 1)
+	select {
+	case n.Query <- rpc.GetPeersMsg{Reply: replyChan}:
+	case <-n.Quit:
+		return errShutdown
+	}
+	var inbound, outbound int
+	for _, sp := range <-replyChan {
+		if sp.Inbound() {
+			inbound++
+		} else {
+			outbound++
+		}
+	}
+	w.family("pod_peers", "gauge", "Connected peers by direction.")
+	w.sample("pod_peers", float64(inbound), "direction", "inbound")
+	w.sample("pod_peers", float64(outbound), "direction", "outbound")
+	txDescs := n.TxMemPool.TxDescs()
+	var mempoolBytes int
+	for _, txD := range txDescs {
+		mempoolBytes += txD.Tx.MsgTx().SerializeSize()
+	}
+	w.family("pod_mempool_transactions", "gauge",
+		"Transactions in the mempool.")
+	w.sample("pod_mempool_transactions", float64(len(txDescs)))
+	w.family("pod_mempool_bytes", "gauge",
+		"Serialized size of the transactions in the mempool.")
+	w.sample("pod_mempool_bytes", float64(mempoolBytes))
+	best := n.Chain.BestSnapshot()
+	w.family("pod_block_height", "gauge", "Height of the best block.")
+	w.sample("pod_block_height", float64(best.Height))
+	blocks, elapsed := n.Chain.ValidationStats()
+	w.family("pod_block_validation_seconds", "summary",
+		"Time spent validating and connecting accepted blocks.")
+	w.sample("pod_block_validation_seconds_sum", elapsed.Seconds())
+	w.sample("pod_block_validation_seconds_count", float64(blocks))
+	if window := n.Chain.RecentAlgoStats(AlgoWindow); window != nil {
+		w.family("pod_algo_blocks", "gauge",
+			"Blocks found with each algorithm in the recent window.")
+		for _, algo := range window.Algos {
+			w.sample("pod_algo_blocks", float64(algo.Blocks), "algo",
+				algo.Name)
+		}
+		w.family("pod_algo_hashes_per_second", "gauge",
+			"Estimated network hash rate of each algorithm over the recent"+
+				" window.")
+		for _, algo := range window.Algos {
+			w.sample("pod_algo_hashes_per_second", float64(algo.HashesPerSec),
+				"algo", algo.Name)
+		}
+	}
+	// The totals of addresses that stop reporting expire, so these can go
+	// down, which Prometheus treats as a counter reset.
+	w.family("pod_controller_solutions_total", "counter",
+		"Solutions sent by kopach workers by algorithm and what became of"+
+			" them.")
+	for _, algo := range n.WorkerStats.Result().Algos {
+		for _, result := range []struct {
+			name  string
+			count uint64
+		}{
+			{"found", algo.Found},
+			{"accepted", algo.Accepted},
+			{"stale", algo.Stale},
+			{"rejected", algo.Rejected},
+		} {
+			w.sample("pod_controller_solutions_total", float64(result.count),
+				"algo", algo.Algo, "result", result.name)
+		}
+	}
+	hits, misses := n.DB.CacheStats()
+	w.family("pod_db_cache_hits_total", "counter",
+		"Database lookups answered by the database cache.")
+	w.sample("pod_db_cache_hits_total", float64(hits))
+	w.family("pod_db_cache_misses_total", "counter",
+		"Database lookups which consulted the underlying storage.")
+	w.sample("pod_db_cache_misses_total", float64(misses))
+	var hitRatio float64
+	if hits+misses > 0 {
+		hitRatio = float64(hits) / float64(hits+misses)
+	}
+	w.family("pod_db_cache_hit_ratio", "gauge",
+		"Share of database lookups answered by the database cache.")
+	w.sample("pod_db_cache_hit_ratio", hitRatio)
+	return w.err
+}
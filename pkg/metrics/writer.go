@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// labelEscaper escapes label values as the text exposition format requires
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// helpEscaper escapes help text as the text exposition format requires
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+// writer writes metrics in the text exposition format, the first error stops
+// it and is kept in err
+type writer struct {
+	w   io.Writer
+	err error
+}
+
+// family writes the lines describing a metric, which come before its samples
+func (w *writer) family(name, typ, help string) {
+	w.write("# HELP " + name + " " + helpEscaper.Replace(help) + "\n" +
+		"# TYPE " + name + " " + typ + "\n")
+}
+
+// sample writes a value of a metric, the labels are given as pairs of names
+// and values
+func (w *writer) sample(name string, value float64, labels ...string) {
+	var b strings.Builder
+	b.WriteString(name)
+	if len(labels) > 1 {
+		b.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(labels[i])
+			b.WriteString(`="`)
+			b.WriteString(labelEscaper.Replace(labels[i+1]))
+			b.WriteByte('"')
+		}
+		b.WriteByte('}')
+	}
+	b.WriteByte(' ')
+	b.WriteString(formatValue(value))
+	b.WriteByte('\n')
+	w.write(b.String())
+}
+
+func (w *writer) write(s string) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = io.WriteString(w.w, s)
+}
+
+// formatValue formats a sample value, using the spellings of the format for
+// the values that are not numbers
+func formatValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
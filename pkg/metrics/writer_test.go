@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &writer{w: &buf}
+	w.family("pod_peers", "gauge", "Connected peers\nby direction.")
+	w.sample("pod_peers", 3, "direction", "inbound")
+	w.sample("pod_solutions", 1, "algo", `sc"ry\pt`, "result", "found")
+	w.sample("pod_ratio", 0.25)
+	w.sample("pod_nan", math.NaN())
+	w.sample("pod_inf", math.Inf(1))
+	w.sample("pod_big", 12345678901)
+	want := `# HELP pod_peers Connected peers\nby direction.
+# TYPE pod_peers gauge
+pod_peers{direction="inbound"} 3
+pod_solutions{algo="sc\"ry\\pt",result="found"} 1
+pod_ratio 0.25
+pod_nan NaN
+pod_inf +Inf
+pod_big 1.2345678901e+10
+`
+	if w.err != nil {
+		t.Fatal(w.err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+type failWriter struct{ n int }
+
+func (f *failWriter) Write(p []byte) (int, error) {
+	f.n++
+	return 0, errors.New("write failed")
+}
+
+func TestWriterStopsAtError(t *testing.T) {
+	f := &failWriter{}
+	w := &writer{w: f}
+	w.family("pod_peers", "gauge", "Connected peers.")
+	w.sample("pod_peers", 1)
+	if w.err == nil || f.n != 1 {
+		t.Errorf("got error %v after %d writes, want an error after 1", w.err,
+			f.n)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -64,6 +65,13 @@ type localAddress struct {
 	score AddressPriority
 }
 
+// LocalAddress is a known local address along with the priority it is
+// advertised with.
+type LocalAddress struct {
+	NetAddress *wire.NetAddress
+	Score      AddressPriority
+}
+
 // AddressPriority type is used to describe the hierarchy of local address
 // routeable methods.
 type AddressPriority int
@@ -1002,6 +1010,21 @@ func // GetBestLocalAddress returns the most appropriate local address to use
 	return bestAddress
 }
 
+func // LocalAddresses returns all of the known local addresses, ordered from
+// the highest advertising priority down.
+(a *AddrManager) LocalAddresses() []LocalAddress {
+	a.lamtx.Lock()
+	defer a.lamtx.Unlock()
+	addrs := make([]LocalAddress, 0, len(a.localAddresses))
+	for _, la := range a.localAddresses {
+		addrs = append(addrs, LocalAddress{NetAddress: la.na, Score: la.score})
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].Score > addrs[j].Score
+	})
+	return addrs
+}
+
 func // New returns a new bitcoin address manager. Use Start to begin processing
 // asynchronous address updates.
 New(dataDir string, lookupFunc func(string) ([]net.IP, error)) *AddrManager {
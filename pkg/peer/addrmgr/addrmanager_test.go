@@ -185,6 +185,36 @@ func TestAddLocalAddress(t *testing.T) {
 		}
 	}
 }
+func TestLocalAddresses(t *testing.T) {
+	amgr := addrmgr.New("testlocaladdresses", nil)
+	if addrs := amgr.LocalAddresses(); len(addrs) != 0 {
+		t.Fatalf("TestLocalAddresses: expected no local addresses, got %d",
+			len(addrs))
+	}
+	low := wire.NetAddress{IP: net.ParseIP("204.124.1.1")}
+	high := wire.NetAddress{IP: net.ParseIP("2620:100::1")}
+	if err := amgr.AddLocalAddress(&low, addrmgr.InterfacePrio); err != nil {
+		t.Fatalf("TestLocalAddresses: adding %s failed: %v", low.IP, err)
+	}
+	if err := amgr.AddLocalAddress(&high, addrmgr.ManualPrio); err != nil {
+		t.Fatalf("TestLocalAddresses: adding %s failed: %v", high.IP, err)
+	}
+	addrs := amgr.LocalAddresses()
+	if len(addrs) != 2 {
+		t.Fatalf("TestLocalAddresses: expected 2 local addresses, got %d",
+			len(addrs))
+	}
+	if !addrs[0].NetAddress.IP.Equal(high.IP) ||
+		addrs[0].Score != addrmgr.ManualPrio {
+		t.Errorf("TestLocalAddresses: expected %s first with priority %d, "+
+			"got %s with priority %d", high.IP, addrmgr.ManualPrio,
+			addrs[0].NetAddress.IP, addrs[0].Score)
+	}
+	if !addrs[1].NetAddress.IP.Equal(low.IP) {
+		t.Errorf("TestLocalAddresses: expected %s second, got %s", low.IP,
+			addrs[1].NetAddress.IP)
+	}
+}
 func TestAttempt(t *testing.T) {
 	n := addrmgr.New("testattempt", lookupFunc)
 	// Add a new address and get it
@@ -0,0 +1,272 @@
+package connmgr
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sort"
+	"sync"
+	"time"
+)
+
+// banListVersion is the version of the serialized ban list.
+const banListVersion = 1
+
+// ErrNotBanned is returned when a subnet which is not banned is unbanned.
+var ErrNotBanned = errors.New("subnet is not banned")
+
+// BanEntry describes the ban of a subnet, or of a host whose address is not
+// an IP address such as an onion address.
+type BanEntry struct {
+	// Subnet is the banned subnet, it is nil for the ban of a host.
+	Subnet *net.IPNet
+	// Host is the banned host when Subnet is nil.
+	Host    string
+	Created time.Time
+	Until   time.Time
+	Reason  string
+}
+
+// String returns the banned subnet or host.
+func (e BanEntry) String() string {
+	if e.Subnet == nil {
+		return e.Host
+	}
+	return e.Subnet.String()
+}
+
+// BanList keeps the banned subnets and hosts until their bans expire. Bans of
+// single addresses are kept as subnets containing only the address, hosts are
+// only banned when their address is not an IP address. The zero value is not
+// usable, use NewBanList or RestoreBanList to create one.
+type BanList struct {
+	entries map[string]*BanEntry
+	mtx     sync.Mutex
+}
+
+// NewBanList returns an empty ban list.
+func NewBanList() *BanList {
+	return &BanList{entries: make(map[string]*BanEntry)}
+}
+
+// ParseSubnet parses an address or a subnet in CIDR notation. An address is
+// returned as the subnet containing only the address.
+func ParseSubnet(s string) (*net.IPNet, error) {
+	if ip := net.ParseIP(s); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+	_, subnet, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address or subnet %q", s)
+	}
+	return subnet, nil
+}
+
+// Ban bans the subnet until the passed time, replacing any ban of the same
+// subnet. This function is safe for concurrent access.
+func (b *BanList) Ban(subnet *net.IPNet, until time.Time, reason string) {
+	b.mtx.Lock()
+	b.entries[subnet.String()] = &BanEntry{
+		Subnet:  subnet,
+		Created: time.Now(),
+		Until:   until,
+		Reason:  reason,
+	}
+	b.mtx.Unlock()
+}
+
+// BanHost bans a host whose address is not an IP address until the passed
+// time, replacing any ban of the same host. This function is safe for
+// concurrent access.
+func (b *BanList) BanHost(host string, until time.Time, reason string) {
+	b.mtx.Lock()
+	b.entries[host] = &BanEntry{
+		Host:    host,
+		Created: time.Now(),
+		Until:   until,
+		Reason:  reason,
+	}
+	b.mtx.Unlock()
+}
+
+// Unban lifts the ban of the subnet. It returns ErrNotBanned when the subnet
+// itself is not banned, even if it is part of a banned subnet. This function
+// is safe for concurrent access.
+func (b *BanList) Unban(subnet *net.IPNet) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	key := subnet.String()
+	if _, ok := b.entries[key]; !ok {
+		return ErrNotBanned
+	}
+	delete(b.entries, key)
+	return nil
+}
+
+// UnbanHost lifts the ban of a host banned with BanHost. It returns
+// ErrNotBanned when the host is not banned. This function is safe for
+// concurrent access.
+func (b *BanList) UnbanHost(host string) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if entry, ok := b.entries[host]; !ok || entry.Subnet != nil {
+		return ErrNotBanned
+	}
+	delete(b.entries, host)
+	return nil
+}
+
+// Clear lifts all bans. This function is safe for concurrent access.
+func (b *BanList) Clear() {
+	b.mtx.Lock()
+	b.entries = make(map[string]*BanEntry)
+	b.mtx.Unlock()
+}
+
+// IsBanned returns the ban of a subnet containing the address if there is
+// one which has not expired. This function is safe for concurrent access.
+func (b *BanList) IsBanned(ip net.IP) (BanEntry, bool) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.removeExpired(time.Now())
+	for _, entry := range b.entries {
+		if entry.Subnet != nil && entry.Subnet.Contains(ip) {
+			return *entry, true
+		}
+	}
+	return BanEntry{}, false
+}
+
+// IsHostBanned returns the ban of the host of a peer if there is one which has
+// not expired, which is the ban of a subnet containing it if it is an IP
+// address. This function is safe for concurrent access.
+func (b *BanList) IsHostBanned(host string) (BanEntry, bool) {
+	if ip := net.ParseIP(host); ip != nil {
+		return b.IsBanned(ip)
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.removeExpired(time.Now())
+	if entry, ok := b.entries[host]; ok && entry.Subnet == nil {
+		return *entry, true
+	}
+	return BanEntry{}, false
+}
+
+// Entries returns the bans which have not expired ordered by subnet or host.
+// This function is safe for concurrent access.
+func (b *BanList) Entries() []BanEntry {
+	b.mtx.Lock()
+	b.removeExpired(time.Now())
+	entries := make([]BanEntry, 0, len(b.entries))
+	for _, entry := range b.entries {
+		entries = append(entries, *entry)
+	}
+	b.mtx.Unlock()
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].String() < entries[j].String()
+	})
+	return entries
+}
+
+// removeExpired removes the bans which expired before the passed time. This
+// function MUST be called with the ban list lock held.
+func (b *BanList) removeExpired(now time.Time) {
+	for key, entry := range b.entries {
+		if !now.Before(entry.Until) {
+			delete(b.entries, key)
+		}
+	}
+}
+
+// Save serializes the bans which have not expired, so they can be restored
+// with RestoreBanList. This function is safe for concurrent access.
+func (b *BanList) Save() []byte {
+	entries := b.Entries()
+	var w bytes.Buffer
+	_ = binary.Write(&w, binary.BigEndian, uint32(banListVersion))
+	_ = binary.Write(&w, binary.BigEndian, uint32(len(entries)))
+	for _, entry := range entries {
+		writeBanString(&w, entry.String())
+		_ = binary.Write(&w, binary.BigEndian, entry.Created.Unix())
+		_ = binary.Write(&w, binary.BigEndian, entry.Until.Unix())
+		writeBanString(&w, entry.Reason)
+	}
+	return w.Bytes()
+}
+
+// RestoreBanList returns the ban list serialized by Save.
+func RestoreBanList(data []byte) (*BanList, error) {
+	r := bytes.NewReader(data)
+	var version, count uint32
+	if err := binary.Read(r, binary.BigEndian, &version); err != nil {
+		return nil, err
+	}
+	if version != banListVersion {
+		return nil, fmt.Errorf("unsupported ban list version %d", version)
+	}
+	if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+		return nil, err
+	}
+	b := NewBanList()
+	for i := uint32(0); i < count; i++ {
+		s, err := readBanString(r)
+		if err != nil {
+			return nil, err
+		}
+		// anything that is not a subnet is a banned host
+		subnet, _ := ParseSubnet(s)
+		var created, until int64
+		if err := binary.Read(r, binary.BigEndian, &created); err != nil {
+			return nil, err
+		}
+		if err := binary.Read(r, binary.BigEndian, &until); err != nil {
+			return nil, err
+		}
+		reason, err := readBanString(r)
+		if err != nil {
+			return nil, err
+		}
+		entry := &BanEntry{
+			Subnet:  subnet,
+			Created: time.Unix(created, 0),
+			Until:   time.Unix(until, 0),
+			Reason:  reason,
+		}
+		if subnet == nil {
+			entry.Host = s
+		}
+		b.entries[entry.String()] = entry
+	}
+	return b, nil
+}
+
+// writeBanString writes a string prefixed by its length, truncating it to
+// the longest length which can be written.
+func writeBanString(w *bytes.Buffer, s string) {
+	if len(s) > math.MaxUint16 {
+		s = s[:math.MaxUint16]
+	}
+	_ = binary.Write(w, binary.BigEndian, uint16(len(s)))
+	w.WriteString(s)
+}
+
+// readBanString reads a string written by writeBanString.
+func readBanString(r io.Reader) (string, error) {
+	var length uint16
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return "", err
+	}
+	s := make([]byte, length)
+	if _, err := io.ReadFull(r, s); err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
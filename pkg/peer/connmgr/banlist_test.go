@@ -0,0 +1,130 @@
+package connmgr
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+// TestParseSubnet tests parsing addresses and subnets to ban.
+func TestParseSubnet(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"192.168.1.10", "192.168.1.10/32"},
+		{"192.168.1.10/24", "192.168.1.0/24"},
+		{"::1", "::1/128"},
+		{"2001:db8::1/32", "2001:db8::/32"},
+	}
+	for _, test := range tests {
+		subnet, err := ParseSubnet(test.in)
+		if err != nil {
+			t.Errorf("ParseSubnet(%q): unexpected error: %v", test.in, err)
+			continue
+		}
+		if subnet.String() != test.want {
+			t.Errorf("ParseSubnet(%q) = %v, want %v", test.in, subnet,
+				test.want)
+		}
+	}
+	if _, err := ParseSubnet("192.168.1.300"); err == nil {
+		t.Errorf("ParseSubnet: invalid address was accepted")
+	}
+}
+
+// TestBanList tests banning, expiring, unbanning and restoring bans.
+func TestBanList(t *testing.T) {
+	mustParse := func(s string) *net.IPNet {
+		subnet, err := ParseSubnet(s)
+		if err != nil {
+			t.Fatalf("ParseSubnet(%q): unexpected error: %v", s, err)
+		}
+		return subnet
+	}
+	b := NewBanList()
+	b.Ban(mustParse("10.0.0.0/8"), time.Now().Add(time.Hour), "manual")
+	b.Ban(mustParse("192.168.1.10"), time.Now().Add(time.Hour), "mempool (+33)")
+	b.Ban(mustParse("172.16.0.1"), time.Now().Add(-time.Second), "expired")
+	banned := []string{"10.1.2.3", "192.168.1.10",
+		"::ffff:10.0.0.1"}
+	for _, addr := range banned {
+		if _, ok := b.IsBanned(net.ParseIP(addr)); !ok {
+			t.Errorf("IsBanned(%v): address is not banned", addr)
+		}
+	}
+	for _, addr := range []string{"11.0.0.1", "192.168.1.11", "172.16.0.1"} {
+		if _, ok := b.IsBanned(net.ParseIP(addr)); ok {
+			t.Errorf("IsBanned(%v): address is banned", addr)
+		}
+	}
+	restored, err := RestoreBanList(b.Save())
+	if err != nil {
+		t.Fatalf("RestoreBanList: unexpected error: %v", err)
+	}
+	entries := restored.Entries()
+	if len(entries) != 2 || entries[0].Subnet.String() != "10.0.0.0/8" ||
+		entries[1].Subnet.String() != "192.168.1.10/32" ||
+		entries[1].Reason != "mempool (+33)" {
+		t.Fatalf("restored bans %v, want 10.0.0.0/8 and 192.168.1.10/32",
+			entries)
+	}
+	if entries[0].Until.Unix() != b.Entries()[0].Until.Unix() {
+		t.Errorf("restored ban expires at %v, want %v", entries[0].Until,
+			b.Entries()[0].Until)
+	}
+	if err := restored.Unban(mustParse("10.1.0.0/16")); err != ErrNotBanned {
+		t.Errorf("Unban: part of a banned subnet returned %v, want %v",
+			err, ErrNotBanned)
+	}
+	if err := restored.Unban(mustParse("10.0.0.0/8")); err != nil {
+		t.Errorf("Unban: unexpected error: %v", err)
+	}
+	if _, ok := restored.IsBanned(net.ParseIP("10.1.2.3")); ok {
+		t.Errorf("IsBanned: address of an unbanned subnet is banned")
+	}
+	restored.Clear()
+	if entries := restored.Entries(); len(entries) != 0 {
+		t.Errorf("Clear: %d bans remain", len(entries))
+	}
+}
+
+// TestBanHost tests banning hosts whose address is not an IP address along
+// with subnets.
+func TestBanHost(t *testing.T) {
+	const onion = "expyuzz4wqqyqhjn.onion"
+	b := NewBanList()
+	b.BanHost(onion, time.Now().Add(time.Hour), "misbehaving")
+	subnet, err := ParseSubnet("10.0.0.0/8")
+	if err != nil {
+		t.Fatalf("ParseSubnet: unexpected error: %v", err)
+	}
+	b.Ban(subnet, time.Now().Add(time.Hour), "manual")
+	if _, ok := b.IsHostBanned(onion); !ok {
+		t.Errorf("IsHostBanned(%v): host is not banned", onion)
+	}
+	if _, ok := b.IsHostBanned("10.1.2.3"); !ok {
+		t.Errorf("IsHostBanned: address of a banned subnet is not banned")
+	}
+	if _, ok := b.IsHostBanned("other.onion"); ok {
+		t.Errorf("IsHostBanned: host that is not banned is banned")
+	}
+	restored, err := RestoreBanList(b.Save())
+	if err != nil {
+		t.Fatalf("RestoreBanList: unexpected error: %v", err)
+	}
+	entries := restored.Entries()
+	if len(entries) != 2 || entries[0].String() != "10.0.0.0/8" ||
+		entries[1].Subnet != nil || entries[1].Host != onion {
+		t.Fatalf("restored bans %v, want 10.0.0.0/8 and %v", entries, onion)
+	}
+	if err := restored.UnbanHost("10.0.0.0/8"); err != ErrNotBanned {
+		t.Errorf("UnbanHost: subnet returned %v, want %v", err, ErrNotBanned)
+	}
+	if err := restored.UnbanHost(onion); err != nil {
+		t.Errorf("UnbanHost: unexpected error: %v", err)
+	}
+	if _, ok := restored.IsHostBanned(onion); ok {
+		t.Errorf("IsHostBanned: unbanned host is banned")
+	}
+}
@@ -3,6 +3,8 @@ package connmgr
 import (
 	"fmt"
 	"math"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -55,7 +57,9 @@ type DynamicBanScore struct {
 	lastUnix   int64
 	transient  float64
 	persistent uint32
-	mtx        sync.Mutex
+	// reasons totals the increases of the score by the reason given for them.
+	reasons map[string]uint32
+	mtx     sync.Mutex
 }
 
 // String returns the ban score as a human-readable string.
@@ -86,12 +90,51 @@ func (s *DynamicBanScore) Increase(persistent, transient uint32) uint32 {
 	return r
 }
 
-// Reset set both persistent and decaying scores to zero. This function is safe for concurrent access.
+// IncreaseWithReason increases the scores like Increase and records the
+// reason for the increase, so it can be reported by Reasons. This function is
+// safe for concurrent access.
+func (s *DynamicBanScore) IncreaseWithReason(persistent, transient uint32,
+	reason string) uint32 {
+	s.mtx.Lock()
+	if s.reasons == nil {
+		s.reasons = make(map[string]uint32)
+	}
+	s.reasons[reason] += persistent + transient
+	r := s.increase(persistent, transient, time.Now())
+	s.mtx.Unlock()
+	return r
+}
+
+// Reasons returns the reasons recorded by IncreaseWithReason along with the
+// total they added to the score, largest first, as a human-readable string.
+// This function is safe for concurrent access.
+func (s *DynamicBanScore) Reasons() string {
+	s.mtx.Lock()
+	reasons := make([]string, 0, len(s.reasons))
+	for reason := range s.reasons {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if s.reasons[reasons[i]] != s.reasons[reasons[j]] {
+			return s.reasons[reasons[i]] > s.reasons[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+	for i, reason := range reasons {
+		reasons[i] = fmt.Sprintf("%s (+%d)", reason, s.reasons[reason])
+	}
+	s.mtx.Unlock()
+	return strings.Join(reasons, ", ")
+}
+
+// Reset set both persistent and decaying scores to zero and forgets the
+// reasons for them. This function is safe for concurrent access.
 func (s *DynamicBanScore) Reset() {
 	s.mtx.Lock()
 	s.persistent = 0
 	s.transient = 0
 	s.lastUnix = 0
+	s.reasons = nil
 	s.mtx.Unlock()
 }
 
@@ -55,3 +55,26 @@ func TestDynamicBanScoreReset(t *testing.T) {
 		t.Errorf("Failed to reset ban score.")
 	}
 }
+
+// TestDynamicBanScoreReasons tests that the reasons for increases are totaled
+// and reported largest first.
+func TestDynamicBanScoreReasons(t *testing.T) {
+	var bs DynamicBanScore
+	if r := bs.Reasons(); r != "" {
+		t.Errorf("Initial reasons are %q instead of none.", r)
+	}
+	bs.IncreaseWithReason(0, 20, "getdata")
+	bs.IncreaseWithReason(0, 33, "mempool")
+	r := bs.IncreaseWithReason(0, 20, "getdata")
+	if r != 73 {
+		t.Errorf("Unexpected result %d after ban score increase.", r)
+	}
+	want := "getdata (+40), mempool (+33)"
+	if r := bs.Reasons(); r != want {
+		t.Errorf("Reasons are %q instead of %q.", r, want)
+	}
+	bs.Reset()
+	if r := bs.Reasons(); r != "" {
+		t.Errorf("Failed to reset reasons, got %q.", r)
+	}
+}
@@ -29,3 +29,32 @@ func GetInterface() (lanInterface []*net.Interface) {
 	//log.SPEW(lanInterface)
 	return
 }
+
+// GetMulticastInterfaces returns the interfaces from GetInterface that are up
+// and multicast capable. If any names are given only the interfaces with
+// those names are returned.
+func GetMulticastInterfaces(names ...string) (interfaces []*net.Interface) {
+	want := make(map[string]bool, len(names))
+	for i := range names {
+		want[names[i]] = false
+	}
+	for _, ifi := range GetInterface() {
+		if ifi.Flags&net.FlagUp == 0 || ifi.Flags&net.FlagMulticast == 0 {
+			continue
+		}
+		if len(names) > 0 {
+			if _, ok := want[ifi.Name]; !ok {
+				continue
+			}
+			want[ifi.Name] = true
+		}
+		interfaces = append(interfaces, ifi)
+	}
+	for name, found := range want {
+		if !found {
+			log.WARN("interface", name,
+				"does not exist or is not up and multicast capable")
+		}
+	}
+	return
+}
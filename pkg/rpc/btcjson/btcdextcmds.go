@@ -84,6 +84,14 @@ func NewGetHeadersCmd(blockLocators []string, hashStop string) *GetHeadersCmd {
 	}
 }
 
+// GetWorkerStatsCmd defines the getworkerstats JSON-RPC command. This command is not a standard Bitcoin command. It is an extension for pod.
+type GetWorkerStatsCmd struct{}
+
+// NewGetWorkerStatsCmd returns a new instance which can be used to issue a getworkerstats JSON-RPC command.
+func NewGetWorkerStatsCmd() *GetWorkerStatsCmd {
+	return &GetWorkerStatsCmd{}
+}
+
 // VersionCmd defines the version JSON-RPC command. NOTE: This is a btcsuite extension ported from github.com/decred/dcrd/dcrjson.
 type VersionCmd struct{}
 
@@ -101,5 +109,6 @@ func init() {
 	MustRegisterCmd("getbestblock", (*GetBestBlockCmd)(nil), flags)
 	MustRegisterCmd("getcurrentnet", (*GetCurrentNetCmd)(nil), flags)
 	MustRegisterCmd("getheaders", (*GetHeadersCmd)(nil), flags)
+	MustRegisterCmd("getworkerstats", (*GetWorkerStatsCmd)(nil), flags)
 	MustRegisterCmd("version", (*VersionCmd)(nil), flags)
 }
@@ -167,6 +167,17 @@ func TestPodExtCmds(t *testing.T) {
 				HashStop: "000000000000000000ba33b33e1fad70b69e234fc24414dd47113bff38f523f7",
 			},
 		},
+		{
+			name: "getworkerstats",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getworkerstats")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetWorkerStatsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getworkerstats","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetWorkerStatsCmd{},
+		},
 		{
 			name: "version",
 			newCmd: func() (interface{}, error) {
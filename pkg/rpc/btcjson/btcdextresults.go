@@ -9,3 +9,31 @@ type VersionResult struct {
 	Prerelease    string `json:"prerelease"`
 	BuildMetadata string `json:"buildmetadata"`
 }
+
+// GetWorkerStatsResult models the data from the getworkerstats command, the
+// statistics of the kopach workers mining for the node by the address they
+// are at and by algorithm. NOTE: This is a pod extension.
+type GetWorkerStatsResult struct {
+	Sources []WorkerSourceResult `json:"sources"`
+	Algos   []WorkerAlgoResult   `json:"algos"`
+}
+
+// WorkerSourceResult models the statistics of the workers at one address.
+type WorkerSourceResult struct {
+	Address      string             `json:"address"`
+	Workers      int                `json:"workers"`
+	LastSeen     int64              `json:"lastseen"`
+	HashesPerSec float64            `json:"hashespersec"`
+	Algos        []WorkerAlgoResult `json:"algos"`
+}
+
+// WorkerAlgoResult models the statistics of workers for one algorithm.
+type WorkerAlgoResult struct {
+	Algo         string  `json:"algo"`
+	HashesPerSec float64 `json:"hashespersec"`
+	Hashes       uint64  `json:"hashes"`
+	Found        uint64  `json:"found"`
+	Accepted     uint64  `json:"accepted"`
+	Stale        uint64  `json:"stale"`
+	Rejected     uint64  `json:"rejected"`
+}
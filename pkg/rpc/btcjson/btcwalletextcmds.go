@@ -66,6 +66,24 @@ func NewImportWalletCmd(filename string) *ImportWalletCmd {
 	}
 }
 
+// ImportXpubCmd defines the importxpub JSON-RPC command.
+type ImportXpubCmd struct {
+	Account  string
+	Xpub     string
+	Rescan   *bool   `jsonrpcdefault:"true"`
+	GapLimit *uint32 `jsonrpcdefault:"20"`
+}
+
+// NewImportXpubCmd returns a new instance which can be used to issue an importxpub JSON-RPC command.
+func NewImportXpubCmd(account, xpub string, rescan *bool, gapLimit *uint32) *ImportXpubCmd {
+	return &ImportXpubCmd{
+		Account:  account,
+		Xpub:     xpub,
+		Rescan:   rescan,
+		GapLimit: gapLimit,
+	}
+}
+
 // RenameAccountCmd defines the renameaccount JSON-RPC command.
 type RenameAccountCmd struct {
 	OldAccount string
@@ -87,5 +105,6 @@ func init() {
 	MustRegisterCmd("importaddress", (*ImportAddressCmd)(nil), flags)
 	MustRegisterCmd("importpubkey", (*ImportPubKeyCmd)(nil), flags)
 	MustRegisterCmd("importwallet", (*ImportWalletCmd)(nil), flags)
+	MustRegisterCmd("importxpub", (*ImportXpubCmd)(nil), flags)
 	MustRegisterCmd("renameaccount", (*RenameAccountCmd)(nil), flags)
 }
@@ -117,6 +117,38 @@ func TestBtcWalletExtCmds(t *testing.T) {
 				Filename: "filename",
 			},
 		},
+		{
+			name: "importxpub",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("importxpub", "cold", "xpub")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewImportXpubCmd("cold", "xpub", nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"importxpub","netparams":["cold","xpub"],"id":1}`,
+			unmarshalled: &btcjson.ImportXpubCmd{
+				Account:  "cold",
+				Xpub:     "xpub",
+				Rescan:   btcjson.Bool(true),
+				GapLimit: btcjson.Uint32(20),
+			},
+		},
+		{
+			name: "importxpub optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("importxpub", "cold", "xpub", true, 100)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewImportXpubCmd("cold", "xpub", btcjson.Bool(true), btcjson.Uint32(100))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"importxpub","netparams":["cold","xpub",true,100],"id":1}`,
+			unmarshalled: &btcjson.ImportXpubCmd{
+				Account:  "cold",
+				Xpub:     "xpub",
+				Rescan:   btcjson.Bool(true),
+				GapLimit: btcjson.Uint32(100),
+			},
+		},
 		{
 			name: "renameaccount",
 			newCmd: func() (interface{}, error) {
@@ -41,6 +41,27 @@ type GetAddedNodeInfoResultAddr struct {
 	Connected string `json:"connected"`
 }
 
+// AlgoStatsResult models the statistics of one proof of work algorithm
+// returned by the getalgostats, getmininginfo and getblockchaininfo commands.
+type AlgoStatsResult struct {
+	Algo           string  `json:"algo"`
+	Version        int32   `json:"version"`
+	Bits           string  `json:"bits"`
+	Difficulty     float64 `json:"difficulty"`
+	NetworkHashPS  int64   `json:"networkhashps"`
+	Blocks         int32   `json:"blocks"`
+	AvgInterval    float64 `json:"avginterval"`
+	TargetInterval int64   `json:"targetinterval"`
+}
+
+// GetAlgoStatsResult models the data returned from the getalgostats command.
+type GetAlgoStatsResult struct {
+	Height   int32             `json:"height"`
+	Blocks   int32             `json:"blocks"`
+	Timespan int64             `json:"timespan"`
+	Algos    []AlgoStatsResult `json:"algos"`
+}
+
 // GetBlockChainInfoResult models the data returned from the getblockchaininfo command.
 type GetBlockChainInfoResult struct {
 	Chain                string                              `json:"chain"`
@@ -55,6 +76,7 @@ type GetBlockChainInfoResult struct {
 	ChainWork            string                              `json:"chainwork,omitempty"`
 	SoftForks            []*SoftForkDescription              `json:"softforks"`
 	Bip9SoftForks        map[string]*Bip9SoftForkDescription `json:"bip9_softforks"`
+	Algos                []AlgoStatsResult                   `json:"algos,omitempty"`
 }
 
 // GetBlockHeaderVerboseResult models the data from the getblockheader command when the verbose flag is set.  When the verbose flag is not set, getblockheader returns a hex-encoded string.
@@ -148,6 +170,14 @@ type GetBlockVerboseResult struct {
 	NextHash      string        `json:"nextblockhash,omitempty"`
 }
 
+// GetChainTipsResult models the data returned from the getchaintips command.
+type GetChainTipsResult struct {
+	Height    int32  `json:"height"`
+	Hash      string `json:"hash"`
+	BranchLen int32  `json:"branchlen"`
+	Status    string `json:"status"`
+}
+
 // GetMempoolEntryResult models the data returned from the getmempoolentry command.
 type GetMempoolEntryResult struct {
 	Size             int32    `json:"size"`
@@ -174,50 +204,52 @@ type GetMempoolInfoResult struct {
 
 // GetMiningInfoResult models the data from the getmininginfo command.
 type GetMiningInfoResult struct {
-	Blocks              int64   `json:"blocks"`
-	CurrentBlockSize    uint64  `json:"currentblocksize"`
-	CurrentBlockWeight  uint64  `json:"currentblockweight"`
-	CurrentBlockTx      uint64  `json:"currentblocktx"`
-	PowAlgoID           uint32  `json:"pow_algo_id"`
-	PowAlgo             string  `json:"pow_algo"`
-	Difficulty          float64 `json:"difficulty"`
-	DifficultyBlake2b   float64 `json:"difficulty_blake2b"`
-	DifficultyBlake14lr float64 `json:"difficulty_blake14lr"`
-	DifficultyBlake2s   float64 `json:"difficulty_blake2s"`
-	DifficultyKeccak    float64 `json:"difficulty_keccak"`
-	DifficultyScrypt    float64 `json:"difficulty_scrypt"`
-	DifficultySHA256D   float64 `json:"difficulty_sha256d"`
-	DifficultySkein     float64 `json:"difficulty_skein"`
-	DifficultyStribog   float64 `json:"difficulty_stribog"`
-	DifficultyX11       float64 `json:"difficulty_x11"`
-	Errors              string  `json:"errors"`
-	Generate            bool    `json:"generate"`
-	GenAlgo             string  `json:"genalgo"`
-	GenProcLimit        int32   `json:"genproclimit"`
-	HashesPerSec        int64   `json:"hashespersec"`
-	NetworkHashPS       int64   `json:"networkhashps"`
-	PooledTx            uint64  `json:"pooledtx"`
-	TestNet             bool    `json:"testnet"`
+	Blocks              int64             `json:"blocks"`
+	CurrentBlockSize    uint64            `json:"currentblocksize"`
+	CurrentBlockWeight  uint64            `json:"currentblockweight"`
+	CurrentBlockTx      uint64            `json:"currentblocktx"`
+	PowAlgoID           uint32            `json:"pow_algo_id"`
+	PowAlgo             string            `json:"pow_algo"`
+	Difficulty          float64           `json:"difficulty"`
+	DifficultyBlake2b   float64           `json:"difficulty_blake2b"`
+	DifficultyBlake14lr float64           `json:"difficulty_blake14lr"`
+	DifficultyBlake2s   float64           `json:"difficulty_blake2s"`
+	DifficultyKeccak    float64           `json:"difficulty_keccak"`
+	DifficultyScrypt    float64           `json:"difficulty_scrypt"`
+	DifficultySHA256D   float64           `json:"difficulty_sha256d"`
+	DifficultySkein     float64           `json:"difficulty_skein"`
+	DifficultyStribog   float64           `json:"difficulty_stribog"`
+	DifficultyX11       float64           `json:"difficulty_x11"`
+	Errors              string            `json:"errors"`
+	Generate            bool              `json:"generate"`
+	GenAlgo             string            `json:"genalgo"`
+	GenProcLimit        int32             `json:"genproclimit"`
+	HashesPerSec        int64             `json:"hashespersec"`
+	NetworkHashPS       int64             `json:"networkhashps"`
+	PooledTx            uint64            `json:"pooledtx"`
+	TestNet             bool              `json:"testnet"`
+	Algos               []AlgoStatsResult `json:"algos,omitempty"`
 }
 
 // GetMiningInfoResult0 is the pre-hardfork mining info response
 type GetMiningInfoResult0 struct {
-	Blocks             int64   `json:"blocks"`
-	CurrentBlockSize   uint64  `json:"currentblocksize"`
-	CurrentBlockWeight uint64  `json:"currentblockweight"`
-	CurrentBlockTx     uint64  `json:"currentblocktx"`
-	PowAlgoID          uint32  `json:"pow_algo_id"`
-	PowAlgo            string  `json:"pow_algo"`
-	Difficulty         float64 `json:"difficulty"`
-	DifficultySHA256D  float64 `json:"difficulty_sha256d"`
-	DifficultyScrypt   float64 `json:"difficulty_scrypt"`
-	Errors             string  `json:"errors"`
-	Generate           bool    `json:"generate"`
-	GenProcLimit       int32   `json:"genproclimit"`
-	HashesPerSec       int64   `json:"hashespersec"`
-	NetworkHashPS      int64   `json:"networkhashps"`
-	PooledTx           uint64  `json:"pooledtx"`
-	TestNet            bool    `json:"testnet"`
+	Blocks             int64             `json:"blocks"`
+	CurrentBlockSize   uint64            `json:"currentblocksize"`
+	CurrentBlockWeight uint64            `json:"currentblockweight"`
+	CurrentBlockTx     uint64            `json:"currentblocktx"`
+	PowAlgoID          uint32            `json:"pow_algo_id"`
+	PowAlgo            string            `json:"pow_algo"`
+	Difficulty         float64           `json:"difficulty"`
+	DifficultySHA256D  float64           `json:"difficulty_sha256d"`
+	DifficultyScrypt   float64           `json:"difficulty_scrypt"`
+	Errors             string            `json:"errors"`
+	Generate           bool              `json:"generate"`
+	GenProcLimit       int32             `json:"genproclimit"`
+	HashesPerSec       int64             `json:"hashespersec"`
+	NetworkHashPS      int64             `json:"networkhashps"`
+	PooledTx           uint64            `json:"pooledtx"`
+	TestNet            bool              `json:"testnet"`
+	Algos              []AlgoStatsResult `json:"algos,omitempty"`
 }
 
 // GetNetTotalsResult models the data returned from the getnettotals command.
@@ -290,6 +322,42 @@ type GetTxOutResult struct {
 	Coinbase      bool               `json:"coinbase"`
 }
 
+// GetTxOutSetInfoResult models the data from the gettxoutsetinfo command.
+type GetTxOutSetInfoResult struct {
+	Height          int64              `json:"height"`
+	BestBlock       string             `json:"bestblock"`
+	Transactions    int64              `json:"transactions"`
+	TxOuts          int64              `json:"txouts"`
+	HashSerialized  string             `json:"hash_serialized"`
+	TotalAmount     float64            `json:"total_amount"`
+	CoinbaseAmounts map[string]float64 `json:"coinbase_amounts"`
+}
+
+// DumpTxOutSetResult models the data from the dumptxoutset command.
+type DumpTxOutSetResult struct {
+	CoinsWritten int64  `json:"coins_written"`
+	BaseHash     string `json:"base_hash"`
+	BaseHeight   int32  `json:"base_height"`
+	Path         string `json:"path"`
+	TxOutSetHash string `json:"txoutset_hash"`
+}
+
+// LoadTxOutSetResult models the data from the loadtxoutset command.
+type LoadTxOutSetResult struct {
+	CoinsLoaded int64  `json:"coins_loaded"`
+	BaseHash    string `json:"base_hash"`
+	BaseHeight  int32  `json:"base_height"`
+	Path        string `json:"path"`
+}
+
+// ListBannedResult models a ban returned by the listbanned command.
+type ListBannedResult struct {
+	Address     string `json:"address"`
+	BanCreated  int64  `json:"ban_created"`
+	BannedUntil int64  `json:"banned_until"`
+	BanReason   string `json:"ban_reason"`
+}
+
 // GetWorkResult models the data from the getwork command.
 type GetWorkResult struct {
 	Data     string `json:"data"`
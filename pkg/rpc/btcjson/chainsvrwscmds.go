@@ -30,6 +30,22 @@ func NewStopNotifyBlocksCmd() *StopNotifyBlocksCmd {
 	return &StopNotifyBlocksCmd{}
 }
 
+// NotifyWorkerStatsCmd defines the notifyworkerstats JSON-RPC command. NOTE: This is a pod extension.
+type NotifyWorkerStatsCmd struct{}
+
+// NewNotifyWorkerStatsCmd returns a new instance which can be used to issue a notifyworkerstats JSON-RPC command.
+func NewNotifyWorkerStatsCmd() *NotifyWorkerStatsCmd {
+	return &NotifyWorkerStatsCmd{}
+}
+
+// StopNotifyWorkerStatsCmd defines the stopnotifyworkerstats JSON-RPC command. NOTE: This is a pod extension.
+type StopNotifyWorkerStatsCmd struct{}
+
+// NewStopNotifyWorkerStatsCmd returns a new instance which can be used to issue a stopnotifyworkerstats JSON-RPC command.
+func NewStopNotifyWorkerStatsCmd() *StopNotifyWorkerStatsCmd {
+	return &StopNotifyWorkerStatsCmd{}
+}
+
 // NotifyNewTransactionsCmd defines the notifynewtransactions JSON-RPC command.
 type NotifyNewTransactionsCmd struct {
 	Verbose *bool `jsonrpcdefault:"false"`
@@ -165,11 +181,13 @@ func init() {
 	MustRegisterCmd("notifynewtransactions", (*NotifyNewTransactionsCmd)(nil), flags)
 	MustRegisterCmd("notifyreceived", (*NotifyReceivedCmd)(nil), flags)
 	MustRegisterCmd("notifyspent", (*NotifySpentCmd)(nil), flags)
+	MustRegisterCmd("notifyworkerstats", (*NotifyWorkerStatsCmd)(nil), flags)
 	MustRegisterCmd("session", (*SessionCmd)(nil), flags)
 	MustRegisterCmd("stopnotifyblocks", (*StopNotifyBlocksCmd)(nil), flags)
 	MustRegisterCmd("stopnotifynewtransactions", (*StopNotifyNewTransactionsCmd)(nil), flags)
 	MustRegisterCmd("stopnotifyspent", (*StopNotifySpentCmd)(nil), flags)
 	MustRegisterCmd("stopnotifyreceived", (*StopNotifyReceivedCmd)(nil), flags)
+	MustRegisterCmd("stopnotifyworkerstats", (*StopNotifyWorkerStatsCmd)(nil), flags)
 	MustRegisterCmd("rescan", (*RescanCmd)(nil), flags)
 	MustRegisterCmd("rescanblocks", (*RescanBlocksCmd)(nil), flags)
 }
@@ -54,6 +54,28 @@ func TestChainSvrWsCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifyblocks","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.StopNotifyBlocksCmd{},
 		},
+		{
+			name: "notifyworkerstats",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("notifyworkerstats")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewNotifyWorkerStatsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"notifyworkerstats","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.NotifyWorkerStatsCmd{},
+		},
+		{
+			name: "stopnotifyworkerstats",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("stopnotifyworkerstats")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewStopNotifyWorkerStatsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifyworkerstats","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.StopNotifyWorkerStatsCmd{},
+		},
 		{
 			name: "notifynewtransactions",
 			newCmd: func() (interface{}, error) {
@@ -23,6 +23,8 @@ const (
 	TxAcceptedVerboseNtfnMethod = "txacceptedverbose"
 	// RelevantTxAcceptedNtfnMethod is the new method used for notifications from the chain server that inform a client that a transaction that matches the loaded filter was accepted by the mempool.
 	RelevantTxAcceptedNtfnMethod = "relevanttxaccepted"
+	// WorkerStatsNtfnMethod is the method used for notifications from the chain server of the statistics of the kopach workers mining for it. NOTE: This is a pod extension.
+	WorkerStatsNtfnMethod = "workerstats"
 )
 
 // BlockConnectedNtfn defines the blockconnected JSON-RPC notification. NOTE: Deprecated. Use FilteredBlockConnectedNtfn instead.
@@ -190,6 +192,16 @@ type RelevantTxAcceptedNtfn struct {
 func NewRelevantTxAcceptedNtfn(txHex string) *RelevantTxAcceptedNtfn {
 	return &RelevantTxAcceptedNtfn{Transaction: txHex}
 }
+
+// WorkerStatsNtfn defines the workerstats JSON-RPC notification.
+type WorkerStatsNtfn struct {
+	Stats GetWorkerStatsResult
+}
+
+// NewWorkerStatsNtfn returns a new instance which can be used to issue a workerstats JSON-RPC notification.
+func NewWorkerStatsNtfn(stats GetWorkerStatsResult) *WorkerStatsNtfn {
+	return &WorkerStatsNtfn{Stats: stats}
+}
 func init() {
 	// The commands in this file are only usable by websockets and are notifications.
 	flags := UFWebsocketOnly | UFNotification
@@ -204,4 +216,5 @@ func init() {
 	MustRegisterCmd(TxAcceptedNtfnMethod, (*TxAcceptedNtfn)(nil), flags)
 	MustRegisterCmd(TxAcceptedVerboseNtfnMethod, (*TxAcceptedVerboseNtfn)(nil), flags)
 	MustRegisterCmd(RelevantTxAcceptedNtfnMethod, (*RelevantTxAcceptedNtfn)(nil), flags)
+	MustRegisterCmd(WorkerStatsNtfnMethod, (*WorkerStatsNtfn)(nil), flags)
 }
@@ -216,6 +216,49 @@ func TestChainSvrWsNtfns(t *testing.T) {
 				Transaction: "001122",
 			},
 		},
+		{
+			name: "workerstats",
+			newNtfn: func() (interface{}, error) {
+				return btcjson.NewCmd("workerstats", `{"sources":[{"address":"10.0.0.2","workers":2,"lastseen":123456789,"hashespersec":100,"algos":[{"algo":"sha256d","hashespersec":100,"hashes":500,"found":1,"accepted":1,"stale":0,"rejected":0}]}],"algos":[]}`)
+			},
+			staticNtfn: func() interface{} {
+				return btcjson.NewWorkerStatsNtfn(btcjson.GetWorkerStatsResult{
+					Sources: []btcjson.WorkerSourceResult{{
+						Address:      "10.0.0.2",
+						Workers:      2,
+						LastSeen:     123456789,
+						HashesPerSec: 100,
+						Algos: []btcjson.WorkerAlgoResult{{
+							Algo:         "sha256d",
+							HashesPerSec: 100,
+							Hashes:       500,
+							Found:        1,
+							Accepted:     1,
+						}},
+					}},
+					Algos: []btcjson.WorkerAlgoResult{},
+				})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"workerstats","netparams":[{"sources":[{"address":"10.0.0.2","workers":2,"lastseen":123456789,"hashespersec":100,"algos":[{"algo":"sha256d","hashespersec":100,"hashes":500,"found":1,"accepted":1,"stale":0,"rejected":0}]}],"algos":[]}],"id":null}`,
+			unmarshalled: &btcjson.WorkerStatsNtfn{
+				Stats: btcjson.GetWorkerStatsResult{
+					Sources: []btcjson.WorkerSourceResult{{
+						Address:      "10.0.0.2",
+						Workers:      2,
+						LastSeen:     123456789,
+						HashesPerSec: 100,
+						Algos: []btcjson.WorkerAlgoResult{{
+							Algo:         "sha256d",
+							HashesPerSec: 100,
+							Hashes:       500,
+							Found:        1,
+							Accepted:     1,
+						}},
+					}},
+					Algos: []btcjson.WorkerAlgoResult{},
+				},
+			},
+		},
 	}
 	t.Logf("Running %d tests", len(tests))
 	for i, test := range tests {
@@ -28,6 +28,50 @@ func NewAddWitnessAddressCmd(address string) *AddWitnessAddressCmd {
 	}
 }
 
+// BackupWalletCmd defines the backupwallet JSON-RPC command.
+type BackupWalletCmd struct {
+	Destination string
+}
+
+// NewBackupWalletCmd returns a new instance which can be used to issue a backupwallet JSON-RPC command.
+func NewBackupWalletCmd(destination string) *BackupWalletCmd {
+	return &BackupWalletCmd{
+		Destination: destination,
+	}
+}
+
+// BumpFeeOpts are the options of the bumpfee JSON-RPC command.
+type BumpFeeOpts struct {
+	FeeRate *float64 `json:"feeRate,omitempty"` // In DUO/kB
+	CPFP    *bool    `json:"cpfp,omitempty"`
+}
+
+// BumpFeeCmd defines the bumpfee JSON-RPC command.
+type BumpFeeCmd struct {
+	TxID    string
+	Options *BumpFeeOpts
+}
+
+// NewBumpFeeCmd returns a new instance which can be used to issue a bumpfee JSON-RPC command. The parameters which are pointers indicate they are optional.  Passing nil for optional parameters will use the default value.
+func NewBumpFeeCmd(txID string, options *BumpFeeOpts) *BumpFeeCmd {
+	return &BumpFeeCmd{
+		TxID:    txID,
+		Options: options,
+	}
+}
+
+// CombinePsbtCmd defines the combinepsbt JSON-RPC command.
+type CombinePsbtCmd struct {
+	Txs []string
+}
+
+// NewCombinePsbtCmd returns a new instance which can be used to issue a combinepsbt JSON-RPC command.
+func NewCombinePsbtCmd(txs []string) *CombinePsbtCmd {
+	return &CombinePsbtCmd{
+		Txs: txs,
+	}
+}
+
 // CreateMultisigCmd defines the createmultisig JSON-RPC command.
 type CreateMultisigCmd struct {
 	NRequired int
@@ -90,6 +134,20 @@ func NewEstimatePriorityCmd(numBlocks int64) *EstimatePriorityCmd {
 	}
 }
 
+// FinalizePsbtCmd defines the finalizepsbt JSON-RPC command.
+type FinalizePsbtCmd struct {
+	Psbt    string
+	Extract *bool `jsonrpcdefault:"true"`
+}
+
+// NewFinalizePsbtCmd returns a new instance which can be used to issue a finalizepsbt JSON-RPC command. The parameters which are pointers indicate they are optional.  Passing nil for optional parameters will use the default value.
+func NewFinalizePsbtCmd(psbt string, extract *bool) *FinalizePsbtCmd {
+	return &FinalizePsbtCmd{
+		Psbt:    psbt,
+		Extract: extract,
+	}
+}
+
 // GetAccountCmd defines the getaccount JSON-RPC command.
 type GetAccountCmd struct {
 	Address string
@@ -352,6 +410,28 @@ func NewListUnspentCmd(minConf, maxConf *int, addresses *[]string) *ListUnspentC
 	}
 }
 
+// ListWalletsCmd defines the listwallets JSON-RPC command.
+type ListWalletsCmd struct{}
+
+// NewListWalletsCmd returns a new instance which can be used to issue a listwallets JSON-RPC command.
+func NewListWalletsCmd() *ListWalletsCmd {
+	return &ListWalletsCmd{}
+}
+
+// LoadWalletCmd defines the loadwallet JSON-RPC command.
+type LoadWalletCmd struct {
+	WalletName    string
+	PubPassphrase *string
+}
+
+// NewLoadWalletCmd returns a new instance which can be used to issue a loadwallet JSON-RPC command. The parameters which are pointers indicate they are optional.  Passing nil for optional parameters will use the default value.
+func NewLoadWalletCmd(walletName string, pubPassphrase *string) *LoadWalletCmd {
+	return &LoadWalletCmd{
+		WalletName:    walletName,
+		PubPassphrase: pubPassphrase,
+	}
+}
+
 // LockUnspentCmd defines the lockunspent JSON-RPC command.
 type LockUnspentCmd struct {
 	Unlock       bool
@@ -408,21 +488,35 @@ func NewSendFromCmd(fromAccount, toAddress string, amount float64, minConf *int,
 	}
 }
 
+// CoinControlOpts are the options of which outputs a transaction spends and where its change goes.
+type CoinControlOpts struct {
+	Inputs         []TransactionInput `json:"inputs,omitempty"`
+	AddInputs      *bool              `json:"addInputs,omitempty"`
+	Exclude        []TransactionInput `json:"exclude,omitempty"`
+	ExcludeLabeled *bool              `json:"excludeLabeled,omitempty"`
+	Strategy       *string            `json:"strategy,omitempty"`
+	ChangeAddress  *string            `json:"changeAddress,omitempty"`
+	MaxInputs      *int               `json:"maxInputs,omitempty"`
+	Replaceable    *bool              `json:"replaceable,omitempty"`
+}
+
 // SendManyCmd defines the sendmany JSON-RPC command.
 type SendManyCmd struct {
 	FromAccount string
 	Amounts     map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In DUO
 	MinConf     *int               `jsonrpcdefault:"1"`
 	Comment     *string
+	CoinControl *CoinControlOpts
 }
 
 // NewSendManyCmd returns a new instance which can be used to issue a sendmany JSON-RPC command. The parameters which are pointers indicate they are optional.  Passing nil for optional parameters will use the default value.
-func NewSendManyCmd(fromAccount string, amounts map[string]float64, minConf *int, comment *string) *SendManyCmd {
+func NewSendManyCmd(fromAccount string, amounts map[string]float64, minConf *int, comment *string, coinControl *CoinControlOpts) *SendManyCmd {
 	return &SendManyCmd{
 		FromAccount: fromAccount,
 		Amounts:     amounts,
 		MinConf:     minConf,
 		Comment:     comment,
+		CoinControl: coinControl,
 	}
 }
 
@@ -458,6 +552,52 @@ func NewSetAccountCmd(address, account string) *SetAccountCmd {
 	}
 }
 
+// SetLabelCmd defines the setlabel JSON-RPC command.
+type SetLabelCmd struct {
+	Address string
+	Label   string
+}
+
+// NewSetLabelCmd returns a new instance which can be used to issue a setlabel JSON-RPC command.
+func NewSetLabelCmd(address, label string) *SetLabelCmd {
+	return &SetLabelCmd{
+		Address: address,
+		Label:   label,
+	}
+}
+
+// SetOutputLabelCmd defines the setoutputlabel JSON-RPC command.
+type SetOutputLabelCmd struct {
+	Txid  string
+	Vout  uint32
+	Label string
+}
+
+// NewSetOutputLabelCmd returns a new instance which can be used to issue a setoutputlabel JSON-RPC command.
+func NewSetOutputLabelCmd(txid string, vout uint32, label string) *SetOutputLabelCmd {
+	return &SetOutputLabelCmd{
+		Txid:  txid,
+		Vout:  vout,
+		Label: label,
+	}
+}
+
+// SetTxCommentCmd defines the settxcomment JSON-RPC command.
+type SetTxCommentCmd struct {
+	Txid      string
+	Comment   string
+	CommentTo *string
+}
+
+// NewSetTxCommentCmd returns a new instance which can be used to issue a settxcomment JSON-RPC command. The parameters which are pointers indicate they are optional.  Passing nil for optional parameters will use the default value.
+func NewSetTxCommentCmd(txid, comment string, commentTo *string) *SetTxCommentCmd {
+	return &SetTxCommentCmd{
+		Txid:      txid,
+		Comment:   comment,
+		CommentTo: commentTo,
+	}
+}
+
 // SetTxFeeCmd defines the settxfee JSON-RPC command.
 type SetTxFeeCmd struct {
 	Amount float64 // In DUO
@@ -510,6 +650,50 @@ func NewSignRawTransactionCmd(hexEncodedTx string, inputs *[]RawTxInput, privKey
 	}
 }
 
+// UnloadWalletCmd defines the unloadwallet JSON-RPC command.
+type UnloadWalletCmd struct {
+	WalletName string
+}
+
+// NewUnloadWalletCmd returns a new instance which can be used to issue an unloadwallet JSON-RPC command.
+func NewUnloadWalletCmd(walletName string) *UnloadWalletCmd {
+	return &UnloadWalletCmd{
+		WalletName: walletName,
+	}
+}
+
+// WalletCreateFundedPsbtOpts are the options of the walletcreatefundedpsbt JSON-RPC command.
+type WalletCreateFundedPsbtOpts struct {
+	ChangeAddress  *string            `json:"changeAddress,omitempty"`
+	LockUnspents   *bool              `json:"lockUnspents,omitempty"`
+	FeeRate        *float64           `json:"feeRate,omitempty"` // In DUO/kB
+	Account        *string            `json:"account,omitempty"`
+	AddInputs      *bool              `json:"addInputs,omitempty"`
+	Exclude        []TransactionInput `json:"exclude,omitempty"`
+	ExcludeLabeled *bool              `json:"excludeLabeled,omitempty"`
+	Strategy       *string            `json:"strategy,omitempty"`
+	MaxInputs      *int               `json:"maxInputs,omitempty"`
+	Replaceable    *bool              `json:"replaceable,omitempty"`
+}
+
+// WalletCreateFundedPsbtCmd defines the walletcreatefundedpsbt JSON-RPC command.
+type WalletCreateFundedPsbtCmd struct {
+	Inputs   []TransactionInput
+	Outputs  map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In DUO
+	LockTime *int64
+	Options  *WalletCreateFundedPsbtOpts
+}
+
+// NewWalletCreateFundedPsbtCmd returns a new instance which can be used to issue a walletcreatefundedpsbt JSON-RPC command. Amounts are in DUO. The parameters which are pointers indicate they are optional.  Passing nil for optional parameters will use the default value.
+func NewWalletCreateFundedPsbtCmd(inputs []TransactionInput, outputs map[string]float64, lockTime *int64, options *WalletCreateFundedPsbtOpts) *WalletCreateFundedPsbtCmd {
+	return &WalletCreateFundedPsbtCmd{
+		Inputs:   inputs,
+		Outputs:  outputs,
+		LockTime: lockTime,
+		Options:  options,
+	}
+}
+
 // WalletLockCmd defines the walletlock JSON-RPC command.
 type WalletLockCmd struct{}
 
@@ -545,16 +729,36 @@ func NewWalletPassphraseChangeCmd(oldPassphrase, newPassphrase string) *WalletPa
 		NewPassphrase: newPassphrase,
 	}
 }
+
+// WalletProcessPsbtCmd defines the walletprocesspsbt JSON-RPC command.
+type WalletProcessPsbtCmd struct {
+	Psbt        string
+	Sign        *bool   `jsonrpcdefault:"true"`
+	SighashType *string `jsonrpcdefault:"\"ALL\""`
+}
+
+// NewWalletProcessPsbtCmd returns a new instance which can be used to issue a walletprocesspsbt JSON-RPC command. The parameters which are pointers indicate they are optional.  Passing nil for optional parameters will use the default value.
+func NewWalletProcessPsbtCmd(psbt string, sign *bool, sighashType *string) *WalletProcessPsbtCmd {
+	return &WalletProcessPsbtCmd{
+		Psbt:        psbt,
+		Sign:        sign,
+		SighashType: sighashType,
+	}
+}
 func init() {
 	// The commands in this file are only usable with a wallet server.
 	flags := UFWalletOnly
 	MustRegisterCmd("addmultisigaddress", (*AddMultisigAddressCmd)(nil), flags)
 	MustRegisterCmd("addwitnessaddress", (*AddWitnessAddressCmd)(nil), flags)
+	MustRegisterCmd("backupwallet", (*BackupWalletCmd)(nil), flags)
+	MustRegisterCmd("bumpfee", (*BumpFeeCmd)(nil), flags)
+	MustRegisterCmd("combinepsbt", (*CombinePsbtCmd)(nil), flags)
 	MustRegisterCmd("createmultisig", (*CreateMultisigCmd)(nil), flags)
 	MustRegisterCmd("dumpprivkey", (*DumpPrivKeyCmd)(nil), flags)
 	MustRegisterCmd("encryptwallet", (*EncryptWalletCmd)(nil), flags)
 	MustRegisterCmd("estimatefee", (*EstimateFeeCmd)(nil), flags)
 	MustRegisterCmd("estimatepriority", (*EstimatePriorityCmd)(nil), flags)
+	MustRegisterCmd("finalizepsbt", (*FinalizePsbtCmd)(nil), flags)
 	MustRegisterCmd("getaccount", (*GetAccountCmd)(nil), flags)
 	MustRegisterCmd("getaccountaddress", (*GetAccountAddressCmd)(nil), flags)
 	MustRegisterCmd("getaddressesbyaccount", (*GetAddressesByAccountCmd)(nil), flags)
@@ -575,16 +779,24 @@ func init() {
 	MustRegisterCmd("listsinceblock", (*ListSinceBlockCmd)(nil), flags)
 	MustRegisterCmd("listtransactions", (*ListTransactionsCmd)(nil), flags)
 	MustRegisterCmd("listunspent", (*ListUnspentCmd)(nil), flags)
+	MustRegisterCmd("listwallets", (*ListWalletsCmd)(nil), flags)
+	MustRegisterCmd("loadwallet", (*LoadWalletCmd)(nil), flags)
 	MustRegisterCmd("lockunspent", (*LockUnspentCmd)(nil), flags)
 	MustRegisterCmd("move", (*MoveCmd)(nil), flags)
 	MustRegisterCmd("sendfrom", (*SendFromCmd)(nil), flags)
 	MustRegisterCmd("sendmany", (*SendManyCmd)(nil), flags)
 	MustRegisterCmd("sendtoaddress", (*SendToAddressCmd)(nil), flags)
 	MustRegisterCmd("setaccount", (*SetAccountCmd)(nil), flags)
+	MustRegisterCmd("setlabel", (*SetLabelCmd)(nil), flags)
+	MustRegisterCmd("setoutputlabel", (*SetOutputLabelCmd)(nil), flags)
+	MustRegisterCmd("settxcomment", (*SetTxCommentCmd)(nil), flags)
 	MustRegisterCmd("settxfee", (*SetTxFeeCmd)(nil), flags)
 	MustRegisterCmd("signmessage", (*SignMessageCmd)(nil), flags)
 	MustRegisterCmd("signrawtransaction", (*SignRawTransactionCmd)(nil), flags)
+	MustRegisterCmd("unloadwallet", (*UnloadWalletCmd)(nil), flags)
+	MustRegisterCmd("walletcreatefundedpsbt", (*WalletCreateFundedPsbtCmd)(nil), flags)
 	MustRegisterCmd("walletlock", (*WalletLockCmd)(nil), flags)
 	MustRegisterCmd("walletpassphrase", (*WalletPassphraseCmd)(nil), flags)
 	MustRegisterCmd("walletpassphrasechange", (*WalletPassphraseChangeCmd)(nil), flags)
+	MustRegisterCmd("walletprocesspsbt", (*WalletProcessPsbtCmd)(nil), flags)
 }
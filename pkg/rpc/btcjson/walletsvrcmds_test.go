@@ -66,6 +66,65 @@ func TestWalletSvrCmds(t *testing.T) {
 				Address: "1address",
 			},
 		},
+		{
+			name: "backupwallet",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("backupwallet", "/tmp/wallet.db")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewBackupWalletCmd("/tmp/wallet.db")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"backupwallet","netparams":["/tmp/wallet.db"],"id":1}`,
+			unmarshalled: &btcjson.BackupWalletCmd{
+				Destination: "/tmp/wallet.db",
+			},
+		},
+		{
+			name: "bumpfee",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("bumpfee", "123")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewBumpFeeCmd("123", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"bumpfee","netparams":["123"],"id":1}`,
+			unmarshalled: &btcjson.BumpFeeCmd{
+				TxID: "123",
+			},
+		},
+		{
+			name: "bumpfee optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("bumpfee", "123", `{"feeRate":0.0002,"cpfp":true}`)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewBumpFeeCmd("123", &btcjson.BumpFeeOpts{
+					FeeRate: btcjson.Float64(0.0002),
+					CPFP:    btcjson.Bool(true),
+				})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"bumpfee","netparams":["123",{"feeRate":0.0002,"cpfp":true}],"id":1}`,
+			unmarshalled: &btcjson.BumpFeeCmd{
+				TxID: "123",
+				Options: &btcjson.BumpFeeOpts{
+					FeeRate: btcjson.Float64(0.0002),
+					CPFP:    btcjson.Bool(true),
+				},
+			},
+		},
+		{
+			name: "combinepsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("combinepsbt", []string{"cHNidP8=", "cHNidP9="})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewCombinePsbtCmd([]string{"cHNidP8=", "cHNidP9="})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"combinepsbt","netparams":[["cHNidP8=","cHNidP9="]],"id":1}`,
+			unmarshalled: &btcjson.CombinePsbtCmd{
+				Txs: []string{"cHNidP8=", "cHNidP9="},
+			},
+		},
 		{
 			name: "createmultisig",
 			newCmd: func() (interface{}, error) {
@@ -133,6 +192,34 @@ func TestWalletSvrCmds(t *testing.T) {
 				NumBlocks: 6,
 			},
 		},
+		{
+			name: "finalizepsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("finalizepsbt", "cHNidP8=")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewFinalizePsbtCmd("cHNidP8=", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"finalizepsbt","netparams":["cHNidP8="],"id":1}`,
+			unmarshalled: &btcjson.FinalizePsbtCmd{
+				Psbt:    "cHNidP8=",
+				Extract: btcjson.Bool(true),
+			},
+		},
+		{
+			name: "finalizepsbt optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("finalizepsbt", "cHNidP8=", false)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewFinalizePsbtCmd("cHNidP8=", btcjson.Bool(false))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"finalizepsbt","netparams":["cHNidP8=",false],"id":1}`,
+			unmarshalled: &btcjson.FinalizePsbtCmd{
+				Psbt:    "cHNidP8=",
+				Extract: btcjson.Bool(false),
+			},
+		},
 		{
 			name: "getaccount",
 			newCmd: func() (interface{}, error) {
@@ -803,6 +890,44 @@ func TestWalletSvrCmds(t *testing.T) {
 				Addresses: &[]string{"1Address", "1Address2"},
 			},
 		},
+		{
+			name: "listwallets",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listwallets")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListWalletsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"listwallets","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListWalletsCmd{},
+		},
+		{
+			name: "loadwallet",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("loadwallet", "hot")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewLoadWalletCmd("hot", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"loadwallet","netparams":["hot"],"id":1}`,
+			unmarshalled: &btcjson.LoadWalletCmd{
+				WalletName: "hot",
+			},
+		},
+		{
+			name: "loadwallet optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("loadwallet", "hot", "pass")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewLoadWalletCmd("hot", btcjson.String("pass"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"loadwallet","netparams":["hot","pass"],"id":1}`,
+			unmarshalled: &btcjson.LoadWalletCmd{
+				WalletName:    "hot",
+				PubPassphrase: btcjson.String("pass"),
+			},
+		},
 		{
 			name: "lockunspent",
 			newCmd: func() (interface{}, error) {
@@ -954,7 +1079,7 @@ func TestWalletSvrCmds(t *testing.T) {
 			},
 			staticCmd: func() interface{} {
 				amounts := map[string]float64{"1Address": 0.5}
-				return btcjson.NewSendManyCmd("from", amounts, nil, nil)
+				return btcjson.NewSendManyCmd("from", amounts, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5}],"id":1}`,
 			unmarshalled: &btcjson.SendManyCmd{
@@ -971,7 +1096,7 @@ func TestWalletSvrCmds(t *testing.T) {
 			},
 			staticCmd: func() interface{} {
 				amounts := map[string]float64{"1Address": 0.5}
-				return btcjson.NewSendManyCmd("from", amounts, btcjson.Int(6), nil)
+				return btcjson.NewSendManyCmd("from", amounts, btcjson.Int(6), nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5},6],"id":1}`,
 			unmarshalled: &btcjson.SendManyCmd{
@@ -988,7 +1113,7 @@ func TestWalletSvrCmds(t *testing.T) {
 			},
 			staticCmd: func() interface{} {
 				amounts := map[string]float64{"1Address": 0.5}
-				return btcjson.NewSendManyCmd("from", amounts, btcjson.Int(6), btcjson.String("comment"))
+				return btcjson.NewSendManyCmd("from", amounts, btcjson.Int(6), btcjson.String("comment"), nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5},6,"comment"],"id":1}`,
 			unmarshalled: &btcjson.SendManyCmd{
@@ -998,6 +1123,44 @@ func TestWalletSvrCmds(t *testing.T) {
 				Comment:     btcjson.String("comment"),
 			},
 		},
+		{
+			name: "sendmany optional3",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sendmany", "from", `{"1Address":0.5}`, 6, "comment",
+					`{"inputs":[{"txid":"123","vout":1}],"addInputs":false,"exclude":[{"txid":"456","vout":0}],"excludeLabeled":true,"strategy":"branchandbound","changeAddress":"789","maxInputs":3,"replaceable":true}`)
+			},
+			staticCmd: func() interface{} {
+				amounts := map[string]float64{"1Address": 0.5}
+				return btcjson.NewSendManyCmd("from", amounts, btcjson.Int(6), btcjson.String("comment"),
+					&btcjson.CoinControlOpts{
+						Inputs:         []btcjson.TransactionInput{{Txid: "123", Vout: 1}},
+						AddInputs:      btcjson.Bool(false),
+						Exclude:        []btcjson.TransactionInput{{Txid: "456", Vout: 0}},
+						ExcludeLabeled: btcjson.Bool(true),
+						Strategy:       btcjson.String("branchandbound"),
+						ChangeAddress:  btcjson.String("789"),
+						MaxInputs:      btcjson.Int(3),
+						Replaceable:    btcjson.Bool(true),
+					})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5},6,"comment",{"inputs":[{"txid":"123","vout":1}],"addInputs":false,"exclude":[{"txid":"456","vout":0}],"excludeLabeled":true,"strategy":"branchandbound","changeAddress":"789","maxInputs":3,"replaceable":true}],"id":1}`,
+			unmarshalled: &btcjson.SendManyCmd{
+				FromAccount: "from",
+				Amounts:     map[string]float64{"1Address": 0.5},
+				MinConf:     btcjson.Int(6),
+				Comment:     btcjson.String("comment"),
+				CoinControl: &btcjson.CoinControlOpts{
+					Inputs:         []btcjson.TransactionInput{{Txid: "123", Vout: 1}},
+					AddInputs:      btcjson.Bool(false),
+					Exclude:        []btcjson.TransactionInput{{Txid: "456", Vout: 0}},
+					ExcludeLabeled: btcjson.Bool(true),
+					Strategy:       btcjson.String("branchandbound"),
+					ChangeAddress:  btcjson.String("789"),
+					MaxInputs:      btcjson.Int(3),
+					Replaceable:    btcjson.Bool(true),
+				},
+			},
+		},
 		{
 			name: "sendtoaddress",
 			newCmd: func() (interface{}, error) {
@@ -1045,6 +1208,66 @@ func TestWalletSvrCmds(t *testing.T) {
 				Account: "acct",
 			},
 		},
+		{
+			name: "setlabel",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("setlabel", "1Address", "invoice 42")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetLabelCmd("1Address", "invoice 42")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setlabel","netparams":["1Address","invoice 42"],"id":1}`,
+			unmarshalled: &btcjson.SetLabelCmd{
+				Address: "1Address",
+				Label:   "invoice 42",
+			},
+		},
+		{
+			name: "setoutputlabel",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("setoutputlabel", "123", 1, "invoice 42")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetOutputLabelCmd("123", 1, "invoice 42")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setoutputlabel","netparams":["123",1,"invoice 42"],"id":1}`,
+			unmarshalled: &btcjson.SetOutputLabelCmd{
+				Txid:  "123",
+				Vout:  1,
+				Label: "invoice 42",
+			},
+		},
+		{
+			name: "settxcomment",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("settxcomment", "123", "invoice 42")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetTxCommentCmd("123", "invoice 42", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"settxcomment","netparams":["123","invoice 42"],"id":1}`,
+			unmarshalled: &btcjson.SetTxCommentCmd{
+				Txid:      "123",
+				Comment:   "invoice 42",
+				CommentTo: nil,
+			},
+		},
+		{
+			name: "settxcomment optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("settxcomment", "123", "invoice 42", "supplier")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetTxCommentCmd("123", "invoice 42",
+					btcjson.String("supplier"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"settxcomment","netparams":["123","invoice 42","supplier"],"id":1}`,
+			unmarshalled: &btcjson.SetTxCommentCmd{
+				Txid:      "123",
+				Comment:   "invoice 42",
+				CommentTo: btcjson.String("supplier"),
+			},
+		},
 		{
 			name: "settxfee",
 			newCmd: func() (interface{}, error) {
@@ -1156,6 +1379,80 @@ func TestWalletSvrCmds(t *testing.T) {
 				Flags:    btcjson.String("ALL"),
 			},
 		},
+		{
+			name: "unloadwallet",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("unloadwallet", "hot")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewUnloadWalletCmd("hot")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"unloadwallet","netparams":["hot"],"id":1}`,
+			unmarshalled: &btcjson.UnloadWalletCmd{
+				WalletName: "hot",
+			},
+		},
+		{
+			name: "walletcreatefundedpsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("walletcreatefundedpsbt", `[{"txid":"123","vout":1}]`,
+					`{"456":0.0123}`)
+			},
+			staticCmd: func() interface{} {
+				txInputs := []btcjson.TransactionInput{
+					{Txid: "123", Vout: 1},
+				}
+				amounts := map[string]float64{"456": .0123}
+				return btcjson.NewWalletCreateFundedPsbtCmd(txInputs, amounts, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletcreatefundedpsbt","netparams":[[{"txid":"123","vout":1}],{"456":0.0123}],"id":1}`,
+			unmarshalled: &btcjson.WalletCreateFundedPsbtCmd{
+				Inputs:  []btcjson.TransactionInput{{Txid: "123", Vout: 1}},
+				Outputs: map[string]float64{"456": .0123},
+			},
+		},
+		{
+			name: "walletcreatefundedpsbt optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("walletcreatefundedpsbt", `[]`,
+					`{"456":0.0123}`, 12312333333, `{"changeAddress":"789","lockUnspents":true,"feeRate":0.0002,"account":"cold","addInputs":false,"exclude":[{"txid":"123","vout":0}],"excludeLabeled":true,"strategy":"noaddressreuse","maxInputs":5,"replaceable":true}`)
+			},
+			staticCmd: func() interface{} {
+				amounts := map[string]float64{"456": .0123}
+				return btcjson.NewWalletCreateFundedPsbtCmd([]btcjson.TransactionInput{},
+					amounts, btcjson.Int64(12312333333),
+					&btcjson.WalletCreateFundedPsbtOpts{
+						ChangeAddress:  btcjson.String("789"),
+						LockUnspents:   btcjson.Bool(true),
+						FeeRate:        btcjson.Float64(0.0002),
+						Account:        btcjson.String("cold"),
+						AddInputs:      btcjson.Bool(false),
+						Exclude:        []btcjson.TransactionInput{{Txid: "123", Vout: 0}},
+						ExcludeLabeled: btcjson.Bool(true),
+						Strategy:       btcjson.String("noaddressreuse"),
+						MaxInputs:      btcjson.Int(5),
+						Replaceable:    btcjson.Bool(true),
+					})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletcreatefundedpsbt","netparams":[[],{"456":0.0123},12312333333,{"changeAddress":"789","lockUnspents":true,"feeRate":0.0002,"account":"cold","addInputs":false,"exclude":[{"txid":"123","vout":0}],"excludeLabeled":true,"strategy":"noaddressreuse","maxInputs":5,"replaceable":true}],"id":1}`,
+			unmarshalled: &btcjson.WalletCreateFundedPsbtCmd{
+				Inputs:   []btcjson.TransactionInput{},
+				Outputs:  map[string]float64{"456": .0123},
+				LockTime: btcjson.Int64(12312333333),
+				Options: &btcjson.WalletCreateFundedPsbtOpts{
+					ChangeAddress:  btcjson.String("789"),
+					LockUnspents:   btcjson.Bool(true),
+					FeeRate:        btcjson.Float64(0.0002),
+					Account:        btcjson.String("cold"),
+					AddInputs:      btcjson.Bool(false),
+					Exclude:        []btcjson.TransactionInput{{Txid: "123", Vout: 0}},
+					ExcludeLabeled: btcjson.Bool(true),
+					Strategy:       btcjson.String("noaddressreuse"),
+					MaxInputs:      btcjson.Int(5),
+					Replaceable:    btcjson.Bool(true),
+				},
+			},
+		},
 		{
 			name: "walletlock",
 			newCmd: func() (interface{}, error) {
@@ -1195,6 +1492,37 @@ func TestWalletSvrCmds(t *testing.T) {
 				NewPassphrase: "new",
 			},
 		},
+		{
+			name: "walletprocesspsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("walletprocesspsbt", "cHNidP8=")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewWalletProcessPsbtCmd("cHNidP8=", nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletprocesspsbt","netparams":["cHNidP8="],"id":1}`,
+			unmarshalled: &btcjson.WalletProcessPsbtCmd{
+				Psbt:        "cHNidP8=",
+				Sign:        btcjson.Bool(true),
+				SighashType: btcjson.String("ALL"),
+			},
+		},
+		{
+			name: "walletprocesspsbt optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("walletprocesspsbt", "cHNidP8=", false, "SINGLE")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewWalletProcessPsbtCmd("cHNidP8=", btcjson.Bool(false),
+					btcjson.String("SINGLE"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletprocesspsbt","netparams":["cHNidP8=",false,"SINGLE"],"id":1}`,
+			unmarshalled: &btcjson.WalletProcessPsbtCmd{
+				Psbt:        "cHNidP8=",
+				Sign:        btcjson.Bool(false),
+				SighashType: btcjson.String("SINGLE"),
+			},
+		},
 	}
 	t.Logf("Running %d tests", len(tests))
 	for i, test := range tests {
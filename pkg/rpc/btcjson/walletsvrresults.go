@@ -1,6 +1,19 @@
 package btcjson
 
 type (
+	// BumpFeeResult models the data from the bumpfee command. The method is "rbf" when the transaction was replaced and "cpfp" when a child transaction pays for it.
+	BumpFeeResult struct {
+		TxID    string  `json:"txid"`
+		Method  string  `json:"method"`
+		OrigFee float64 `json:"origfee"`
+		Fee     float64 `json:"fee"`
+	}
+	// FinalizePsbtResult models the data from the finalizepsbt command. The signed transaction is only given when the PSBT is complete and extracting it was asked for, the PSBT is given otherwise.
+	FinalizePsbtResult struct {
+		Psbt     string `json:"psbt,omitempty"`
+		Hex      string `json:"hex,omitempty"`
+		Complete bool   `json:"complete"`
+	}
 	// GetTransactionDetailsResult models the details data from the gettransaction command. This models the "short" version of the ListTransactionsResult type, which excludes fields common to the transaction.  These common fields are instead part of the GetTransactionResult.
 	GetTransactionDetailsResult struct {
 		Account           string   `json:"account"`
@@ -10,6 +23,7 @@ type (
 		InvolvesWatchOnly bool     `json:"involveswatchonly,omitempty"`
 		Fee               *float64 `json:"fee,omitempty"`
 		Vout              uint32   `json:"vout"`
+		Label             string   `json:"label,omitempty"`
 	}
 	// GetTransactionResult models the data from the gettransaction command.
 	GetTransactionResult struct {
@@ -25,6 +39,20 @@ type (
 		TimeReceived    int64                         `json:"timereceived"`
 		Details         []GetTransactionDetailsResult `json:"details"`
 		Hex             string                        `json:"hex"`
+		Comment         string                        `json:"comment,omitempty"`
+		To              string                        `json:"to,omitempty"`
+	}
+	// GetWalletInfoResult models the data returned by the getwalletinfo command.
+	GetWalletInfoResult struct {
+		WalletVersion      int32   `json:"walletversion"`
+		Balance            float64 `json:"balance"`
+		UnconfirmedBalance float64 `json:"unconfirmed_balance"`
+		ImmatureBalance    float64 `json:"immature_balance"`
+		TxCount            int     `json:"txcount"`
+		PaytxFee           float64 `json:"paytxfee"`
+		Birthday           int64   `json:"birthday"`
+		Blocks             int32   `json:"blocks"`
+		BestBlockHash      string  `json:"bestblockhash"`
 	}
 	// InfoWalletResult models the data returned by the wallet server getinfo command.
 	InfoWalletResult struct {
@@ -68,6 +96,8 @@ type (
 		WalletConflicts   []string `json:"walletconflicts"`
 		Comment           string   `json:"comment,omitempty"`
 		OtherAccount      string   `json:"otheraccount,omitempty"`
+		To                string   `json:"to,omitempty"`
+		Label             string   `json:"label,omitempty"`
 	}
 	// ListReceivedByAccountResult models the data from the listreceivedbyaccount command.
 	ListReceivedByAccountResult struct {
@@ -101,6 +131,11 @@ type (
 		Confirmations int64   `json:"confirmations"`
 		Spendable     bool    `json:"spendable"`
 	}
+	// LoadWalletResult models the data returned by the loadwallet command.
+	LoadWalletResult struct {
+		Name    string `json:"name"`
+		Warning string `json:"warning"`
+	}
 	// SignRawTransactionError models the data that contains script verification errors from the signrawtransaction request.
 	SignRawTransactionError struct {
 		TxID      string `json:"txid"`
@@ -130,6 +165,17 @@ type (
 		Script       string   `json:"script,omitempty"`
 		SigsRequired int32    `json:"sigsrequired,omitempty"`
 	}
+	// WalletCreateFundedPsbtResult models the data from the walletcreatefundedpsbt command.
+	WalletCreateFundedPsbtResult struct {
+		Psbt      string  `json:"psbt"`
+		Fee       float64 `json:"fee"`
+		ChangePos int64   `json:"changepos"`
+	}
+	// WalletProcessPsbtResult models the data from the walletprocesspsbt command.
+	WalletProcessPsbtResult struct {
+		Psbt     string `json:"psbt"`
+		Complete bool   `json:"complete"`
+	}
 	// GetBestBlockResult models the data from the getbestblock command.
 	GetBestBlockResult struct {
 		Hash   string `json:"hash"`
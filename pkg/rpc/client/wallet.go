@@ -612,7 +612,7 @@ func (c *Client) SendManyAsync(fromAccount string, amounts map[util.Address]util
 	for addr, amount := range amounts {
 		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
 	}
-	cmd := btcjson.NewSendManyCmd(fromAccount, convertedAmounts, nil, nil)
+	cmd := btcjson.NewSendManyCmd(fromAccount, convertedAmounts, nil, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -638,7 +638,7 @@ func (c *Client) SendManyMinConfAsync(fromAccount string,
 		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
 	}
 	cmd := btcjson.NewSendManyCmd(fromAccount, convertedAmounts,
-		&minConfirms, nil)
+		&minConfirms, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -667,7 +667,7 @@ func (c *Client) SendManyCommentAsync(fromAccount string,
 		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
 	}
 	cmd := btcjson.NewSendManyCmd(fromAccount, convertedAmounts,
-		&minConfirms, &comment)
+		&minConfirms, &comment, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -686,6 +686,36 @@ func (c *Client) SendManyComment(fromAccount string,
 		comment).Receive()
 }
 
+// SendManyCoinControlAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+// See SendManyCoinControl for the blocking version and more details.
+func (c *Client) SendManyCoinControlAsync(fromAccount string,
+	amounts map[util.Address]util.Amount, minConfirms int,
+	coinControl *btcjson.CoinControlOpts) FutureSendManyResult {
+	convertedAmounts := make(map[string]float64, len(amounts))
+	for addr, amount := range amounts {
+		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+	}
+	cmd := btcjson.NewSendManyCmd(fromAccount, convertedAmounts,
+		&minConfirms, nil, coinControl)
+	return c.sendCmd(cmd)
+}
+
+// SendManyCoinControl sends multiple amounts to multiple addresses using the
+// provided account as a source of funds in a single transaction, with the
+// outputs spent and where change goes chosen as the coin control options
+// direct.  Only funds with the passed number of minimum confirmations will be
+// used.
+// NOTE: This function requires to the wallet to be unlocked.  See the
+// WalletPassphrase function for more details.
+func (c *Client) SendManyCoinControl(fromAccount string,
+	amounts map[util.Address]util.Amount, minConfirms int,
+	coinControl *btcjson.CoinControlOpts) (*chainhash.Hash, error) {
+	return c.SendManyCoinControlAsync(fromAccount, amounts, minConfirms,
+		coinControl).Receive()
+}
+
 // *************************
 // Address/Account Functions
 // *************************
@@ -9,6 +9,25 @@ var helpDescsEnUS = map[string]string{
 	"addmultisigaddress-keys":      "Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address",
 	"addmultisigaddress-nrequired": "The number of signatures required to redeem outputs paid to this address",
 	"addmultisigaddress--result0":  "The imported pay-to-script-hash address",
+	// BackupWalletCmd help.
+	"backupwallet--synopsis":   "Safely copies the wallet database to a file or directory.",
+	"backupwallet-destination": "The file to write the backup to, or the directory to write it to with the name of the wallet database",
+	// BumpFeeCmd help.
+	"bumpfee--synopsis": "Raises the fee of an unconfirmed transaction of the wallet so it is mined sooner.\n" +
+		"A transaction signaling replaceability (BIP125) is replaced by one paying the higher fee from its change, otherwise a child transaction spending its change pays for both.",
+	"bumpfee-txid":        "The hash of the transaction",
+	"bumpfee-options":     "Options for bumping the fee",
+	"bumpfeeopts-feeRate": "The fee rate to pay valued in bitcoin per kilobyte, for the transaction and its child together when a child is created, by default that of the transaction plus the relay fee",
+	"bumpfeeopts-cpfp":    "Whether to create a child transaction even if the transaction can be replaced",
+	// BumpFeeResult help.
+	"bumpfeeresult-txid":    "The hash of the replacement or the child transaction",
+	"bumpfeeresult-method":  "How the fee was bumped, rbf when the transaction was replaced or cpfp when a child pays for it",
+	"bumpfeeresult-origfee": "The fee the transaction paid valued in bitcoin",
+	"bumpfeeresult-fee":     "The fee the replacement or the child pays valued in bitcoin",
+	// CombinePsbtCmd help.
+	"combinepsbt--synopsis": "Combines PSBTs of the same transaction, such as ones signed by different parties, into one with all of their signatures and data.",
+	"combinepsbt-txs":       "The base64 encoded PSBTs to combine",
+	"combinepsbt--result0":  "The combined PSBT encoded in base64",
 	// CreateMultisigCmd help.
 	"createmultisig--synopsis": "Generate a multisig address and redeem script.",
 	"createmultisig-keys":      "Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address",
@@ -20,6 +39,18 @@ var helpDescsEnUS = map[string]string{
 	"dumpprivkey--synopsis": "Returns the private key in WIF encoding that controls some wallet address.",
 	"dumpprivkey-address":   "The address to return a private key for",
 	"dumpprivkey--result0":  "The WIF-encoded private key",
+	// DumpWalletCmd help.
+	"dumpwallet--synopsis": "Writes all of the private keys of the wallet to a file with their birthdays, accounts and derivation paths, in the format of the reference implementation.",
+	"dumpwallet-filename":  "The file to write the keys to, which must not already exist",
+	// FinalizePsbtCmd help.
+	"finalizepsbt--synopsis": "Finalizes the inputs of a PSBT that have all of the signatures they need.\n" +
+		"When every input is finalized and extract is set the signed transaction is returned, otherwise the PSBT is.",
+	"finalizepsbt-psbt":    "The base64 encoded PSBT",
+	"finalizepsbt-extract": "Whether to return the signed transaction once the PSBT is complete",
+	// FinalizePsbtResult help.
+	"finalizepsbtresult-psbt":     "The finalized PSBT encoded in base64, when the transaction is not extracted",
+	"finalizepsbtresult-hex":      "The signed transaction encoded as a hexadecimal string, when it is extracted",
+	"finalizepsbtresult-complete": "Whether all of the inputs are finalized",
 	// GetAccountCmd help.
 	"getaccount--synopsis": "DEPRECATED -- Lookup the account name that some wallet address belongs to.",
 	"getaccount-address":   "The address to query the account for",
@@ -88,6 +119,18 @@ var helpDescsEnUS = map[string]string{
 	"gettransaction--synopsis":        "Returns a JSON object with details regarding a transaction relevant to this wallet.",
 	"gettransaction-txid":             "Hash of the transaction to query",
 	"gettransaction-includewatchonly": "Also consider transactions involving watched addresses",
+	// GetWalletInfoCmd help.
+	"getwalletinfo--synopsis": "Returns a JSON object with the balances, transaction count and sync state of the wallet.",
+	// GetWalletInfoResult help.
+	"getwalletinforesult-walletversion":       "The version of the address manager database",
+	"getwalletinforesult-balance":             "The balance of outputs with one or more confirmations valued in bitcoin",
+	"getwalletinforesult-unconfirmed_balance": "The balance of unconfirmed outputs valued in bitcoin",
+	"getwalletinforesult-immature_balance":    "The balance of immature coinbase outputs valued in bitcoin",
+	"getwalletinforesult-txcount":             "The number of transactions relevant to the wallet",
+	"getwalletinforesult-paytxfee":            "The transaction fee per kilobyte valued in bitcoin",
+	"getwalletinforesult-birthday":            "The Unix time before which the wallet has no transactions",
+	"getwalletinforesult-blocks":              "The height of the block the wallet is synced to",
+	"getwalletinforesult-bestblockhash":       "The hash of the block the wallet is synced to",
 	// HelpCmd help.
 	"help--synopsis":   "Returns a list of all commands or help for a specified command.",
 	"help-command":     "The command to retrieve help for",
@@ -108,6 +151,8 @@ var helpDescsEnUS = map[string]string{
 	"gettransactionresult-timereceived":    "The earliest Unix time this transaction was known to exist",
 	"gettransactionresult-details":         "Additional details for each recorded wallet credit and debit",
 	"gettransactionresult-hex":             "The transaction encoded as a hexadecimal string",
+	"gettransactionresult-comment":         "The comment saved with the transaction, if any",
+	"gettransactionresult-to":              "Who the transaction was sent to as saved with it, if any",
 	// GetTransactionDetailsResult help.
 	"gettransactiondetailsresult-account":           "DEPRECATED -- Unset",
 	"gettransactiondetailsresult-address":           "The address an output was paid to, or the empty string if the output is nonstandard or this detail is regarding a transaction input",
@@ -116,11 +161,15 @@ var helpDescsEnUS = map[string]string{
 	"gettransactiondetailsresult-fee":               "The included fee for a sent transaction",
 	"gettransactiondetailsresult-vout":              "The transaction output index",
 	"gettransactiondetailsresult-involveswatchonly": "Unset",
+	"gettransactiondetailsresult-label":             "The label of the output, or of the address it pays to if the output has none",
 	// ImportPrivKeyCmd help.
 	"importprivkey--synopsis": "Imports a WIF-encoded private key to the 'imported' account.",
 	"importprivkey-privkey":   "The WIF-encoded private key",
 	"importprivkey-label":     "Unused (must be unset or 'imported')",
 	"importprivkey-rescan":    "Rescan the blockchain (since the genesis block) for outputs controlled by the imported key",
+	// ImportWalletCmd help.
+	"importwallet--synopsis": "Imports the private keys in a wallet dump file to the 'imported' account and rescans the blockchain from the earliest of their birthdays.",
+	"importwallet-filename":  "The wallet dump file to read",
 	// KeypoolRefillCmd help.
 	"keypoolrefill--synopsis": "DEPRECATED -- This request does nothing since no keypool is maintained.",
 	"keypoolrefill-newsize":   "Unused",
@@ -130,6 +179,9 @@ var helpDescsEnUS = map[string]string{
 	"listaccounts--result0--desc":  "JSON object with account names as keys and bitcoin amounts as values",
 	"listaccounts--result0--key":   "The account name",
 	"listaccounts--result0--value": "The account balance valued in bitcoin",
+	// ListAddressGroupingsCmd help.
+	"listaddressgroupings--synopsis": "Returns the addresses of the wallet in groups made public as having the same owner by being spent from together or receiving change from each other.",
+	"listaddressgroupings--result0":  "Groups of addresses, each address as an array of the address, its balance valued in bitcoin and its account",
 	// ListLockUnspentCmd help.
 	"listlockunspent--synopsis": "Returns a JSON array of outpoints marked as locked (with lockunspent) for this wallet session.",
 	// TransactionInput help.
@@ -185,11 +237,13 @@ var helpDescsEnUS = map[string]string{
 	"listtransactionsresult-time":               "The earliest Unix time this transaction was known to exist",
 	"listtransactionsresult-timereceived":       "The earliest Unix time this transaction was known to exist",
 	"listtransactionsresult-involveswatchonly":  "Unset",
-	"listtransactionsresult-comment":            "Unset",
+	"listtransactionsresult-comment":            "The comment saved with the transaction, if any",
 	"listtransactionsresult-otheraccount":       "Unset",
 	"listtransactionsresult-trusted":            "Unset",
 	"listtransactionsresult-bip125-replaceable": "Unset",
 	"listtransactionsresult-abandoned":          "Unset",
+	"listtransactionsresult-to":                 "Who the transaction was sent to as saved with it, if any",
+	"listtransactionsresult-label":              "The label of the output, or of the address it pays to if the output has none",
 	// ListTransactionsCmd help.
 	"listtransactions--synopsis":        "Returns a JSON array of objects containing verbose details for wallet transactions.",
 	"listtransactions-account":          "DEPRECATED -- Unused (must be unset or \"*\")",
@@ -211,6 +265,18 @@ var helpDescsEnUS = map[string]string{
 	"listunspentresult-amount":        "The amount of the output valued in bitcoin",
 	"listunspentresult-confirmations": "The number of block confirmations of the transaction",
 	"listunspentresult-spendable":     "Whether the output is entirely controlled by wallet keys/scripts (false for partially controlled multisig outputs or outputs to watch-only addresses)",
+	// ListWalletsCmd help.
+	"listwallets--synopsis": "Returns the names of the loaded wallets.\n" +
+		"The default wallet is named by the empty string, other wallets are used by sending requests to the URL path /wallet/<name>.",
+	"listwallets--result0": "The names of the loaded wallets",
+	// LoadWalletCmd help.
+	"loadwallet--synopsis": "Loads the named wallet kept in the wallets directory of the network directory so requests can be sent to it at the URL path /wallet/<name>.\n" +
+		"The wallet stays loaded until it is unloaded with unloadwallet or the wallet server stops.",
+	"loadwallet-walletname":    "The name of the wallet",
+	"loadwallet-pubpassphrase": "The public passphrase of the wallet, if it was created with one",
+	// LoadWalletResult help.
+	"loadwalletresult-name":    "The name of the loaded wallet",
+	"loadwalletresult-warning": "Warning about the loading of the wallet, if any",
 	// LockUnspentCmd help.
 	"lockunspent--synopsis": "Locks or unlocks an unspent output.\n" +
 		"Locked outputs are not chosen for transaction inputs of authored transactions and are not included in 'listunspent' results.\n" +
@@ -226,8 +292,8 @@ var helpDescsEnUS = map[string]string{
 	"sendfrom-toaddress":   "Address to pay",
 	"sendfrom-amount":      "Amount to send to the payment address valued in bitcoin",
 	"sendfrom-minconf":     "Minimum number of block confirmations required before a transaction output is eligible to be spent",
-	"sendfrom-comment":     "Unused",
-	"sendfrom-commentto":   "Unused",
+	"sendfrom-comment":     "A comment saved with the transaction",
+	"sendfrom-commentto":   "Who the transaction is sent to, saved with the transaction",
 	"sendfrom--result0":    "The transaction hash of the sent transaction",
 	// SendManyCmd help.
 	"sendmany--synopsis": "Authors, signs, and sends a transaction that outputs to many payment addresses.\n" +
@@ -238,17 +304,41 @@ var helpDescsEnUS = map[string]string{
 	"sendmany-amounts--key":   "Address to pay",
 	"sendmany-amounts--value": "Amount to send to the payment address valued in bitcoin",
 	"sendmany-minconf":        "Minimum number of block confirmations required before a transaction output is eligible to be spent",
-	"sendmany-comment":        "Unused",
+	"sendmany-comment":        "A comment saved with the transaction",
+	"sendmany-coincontrol":    "Options for choosing the outputs the transaction spends",
 	"sendmany--result0":       "The transaction hash of the sent transaction",
+	// CoinControlOpts help.
+	"coincontrolopts-inputs":         "Outputs of the wallet that are always spent",
+	"coincontrolopts-addInputs":      "Whether other outputs of the account are added when the inputs are not enough (default=true)",
+	"coincontrolopts-exclude":        "Outputs that are never added to the inputs",
+	"coincontrolopts-excludeLabeled": "Whether outputs with a label, or paying to an address with a label, are never added to the inputs",
+	"coincontrolopts-strategy":       "How outputs are added to the inputs: largestfirst spends the largest first, branchandbound looks for outputs needing no change and noaddressreuse spends all outputs paying to an address together (default=\"largestfirst\")",
+	"coincontrolopts-changeAddress":  "The address to send change to instead of a new change address of the wallet",
+	"coincontrolopts-maxInputs":      "The most inputs the transaction may have, the given ones included, or no maximum if zero",
+	"coincontrolopts-replaceable":    "Whether the transaction signals that it can be replaced by one paying a higher fee as described by BIP125 (default=false)",
 	// SendToAddressCmd help.
 	"sendtoaddress--synopsis": "Authors, signs, and sends a transaction that outputs some amount to a payment address.\n" +
 		"Unlike sendfrom, outputs are always chosen from the default account.\n" +
 		"A change output is automatically included to send extra output value back to the original account.",
 	"sendtoaddress-address":   "Address to pay",
 	"sendtoaddress-amount":    "Amount to send to the payment address valued in bitcoin",
-	"sendtoaddress-comment":   "Unused",
-	"sendtoaddress-commentto": "Unused",
+	"sendtoaddress-comment":   "A comment saved with the transaction",
+	"sendtoaddress-commentto": "Who the transaction is sent to, saved with the transaction",
 	"sendtoaddress--result0":  "The transaction hash of the sent transaction",
+	// SetLabelCmd help.
+	"setlabel--synopsis": "Saves a label for an address, which is shown for the transaction outputs paying to it unless the output has a label of its own.",
+	"setlabel-address":   "The address to label",
+	"setlabel-label":     "The label, or the empty string to remove it",
+	// SetOutputLabelCmd help.
+	"setoutputlabel--synopsis": "Saves a label for a transaction output, which is shown instead of the label of the address it pays to.",
+	"setoutputlabel-txid":      "The hash of the transaction",
+	"setoutputlabel-vout":      "The index of the output",
+	"setoutputlabel-label":     "The label, or the empty string to remove it",
+	// SetTxCommentCmd help.
+	"settxcomment--synopsis": "Saves a comment with a transaction and who it was sent to, replacing the ones saved before.",
+	"settxcomment-txid":      "The hash of the transaction",
+	"settxcomment-comment":   "The comment, or the empty string to remove it",
+	"settxcomment-commentto": "Who the transaction was sent to",
 	// SetTxFeeCmd help.
 	"settxfee--synopsis": "Modify the increment used each time more fee is required for an authored transaction.",
 	"settxfee-amount":    "The new fee increment valued in bitcoin",
@@ -275,6 +365,9 @@ var helpDescsEnUS = map[string]string{
 	"signrawtransactionerror-scriptSig": "The hex-encoded signature script",
 	"signrawtransactionerror-txid":      "The transaction hash of the referenced previous output",
 	"signrawtransactionerror-vout":      "The output index of the referenced previous output",
+	// UnloadWalletCmd help.
+	"unloadwallet--synopsis":  "Unloads a wallet loaded with loadwallet.",
+	"unloadwallet-walletname": "The name of the wallet",
 	// ValidateAddressCmd help.
 	"validateaddress--synopsis": "Verify that an address is valid.\n" +
 		"Extra details are returned if the address is controlled by this wallet.\n" +
@@ -302,6 +395,30 @@ var helpDescsEnUS = map[string]string{
 	"verifymessage-signature": "The signature to verify",
 	"verifymessage-message":   "The message to verify",
 	"verifymessage--result0":  "Whether the message was signed with the private key of 'address'",
+	// WalletCreateFundedPsbtCmd help.
+	"walletcreatefundedpsbt--synopsis": "Creates a PSBT paying to the outputs with inputs from an account, the default one unless another is given, chosen as they are for a transaction the wallet sends, with change going back to the account.\n" +
+		"Inputs that are given are always spent and others are only added if they are not enough. The PSBT is not signed.",
+	"walletcreatefundedpsbt-inputs":             "The outputs of the wallet to spend",
+	"walletcreatefundedpsbt-outputs":            "Pairs of payment addresses and the output amount to pay each",
+	"walletcreatefundedpsbt-outputs--desc":      "JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address",
+	"walletcreatefundedpsbt-outputs--key":       "Address to pay",
+	"walletcreatefundedpsbt-outputs--value":     "Amount to send to the payment address valued in bitcoin",
+	"walletcreatefundedpsbt-locktime":           "Locktime value; a non-zero value will also locktime-activate the inputs",
+	"walletcreatefundedpsbt-options":            "Options for funding the transaction",
+	"walletcreatefundedpsbtopts-changeAddress":  "The address to send change to instead of a new change address of the wallet",
+	"walletcreatefundedpsbtopts-lockUnspents":   "Whether to lock the outputs spent by the PSBT so they are not spent by other transactions",
+	"walletcreatefundedpsbtopts-feeRate":        "The fee rate to pay valued in bitcoin per kilobyte",
+	"walletcreatefundedpsbtopts-account":        "The account to spend from and send change to, such as a watch-only account to be signed for offline (default=\"default\")",
+	"walletcreatefundedpsbtopts-addInputs":      "Whether other outputs of the account are added when the inputs are not enough (default=true)",
+	"walletcreatefundedpsbtopts-exclude":        "Outputs that are never added to the inputs",
+	"walletcreatefundedpsbtopts-excludeLabeled": "Whether outputs with a label, or paying to an address with a label, are never added to the inputs",
+	"walletcreatefundedpsbtopts-strategy":       "How outputs are added to the inputs: largestfirst spends the largest first, branchandbound looks for outputs needing no change and noaddressreuse spends all outputs paying to an address together (default=\"largestfirst\")",
+	"walletcreatefundedpsbtopts-maxInputs":      "The most inputs the transaction may have, the given ones included, or no maximum if zero",
+	"walletcreatefundedpsbtopts-replaceable":    "Whether the transaction signals that it can be replaced by one paying a higher fee as described by BIP125 (default=false)",
+	// WalletCreateFundedPsbtResult help.
+	"walletcreatefundedpsbtresult-psbt":      "The unsigned PSBT encoded in base64",
+	"walletcreatefundedpsbtresult-fee":       "The fee the transaction pays valued in bitcoin",
+	"walletcreatefundedpsbtresult-changepos": "The index of the change output, or -1 if there is none",
 	// WalletLockCmd help.
 	"walletlock--synopsis": "Lock the wallet.",
 	// WalletPassphraseCmd help.
@@ -312,6 +429,15 @@ var helpDescsEnUS = map[string]string{
 	"walletpassphrasechange--synopsis":     "Change the wallet passphrase.",
 	"walletpassphrasechange-oldpassphrase": "The old wallet passphrase",
 	"walletpassphrasechange-newpassphrase": "The new wallet passphrase",
+	// WalletProcessPsbtCmd help.
+	"walletprocesspsbt--synopsis": "Adds what the wallet knows about the inputs of a PSBT, signs the inputs it has keys for and finalizes the inputs that have all of the signatures they need.\n" +
+		"The valid sighashtype options are ALL, NONE, SINGLE, ALL|ANYONECANPAY, NONE|ANYONECANPAY, and SINGLE|ANYONECANPAY.",
+	"walletprocesspsbt-psbt":        "The base64 encoded PSBT",
+	"walletprocesspsbt-sign":        "Whether to sign the inputs the wallet has keys for",
+	"walletprocesspsbt-sighashtype": "Sighash flags, which must match the ones given in the PSBT for an input if there are any",
+	// WalletProcessPsbtResult help.
+	"walletprocesspsbtresult-psbt":     "The updated PSBT encoded in base64",
+	"walletprocesspsbtresult-complete": "Whether all of the inputs are finalized",
 	// CreateNewAccountCmd help.
 	"createnewaccount--synopsis": "Creates a new account.\n" +
 		"The wallet must be unlocked for this request to succeed.",
@@ -330,6 +456,13 @@ var helpDescsEnUS = map[string]string{
 	"getunconfirmedbalance--synopsis": "Calculates the unspent output value of all unmined transaction outputs for an account.",
 	"getunconfirmedbalance-account":   "The account to query the unconfirmed balance for (default=\"default\")",
 	"getunconfirmedbalance--result0":  "Total amount of all unmined unspent outputs of the account valued in bitcoin.",
+	// ImportXpubCmd help.
+	"importxpub--synopsis": "Creates a watch-only account from the extended public key of an account (m/purpose'/coin'/account') of a wallet that keeps its private keys elsewhere.\n" +
+		"Its outputs can be spent with walletcreatefundedpsbt and the PSBT signed by that wallet.",
+	"importxpub-account":  "Name of the new account",
+	"importxpub-xpub":     "The base58 encoded extended public key",
+	"importxpub-rescan":   "Search the blockchain (since the genesis block) for used addresses of the account and rescan for them",
+	"importxpub-gaplimit": "The number of unused addresses in a row after which the search stops",
 	// ListAddressTransactionsCmd help.
 	"listaddresstransactions--synopsis": "Returns a JSON array of objects containing verbose details for wallet transactions pertaining some addresses.",
 	"listaddresstransactions-addresses": "Addresses to filter transaction results by",
@@ -66,4 +66,11 @@ var (
 		Code:    btcjson.ErrRPCInvalidParameter,
 		Message: "Account name is reserved by RPC server",
 	}
+	ErrWalletNotFound = btcjson.RPCError{
+		Code:    btcjson.ErrRPCWalletNotFound,
+		Message: "Requested wallet does not exist or is not loaded",
+	}
+	ErrDefaultWalletName = InvalidParameterError{
+		errors.New("the default wallet can't be loaded or unloaded by name"),
+	}
 )
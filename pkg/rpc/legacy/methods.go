@@ -7,6 +7,7 @@ import (
 	js "encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
@@ -21,6 +22,8 @@ import (
 	rpcclient "github.com/p9c/pod/pkg/rpc/client"
 	"github.com/p9c/pod/pkg/util"
 	ec "github.com/p9c/pod/pkg/util/elliptic"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+	"github.com/p9c/pod/pkg/util/psbt"
 	"github.com/p9c/pod/pkg/wallet"
 	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
 	"github.com/p9c/pod/pkg/wallet/chain"
@@ -54,9 +57,14 @@ type RequestHandler func(interface{}, *wallet.Wallet) (interface{}, error)
 // requestHandlerChain is a requestHandler that also takes a parameter for
 type RequestHandlerChainRequired func(interface{}, *wallet.Wallet, *chain.RPCClient) (interface{}, error)
 
+// RequestHandlerLoader is a handler of a request about the wallets of the
+// loader rather than a single wallet.
+type RequestHandlerLoader func(interface{}, *wallet.Loader) (interface{}, error)
+
 var RPCHandlers = map[string]struct {
-	Handler          RequestHandler
-	HandlerWithChain RequestHandlerChainRequired
+	Handler           RequestHandler
+	HandlerWithChain  RequestHandlerChainRequired
+	HandlerWithLoader RequestHandlerLoader
 	// Function variables cannot be compared against anything but nil, so
 	// use a boolean to record whether help generation is necessary.  This
 	// is used by the tests to ensure that help can be generated for every
@@ -69,8 +77,13 @@ var RPCHandlers = map[string]struct {
 }{
 	// Reference implementation wallet methods (implemented)
 	"addmultisigaddress":     {Handler: AddMultiSigAddress},
+	"backupwallet":           {Handler: BackupWallet},
+	"bumpfee":                {Handler: BumpFee},
+	"combinepsbt":            {Handler: CombinePsbt},
 	"createmultisig":         {Handler: CreateMultiSig},
 	"dumpprivkey":            {Handler: DumpPrivKey},
+	"dumpwallet":             {Handler: DumpWallet},
+	"finalizepsbt":           {Handler: FinalizePsbt},
 	"getaccount":             {Handler: GetAccount},
 	"getaccountaddress":      {Handler: GetAccountAddress},
 	"getaddressesbyaccount":  {Handler: GetAddressesByAccount},
@@ -83,34 +96,39 @@ var RPCHandlers = map[string]struct {
 	"getreceivedbyaccount":   {Handler: GetReceivedByAccount},
 	"getreceivedbyaddress":   {Handler: GetReceivedByAddress},
 	"gettransaction":         {Handler: GetTransaction},
+	"getwalletinfo":          {Handler: GetWalletInfo},
 	"help":                   {Handler: HelpNoChainRPC, HandlerWithChain: HelpWithChainRPC},
 	"importprivkey":          {Handler: ImportPrivKey},
+	"importwallet":           {Handler: ImportWallet},
 	"keypoolrefill":          {Handler: KeypoolRefill},
 	"listaccounts":           {Handler: ListAccounts},
+	"listaddressgroupings":   {Handler: ListAddressGroupings},
 	"listlockunspent":        {Handler: ListLockUnspent},
 	"listreceivedbyaccount":  {Handler: ListReceivedByAccount},
 	"listreceivedbyaddress":  {Handler: ListReceivedByAddress},
 	"listsinceblock":         {HandlerWithChain: ListSinceBlock},
 	"listtransactions":       {Handler: ListTransactions},
 	"listunspent":            {Handler: ListUnspent},
+	"listwallets":            {HandlerWithLoader: ListWallets},
+	"loadwallet":             {HandlerWithLoader: LoadWallet},
 	"lockunspent":            {Handler: LockUnspent},
 	"sendfrom":               {HandlerWithChain: SendFrom},
 	"sendmany":               {Handler: SendMany},
 	"sendtoaddress":          {Handler: SendToAddress},
+	"setlabel":               {Handler: SetLabel},
+	"setoutputlabel":         {Handler: SetOutputLabel},
+	"settxcomment":           {Handler: SetTxComment},
 	"settxfee":               {Handler: SetTxFee},
 	"signmessage":            {Handler: SignMessage},
 	"signrawtransaction":     {HandlerWithChain: SignRawTransaction},
+	"unloadwallet":           {HandlerWithLoader: UnloadWallet},
 	"validateaddress":        {Handler: ValidateAddress},
 	"verifymessage":          {Handler: VerifyMessage},
+	"walletcreatefundedpsbt": {Handler: WalletCreateFundedPsbt},
 	"walletlock":             {Handler: WalletLock},
 	"walletpassphrase":       {Handler: WalletPassphrase},
 	"walletpassphrasechange": {Handler: WalletPassphraseChange},
-	// Reference implementation methods (still unimplemented)
-	"backupwallet":         {Handler: Unimplemented, NoHelp: true},
-	"dumpwallet":           {Handler: Unimplemented, NoHelp: true},
-	"getwalletinfo":        {Handler: Unimplemented, NoHelp: true},
-	"importwallet":         {Handler: Unimplemented, NoHelp: true},
-	"listaddressgroupings": {Handler: Unimplemented, NoHelp: true},
+	"walletprocesspsbt":      {Handler: WalletProcessPsbt},
 	// Reference methods which can't be implemented by btcwallet due to
 	// design decision differences
 	"encryptwallet": {Handler: Unsupported, NoHelp: true},
@@ -124,6 +142,7 @@ var RPCHandlers = map[string]struct {
 	// here because it hasn't been update to use the reference
 	// implemenation's API.
 	"getunconfirmedbalance":   {Handler: GetUnconfirmedBalance},
+	"importxpub":              {Handler: ImportXpub},
 	"listaddresstransactions": {Handler: ListAddressTransactions},
 	"listalltransactions":     {Handler: ListAllTransactions},
 	"renameaccount":           {Handler: RenameAccount},
@@ -155,10 +174,27 @@ type LazyHandler func() (interface{}, *btcjson.RPCError)
 
 // LazyApplyHandler looks up the best request handler func for the method,
 // returning a closure that will execute it with the (required) wallet and
-// (optional) consensus RPC server.  If no handlers are found and the
+// (optional) consensus RPC server.  Requests about the loaded wallets are
+// handled with the loader instead.  If no handlers are found and the
 // chainClient is not nil, the returned handler performs RPC passthrough.
-func LazyApplyHandler(request *btcjson.Request, w *wallet.Wallet, chainClient chain.Interface) LazyHandler {
+func LazyApplyHandler(request *btcjson.Request, w *wallet.Wallet,
+	chainClient chain.Interface, loader *wallet.Loader) LazyHandler {
 	handlerData, ok := RPCHandlers[request.Method]
+	if ok && handlerData.HandlerWithLoader != nil && loader != nil {
+		return func() (interface{}, *btcjson.RPCError) {
+			cmd, err := btcjson.UnmarshalCmd(request)
+			if err != nil {
+				log.ERROR(err)
+				return nil, btcjson.ErrRPCInvalidRequest
+			}
+			resp, err := handlerData.HandlerWithLoader(cmd, loader)
+			if err != nil {
+				log.ERROR(err)
+				return nil, JSONError(err)
+			}
+			return resp, nil
+		}
+	}
 	if ok && handlerData.HandlerWithChain != nil && w != nil && chainClient != nil {
 		return func() (interface{}, *btcjson.RPCError) {
 			cmd, err := btcjson.UnmarshalCmd(request)
@@ -218,6 +254,14 @@ func LazyApplyHandler(request *btcjson.Request, w *wallet.Wallet, chainClient ch
 				return nil, JSONError(err)
 			}
 			return &resp, nil
+		case *chain.NodeClient:
+			resp, err := client.RawRequest(request.Method,
+				request.Params)
+			if err != nil {
+				log.ERROR(err)
+				return nil, JSONError(err)
+			}
+			return &resp, nil
 		default:
 			return nil, &btcjson.RPCError{
 				Code:    -1,
@@ -350,6 +394,28 @@ func AddMultiSigAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error)
 	return p2shAddr.EncodeAddress(), nil
 }
 
+// CombinePsbt handles a combinepsbt request by merging PSBTs of the same
+// transaction, such as copies signed by different parties, into one.
+func CombinePsbt(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.CombinePsbtCmd)
+	if len(cmd.Txs) == 0 {
+		return nil, InvalidParameterError{errors.New("no PSBTs to combine")}
+	}
+	packets := make([]*psbt.Packet, len(cmd.Txs))
+	for i := range cmd.Txs {
+		var err error
+		if packets[i], err = DecodePsbt(cmd.Txs[i]); err != nil {
+			return nil, err
+		}
+	}
+	combined, err := psbt.Combine(packets...)
+	if err != nil {
+		log.ERROR(err)
+		return nil, InvalidParameterError{err}
+	}
+	return combined.B64Encode()
+}
+
 // CreateMultiSig handles an createmultisig request by returning a
 // multisig address for the given inputs.
 func CreateMultiSig(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
@@ -371,6 +437,73 @@ func CreateMultiSig(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	}, nil
 }
 
+// BackupWallet handles a backupwallet request by copying the wallet database
+// to the destination, which may be a directory or a file name.
+func BackupWallet(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.BackupWalletCmd)
+	if err := w.Backup(cmd.Destination); err != nil {
+		log.ERROR(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWallet,
+			Message: "Wallet backup failed: " + err.Error(),
+		}
+	}
+	return nil, nil
+}
+
+// BumpFee handles a bumpfee request by replacing an unconfirmed transaction
+// of the wallet with one paying a higher fee, or by paying for it with a child
+// transaction spending its change when it can't be replaced or that is asked
+// for.
+func BumpFee(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.BumpFeeCmd)
+	txHash, err := chainhash.NewHashFromStr(cmd.TxID)
+	if err != nil {
+		log.ERROR(err)
+		return nil, DeserializationError{err}
+	}
+	var feeRate util.Amount
+	var cpfp bool
+	if opts := cmd.Options; opts != nil {
+		if opts.FeeRate != nil {
+			if feeRate, err = util.NewAmount(*opts.FeeRate); err != nil {
+				log.ERROR(err)
+				return nil, err
+			}
+		}
+		if opts.CPFP != nil {
+			cpfp = *opts.CPFP
+		}
+	}
+	bumped, err := w.BumpFee(txHash, feeRate, cpfp)
+	switch {
+	case err == wallet.ErrBumpTxNotFound:
+		return nil, &ErrNoTransactionInfo
+	case waddrmgr.IsError(err, waddrmgr.ErrLocked):
+		return nil, &ErrWalletUnlockNeeded
+	case err == wallet.ErrBumpTxConfirmed, err == wallet.ErrBumpForeignInputs,
+		err == wallet.ErrBumpNoChange, err == wallet.ErrBumpChangeTooSmall,
+		err == wallet.ErrBumpForeignDescendants:
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWallet,
+			Message: "Cannot bump fee: " + err.Error(),
+		}
+	case err != nil:
+		log.ERROR(err)
+		return nil, err
+	}
+	method := "rbf"
+	if bumped.CPFP {
+		method = "cpfp"
+	}
+	return btcjson.BumpFeeResult{
+		TxID:    bumped.Tx.TxHash().String(),
+		Method:  method,
+		OrigFee: bumped.OrigFee.ToDUO(),
+		Fee:     bumped.Fee.ToDUO(),
+	}, nil
+}
+
 // DumpPrivKey handles a dumpprivkey request with the private key
 // for a single address, or an appropiate error if the wallet
 // is locked.
@@ -390,16 +523,37 @@ func DumpPrivKey(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return key, err
 }
 
-// // dumpWallet handles a dumpwallet request by returning  all private
-// // keys in a wallet, or an appropiate error if the wallet is locked.
-// // TODO: finish this to match bitcoind by writing the dump to a file.
-// func dumpWallet(// 	icmd interface{}, w *wallet.Wallet) (interface{}, error) {
-// 	keys, err := w.DumpPrivKeys()
-// 	if waddrmgr.IsError(err, waddrmgr.ErrLocked) {
-// 		return nil, &ErrWalletUnlockNeeded
-// 	}
-// 	return keys, err
-// }
+// DumpWallet handles a dumpwallet request by writing all private keys in the
+// wallet to a file in the format of the reference implementation, or an
+// appropiate error if the wallet is locked.
+func DumpWallet(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.DumpWalletCmd)
+	if w.Locked() {
+		return nil, &ErrWalletUnlockNeeded
+	}
+	f, err := os.OpenFile(cmd.Filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL,
+		0600)
+	if err != nil {
+		log.ERROR(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Cannot create dump file: " + err.Error(),
+		}
+	}
+	err = w.WriteDump(f)
+	if e := f.Close(); err == nil {
+		err = e
+	}
+	if err != nil {
+		log.ERROR(err)
+		_ = os.Remove(cmd.Filename)
+		if waddrmgr.IsError(err, waddrmgr.ErrLocked) {
+			return nil, &ErrWalletUnlockNeeded
+		}
+		return nil, err
+	}
+	return nil, nil
+}
 
 // GetAddressesByAccount handles a getaddressesbyaccount request by returning
 // all addresses for an account, or an error if the requested account does
@@ -531,6 +685,43 @@ func DecodeAddress(s string, params *netparams.Params) (util.Address, error) {
 	return addr, nil
 }
 
+// FinalizePsbt handles a finalizepsbt request by finalizing the inputs of a
+// PSBT that have all of the signatures they need. If all of them are and
+// extracting is asked for the signed transaction is returned, otherwise the
+// PSBT is.
+func FinalizePsbt(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.FinalizePsbtCmd)
+	p, err := DecodePsbt(cmd.Psbt)
+	if err != nil {
+		return nil, err
+	}
+	complete, err := psbt.MaybeFinalizeAll(p)
+	if err != nil {
+		log.ERROR(err)
+		return nil, InvalidParameterError{err}
+	}
+	result := btcjson.FinalizePsbtResult{Complete: complete}
+	if complete && *cmd.Extract {
+		tx, err := psbt.Extract(p)
+		if err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err = tx.Serialize(&buf); err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+		result.Hex = hex.EncodeToString(buf.Bytes())
+		return result, nil
+	}
+	if result.Psbt, err = p.B64Encode(); err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	return result, nil
+}
+
 // GetAccount handles a getaccount request by returning the account name
 // associated with a single address.
 func GetAccount(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
@@ -596,6 +787,34 @@ func GetUnconfirmedBalance(icmd interface{}, w *wallet.Wallet) (interface{}, err
 	return (bals.Total - bals.Spendable).ToDUO(), nil
 }
 
+// GetWalletInfo handles a getwalletinfo request by returning the balances,
+// transaction count and sync state of the wallet.
+func GetWalletInfo(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	bals, err := w.CalculateWalletBalances(1)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	txCount, err := w.TransactionCount()
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	blk := w.Manager.SyncedTo()
+	return &btcjson.GetWalletInfoResult{
+		WalletVersion: int32(waddrmgr.LatestMgrVersion),
+		Balance:       bals.Spendable.ToDUO(),
+		UnconfirmedBalance: (bals.Total - bals.Spendable -
+			bals.ImmatureReward).ToDUO(),
+		ImmatureBalance: bals.ImmatureReward.ToDUO(),
+		TxCount:         txCount,
+		PaytxFee:        txrules.DefaultRelayFeePerKb.ToDUO(),
+		Birthday:        w.Manager.Birthday().Unix(),
+		Blocks:          blk.Height,
+		BestBlockHash:   blk.Hash.String(),
+	}, nil
+}
+
 // ImportPrivKey handles an importprivkey request by parsing
 // a WIF-encoded private key and adding it to an account.
 func ImportPrivKey(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
@@ -632,6 +851,27 @@ func ImportPrivKey(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return nil, err
 }
 
+// ImportWallet handles an importwallet request by importing the private keys
+// in a wallet dump file that the wallet doesn't already have and rescanning
+// for them from the earliest of their birthdays.
+func ImportWallet(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.ImportWalletCmd)
+	f, err := os.Open(cmd.Filename)
+	if err != nil {
+		log.ERROR(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Cannot open wallet dump file: " + err.Error(),
+		}
+	}
+	defer f.Close()
+	_, err = w.ImportDump(f)
+	if waddrmgr.IsError(err, waddrmgr.ErrLocked) {
+		return nil, &ErrWalletUnlockNeeded
+	}
+	return nil, err
+}
+
 // KeypoolRefill handles the keypoolrefill command. Since we handle the keypool
 // automatically this does nothing since refilling is never manually required.
 func KeypoolRefill(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
@@ -659,6 +899,42 @@ func CreateNewAccount(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return nil, err
 }
 
+// ImportXpub handles an importxpub request by creating a watch-only account
+// from an extended public key and, if asked, searching the chain for the
+// addresses of the account that were used.
+func ImportXpub(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.ImportXpubCmd)
+	// The wildcard * is reserved by the rpc server with the special meaning
+	// of "all accounts", so disallow naming accounts to this string.
+	if cmd.Account == "*" {
+		return nil, &ErrReservedAccountName
+	}
+	xpub, err := hdkeychain.NewKeyFromString(cmd.Xpub)
+	if err != nil {
+		log.ERROR(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: "Extended key decode failed: " + err.Error(),
+		}
+	}
+	_, err = w.ImportAccountWatchingOnly(waddrmgr.KeyScopeBIP0044,
+		cmd.Account, xpub, nil, *cmd.Rescan, *cmd.GapLimit)
+	switch {
+	case err == wallet.ErrXpubPrivate, err == wallet.ErrXpubNotAccount,
+		err == wallet.ErrXpubWrongNet:
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: err.Error(),
+		}
+	case waddrmgr.IsError(err, waddrmgr.ErrDuplicateAccount):
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWalletInvalidAccountName,
+			Message: err.Error(),
+		}
+	}
+	return nil, err
+}
+
 // RenameAccount handles a renameaccount request by renaming an account.
 // If the account does not exist an appropiate error will be returned.
 func RenameAccount(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
@@ -788,6 +1064,19 @@ func GetTransaction(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 		return nil, err
 	}
 	if details == nil {
+		// A transaction replaced by bumpfee is gone, but the wallet knows
+		// what replaced it.
+		replacedBy, err := w.TxReplacedBy(txHash)
+		if err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+		if replacedBy != nil {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCNoTxInfo,
+				Message: "Transaction was replaced by " + replacedBy.String(),
+			}
+		}
 		return nil, &ErrNoTransactionInfo
 	}
 	syncBlock := w.Manager.SyncedTo()
@@ -810,6 +1099,15 @@ func GetTransaction(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 		WalletConflicts: []string{}, // Not saved
 		// Generated:     blockchain.IsCoinBaseTx(&details.MsgTx),
 	}
+	label, err := w.TxLabel(txHash)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	if label != nil {
+		ret.Comment = label.Comment
+		ret.To = label.CommentTo
+	}
 	if details.Block.Height != -1 {
 		ret.BlockHash = details.Block.Hash.String()
 		ret.BlockTime = details.Block.Time.Unix()
@@ -870,10 +1168,12 @@ func GetTransaction(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 		}
 		var address string
 		var accountName string
+		var outputAddr util.Address
 		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
 			details.MsgTx.TxOut[cred.Index].PkScript, w.ChainParams())
 		if err == nil && len(addrs) == 1 {
 			addr := addrs[0]
+			outputAddr = addr
 			address = addr.EncodeAddress()
 			account, err := w.AccountOfAddress(addr)
 			if err == nil {
@@ -883,6 +1183,12 @@ func GetTransaction(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 				}
 			}
 		}
+		outputLabel, err := w.OutputLabel(
+			wire.NewOutPoint(txHash, cred.Index), outputAddr)
+		if err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
 		ret.Details = append(ret.Details, btcjson.GetTransactionDetailsResult{
 			// Fields left zeroed:
 			//   InvolvesWatchOnly
@@ -892,6 +1198,7 @@ func GetTransaction(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 			Category: credCat,
 			Amount:   cred.Amount.ToDUO(),
 			Vout:     cred.Index,
+			Label:    outputLabel,
 		})
 	}
 	ret.Amount = creditTotal.ToDUO()
@@ -1030,6 +1337,27 @@ func ListAccounts(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return accountBalances, nil
 }
 
+// ListAddressGroupings handles a listaddressgroupings request by returning
+// the addresses of the wallet grouped by common ownership made public in
+// transactions, each as an array of the address, its balance and its account.
+func ListAddressGroupings(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	groups, err := w.AddressGroupings()
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	result := make([][][]interface{}, len(groups))
+	for i, group := range groups {
+		result[i] = make([][]interface{}, len(group))
+		for j, a := range group {
+			result[i][j] = []interface{}{
+				a.Address.EncodeAddress(), a.Balance.ToDUO(), a.Account,
+			}
+		}
+	}
+	return result, nil
+}
+
 // ListLockUnspent handles a listlockunspent request by returning an slice of
 // all locked outpoints.
 func ListLockUnspent(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
@@ -1284,6 +1612,35 @@ func ListUnspent(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return w.ListUnspent(int32(*cmd.MinConf), int32(*cmd.MaxConf), addresses)
 }
 
+// ListWallets handles the listwallets command.
+func ListWallets(icmd interface{}, loader *wallet.Loader) (interface{}, error) {
+	return loader.LoadedWalletNames(), nil
+}
+
+// LoadWallet handles the loadwallet command by opening a named wallet.
+func LoadWallet(icmd interface{}, loader *wallet.Loader) (interface{}, error) {
+	cmd := icmd.(*btcjson.LoadWalletCmd)
+	if cmd.WalletName == "" {
+		return nil, ErrDefaultWalletName
+	}
+	pubPassphrase := []byte(wallet.InsecurePubPassphrase)
+	if cmd.PubPassphrase != nil {
+		pubPassphrase = []byte(*cmd.PubPassphrase)
+	}
+	_, err := loader.OpenNamedWallet(cmd.WalletName, pubPassphrase)
+	switch err {
+	case nil:
+	case wallet.ErrInvalidWalletName:
+		return nil, InvalidParameterError{err}
+	case wallet.ErrNotExists:
+		return nil, ErrWalletNotFound
+	default:
+		log.ERROR(err)
+		return nil, err
+	}
+	return btcjson.LoadWalletResult{Name: cmd.WalletName}, nil
+}
+
 // LockUnspent handles the lockunspent command.
 func LockUnspent(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.LockUnspentCmd)
@@ -1333,14 +1690,18 @@ func MakeOutputs(pairs map[string]util.Amount, chainParams *netparams.Params) ([
 // SendPairs creates and sends payment transactions.
 // It returns the transaction hash in string format upon success
 // All errors are returned in json.RPCError format
+// The coin control options, if not nil, direct which outputs are spent.
+// The label, if not nil, is saved with the transaction.
 func SendPairs(w *wallet.Wallet, amounts map[string]util.Amount,
-	account uint32, minconf int32, feeSatPerKb util.Amount) (string, error) {
+	account uint32, minconf int32, feeSatPerKb util.Amount,
+	coinControl *wallet.CoinControl, label *wallet.TxLabel) (string, error) {
 	outputs, err := MakeOutputs(amounts, w.ChainParams())
 	if err != nil {
 		log.ERROR(err)
 		return "", err
 	}
-	txHash, err := w.SendOutputs(outputs, account, minconf, feeSatPerKb)
+	txHash, err := w.SendOutputs(outputs, account, minconf, feeSatPerKb,
+		coinControl, label)
 	if err != nil {
 		log.ERROR(err)
 		if err == txrules.ErrAmountNegative {
@@ -1362,10 +1723,100 @@ func SendPairs(w *wallet.Wallet, amounts map[string]util.Amount,
 	log.INFO("successfully sent transaction", txHashStr)
 	return txHashStr, nil
 }
+
+// CoinControlFromOpts returns the coin control options of a send command, or
+// nil if it has none.
+func CoinControlFromOpts(opts *btcjson.CoinControlOpts,
+	params *netparams.Params) (*wallet.CoinControl, error) {
+	if opts == nil {
+		return nil, nil
+	}
+	coinControl, err := makeCoinControl(opts.AddInputs, opts.Exclude,
+		opts.ExcludeLabeled, opts.Strategy, opts.MaxInputs,
+		opts.Replaceable)
+	if err != nil {
+		return nil, err
+	}
+	if coinControl.Inputs, err = decodeOutPoints(opts.Inputs); err != nil {
+		return nil, err
+	}
+	if !IsNilOrEmpty(opts.ChangeAddress) {
+		if coinControl.ChangeAddress, err = DecodeAddress(*opts.ChangeAddress,
+			params); err != nil {
+			return nil, err
+		}
+	}
+	return coinControl, nil
+}
+
+// makeCoinControl returns coin control options from the options of a command
+// that are not the inputs and change address.
+func makeCoinControl(addInputs *bool, exclude []btcjson.TransactionInput,
+	excludeLabeled *bool, strategy *string, maxInputs *int,
+	replaceable *bool) (*wallet.CoinControl, error) {
+	coinControl := &wallet.CoinControl{
+		InputsOnly:     addInputs != nil && !*addInputs,
+		ExcludeLabeled: excludeLabeled != nil && *excludeLabeled,
+		Replaceable:    replaceable != nil && *replaceable,
+	}
+	var err error
+	if coinControl.Exclude, err = decodeOutPoints(exclude); err != nil {
+		return nil, err
+	}
+	if strategy != nil {
+		if coinControl.Strategy, err = wallet.ParseCoinSelectionStrategy(
+			*strategy); err != nil {
+			return nil, InvalidParameterError{err}
+		}
+	}
+	if maxInputs != nil {
+		if *maxInputs < 0 {
+			return nil, InvalidParameterError{
+				errors.New("maximum number of inputs must be positive")}
+		}
+		coinControl.MaxInputs = *maxInputs
+	}
+	return coinControl, nil
+}
+
+// decodeOutPoints returns the outpoints of the transaction inputs of a command.
+func decodeOutPoints(inputs []btcjson.TransactionInput) ([]wire.OutPoint,
+	error) {
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+	outPoints := make([]wire.OutPoint, len(inputs))
+	for i, in := range inputs {
+		hash, err := chainhash.NewHashFromStr(in.Txid)
+		if err != nil {
+			log.ERROR(err)
+			return nil, DeserializationError{err}
+		}
+		outPoints[i] = *wire.NewOutPoint(hash, in.Vout)
+	}
+	return outPoints, nil
+}
 func IsNilOrEmpty(s *string) bool {
 	return s == nil || *s == ""
 }
 
+// TxLabelFromComments returns the label to save with a sent transaction for
+// the comment and commentto arguments of the send commands, or nil if both
+// are empty.
+func TxLabelFromComments(comment, commentTo *string) *wallet.TxLabel {
+	if IsNilOrEmpty(comment) && IsNilOrEmpty(commentTo) {
+		return nil
+	}
+	label := &wallet.TxLabel{}
+	if comment != nil {
+		label.Comment = *comment
+	}
+	if commentTo != nil {
+		label.CommentTo = *commentTo
+	}
+	return label
+}
+
 // SendFrom handles a sendfrom RPC request by creating a new transaction
 // spending unspent transaction outputs for a wallet to another payment
 // address.  Leftover inputs not sent to the payment address or a fee for
@@ -1373,14 +1824,6 @@ func IsNilOrEmpty(s *string) bool {
 // the TxID for the created transaction is returned.
 func SendFrom(icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient) (interface{}, error) {
 	cmd := icmd.(*btcjson.SendFromCmd)
-	// Transaction comments are not yet supported.  Error instead of
-	// pretending to save them.
-	if !IsNilOrEmpty(cmd.Comment) || !IsNilOrEmpty(cmd.CommentTo) {
-		return nil, &btcjson.RPCError{
-			Code:    btcjson.ErrRPCUnimplemented,
-			Message: "Transaction comments are not yet supported",
-		}
-	}
 	account, err := w.AccountNumber(
 		waddrmgr.KeyScopeBIP0044, cmd.FromAccount,
 	)
@@ -1406,7 +1849,8 @@ func SendFrom(icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient)
 		cmd.ToAddress: amt,
 	}
 	return SendPairs(w, pairs, account, minConf,
-		txrules.DefaultRelayFeePerKb)
+		txrules.DefaultRelayFeePerKb, nil,
+		TxLabelFromComments(cmd.Comment, cmd.CommentTo))
 }
 
 // SendMany handles a sendmany RPC request by creating a new transaction
@@ -1416,14 +1860,6 @@ func SendFrom(icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient)
 // Upon success, the TxID for the created transaction is returned.
 func SendMany(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.SendManyCmd)
-	// Transaction comments are not yet supported.  Error instead of
-	// pretending to save them.
-	if !IsNilOrEmpty(cmd.Comment) {
-		return nil, &btcjson.RPCError{
-			Code:    btcjson.ErrRPCUnimplemented,
-			Message: "Transaction comments are not yet supported",
-		}
-	}
 	account, err := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.FromAccount)
 	if err != nil {
 		log.ERROR(err)
@@ -1444,7 +1880,13 @@ func SendMany(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 		}
 		pairs[k] = amt
 	}
-	return SendPairs(w, pairs, account, minConf, txrules.DefaultRelayFeePerKb)
+	coinControl, err := CoinControlFromOpts(cmd.CoinControl, w.ChainParams())
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	return SendPairs(w, pairs, account, minConf, txrules.DefaultRelayFeePerKb,
+		coinControl, TxLabelFromComments(cmd.Comment, nil))
 }
 
 // SendToAddress handles a sendtoaddress RPC request by creating a new
@@ -1454,14 +1896,6 @@ func SendMany(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 // the TxID for the created transaction is returned.
 func SendToAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.SendToAddressCmd)
-	// Transaction comments are not yet supported.  Error instead of
-	// pretending to save them.
-	if !IsNilOrEmpty(cmd.Comment) || !IsNilOrEmpty(cmd.CommentTo) {
-		return nil, &btcjson.RPCError{
-			Code:    btcjson.ErrRPCUnimplemented,
-			Message: "Transaction comments are not yet supported",
-		}
-	}
 	amt, err := util.NewAmount(cmd.Amount)
 	if err != nil {
 		log.ERROR(err)
@@ -1477,7 +1911,8 @@ func SendToAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	}
 	// sendtoaddress always spends from the default account, this matches bitcoind
 	return SendPairs(w, pairs, waddrmgr.DefaultAccountNum, 1,
-		txrules.DefaultRelayFeePerKb)
+		txrules.DefaultRelayFeePerKb, nil,
+		TxLabelFromComments(cmd.Comment, cmd.CommentTo))
 }
 
 // SetTxFee sets the transaction fee per kilobyte added to transactions.
@@ -1491,34 +1926,78 @@ func SetTxFee(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return true, nil
 }
 
-// SignMessage signs the given message with the private key for the given
-// address
-func SignMessage(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
-	cmd := icmd.(*btcjson.SignMessageCmd)
+// SetLabel handles a setlabel request by saving a label for an address, which
+// is shown for the transaction outputs paying to it.  An empty label removes
+// it.
+func SetLabel(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.SetLabelCmd)
 	addr, err := DecodeAddress(cmd.Address, w.ChainParams())
 	if err != nil {
 		log.ERROR(err)
 		return nil, err
 	}
-	privKey, err := w.PrivKeyForAddress(addr)
+	if err := w.SetAddressLabel(addr, cmd.Label); err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// SetOutputLabel handles a setoutputlabel request by saving a label for a
+// transaction output, which is shown instead of the label of the address it
+// pays to.  An empty label removes it.
+func SetOutputLabel(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.SetOutputLabelCmd)
+	txHash, err := chainhash.NewHashFromStr(cmd.Txid)
 	if err != nil {
+		log.ERROR(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDecodeHexString,
+			Message: "Transaction hash string decode failed: " + err.Error(),
+		}
+	}
+	op := wire.NewOutPoint(txHash, cmd.Vout)
+	if err := w.SetOutPointLabel(op, cmd.Label); err != nil {
 		log.ERROR(err)
 		return nil, err
 	}
-	var buf bytes.Buffer
-	err = wire.WriteVarString(&buf, 0, "Bitcoin Signed Message:\n")
+	return nil, nil
+}
+
+// SetTxComment handles a settxcomment request by saving the comment of a
+// transaction and who it was sent to, replacing what was saved before.
+// Empty values remove them.
+func SetTxComment(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.SetTxCommentCmd)
+	txHash, err := chainhash.NewHashFromStr(cmd.Txid)
 	if err != nil {
 		log.ERROR(err)
-		log.DEBUG(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDecodeHexString,
+			Message: "Transaction hash string decode failed: " + err.Error(),
+		}
 	}
-	err = wire.WriteVarString(&buf, 0, cmd.Message)
+	label := &wallet.TxLabel{Comment: cmd.Comment}
+	if cmd.CommentTo != nil {
+		label.CommentTo = *cmd.CommentTo
+	}
+	if err := w.SetTxLabel(txHash, label); err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// SignMessage signs the given message with the private key for the given
+// address
+func SignMessage(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.SignMessageCmd)
+	addr, err := DecodeAddress(cmd.Address, w.ChainParams())
 	if err != nil {
 		log.ERROR(err)
-		log.DEBUG(err)
+		return nil, err
 	}
-	messageHash := chainhash.DoubleHashB(buf.Bytes())
-	sigbytes, err := ec.SignCompact(ec.S256(), privKey,
-		messageHash, true)
+	sigbytes, err := w.SignMessage(addr, cmd.Message)
 	if err != nil {
 		log.ERROR(err)
 		return nil, err
@@ -1541,23 +2020,9 @@ func SignRawTransaction(icmd interface{}, w *wallet.Wallet, chainClient *chain.R
 		e := errors.New("TX decode failed")
 		return nil, DeserializationError{e}
 	}
-	var hashType txscript.SigHashType
-	switch *cmd.Flags {
-	case "ALL":
-		hashType = txscript.SigHashAll
-	case "NONE":
-		hashType = txscript.SigHashNone
-	case "SINGLE":
-		hashType = txscript.SigHashSingle
-	case "ALL|ANYONECANPAY":
-		hashType = txscript.SigHashAll | txscript.SigHashAnyOneCanPay
-	case "NONE|ANYONECANPAY":
-		hashType = txscript.SigHashNone | txscript.SigHashAnyOneCanPay
-	case "SINGLE|ANYONECANPAY":
-		hashType = txscript.SigHashSingle | txscript.SigHashAnyOneCanPay
-	default:
-		e := errors.New("Invalid sighash parameter")
-		return nil, InvalidParameterError{e}
+	hashType, err := ParseSigHashType(*cmd.Flags)
+	if err != nil {
+		return nil, err
 	}
 	// TODO: really we probably should look these up with pod anyway to
 	// make sure that they match the blockchain if present.
@@ -1693,6 +2158,20 @@ func SignRawTransaction(icmd interface{}, w *wallet.Wallet, chainClient *chain.R
 	}, nil
 }
 
+// UnloadWallet handles the unloadwallet command by unloading a wallet loaded
+// with loadwallet.
+func UnloadWallet(icmd interface{}, loader *wallet.Loader) (interface{}, error) {
+	cmd := icmd.(*btcjson.UnloadWalletCmd)
+	if cmd.WalletName == "" {
+		return nil, ErrDefaultWalletName
+	}
+	err := loader.UnloadNamedWallet(cmd.WalletName)
+	if err == wallet.ErrNotLoaded {
+		return nil, ErrWalletNotFound
+	}
+	return nil, err
+}
+
 // ValidateAddress handles the validateaddress command.
 func ValidateAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	cmd := icmd.(*btcjson.ValidateAddressCmd)
@@ -1819,6 +2298,108 @@ func VerifyMessage(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	}
 }
 
+// WalletCreateFundedPsbt handles a walletcreatefundedpsbt request by
+// creating a PSBT paying to the outputs with inputs from the default account
+// chosen as they are for a transaction the wallet sends. Inputs that are given
+// are always spent. The PSBT is not signed.
+func WalletCreateFundedPsbt(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.WalletCreateFundedPsbtCmd)
+	var lockTime uint32
+	if cmd.LockTime != nil {
+		if *cmd.LockTime < 0 ||
+			*cmd.LockTime > int64(wire.MaxTxInSequenceNum) {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: "Locktime out of range",
+			}
+		}
+		lockTime = uint32(*cmd.LockTime)
+	}
+	inputs, err := decodeOutPoints(cmd.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	pairs := make(map[string]util.Amount, len(cmd.Outputs))
+	for addr, v := range cmd.Outputs {
+		amt, err := util.NewAmount(v)
+		if err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+		if amt <= 0 {
+			return nil, ErrNeedPositiveAmount
+		}
+		pairs[addr] = amt
+	}
+	outputs, err := MakeOutputs(pairs, w.ChainParams())
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	feeRate := txrules.DefaultRelayFeePerKb
+	coinControl := &wallet.CoinControl{}
+	var lockUnspents bool
+	account := uint32(waddrmgr.DefaultAccountNum)
+	if opts := cmd.Options; opts != nil {
+		if coinControl, err = makeCoinControl(opts.AddInputs, opts.Exclude,
+			opts.ExcludeLabeled, opts.Strategy, opts.MaxInputs,
+			opts.Replaceable); err != nil {
+			return nil, err
+		}
+		if !IsNilOrEmpty(opts.ChangeAddress) {
+			if coinControl.ChangeAddress, err = DecodeAddress(
+				*opts.ChangeAddress, w.ChainParams()); err != nil {
+				return nil, err
+			}
+		}
+		if opts.FeeRate != nil {
+			if feeRate, err = util.NewAmount(*opts.FeeRate); err != nil {
+				log.ERROR(err)
+				return nil, err
+			}
+			if feeRate < 0 {
+				return nil, InvalidParameterError{
+					errors.New("fee rate must be positive")}
+			}
+		}
+		lockUnspents = opts.LockUnspents != nil && *opts.LockUnspents
+		if opts.Account != nil {
+			if account, err = w.AccountNumber(waddrmgr.KeyScopeBIP0044,
+				*opts.Account); err != nil {
+				log.ERROR(err)
+				return nil, err
+			}
+		}
+	}
+	coinControl.Inputs = inputs
+	p, changeIndex, fee, err := w.FundPsbt(account, outputs, lockTime, 1,
+		feeRate, coinControl, lockUnspents)
+	if err != nil {
+		log.ERROR(err)
+		if err == txrules.ErrAmountNegative {
+			return nil, ErrNeedPositiveAmount
+		}
+		switch err.(type) {
+		case btcjson.RPCError:
+			return nil, err
+		}
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInternal.Code,
+			Message: err.Error(),
+		}
+	}
+	b64, err := p.B64Encode()
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	return btcjson.WalletCreateFundedPsbtResult{
+		Psbt:      b64,
+		Fee:       fee.ToDUO(),
+		ChangePos: int64(changeIndex),
+	}, nil
+}
+
 // WalletIsLocked handles the walletislocked extension request by
 // returning the current lock state (false for unlocked, true for locked)
 // of an account.
@@ -1868,6 +2449,70 @@ func WalletPassphraseChange(icmd interface{}, w *wallet.Wallet) (interface{}, er
 	return nil, err
 }
 
+// WalletProcessPsbt handles a walletprocesspsbt request by adding what the
+// wallet knows about the inputs of a PSBT, signing the inputs it has keys for
+// if asked to and finalizing those that have all the signatures they need.
+func WalletProcessPsbt(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.WalletProcessPsbtCmd)
+	p, err := DecodePsbt(cmd.Psbt)
+	if err != nil {
+		return nil, err
+	}
+	hashType, err := ParseSigHashType(*cmd.SighashType)
+	if err != nil {
+		return nil, err
+	}
+	if *cmd.Sign && w.Locked() {
+		return nil, &ErrWalletUnlockNeeded
+	}
+	complete, err := w.SignPsbt(p, hashType, *cmd.Sign)
+	if err != nil {
+		log.ERROR(err)
+		if waddrmgr.IsError(err, waddrmgr.ErrLocked) {
+			return nil, &ErrWalletUnlockNeeded
+		}
+		return nil, err
+	}
+	b64, err := p.B64Encode()
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	return btcjson.WalletProcessPsbtResult{Psbt: b64, Complete: complete}, nil
+}
+
+// DecodePsbt decodes a base64 encoded PSBT passed in a request.
+func DecodePsbt(b64 string) (*psbt.Packet, error) {
+	p, err := psbt.Decode(b64)
+	if err != nil {
+		log.ERROR(err)
+		return nil, DeserializationError{fmt.Errorf("PSBT decode failed: %v",
+			err)}
+	}
+	return p, nil
+}
+
+// ParseSigHashType returns the signature hash type named by the flags of a
+// signing request.
+func ParseSigHashType(flags string) (txscript.SigHashType, error) {
+	switch flags {
+	case "ALL":
+		return txscript.SigHashAll, nil
+	case "NONE":
+		return txscript.SigHashNone, nil
+	case "SINGLE":
+		return txscript.SigHashSingle, nil
+	case "ALL|ANYONECANPAY":
+		return txscript.SigHashAll | txscript.SigHashAnyOneCanPay, nil
+	case "NONE|ANYONECANPAY":
+		return txscript.SigHashNone | txscript.SigHashAnyOneCanPay, nil
+	case "SINGLE|ANYONECANPAY":
+		return txscript.SigHashSingle | txscript.SigHashAnyOneCanPay, nil
+	}
+	e := errors.New("Invalid sighash parameter")
+	return 0, InvalidParameterError{e}
+}
+
 // DecodeHexStr decodes the hex encoding of a string, possibly prepending a
 // leading '0' character if there is an odd number of bytes in the hex string.
 // This is to prevent an error for an invalid hex string when using an odd
@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -103,7 +104,7 @@ func NewServer(opts *Options, walletLoader *wallet.Loader, listeners []net.Liste
 		Quit:                make(chan struct{}),
 		RequestShutdownChan: make(chan struct{}, 1),
 	}
-	serveMux.Handle("/", ThrottledFn(opts.MaxPOSTClients,
+	postHandler := ThrottledFn(opts.MaxPOSTClients,
 		func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Connection", "close")
 			w.Header().Set("Content-Type", "application/json")
@@ -116,7 +117,10 @@ func NewServer(opts *Options, walletLoader *wallet.Loader, listeners []net.Liste
 			server.WG.Add(1)
 			server.POSTClientRPC(w, r)
 			server.WG.Done()
-		}))
+		})
+	serveMux.Handle("/", postHandler)
+	// Requests for wallets loaded by name are sent to the path of their name.
+	serveMux.Handle(WalletPathPrefix, postHandler)
 	serveMux.Handle("/ws", ThrottledFn(opts.MaxWebsocketClients,
 		func(w http.ResponseWriter, r *http.Request) {
 			authenticated := false
@@ -260,7 +264,44 @@ func (s *Server) HandlerClosure(request *btcjson.Request) LazyHandler {
 		s.ChainClient = chainClient
 	}
 	s.HandlerMutex.Unlock()
-	return LazyApplyHandler(request, wllt, chainClient)
+	return LazyApplyHandler(request, wllt, chainClient, s.WalletLoader)
+}
+
+// WalletPathPrefix is the prefix of the URL path HTTP POST requests for a
+// wallet loaded by name are sent to, followed by the name.
+const WalletPathPrefix = "/wallet/"
+
+// WalletNameFromPath returns the name of the wallet requests sent to the URL
+// path are for, the empty name of the default wallet for paths not starting
+// with WalletPathPrefix.
+func WalletNameFromPath(path string) string {
+	if !strings.HasPrefix(path, WalletPathPrefix) {
+		return ""
+	}
+	return strings.TrimSuffix(path[len(WalletPathPrefix):], "/")
+}
+
+// WalletHandlerClosure creates a closure function for handling requests of
+// the given method for the wallet with the name in the same way as
+// HandlerClosure does for the default wallet, which has the empty name.  If
+// no wallet with the name is loaded, only requests about the loaded wallets
+// are handled.
+func (s *Server) WalletHandlerClosure(name string,
+	request *btcjson.Request) LazyHandler {
+	if name == "" {
+		return s.HandlerClosure(request)
+	}
+	w, ok := s.WalletLoader.NamedWallet(name)
+	if !ok {
+		handlerData := RPCHandlers[request.Method]
+		if handlerData.HandlerWithLoader != nil {
+			return LazyApplyHandler(request, nil, nil, s.WalletLoader)
+		}
+		return func() (interface{}, *btcjson.RPCError) {
+			return nil, &ErrWalletNotFound
+		}
+	}
+	return LazyApplyHandler(request, w, w.ChainClient(), s.WalletLoader)
 }
 
 // ErrNoAuth represents an error where authentication could not succeed
@@ -593,7 +634,8 @@ func (s *Server) POSTClientRPC(w http.ResponseWriter, r *http.Request) {
 		stop = true
 		res = "pod/wallet stopping"
 	default:
-		res, jsonErr = s.HandlerClosure(&req)()
+		res, jsonErr = s.WalletHandlerClosure(WalletNameFromPath(r.URL.Path),
+			&req)()
 	}
 	// Marshal and send.
 	mResp, err := btcjson.MarshalResponse(req.ID, res, jsonErr)
@@ -13,7 +13,10 @@ package rpcserver
 
 import (
 	"bytes"
+	"encoding/hex"
 	"errors"
+	"io"
+	"math"
 	"sync"
 	"time"
 
@@ -24,6 +27,7 @@ import (
 
 	"github.com/p9c/pod/pkg/chain/config/netparams"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txrules "github.com/p9c/pod/pkg/chain/tx/rules"
 	txscript "github.com/p9c/pod/pkg/chain/tx/script"
 	"github.com/p9c/pod/pkg/chain/wire"
 	"github.com/p9c/pod/pkg/log"
@@ -32,6 +36,7 @@ import (
 	"github.com/p9c/pod/pkg/util"
 	cfgutil "github.com/p9c/pod/pkg/util/config"
 	"github.com/p9c/pod/pkg/util/hdkeychain"
+	"github.com/p9c/pod/pkg/util/psbt"
 	"github.com/p9c/pod/pkg/util/zero"
 	"github.com/p9c/pod/pkg/wallet"
 	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
@@ -41,10 +46,10 @@ import (
 
 // Public API version constants
 const (
-	semverString = "2.0.1"
+	semverString = "2.3.0"
 	semverMajor  = 2
-	semverMinor  = 0
-	semverPatch  = 1
+	semverMinor  = 3
+	semverPatch  = 0
 )
 
 // translateError creates a new gRPC error with an appropiate error code for
@@ -73,12 +78,23 @@ func errorCode(err error) codes.Code {
 			return codes.InvalidArgument
 		case waddrmgr.ErrDuplicateAccount:
 			return codes.AlreadyExists
+		case waddrmgr.ErrAddressNotFound:
+			return codes.NotFound
+		case waddrmgr.ErrLocked, waddrmgr.ErrWatchingOnly:
+			return codes.FailedPrecondition
 		}
 		err = e.Err
 	}
 	switch err {
+	case txrules.ErrAmountNegative, txrules.ErrAmountExceedsMax,
+		txrules.ErrOutputIsDust:
+		return codes.InvalidArgument
 	case wallet.ErrLoaded:
 		return codes.FailedPrecondition
+	case wallet.ErrInvalidWalletName:
+		return codes.InvalidArgument
+	case wallet.ErrNotExists:
+		return codes.NotFound
 	case walletdb.ErrDbNotOpen:
 		return codes.Aborted
 	case walletdb.ErrDbExists:
@@ -491,6 +507,475 @@ func (s *walletServer) PublishTransaction(ctx context.Context, req *pb.PublishTr
 	}
 	return &pb.PublishTransactionResponse{}, nil
 }
+func (s *walletServer) FundPsbt(ctx context.Context, req *pb.FundPsbtRequest) (
+	*pb.FundPsbtResponse, error) {
+	inputs := make([]wire.OutPoint, len(req.Inputs))
+	for i, in := range req.Inputs {
+		hash, err := chainhash.NewHash(in.TransactionHash)
+		if err != nil {
+			log.ERROR(err)
+			return nil, status.Errorf(codes.InvalidArgument,
+				"Invalid transaction hash: %v", err)
+		}
+		inputs[i] = *wire.NewOutPoint(hash, in.OutputIndex)
+	}
+	outputs := make([]*wire.TxOut, len(req.Outputs))
+	for i, out := range req.Outputs {
+		outputs[i] = wire.NewTxOut(out.Amount, out.PkScript)
+	}
+	feeRate := txrules.DefaultRelayFeePerKb
+	if req.FeeRate != 0 {
+		feeRate = util.Amount(req.FeeRate)
+	}
+	coinControl, err := s.unmarshalCoinControl(req.CoinControl)
+	if err != nil {
+		return nil, err
+	}
+	coinControl.Inputs = append(inputs, coinControl.Inputs...)
+	p, changeIndex, fee, err := s.wallet.FundPsbt(req.Account, outputs,
+		req.LockTime, req.RequiredConfirmations, feeRate, coinControl,
+		req.LockUnspents)
+	if err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	var buf bytes.Buffer
+	if err = p.Serialize(&buf); err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	return &pb.FundPsbtResponse{
+		Psbt:        buf.Bytes(),
+		ChangeIndex: int32(changeIndex),
+		Fee:         int64(fee),
+	}, nil
+}
+func (s *walletServer) ProcessPsbt(ctx context.Context, req *pb.ProcessPsbtRequest) (
+	*pb.ProcessPsbtResponse, error) {
+	defer zero.Bytes(req.Passphrase)
+	p, err := psbt.NewFromRawBytes(bytes.NewReader(req.Psbt), false)
+	if err != nil {
+		log.ERROR(err)
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Bytes do not represent a valid PSBT: %v", err)
+	}
+	if req.Sign {
+		lock := make(chan time.Time, 1)
+		defer func() {
+			lock <- time.Time{} // send matters, not the value
+		}()
+		err = s.wallet.Unlock(req.Passphrase, lock)
+		if err != nil {
+			log.ERROR(err)
+			return nil, translateError(err)
+		}
+	}
+	hashType := txscript.SigHashAll
+	if req.SighashType != 0 {
+		hashType = txscript.SigHashType(req.SighashType)
+	}
+	complete, err := s.wallet.SignPsbt(p, hashType, req.Sign)
+	if err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	var buf bytes.Buffer
+	if err = p.Serialize(&buf); err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	return &pb.ProcessPsbtResponse{Psbt: buf.Bytes(), Complete: complete}, nil
+}
+func (s *walletServer) FinalizePsbt(ctx context.Context, req *pb.FinalizePsbtRequest) (
+	*pb.FinalizePsbtResponse, error) {
+	p, err := psbt.NewFromRawBytes(bytes.NewReader(req.Psbt), false)
+	if err != nil {
+		log.ERROR(err)
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Bytes do not represent a valid PSBT: %v", err)
+	}
+	complete, err := psbt.MaybeFinalizeAll(p)
+	if err != nil {
+		log.ERROR(err)
+		return nil, status.Errorf(codes.InvalidArgument,
+			"PSBT can not be finalized: %v", err)
+	}
+	resp := &pb.FinalizePsbtResponse{Complete: complete}
+	var buf bytes.Buffer
+	if complete && req.Extract {
+		tx, err := psbt.Extract(p)
+		if err != nil {
+			log.ERROR(err)
+			return nil, translateError(err)
+		}
+		buf.Grow(tx.SerializeSize())
+		if err = tx.Serialize(&buf); err != nil {
+			log.ERROR(err)
+			return nil, translateError(err)
+		}
+		resp.Transaction = buf.Bytes()
+		return resp, nil
+	}
+	if err = p.Serialize(&buf); err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	resp.Psbt = buf.Bytes()
+	return resp, nil
+}
+func (s *walletServer) CombinePsbt(ctx context.Context, req *pb.CombinePsbtRequest) (
+	*pb.CombinePsbtResponse, error) {
+	if len(req.Psbts) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "No PSBTs to combine")
+	}
+	packets := make([]*psbt.Packet, len(req.Psbts))
+	for i := range req.Psbts {
+		var err error
+		packets[i], err = psbt.NewFromRawBytes(bytes.NewReader(req.Psbts[i]),
+			false)
+		if err != nil {
+			log.ERROR(err)
+			return nil, status.Errorf(codes.InvalidArgument,
+				"Bytes do not represent a valid PSBT: %v", err)
+		}
+	}
+	combined, err := psbt.Combine(packets...)
+	if err != nil {
+		log.ERROR(err)
+		return nil, status.Errorf(codes.InvalidArgument,
+			"PSBTs can not be combined: %v", err)
+	}
+	var buf bytes.Buffer
+	if err = combined.Serialize(&buf); err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	return &pb.CombinePsbtResponse{Psbt: buf.Bytes()}, nil
+}
+
+// decodeAddress decodes an address of the wallet's network given in a
+// request.
+func (s *walletServer) decodeAddress(a string) (util.Address, error) {
+	params := s.wallet.ChainParams()
+	addr, err := util.DecodeAddress(a, params)
+	if err != nil {
+		log.ERROR(err)
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Invalid address %q: %v", a, err)
+	}
+	if !addr.IsForNet(params) {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Address %q is not intended for use on %s", a, params.Name)
+	}
+	return addr, nil
+}
+func unmarshalOutPoint(op *pb.OutPoint) (*wire.OutPoint, error) {
+	if op == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Output is missing")
+	}
+	hash, err := chainhash.NewHash(op.TransactionHash)
+	if err != nil {
+		log.ERROR(err)
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Invalid transaction hash: %v", err)
+	}
+	return wire.NewOutPoint(hash, op.OutputIndex), nil
+}
+func (s *walletServer) unmarshalCoinControl(cc *pb.CoinControl) (
+	*wallet.CoinControl, error) {
+	coinControl := &wallet.CoinControl{}
+	if cc == nil {
+		return coinControl, nil
+	}
+	for _, op := range cc.Inputs {
+		outPoint, err := unmarshalOutPoint(op)
+		if err != nil {
+			return nil, err
+		}
+		coinControl.Inputs = append(coinControl.Inputs, *outPoint)
+	}
+	for _, op := range cc.Exclude {
+		outPoint, err := unmarshalOutPoint(op)
+		if err != nil {
+			return nil, err
+		}
+		coinControl.Exclude = append(coinControl.Exclude, *outPoint)
+	}
+	switch cc.Strategy {
+	case pb.CoinControl_LARGEST_FIRST:
+		coinControl.Strategy = wallet.CoinSelectionLargestFirst
+	case pb.CoinControl_BRANCH_AND_BOUND:
+		coinControl.Strategy = wallet.CoinSelectionBranchAndBound
+	case pb.CoinControl_NO_ADDRESS_REUSE:
+		coinControl.Strategy = wallet.CoinSelectionNoAddressReuse
+	default:
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Unknown coin selection strategy %v", cc.Strategy)
+	}
+	if cc.ChangeAddress != "" {
+		addr, err := s.decodeAddress(cc.ChangeAddress)
+		if err != nil {
+			return nil, err
+		}
+		coinControl.ChangeAddress = addr
+	}
+	coinControl.InputsOnly = cc.InputsOnly
+	coinControl.ExcludeLabeled = cc.ExcludeLabeled
+	coinControl.MaxInputs = int(cc.MaxInputs)
+	return coinControl, nil
+}
+func (s *walletServer) ListUnspent(ctx context.Context, req *pb.ListUnspentRequest) (
+	*pb.ListUnspentResponse, error) {
+	maxConfs := req.MaxConfirmations
+	if maxConfs == 0 {
+		maxConfs = math.MaxInt32
+	}
+	var addresses map[string]struct{}
+	if len(req.Addresses) != 0 {
+		addresses = make(map[string]struct{}, len(req.Addresses))
+		for _, a := range req.Addresses {
+			addr, err := s.decodeAddress(a)
+			if err != nil {
+				log.ERROR(err)
+				return nil, err
+			}
+			addresses[addr.EncodeAddress()] = struct{}{}
+		}
+	}
+	results, err := s.wallet.ListUnspent(req.MinConfirmations, maxConfs,
+		addresses)
+	if err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	outputs := make([]*pb.ListUnspentResponse_Output, len(results))
+	for i, r := range results {
+		hash, err := chainhash.NewHashFromStr(r.TxID)
+		if err != nil {
+			log.ERROR(err)
+			return nil, translateError(err)
+		}
+		pkScript, err := hex.DecodeString(r.ScriptPubKey)
+		if err != nil {
+			log.ERROR(err)
+			return nil, translateError(err)
+		}
+		redeemScript, err := hex.DecodeString(r.RedeemScript)
+		if err != nil {
+			log.ERROR(err)
+			return nil, translateError(err)
+		}
+		amount, err := util.NewAmount(r.Amount)
+		if err != nil {
+			log.ERROR(err)
+			return nil, translateError(err)
+		}
+		outputs[i] = &pb.ListUnspentResponse_Output{
+			TransactionHash: hash[:],
+			OutputIndex:     r.Vout,
+			Address:         r.Address,
+			AccountName:     r.Account,
+			PkScript:        pkScript,
+			RedeemScript:    redeemScript,
+			Amount:          int64(amount),
+			Confirmations:   r.Confirmations,
+			Spendable:       r.Spendable,
+		}
+	}
+	return &pb.ListUnspentResponse{Outputs: outputs}, nil
+}
+func (s *walletServer) LockedOutputs(ctx context.Context, req *pb.LockedOutputsRequest) (
+	*pb.LockedOutputsResponse, error) {
+	locked := s.wallet.LockedOutpoints()
+	outputs := make([]*pb.OutPoint, len(locked))
+	for i, op := range locked {
+		hash, err := chainhash.NewHashFromStr(op.Txid)
+		if err != nil {
+			log.ERROR(err)
+			return nil, translateError(err)
+		}
+		outputs[i] = &pb.OutPoint{
+			TransactionHash: hash[:],
+			OutputIndex:     op.Vout,
+		}
+	}
+	return &pb.LockedOutputsResponse{Outputs: outputs}, nil
+}
+func (s *walletServer) LockOutputs(ctx context.Context, req *pb.LockOutputsRequest) (
+	*pb.LockOutputsResponse, error) {
+	if req.Unlock && len(req.Outputs) == 0 {
+		s.wallet.ResetLockedOutpoints()
+		return &pb.LockOutputsResponse{}, nil
+	}
+	ops := make([]*wire.OutPoint, len(req.Outputs))
+	for i := range req.Outputs {
+		var err error
+		if ops[i], err = unmarshalOutPoint(req.Outputs[i]); err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+	}
+	for _, op := range ops {
+		if req.Unlock {
+			s.wallet.UnlockOutpoint(*op)
+		} else {
+			s.wallet.LockOutpoint(*op)
+		}
+	}
+	return &pb.LockOutputsResponse{}, nil
+}
+func (s *walletServer) SendMany(ctx context.Context, req *pb.SendManyRequest) (
+	*pb.SendManyResponse, error) {
+	defer zero.Bytes(req.Passphrase)
+	if len(req.Outputs) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "No outputs to send to")
+	}
+	outputs := make([]*wire.TxOut, len(req.Outputs))
+	for i, out := range req.Outputs {
+		addr, err := s.decodeAddress(out.Address)
+		if err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			log.ERROR(err)
+			return nil, translateError(err)
+		}
+		outputs[i] = wire.NewTxOut(out.Amount, pkScript)
+	}
+	feeRate := txrules.DefaultRelayFeePerKb
+	if req.FeeRate != 0 {
+		feeRate = util.Amount(req.FeeRate)
+	}
+	var label *wallet.TxLabel
+	if req.Comment != "" || req.CommentTo != "" {
+		label = &wallet.TxLabel{Comment: req.Comment, CommentTo: req.CommentTo}
+	}
+	coinControl, err := s.unmarshalCoinControl(req.CoinControl)
+	if err != nil {
+		return nil, err
+	}
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{} // send matters, not the value
+	}()
+	err = s.wallet.Unlock(req.Passphrase, lock)
+	if err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	txHash, err := s.wallet.SendOutputs(outputs, req.Account,
+		req.RequiredConfirmations, feeRate, coinControl, label)
+	if err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	return &pb.SendManyResponse{TransactionHash: txHash[:]}, nil
+}
+func (s *walletServer) SignMessage(ctx context.Context, req *pb.SignMessageRequest) (
+	*pb.SignMessageResponse, error) {
+	defer zero.Bytes(req.Passphrase)
+	addr, err := s.decodeAddress(req.Address)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{} // send matters, not the value
+	}()
+	err = s.wallet.Unlock(req.Passphrase, lock)
+	if err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	sig, err := s.wallet.SignMessage(addr, req.Message)
+	if err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	return &pb.SignMessageResponse{Signature: sig}, nil
+}
+func (s *walletServer) DumpPrivateKey(ctx context.Context, req *pb.DumpPrivateKeyRequest) (
+	*pb.DumpPrivateKeyResponse, error) {
+	defer zero.Bytes(req.Passphrase)
+	addr, err := s.decodeAddress(req.Address)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{} // send matters, not the value
+	}()
+	err = s.wallet.Unlock(req.Passphrase, lock)
+	if err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	wif, err := s.wallet.DumpWIFPrivateKey(addr)
+	if err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	return &pb.DumpPrivateKeyResponse{PrivateKeyWif: wif}, nil
+}
+func (s *walletServer) AddressLabel(ctx context.Context, req *pb.AddressLabelRequest) (
+	*pb.AddressLabelResponse, error) {
+	addr, err := s.decodeAddress(req.Address)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	label, err := s.wallet.AddressLabel(addr)
+	if err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	return &pb.AddressLabelResponse{Label: label}, nil
+}
+func (s *walletServer) SetAddressLabel(ctx context.Context, req *pb.SetAddressLabelRequest) (
+	*pb.SetAddressLabelResponse, error) {
+	addr, err := s.decodeAddress(req.Address)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	if err = s.wallet.SetAddressLabel(addr, req.Label); err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	return &pb.SetAddressLabelResponse{}, nil
+}
+func (s *walletServer) SetOutputLabel(ctx context.Context, req *pb.SetOutputLabelRequest) (
+	*pb.SetOutputLabelResponse, error) {
+	op, err := unmarshalOutPoint(req.Output)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	if err = s.wallet.SetOutPointLabel(op, req.Label); err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	return &pb.SetOutputLabelResponse{}, nil
+}
+func (s *walletServer) SetTransactionComment(ctx context.Context,
+	req *pb.SetTransactionCommentRequest) (*pb.SetTransactionCommentResponse, error) {
+	hash, err := chainhash.NewHash(req.TransactionHash)
+	if err != nil {
+		log.ERROR(err)
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Invalid transaction hash: %v", err)
+	}
+	label := &wallet.TxLabel{Comment: req.Comment, CommentTo: req.CommentTo}
+	if err = s.wallet.SetTxLabel(hash, label); err != nil {
+		log.ERROR(err)
+		return nil, translateError(err)
+	}
+	return &pb.SetTransactionCommentResponse{}, nil
+}
 func marshalTransactionInputs(v []wallet.TransactionSummaryInput) []*pb.TransactionDetails_Input {
 	inputs := make([]*pb.TransactionDetails_Input, len(v))
 	for i := range v {
@@ -511,6 +996,7 @@ func marshalTransactionOutputs(v []wallet.TransactionSummaryOutput) []*pb.Transa
 			Index:    output.Index,
 			Account:  output.Account,
 			Internal: output.Internal,
+			Label:    output.Label,
 		}
 	}
 	return outputs
@@ -526,6 +1012,8 @@ func marshalTransactionDetails(v []wallet.TransactionSummary) []*pb.TransactionD
 			Credits:     marshalTransactionOutputs(tx.MyOutputs),
 			Fee:         int64(tx.Fee),
 			Timestamp:   tx.Timestamp,
+			Comment:     tx.Comment,
+			CommentTo:   tx.CommentTo,
 		}
 	}
 	return txs
@@ -550,6 +1038,47 @@ func marshalHashes(v []*chainhash.Hash) [][]byte {
 	}
 	return hashes
 }
+func marshalTransactionNotifications(
+	v *wallet.TransactionNotifications) *pb.TransactionNotificationsResponse {
+	return &pb.TransactionNotificationsResponse{
+		AttachedBlocks:           marshalBlocks(v.AttachedBlocks),
+		DetachedBlocks:           marshalHashes(v.DetachedBlocks),
+		UnminedTransactions:      marshalTransactionDetails(v.UnminedTransactions),
+		UnminedTransactionHashes: marshalHashes(v.UnminedTransactionHashes),
+	}
+}
+func marshalSpentnessNotification(
+	v *wallet.SpentnessNotifications) *pb.SpentnessNotificationsResponse {
+	resp := &pb.SpentnessNotificationsResponse{
+		TransactionHash: v.Hash()[:],
+		OutputIndex:     v.Index(),
+	}
+	if spenderHash, spenderIndex, spent := v.Spender(); spent {
+		resp.Spender = &pb.SpentnessNotificationsResponse_Spender{
+			TransactionHash: spenderHash[:],
+			InputIndex:      spenderIndex,
+		}
+	}
+	return resp
+}
+func marshalAccountNotification(
+	v *wallet.AccountNotification) *pb.AccountNotificationsResponse {
+	return &pb.AccountNotificationsResponse{
+		AccountNumber:    v.AccountNumber,
+		AccountName:      v.AccountName,
+		ExternalKeyCount: v.ExternalKeyCount,
+		InternalKeyCount: v.InternalKeyCount,
+		ImportedKeyCount: v.ImportedKeyCount,
+	}
+}
+func marshalRescanNotification(v *wallet.RescanNotification) *pb.RescanResponse {
+	return &pb.RescanResponse{
+		BlockHash:      v.Hash[:],
+		BlockHeight:    v.Height,
+		BlockTimestamp: v.Time.Unix(),
+		Finished:       v.Finished,
+	}
+}
 
 // func marshalAccountBalances(// 	v []wallet.AccountBalance) []*pb.AccountBalance {
 // 	balances := make([]*pb.AccountBalance, len(v))
@@ -570,13 +1099,7 @@ func (s *walletServer) TransactionNotifications(req *pb.TransactionNotifications
 	for {
 		select {
 		case v := <-n.C:
-			resp := pb.TransactionNotificationsResponse{
-				AttachedBlocks:           marshalBlocks(v.AttachedBlocks),
-				DetachedBlocks:           marshalHashes(v.DetachedBlocks),
-				UnminedTransactions:      marshalTransactionDetails(v.UnminedTransactions),
-				UnminedTransactionHashes: marshalHashes(v.UnminedTransactionHashes),
-			}
-			err := svr.Send(&resp)
+			err := svr.Send(marshalTransactionNotifications(v))
 			if err != nil {
 				log.ERROR(err)
 				return translateError(err)
@@ -598,22 +1121,11 @@ func (s *walletServer) SpentnessNotifications(req *pb.SpentnessNotificationsRequ
 	for {
 		select {
 		case v := <-n.C:
-			spenderHash, spenderIndex, spent := v.Spender()
+			_, _, spent := v.Spender()
 			if (spent && req.NoNotifySpent) || (!spent && req.NoNotifyUnspent) {
 				continue
 			}
-			index := v.Index()
-			resp := pb.SpentnessNotificationsResponse{
-				TransactionHash: v.Hash()[:],
-				OutputIndex:     index,
-			}
-			if spent {
-				resp.Spender = &pb.SpentnessNotificationsResponse_Spender{
-					TransactionHash: spenderHash[:],
-					InputIndex:      spenderIndex,
-				}
-			}
-			err := svr.Send(&resp)
+			err := svr.Send(marshalSpentnessNotification(v))
 			if err != nil {
 				log.ERROR(err)
 				return translateError(err)
@@ -631,14 +1143,131 @@ func (s *walletServer) AccountNotifications(req *pb.AccountNotificationsRequest,
 	for {
 		select {
 		case v := <-n.C:
-			resp := pb.AccountNotificationsResponse{
-				AccountNumber:    v.AccountNumber,
-				AccountName:      v.AccountName,
-				ExternalKeyCount: v.ExternalKeyCount,
-				InternalKeyCount: v.InternalKeyCount,
-				ImportedKeyCount: v.ImportedKeyCount,
+			err := svr.Send(marshalAccountNotification(v))
+			if err != nil {
+				log.ERROR(err)
+				return translateError(err)
+			}
+		case <-ctxDone:
+			return nil
+		}
+	}
+}
+func (s *walletServer) Rescan(req *pb.RescanRequest,
+	svr pb.WalletService_RescanServer) error {
+	if req.BeginHeight < 0 {
+		return status.Errorf(codes.InvalidArgument,
+			"begin_height may not be negative")
+	}
+	// Progress is subscribed to before the rescan is submitted so none of it
+	// is missed, the notifications of other rescans are skipped.
+	n := s.wallet.NtfnServer.RescanNotifications()
+	defer n.Done()
+	job, errChan, err := s.wallet.SubmitRescanFromHeight(req.BeginHeight)
+	if err != nil {
+		log.ERROR(err)
+		return translateError(err)
+	}
+	// The rescan result and its finished notification arrive separately and
+	// in either order, so the stream only ends once both have been seen.
+	var finished bool
+	ctxDone := svr.Context().Done()
+	for {
+		select {
+		case v := <-n.C:
+			if !v.ForJob(job) {
+				continue
+			}
+			err := svr.Send(marshalRescanNotification(v))
+			if err != nil {
+				log.ERROR(err)
+				return translateError(err)
+			}
+			finished = v.Finished
+			if finished && errChan == nil {
+				return nil
+			}
+		case err := <-errChan:
+			if err != nil {
+				log.ERROR(err)
+				return translateError(err)
+			}
+			if finished {
+				return nil
+			}
+			errChan = nil
+		case <-ctxDone:
+			return nil
+		}
+	}
+}
+
+// notificationsSub identifies a subscription of a Notifications stream.  The
+// account is only set for spentness notifications.
+type notificationsSub struct {
+	ntype   pb.NotificationsRequest_Type
+	account uint32
+}
+
+func (s *walletServer) Notifications(svr pb.WalletService_NotificationsServer) error {
+	ctxDone := svr.Context().Done()
+	reqs := make(chan *pb.NotificationsRequest)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := svr.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case reqs <- req:
+			case <-ctxDone:
+				return
 			}
-			err := svr.Send(&resp)
+		}
+	}()
+	// Notifications of all subscriptions are sent from this goroutine only as
+	// a stream may not be sent to concurrently.
+	out := make(chan *pb.NotificationsResponse)
+	subs := make(map[notificationsSub]chan struct{})
+	defer func() {
+		for _, quit := range subs {
+			close(quit)
+		}
+	}()
+	for {
+		select {
+		case req := <-reqs:
+			if _, ok := pb.NotificationsRequest_Type_name[int32(req.Type)]; !ok {
+				return status.Errorf(codes.InvalidArgument,
+					"Unknown notification type %d", req.Type)
+			}
+			sub := notificationsSub{ntype: req.Type}
+			if req.Type == pb.NotificationsRequest_SPENTNESS {
+				sub.account = req.Account
+			}
+			quit, subscribed := subs[sub]
+			switch {
+			case req.Unsubscribe && subscribed:
+				close(quit)
+				delete(subs, sub)
+			case !req.Unsubscribe && !subscribed:
+				quit = make(chan struct{})
+				subs[sub] = quit
+				go s.forwardNotifications(sub, quit, out)
+			}
+		case err := <-recvErr:
+			if err == io.EOF {
+				// The client is done subscribing but still receives
+				// notifications.
+				recvErr = nil
+				continue
+			}
+			log.ERROR(err)
+			return translateError(err)
+		case resp := <-out:
+			err := svr.Send(resp)
 			if err != nil {
 				log.ERROR(err)
 				return translateError(err)
@@ -649,6 +1278,83 @@ func (s *walletServer) AccountNotifications(req *pb.AccountNotificationsRequest,
 	}
 }
 
+// forwardNotifications sends the wallet notifications of a subscription of a
+// Notifications stream to out until quit is closed.
+func (s *walletServer) forwardNotifications(sub notificationsSub,
+	quit <-chan struct{}, out chan<- *pb.NotificationsResponse) {
+	send := func(resp *pb.NotificationsResponse) bool {
+		select {
+		case out <- resp:
+			return true
+		case <-quit:
+			return false
+		}
+	}
+	ntfns := s.wallet.NtfnServer
+	switch sub.ntype {
+	case pb.NotificationsRequest_TRANSACTIONS:
+		n := ntfns.TransactionNotifications()
+		defer n.Done()
+		for {
+			select {
+			case v := <-n.C:
+				if !send(&pb.NotificationsResponse{
+					Transactions: marshalTransactionNotifications(v),
+				}) {
+					return
+				}
+			case <-quit:
+				return
+			}
+		}
+	case pb.NotificationsRequest_SPENTNESS:
+		n := ntfns.AccountSpentnessNotifications(sub.account)
+		defer n.Done()
+		for {
+			select {
+			case v := <-n.C:
+				if !send(&pb.NotificationsResponse{
+					Spentness: marshalSpentnessNotification(v),
+				}) {
+					return
+				}
+			case <-quit:
+				return
+			}
+		}
+	case pb.NotificationsRequest_ACCOUNTS:
+		n := ntfns.AccountNotifications()
+		defer n.Done()
+		for {
+			select {
+			case v := <-n.C:
+				if !send(&pb.NotificationsResponse{
+					Accounts: marshalAccountNotification(v),
+				}) {
+					return
+				}
+			case <-quit:
+				return
+			}
+		}
+	case pb.NotificationsRequest_RESCAN:
+		n := ntfns.RescanNotifications()
+		defer n.Done()
+		for {
+			select {
+			case v := <-n.C:
+				if !send(&pb.NotificationsResponse{
+					Rescan: marshalRescanNotification(v),
+				}) {
+					return
+				}
+			case <-quit:
+				return
+			}
+		}
+	}
+}
+
 // StartWalletLoaderService creates an implementation of the WalletLoaderService
 // and registers it with the gRPC server.
 func StartWalletLoaderService(server *grpc.Server, loader *wallet.Loader,
@@ -668,6 +1374,17 @@ func (s *loaderServer) CreateWallet(ctx context.Context, req *pb.CreateWalletReq
 	if len(pubPassphrase) == 0 {
 		pubPassphrase = []byte(wallet.InsecurePubPassphrase)
 	}
+	if req.Name != "" {
+		// Named wallets are synchronized by their own chain client once
+		// loaded.
+		_, err := s.loader.CreateNamedWallet(req.Name, pubPassphrase,
+			req.PrivatePassphrase, req.Seed, time.Now())
+		if err != nil {
+			log.ERROR(err)
+			return nil, translateError(err)
+		}
+		return &pb.CreateWalletResponse{}, nil
+	}
 	wallet, err := s.loader.CreateNewWallet(pubPassphrase, req.PrivatePassphrase, req.Seed, time.Now(), false, )
 	if err != nil {
 		log.ERROR(err)
@@ -688,6 +1405,14 @@ func (s *loaderServer) OpenWallet(ctx context.Context, req *pb.OpenWalletRequest
 	if len(pubPassphrase) == 0 {
 		pubPassphrase = []byte(wallet.InsecurePubPassphrase)
 	}
+	if req.Name != "" {
+		_, err := s.loader.OpenNamedWallet(req.Name, pubPassphrase)
+		if err != nil {
+			log.ERROR(err)
+			return nil, translateError(err)
+		}
+		return &pb.OpenWalletResponse{}, nil
+	}
 	wallet, err := s.loader.OpenExistingWallet(pubPassphrase, false)
 	if err != nil {
 		log.ERROR(err)
@@ -703,16 +1428,20 @@ func (s *loaderServer) OpenWallet(ctx context.Context, req *pb.OpenWalletRequest
 }
 func (s *loaderServer) WalletExists(ctx context.Context, req *pb.WalletExistsRequest) (
 	*pb.WalletExistsResponse, error) {
-	exists, err := s.loader.WalletExists()
+	exists, err := s.loader.NamedWalletExists(req.Name)
 	if err != nil {
 		log.ERROR(err)
 		return nil, translateError(err)
 	}
 	return &pb.WalletExistsResponse{Exists: exists}, nil
 }
+func (s *loaderServer) ListWallets(ctx context.Context, req *pb.ListWalletsRequest) (
+	*pb.ListWalletsResponse, error) {
+	return &pb.ListWalletsResponse{Names: s.loader.LoadedWalletNames()}, nil
+}
 func (s *loaderServer) CloseWallet(ctx context.Context, req *pb.CloseWalletRequest) (
 	*pb.CloseWalletResponse, error) {
-	err := s.loader.UnloadWallet()
+	err := s.loader.UnloadNamedWallet(req.Name)
 	if err == wallet.ErrNotLoaded {
 		return nil, status.Errorf(codes.FailedPrecondition, "wallet is not loaded")
 	}
@@ -74,6 +74,65 @@ func (ChangePassphraseRequest_Key) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_00212fb1f9d3bf1c, []int{25, 0}
 }
 
+type CoinControl_Strategy int32
+
+const (
+	CoinControl_LARGEST_FIRST    CoinControl_Strategy = 0
+	CoinControl_BRANCH_AND_BOUND CoinControl_Strategy = 1
+	CoinControl_NO_ADDRESS_REUSE CoinControl_Strategy = 2
+)
+
+var CoinControl_Strategy_name = map[int32]string{
+	0: "LARGEST_FIRST",
+	1: "BRANCH_AND_BOUND",
+	2: "NO_ADDRESS_REUSE",
+}
+
+var CoinControl_Strategy_value = map[string]int32{
+	"LARGEST_FIRST":    0,
+	"BRANCH_AND_BOUND": 1,
+	"NO_ADDRESS_REUSE": 2,
+}
+
+func (x CoinControl_Strategy) String() string {
+	return proto.EnumName(CoinControl_Strategy_name, int32(x))
+}
+
+func (CoinControl_Strategy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{58, 0}
+}
+
+type NotificationsRequest_Type int32
+
+const (
+	NotificationsRequest_TRANSACTIONS NotificationsRequest_Type = 0
+	NotificationsRequest_SPENTNESS    NotificationsRequest_Type = 1
+	NotificationsRequest_ACCOUNTS     NotificationsRequest_Type = 2
+	NotificationsRequest_RESCAN       NotificationsRequest_Type = 3
+)
+
+var NotificationsRequest_Type_name = map[int32]string{
+	0: "TRANSACTIONS",
+	1: "SPENTNESS",
+	2: "ACCOUNTS",
+	3: "RESCAN",
+}
+
+var NotificationsRequest_Type_value = map[string]int32{
+	"TRANSACTIONS": 0,
+	"SPENTNESS":    1,
+	"ACCOUNTS":     2,
+	"RESCAN":       3,
+}
+
+func (x NotificationsRequest_Type) String() string {
+	return proto.EnumName(NotificationsRequest_Type_name, int32(x))
+}
+
+func (NotificationsRequest_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{81, 0}
+}
+
 type VersionRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -191,6 +250,8 @@ type TransactionDetails struct {
 	Credits              []*TransactionDetails_Output `protobuf:"bytes,4,rep,name=credits,proto3" json:"credits,omitempty"`
 	Fee                  int64                        `protobuf:"varint,5,opt,name=fee,proto3" json:"fee,omitempty"`
 	Timestamp            int64                        `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Comment              string                       `protobuf:"bytes,7,opt,name=comment,proto3" json:"comment,omitempty"`
+	CommentTo            string                       `protobuf:"bytes,8,opt,name=comment_to,json=commentTo,proto3" json:"comment_to,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
 	XXX_unrecognized     []byte                       `json:"-"`
 	XXX_sizecache        int32                        `json:"-"`
@@ -263,6 +324,20 @@ func (m *TransactionDetails) GetTimestamp() int64 {
 	return 0
 }
 
+func (m *TransactionDetails) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+func (m *TransactionDetails) GetCommentTo() string {
+	if m != nil {
+		return m.CommentTo
+	}
+	return ""
+}
+
 type TransactionDetails_Input struct {
 	Index                uint32   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
 	PreviousAccount      uint32   `protobuf:"varint,2,opt,name=previous_account,json=previousAccount,proto3" json:"previous_account,omitempty"`
@@ -322,6 +397,7 @@ type TransactionDetails_Output struct {
 	Index                uint32   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
 	Account              uint32   `protobuf:"varint,2,opt,name=account,proto3" json:"account,omitempty"`
 	Internal             bool     `protobuf:"varint,3,opt,name=internal,proto3" json:"internal,omitempty"`
+	Label                string   `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -373,6 +449,13 @@ func (m *TransactionDetails_Output) GetInternal() bool {
 	return false
 }
 
+func (m *TransactionDetails_Output) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
 type BlockDetails struct {
 	Hash                 []byte                `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	Height               int32                 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
@@ -2251,9 +2334,11 @@ func (m *AccountNotificationsResponse) GetImportedKeyCount() uint32 {
 }
 
 type CreateWalletRequest struct {
-	PublicPassphrase     []byte   `protobuf:"bytes,1,opt,name=public_passphrase,json=publicPassphrase,proto3" json:"public_passphrase,omitempty"`
-	PrivatePassphrase    []byte   `protobuf:"bytes,2,opt,name=private_passphrase,json=privatePassphrase,proto3" json:"private_passphrase,omitempty"`
-	Seed                 []byte   `protobuf:"bytes,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	PublicPassphrase  []byte `protobuf:"bytes,1,opt,name=public_passphrase,json=publicPassphrase,proto3" json:"public_passphrase,omitempty"`
+	PrivatePassphrase []byte `protobuf:"bytes,2,opt,name=private_passphrase,json=privatePassphrase,proto3" json:"private_passphrase,omitempty"`
+	Seed              []byte `protobuf:"bytes,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	// The name of the wallet, empty for the default wallet.
+	Name                 string   `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2305,6 +2390,13 @@ func (m *CreateWalletRequest) GetSeed() []byte {
 	return nil
 }
 
+func (m *CreateWalletRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 type CreateWalletResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -2338,6 +2430,7 @@ var xxx_messageInfo_CreateWalletResponse proto.InternalMessageInfo
 
 type OpenWalletRequest struct {
 	PublicPassphrase     []byte   `protobuf:"bytes,1,opt,name=public_passphrase,json=publicPassphrase,proto3" json:"public_passphrase,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2375,6 +2468,13 @@ func (m *OpenWalletRequest) GetPublicPassphrase() []byte {
 	return nil
 }
 
+func (m *OpenWalletRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 type OpenWalletResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -2407,6 +2507,7 @@ func (m *OpenWalletResponse) XXX_DiscardUnknown() {
 var xxx_messageInfo_OpenWalletResponse proto.InternalMessageInfo
 
 type CloseWalletRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2437,6 +2538,13 @@ func (m *CloseWalletRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CloseWalletRequest proto.InternalMessageInfo
 
+func (m *CloseWalletRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 type CloseWalletResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -2469,6 +2577,7 @@ func (m *CloseWalletResponse) XXX_DiscardUnknown() {
 var xxx_messageInfo_CloseWalletResponse proto.InternalMessageInfo
 
 type WalletExistsRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2499,6 +2608,13 @@ func (m *WalletExistsRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_WalletExistsRequest proto.InternalMessageInfo
 
+func (m *WalletExistsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 type WalletExistsResponse struct {
 	Exists               bool     `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2632,348 +2748,2506 @@ func (m *StartConsensusRPCResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StartConsensusRPCResponse proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterEnum("walletrpc.NextAddressRequest_Kind", NextAddressRequest_Kind_name, NextAddressRequest_Kind_value)
-	proto.RegisterEnum("walletrpc.ChangePassphraseRequest_Key", ChangePassphraseRequest_Key_name, ChangePassphraseRequest_Key_value)
-	proto.RegisterType((*VersionRequest)(nil), "walletrpc.VersionRequest")
-	proto.RegisterType((*VersionResponse)(nil), "walletrpc.VersionResponse")
-	proto.RegisterType((*TransactionDetails)(nil), "walletrpc.TransactionDetails")
-	proto.RegisterType((*TransactionDetails_Input)(nil), "walletrpc.TransactionDetails.Input")
-	proto.RegisterType((*TransactionDetails_Output)(nil), "walletrpc.TransactionDetails.Output")
-	proto.RegisterType((*BlockDetails)(nil), "walletrpc.BlockDetails")
-	proto.RegisterType((*AccountBalance)(nil), "walletrpc.AccountBalance")
-	proto.RegisterType((*PingRequest)(nil), "walletrpc.PingRequest")
-	proto.RegisterType((*PingResponse)(nil), "walletrpc.PingResponse")
-	proto.RegisterType((*NetworkRequest)(nil), "walletrpc.NetworkRequest")
-	proto.RegisterType((*NetworkResponse)(nil), "walletrpc.NetworkResponse")
-	proto.RegisterType((*AccountNumberRequest)(nil), "walletrpc.AccountNumberRequest")
-	proto.RegisterType((*AccountNumberResponse)(nil), "walletrpc.AccountNumberResponse")
-	proto.RegisterType((*AccountsRequest)(nil), "walletrpc.AccountsRequest")
-	proto.RegisterType((*AccountsResponse)(nil), "walletrpc.AccountsResponse")
-	proto.RegisterType((*AccountsResponse_Account)(nil), "walletrpc.AccountsResponse.Account")
-	proto.RegisterType((*RenameAccountRequest)(nil), "walletrpc.RenameAccountRequest")
-	proto.RegisterType((*RenameAccountResponse)(nil), "walletrpc.RenameAccountResponse")
-	proto.RegisterType((*NextAccountRequest)(nil), "walletrpc.NextAccountRequest")
-	proto.RegisterType((*NextAccountResponse)(nil), "walletrpc.NextAccountResponse")
-	proto.RegisterType((*NextAddressRequest)(nil), "walletrpc.NextAddressRequest")
-	proto.RegisterType((*NextAddressResponse)(nil), "walletrpc.NextAddressResponse")
-	proto.RegisterType((*ImportPrivateKeyRequest)(nil), "walletrpc.ImportPrivateKeyRequest")
-	proto.RegisterType((*ImportPrivateKeyResponse)(nil), "walletrpc.ImportPrivateKeyResponse")
-	proto.RegisterType((*BalanceRequest)(nil), "walletrpc.BalanceRequest")
-	proto.RegisterType((*BalanceResponse)(nil), "walletrpc.BalanceResponse")
-	proto.RegisterType((*GetTransactionsRequest)(nil), "walletrpc.GetTransactionsRequest")
-	proto.RegisterType((*GetTransactionsResponse)(nil), "walletrpc.GetTransactionsResponse")
-	proto.RegisterType((*ChangePassphraseRequest)(nil), "walletrpc.ChangePassphraseRequest")
-	proto.RegisterType((*ChangePassphraseResponse)(nil), "walletrpc.ChangePassphraseResponse")
-	proto.RegisterType((*FundTransactionRequest)(nil), "walletrpc.FundTransactionRequest")
-	proto.RegisterType((*FundTransactionResponse)(nil), "walletrpc.FundTransactionResponse")
-	proto.RegisterType((*FundTransactionResponse_PreviousOutput)(nil), "walletrpc.FundTransactionResponse.PreviousOutput")
-	proto.RegisterType((*SignTransactionRequest)(nil), "walletrpc.SignTransactionRequest")
-	proto.RegisterType((*SignTransactionResponse)(nil), "walletrpc.SignTransactionResponse")
-	proto.RegisterType((*PublishTransactionRequest)(nil), "walletrpc.PublishTransactionRequest")
-	proto.RegisterType((*PublishTransactionResponse)(nil), "walletrpc.PublishTransactionResponse")
-	proto.RegisterType((*TransactionNotificationsRequest)(nil), "walletrpc.TransactionNotificationsRequest")
-	proto.RegisterType((*TransactionNotificationsResponse)(nil), "walletrpc.TransactionNotificationsResponse")
-	proto.RegisterType((*SpentnessNotificationsRequest)(nil), "walletrpc.SpentnessNotificationsRequest")
-	proto.RegisterType((*SpentnessNotificationsResponse)(nil), "walletrpc.SpentnessNotificationsResponse")
-	proto.RegisterType((*SpentnessNotificationsResponse_Spender)(nil), "walletrpc.SpentnessNotificationsResponse.Spender")
-	proto.RegisterType((*AccountNotificationsRequest)(nil), "walletrpc.AccountNotificationsRequest")
-	proto.RegisterType((*AccountNotificationsResponse)(nil), "walletrpc.AccountNotificationsResponse")
-	proto.RegisterType((*CreateWalletRequest)(nil), "walletrpc.CreateWalletRequest")
-	proto.RegisterType((*CreateWalletResponse)(nil), "walletrpc.CreateWalletResponse")
-	proto.RegisterType((*OpenWalletRequest)(nil), "walletrpc.OpenWalletRequest")
-	proto.RegisterType((*OpenWalletResponse)(nil), "walletrpc.OpenWalletResponse")
-	proto.RegisterType((*CloseWalletRequest)(nil), "walletrpc.CloseWalletRequest")
-	proto.RegisterType((*CloseWalletResponse)(nil), "walletrpc.CloseWalletResponse")
-	proto.RegisterType((*WalletExistsRequest)(nil), "walletrpc.WalletExistsRequest")
-	proto.RegisterType((*WalletExistsResponse)(nil), "walletrpc.WalletExistsResponse")
-	proto.RegisterType((*StartConsensusRPCRequest)(nil), "walletrpc.StartConsensusRPCRequest")
-	proto.RegisterType((*StartConsensusRPCResponse)(nil), "walletrpc.StartConsensusRPCResponse")
+type FundPsbtRequest struct {
+	Account               uint32                    `protobuf:"varint,1,opt,name=account,proto3" json:"account,omitempty"`
+	Inputs                []*FundPsbtRequest_Input  `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs               []*FundPsbtRequest_Output `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	LockTime              uint32                    `protobuf:"varint,4,opt,name=lock_time,json=lockTime,proto3" json:"lock_time,omitempty"`
+	RequiredConfirmations int32                     `protobuf:"varint,5,opt,name=required_confirmations,json=requiredConfirmations,proto3" json:"required_confirmations,omitempty"`
+	// Fee rate in atoms per kilobyte, the wallet's relay fee is used if
+	// this is zero.
+	FeeRate      int64 `protobuf:"varint,6,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"`
+	LockUnspents bool  `protobuf:"varint,7,opt,name=lock_unspents,json=lockUnspents,proto3" json:"lock_unspents,omitempty"`
+	// The inputs are added to those of the coin control options.
+	CoinControl          *CoinControl `protobuf:"bytes,8,opt,name=coin_control,json=coinControl,proto3" json:"coin_control,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
-
-var fileDescriptor_00212fb1f9d3bf1c = []byte{
-	// 2402 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x19, 0x4b, 0x73, 0x1b, 0x49,
-	0x79, 0xc7, 0xe3, 0x87, 0xfc, 0xe9, 0xdd, 0x96, 0x65, 0x65, 0x12, 0x27, 0xce, 0xec, 0x23, 0xd9,
-	0xec, 0xae, 0x09, 0x26, 0x0b, 0x4b, 0xb1, 0x15, 0x36, 0x31, 0x59, 0x56, 0x24, 0x38, 0xaa, 0x71,
-	0xb2, 0x49, 0xd5, 0x52, 0x4c, 0x8d, 0x67, 0xda, 0x76, 0x63, 0xa9, 0x47, 0x99, 0x19, 0xc5, 0x31,
-	0x27, 0x8a, 0x2a, 0x8e, 0x5c, 0x80, 0x03, 0x05, 0xb5, 0x17, 0x7e, 0x01, 0x55, 0x5c, 0x38, 0xb2,
-	0xbf, 0x81, 0x23, 0xff, 0x82, 0x5f, 0x40, 0xf5, 0x4b, 0xea, 0xd6, 0x8c, 0x64, 0x7b, 0x8b, 0x9b,
-	0xe6, 0x7b, 0xf5, 0xd7, 0x5f, 0x7f, 0x6f, 0xc1, 0x6a, 0x30, 0x24, 0xdb, 0xc3, 0x24, 0xce, 0x62,
-	0xb4, 0x7a, 0x1a, 0xf4, 0xfb, 0x38, 0x4b, 0x86, 0xa1, 0xdb, 0x80, 0xda, 0x97, 0x38, 0x49, 0x49,
-	0x4c, 0x3d, 0xfc, 0x6a, 0x84, 0xd3, 0xcc, 0xfd, 0xc6, 0x82, 0xfa, 0x18, 0x94, 0x0e, 0x63, 0x9a,
-	0x62, 0xf4, 0x2e, 0xd4, 0x5e, 0x0b, 0x90, 0x9f, 0x66, 0x09, 0xa1, 0x47, 0x1d, 0x6b, 0xcb, 0xba,
-	0xbd, 0xea, 0x55, 0x25, 0x74, 0x9f, 0x03, 0x51, 0x0b, 0x96, 0x06, 0xc1, 0xaf, 0xe2, 0xa4, 0xb3,
-	0xb0, 0x65, 0xdd, 0xae, 0x7a, 0xe2, 0x83, 0x43, 0x09, 0x8d, 0x93, 0x8e, 0x2d, 0xa1, 0xec, 0x83,
-	0x41, 0x87, 0x41, 0x16, 0x1e, 0x77, 0x16, 0x05, 0x94, 0x7f, 0xa0, 0xeb, 0x00, 0xc3, 0x04, 0x27,
-	0xb8, 0x8f, 0x83, 0x14, 0x77, 0x96, 0xf8, 0x21, 0x1a, 0x84, 0x29, 0x72, 0x30, 0x22, 0xfd, 0xc8,
-	0x1f, 0xe0, 0x2c, 0x88, 0x82, 0x2c, 0xe8, 0x2c, 0x0b, 0x45, 0x38, 0xf4, 0xe7, 0x12, 0xe8, 0xfe,
-	0xcb, 0x06, 0xf4, 0x2c, 0x09, 0x68, 0x1a, 0x84, 0x19, 0x89, 0xe9, 0x4f, 0x70, 0x16, 0x90, 0x7e,
-	0x8a, 0x10, 0x2c, 0x1e, 0x07, 0xe9, 0x31, 0x57, 0xbe, 0xe2, 0xf1, 0xdf, 0x68, 0x0b, 0xca, 0xd9,
-	0x84, 0x92, 0x6b, 0x5e, 0xf1, 0x74, 0x10, 0xfa, 0x11, 0x2c, 0x47, 0xf8, 0x80, 0x64, 0x69, 0xc7,
-	0xde, 0xb2, 0x6f, 0x97, 0x77, 0xde, 0xde, 0x1e, 0x9b, 0x6f, 0x3b, 0x7f, 0xc8, 0x76, 0x97, 0x0e,
-	0x47, 0x99, 0x27, 0x59, 0xd0, 0x7d, 0x58, 0x09, 0x13, 0x1c, 0x31, 0xee, 0x45, 0xce, 0xfd, 0xce,
-	0x7c, 0xee, 0xa7, 0xa3, 0x8c, 0xb1, 0x2b, 0x26, 0xd4, 0x00, 0xfb, 0x10, 0x0b, 0x4b, 0xd8, 0x1e,
-	0xfb, 0x89, 0xae, 0xc1, 0x6a, 0x46, 0x06, 0x38, 0xcd, 0x82, 0xc1, 0x90, 0xdf, 0xde, 0xf6, 0x26,
-	0x00, 0xe7, 0x15, 0x2c, 0x71, 0x05, 0x98, 0x7d, 0x09, 0x8d, 0xf0, 0x1b, 0x7e, 0xd9, 0xaa, 0x27,
-	0x3e, 0xd0, 0xfb, 0xd0, 0x18, 0x26, 0xf8, 0x35, 0x89, 0x47, 0xa9, 0x1f, 0x84, 0x61, 0x3c, 0xa2,
-	0x99, 0x7c, 0xac, 0xba, 0x82, 0x3f, 0x10, 0x60, 0x74, 0x0b, 0xea, 0x13, 0xd2, 0x01, 0xa7, 0xb4,
-	0xf9, 0x69, 0xb5, 0x31, 0x25, 0x87, 0x3a, 0xcf, 0x60, 0x59, 0x68, 0x3d, 0xe3, 0xcc, 0x0e, 0xac,
-	0x98, 0x47, 0xa9, 0x4f, 0xe4, 0x40, 0x89, 0xd0, 0x0c, 0x27, 0x34, 0xe8, 0x73, 0xd9, 0x25, 0x6f,
-	0xfc, 0xed, 0xfe, 0xd5, 0x82, 0xca, 0xc3, 0x7e, 0x1c, 0x9e, 0xcc, 0x7b, 0xbc, 0x36, 0x2c, 0x1f,
-	0x63, 0x72, 0x74, 0x2c, 0x24, 0x2f, 0x79, 0xf2, 0xcb, 0xb4, 0x91, 0x3d, 0x65, 0x23, 0xf4, 0x00,
-	0x2a, 0xda, 0xfb, 0xaa, 0x87, 0xd9, 0x9c, 0xfb, 0x30, 0x9e, 0xc1, 0xe2, 0x3e, 0x85, 0x9a, 0xb4,
-	0xd3, 0xc3, 0xa0, 0x1f, 0xd0, 0x10, 0xeb, 0xb7, 0xb4, 0xcc, 0x5b, 0xbe, 0x0d, 0xd5, 0x2c, 0xce,
-	0x82, 0xbe, 0x7f, 0x20, 0x48, 0xb9, 0xae, 0xb6, 0x57, 0xe1, 0x40, 0xc9, 0xee, 0x56, 0xa1, 0xdc,
-	0x23, 0xf4, 0x48, 0x05, 0x61, 0x0d, 0x2a, 0xe2, 0x53, 0x04, 0x20, 0x0b, 0xd3, 0x3d, 0x9c, 0x9d,
-	0xc6, 0xc9, 0x89, 0xa2, 0xf8, 0x04, 0xea, 0x63, 0xc8, 0x24, 0x4a, 0x99, 0x7e, 0xaf, 0xb1, 0x4f,
-	0x05, 0x46, 0x6a, 0x52, 0x15, 0x50, 0x49, 0xee, 0xfe, 0x10, 0x5a, 0x52, 0xf7, 0xbd, 0xd1, 0xe0,
-	0x00, 0x27, 0x52, 0x22, 0xba, 0x09, 0x15, 0xa9, 0xb2, 0x4f, 0x83, 0x01, 0x96, 0x21, 0x5e, 0x96,
-	0xb0, 0xbd, 0x60, 0x80, 0xdd, 0xfb, 0xb0, 0x3e, 0xc5, 0xaa, 0x1f, 0x2d, 0x79, 0x39, 0x66, 0x72,
-	0xb4, 0x46, 0xee, 0x36, 0xa1, 0x2e, 0xf9, 0x53, 0x75, 0x8f, 0x7f, 0xda, 0xd0, 0x98, 0xc0, 0xa4,
-	0xb8, 0x1f, 0x43, 0x49, 0x32, 0xa6, 0x1d, 0x2b, 0x17, 0x74, 0xd3, 0xe4, 0x0a, 0xe0, 0x8d, 0x99,
-	0xd0, 0x87, 0x80, 0xc2, 0x51, 0x92, 0x60, 0x9a, 0xf9, 0x07, 0xcc, 0x89, 0x7c, 0xee, 0x3a, 0x22,
-	0xb8, 0x1b, 0x12, 0xc3, 0xbd, 0xeb, 0x0b, 0xe6, 0x46, 0x77, 0xa1, 0x35, 0x45, 0x2d, 0x9c, 0xca,
-	0xe6, 0x4e, 0x85, 0x0c, 0x7a, 0x8e, 0x71, 0x7e, 0xbb, 0x00, 0x2b, 0x2a, 0x50, 0x2e, 0x76, 0xf7,
-	0x9c, 0x79, 0x17, 0x72, 0xe6, 0xcd, 0x7b, 0x8a, 0x9d, 0xf7, 0x14, 0x76, 0x35, 0xfc, 0x46, 0x04,
-	0x89, 0x7f, 0x82, 0xcf, 0x7c, 0xe1, 0x73, 0x22, 0x8b, 0x36, 0x14, 0xe6, 0x31, 0x3e, 0xdb, 0xe5,
-	0xca, 0x7d, 0x08, 0x48, 0x85, 0x94, 0x46, 0xbd, 0x24, 0xa8, 0x15, 0xc6, 0xa0, 0x1e, 0x0c, 0xe3,
-	0x24, 0xc3, 0x91, 0x46, 0xbd, 0x2c, 0xa9, 0x25, 0x46, 0x51, 0xbb, 0x2f, 0xa1, 0xe5, 0x61, 0x76,
-	0x17, 0x65, 0x7f, 0xe9, 0x48, 0x17, 0x34, 0xc8, 0x15, 0x28, 0x51, 0x7c, 0xaa, 0x1b, 0x63, 0x85,
-	0xe2, 0x53, 0xee, 0x67, 0x1b, 0xb0, 0x3e, 0x25, 0x59, 0xc6, 0xc1, 0x0b, 0x40, 0x7b, 0xf8, 0x4d,
-	0x36, 0x75, 0x20, 0xab, 0x1a, 0x41, 0x9a, 0x0e, 0x8f, 0x13, 0x56, 0x35, 0x44, 0x82, 0xd0, 0x20,
-	0x17, 0x30, 0xbd, 0xfb, 0x29, 0xac, 0x19, 0x82, 0x2f, 0xe7, 0xd7, 0x7f, 0xb1, 0xa4, 0x5e, 0x51,
-	0x94, 0xe0, 0x54, 0xf9, 0xf6, 0x9c, 0x9c, 0xf0, 0x7d, 0x58, 0x3c, 0x21, 0x34, 0xe2, 0x9a, 0xd4,
-	0x76, 0x5c, 0xcd, 0xb9, 0xf3, 0x62, 0xb6, 0x1f, 0x13, 0x1a, 0x79, 0x9c, 0xde, 0xdd, 0x81, 0x45,
-	0xf6, 0x85, 0x5a, 0xd0, 0x78, 0xd8, 0xed, 0xdd, 0xbd, 0x7b, 0xef, 0x9e, 0xff, 0xe8, 0xe5, 0xb3,
-	0x47, 0xde, 0xde, 0x83, 0x27, 0x8d, 0xb7, 0x74, 0x68, 0x77, 0x4f, 0x42, 0x2d, 0xf7, 0x3b, 0xf2,
-	0x6a, 0x4a, 0xa8, 0xbc, 0x1a, 0x53, 0x4e, 0x80, 0x64, 0xa4, 0xab, 0x4f, 0xf7, 0x8f, 0x16, 0x6c,
-	0x74, 0xf9, 0x63, 0xf7, 0x12, 0xf2, 0x3a, 0xc8, 0xf0, 0x63, 0x7c, 0x76, 0x51, 0x53, 0xcf, 0x4e,
-	0xf6, 0xef, 0xb1, 0x7a, 0xc2, 0xc5, 0x71, 0xd7, 0x3a, 0x25, 0x87, 0xdc, 0xbd, 0x57, 0xbd, 0xea,
-	0x70, 0x7c, 0xca, 0x0b, 0x72, 0xc8, 0x72, 0x7a, 0x82, 0xd3, 0x30, 0xa0, 0xdc, 0xa7, 0x4b, 0x9e,
-	0xfc, 0x72, 0x1d, 0xe8, 0xe4, 0x95, 0x92, 0x6e, 0x41, 0xa1, 0x26, 0xc3, 0xe3, 0x92, 0x3e, 0xf8,
-	0x31, 0xb4, 0x13, 0xfc, 0x6a, 0x44, 0x12, 0x1c, 0xf9, 0x61, 0x4c, 0x0f, 0x49, 0x32, 0x08, 0x44,
-	0x51, 0x10, 0x05, 0x65, 0x5d, 0x61, 0x77, 0x75, 0xa4, 0x4b, 0xa1, 0x3e, 0x3e, 0x4f, 0x9a, 0xb3,
-	0x05, 0x4b, 0x3c, 0x4c, 0xf9, 0x39, 0xb6, 0x27, 0x3e, 0x58, 0x21, 0x4a, 0x87, 0x98, 0x46, 0xc1,
-	0x41, 0x5f, 0xe5, 0xfd, 0x09, 0x80, 0x95, 0x58, 0x32, 0x18, 0x04, 0xd9, 0x28, 0xc1, 0x7e, 0x82,
-	0x4f, 0x83, 0x24, 0x52, 0x25, 0x56, 0x81, 0x3d, 0x0e, 0x75, 0xff, 0xbc, 0x00, 0xed, 0x9f, 0xe2,
-	0x4c, 0x2b, 0x4b, 0x63, 0x1f, 0xdb, 0x86, 0xb5, 0x34, 0x0b, 0x92, 0x8c, 0xd0, 0x23, 0x3d, 0xd5,
-	0x89, 0x97, 0x69, 0x2a, 0xd4, 0x24, 0xd7, 0xed, 0xc0, 0xfa, 0x34, 0xfd, 0xa4, 0x82, 0x36, 0xbd,
-	0x35, 0x93, 0x43, 0x94, 0xd3, 0x3b, 0xd0, 0xc4, 0x34, 0x9a, 0x3a, 0xc1, 0xe6, 0x27, 0xd4, 0x05,
-	0x62, 0x22, 0x7f, 0x1b, 0xd6, 0x4c, 0x5a, 0x21, 0x7d, 0x91, 0x9b, 0xb3, 0xa9, 0x53, 0x0b, 0xd9,
-	0xf7, 0xe1, 0xea, 0x80, 0x50, 0x32, 0x18, 0x0d, 0xfc, 0x04, 0x87, 0x2c, 0x05, 0x1b, 0xb5, 0x79,
-	0x89, 0xf3, 0x5d, 0x91, 0x24, 0x1e, 0xa7, 0xd0, 0xcd, 0xe0, 0xfe, 0xc3, 0x82, 0x8d, 0x9c, 0x69,
-	0xe4, 0x9b, 0x7c, 0x0e, 0x68, 0x40, 0x28, 0x8e, 0x4c, 0x91, 0xa2, 0xa0, 0x6c, 0x68, 0x31, 0xa7,
-	0xf7, 0x19, 0x5e, 0x93, 0xb3, 0xe8, 0xf2, 0x50, 0x0f, 0x5a, 0x23, 0x5a, 0x20, 0x69, 0xe1, 0x22,
-	0x8d, 0xc3, 0x9a, 0x64, 0x35, 0xb4, 0xfe, 0xc6, 0x82, 0x8d, 0xdd, 0xe3, 0x80, 0x1e, 0xe1, 0xde,
-	0x38, 0x76, 0xd4, 0x8b, 0x7e, 0x02, 0xf6, 0x09, 0x3e, 0xe3, 0x2f, 0x58, 0xdb, 0x79, 0x4f, 0x13,
-	0x3e, 0x83, 0x61, 0x9b, 0x45, 0x02, 0x63, 0x61, 0x4e, 0x1f, 0xf7, 0x23, 0x5f, 0x0b, 0x50, 0x51,
-	0xf1, 0xaa, 0x71, 0x3f, 0x9a, 0xb0, 0x31, 0x32, 0x96, 0x78, 0x35, 0x32, 0xf1, 0x96, 0x55, 0x8a,
-	0x4f, 0x27, 0x64, 0xee, 0x75, 0xb0, 0x1f, 0xe3, 0x33, 0x54, 0x86, 0x95, 0x9e, 0xd7, 0xfd, 0xf2,
-	0xc1, 0xb3, 0x47, 0x8d, 0xb7, 0x10, 0xc0, 0x72, 0xef, 0xf9, 0xc3, 0x27, 0xdd, 0xdd, 0x86, 0xc5,
-	0x02, 0x32, 0xaf, 0x91, 0x0c, 0xc8, 0xdf, 0x2c, 0x40, 0xfb, 0xf3, 0x11, 0xd5, 0x2f, 0x7d, 0x7e,
-	0x52, 0x64, 0xe5, 0x2f, 0x48, 0x8e, 0x70, 0xa6, 0xfa, 0x4d, 0xd5, 0x28, 0x71, 0xa0, 0xe8, 0x36,
-	0xe7, 0x44, 0xac, 0x3d, 0x27, 0x62, 0xd1, 0xa7, 0xe0, 0x10, 0x1a, 0xf6, 0x47, 0x11, 0xf6, 0xc7,
-	0x21, 0x17, 0xc6, 0x84, 0x1e, 0x04, 0x29, 0x4e, 0x65, 0xa6, 0xe9, 0x48, 0x8a, 0xae, 0x24, 0xd8,
-	0x55, 0x78, 0x16, 0x34, 0x8a, 0x3b, 0xe4, 0x57, 0xf6, 0xd3, 0x30, 0x21, 0x43, 0x51, 0x48, 0x4b,
-	0xde, 0x9a, 0x44, 0x0a, 0x73, 0xec, 0x73, 0x94, 0xfb, 0x37, 0x1b, 0x36, 0x72, 0x26, 0x90, 0x8e,
-	0xf9, 0x0b, 0x68, 0xa4, 0xb8, 0x8f, 0x43, 0x56, 0x67, 0x63, 0xde, 0x3b, 0x2b, 0xb7, 0xfc, 0xae,
-	0xf6, 0xde, 0x33, 0xb8, 0xb7, 0x7b, 0xb2, 0xff, 0x96, 0xb3, 0x42, 0x5d, 0x89, 0x12, 0xdf, 0x29,
-	0x2b, 0x77, 0xa2, 0x8d, 0x30, 0xcc, 0x58, 0xe6, 0x30, 0x69, 0xc5, 0xdb, 0xd0, 0x90, 0x17, 0x19,
-	0x9e, 0xa8, 0xbb, 0x08, 0x27, 0xa8, 0x09, 0x78, 0xef, 0x44, 0x5c, 0xc3, 0xf9, 0x8f, 0x05, 0x35,
-	0xf3, 0x40, 0x36, 0x44, 0x68, 0x61, 0xa0, 0xe7, 0x9b, 0xba, 0x06, 0xe7, 0xd9, 0xe0, 0x26, 0x54,
-	0xc4, 0xfd, 0x7c, 0x31, 0x18, 0x88, 0x9a, 0x50, 0x16, 0xb0, 0x2e, 0x1f, 0x0f, 0xda, 0xb0, 0x6c,
-	0x8c, 0x17, 0xf2, 0x0b, 0x5d, 0x85, 0xd5, 0x89, 0x6e, 0x8b, 0x5c, 0x7c, 0x69, 0x28, 0xb5, 0x62,
-	0x72, 0x59, 0xb6, 0x60, 0xbd, 0x2e, 0xeb, 0xeb, 0xe5, 0x7c, 0x54, 0x96, 0xb0, 0x67, 0x44, 0x34,
-	0x53, 0x87, 0x49, 0x3c, 0x18, 0xbf, 0x32, 0x6f, 0x63, 0x4a, 0x5e, 0x85, 0x01, 0xd5, 0xcb, 0xba,
-	0x7f, 0xb2, 0xa0, 0xbd, 0x4f, 0x8e, 0x68, 0x81, 0x9f, 0x9e, 0x57, 0xe9, 0x3e, 0x86, 0x76, 0x8a,
-	0x13, 0x12, 0xf4, 0xc9, 0xaf, 0xcd, 0xbc, 0x20, 0x83, 0x6e, 0x7d, 0x82, 0xd5, 0xa4, 0x33, 0xb5,
-	0x08, 0x1d, 0x1b, 0x04, 0x8b, 0xa1, 0xb2, 0xea, 0x55, 0x38, 0xb0, 0x2b, 0x60, 0xee, 0x2b, 0xd8,
-	0xc8, 0x69, 0x25, 0x5d, 0x67, 0x6a, 0x5e, 0xb5, 0xf2, 0xf3, 0xea, 0x3d, 0x68, 0x8f, 0x68, 0x4a,
-	0x8e, 0x58, 0xba, 0x32, 0x8f, 0x5a, 0xe0, 0x47, 0xb5, 0x14, 0xb6, 0xab, 0x1f, 0xf9, 0x33, 0xb8,
-	0xd2, 0x1b, 0x1d, 0xf4, 0x49, 0x7a, 0x5c, 0x60, 0x8b, 0x8f, 0x00, 0x49, 0x81, 0xf9, 0xb3, 0x9b,
-	0x02, 0xa3, 0x71, 0xb9, 0xd7, 0xc0, 0x29, 0x92, 0x25, 0x73, 0xc3, 0x4d, 0xb8, 0xa1, 0x81, 0xf7,
-	0xe2, 0x8c, 0x1c, 0x92, 0x30, 0xd0, 0x8b, 0x9a, 0xfb, 0xf5, 0x02, 0x6c, 0xcd, 0xa6, 0x91, 0x96,
-	0xf8, 0x0c, 0xea, 0x41, 0x96, 0x05, 0xe1, 0x31, 0x8e, 0x44, 0xad, 0x39, 0x37, 0xb5, 0xd7, 0x14,
-	0x3d, 0x87, 0xa6, 0xac, 0xfe, 0x46, 0xd8, 0x94, 0xc0, 0x4c, 0x54, 0xf1, 0x6a, 0x0a, 0x2c, 0x09,
-	0x67, 0x15, 0x00, 0xfb, 0xdb, 0x16, 0x00, 0x96, 0x8f, 0x0a, 0x24, 0xf2, 0x58, 0xc2, 0x62, 0x22,
-	0xad, 0x78, 0x9d, 0x3c, 0xe3, 0x17, 0x1c, 0xef, 0xfe, 0xde, 0x82, 0xcd, 0xfd, 0x21, 0xa6, 0x19,
-	0xc5, 0x69, 0x5a, 0x64, 0xc1, 0x39, 0x59, 0xf6, 0x0e, 0x34, 0x69, 0xec, 0x53, 0xc6, 0x74, 0xe6,
-	0x8f, 0x28, 0xeb, 0x46, 0x44, 0x8a, 0x28, 0x79, 0x75, 0x1a, 0x73, 0x61, 0x67, 0xcf, 0x05, 0x98,
-	0xf5, 0x6c, 0x13, 0x5a, 0x41, 0x29, 0xe6, 0xf4, 0xaa, 0xa2, 0xe4, 0x5a, 0xb8, 0x7f, 0x58, 0x80,
-	0xeb, 0xb3, 0xf4, 0x91, 0xaf, 0xf5, 0xff, 0x4d, 0x1a, 0x8f, 0x61, 0x85, 0xb7, 0x51, 0x58, 0x6c,
-	0x95, 0xcc, 0xbc, 0x39, 0x5f, 0x13, 0x8e, 0x8e, 0x70, 0xe2, 0x29, 0x09, 0xce, 0x73, 0x58, 0x91,
-	0xb0, 0xcb, 0x68, 0x79, 0x03, 0xca, 0x5a, 0x74, 0x49, 0x25, 0x61, 0x12, 0xc6, 0xee, 0x26, 0x5c,
-	0x55, 0xc3, 0x72, 0x91, 0x8f, 0xff, 0xd7, 0x82, 0x6b, 0xc5, 0xf8, 0x4b, 0xcd, 0x1e, 0x17, 0x99,
-	0x2b, 0x8b, 0x47, 0x46, 0xfb, 0x52, 0x23, 0xe3, 0xe2, 0xa5, 0x46, 0xc6, 0xa5, 0x19, 0x23, 0xe3,
-	0xef, 0x2c, 0x58, 0xdb, 0x4d, 0x70, 0x90, 0xe1, 0x17, 0xfc, 0xb9, 0x94, 0xbb, 0x7e, 0x00, 0xcd,
-	0x21, 0xcb, 0x18, 0xa1, 0x9f, 0xcb, 0xb9, 0x0d, 0x81, 0xd0, 0xfa, 0x97, 0x8f, 0x00, 0xa9, 0x49,
-	0x22, 0xd7, 0xea, 0x34, 0x25, 0x46, 0x23, 0x47, 0xb0, 0x98, 0x62, 0x1c, 0xc9, 0xfa, 0xc6, 0x7f,
-	0xbb, 0x6d, 0x68, 0x99, 0x6a, 0xc8, 0xdc, 0xf4, 0x19, 0x34, 0x9f, 0x0e, 0x31, 0xfd, 0xf6, 0xca,
-	0xb9, 0x2d, 0x40, 0xba, 0x04, 0x29, 0xb7, 0x05, 0x68, 0xb7, 0x1f, 0xa7, 0xe6, 0xad, 0xdd, 0x75,
-	0x58, 0x33, 0xa0, 0x92, 0x78, 0x1d, 0xd6, 0x04, 0xe4, 0xd1, 0x1b, 0x92, 0x4e, 0x36, 0x25, 0xdb,
-	0xd0, 0x32, 0xc1, 0xd2, 0x4f, 0xda, 0xb0, 0x8c, 0x39, 0x84, 0xeb, 0x54, 0xf2, 0xe4, 0x97, 0xfb,
-	0xb5, 0x05, 0x9d, 0x7d, 0xd6, 0xcd, 0xef, 0x32, 0x32, 0x9a, 0x8e, 0x52, 0xaf, 0xb7, 0xab, 0xee,
-	0x74, 0x0b, 0xea, 0x72, 0x49, 0xe4, 0x9b, 0x53, 0x60, 0x4d, 0x82, 0xe5, 0xb8, 0x88, 0x1c, 0x28,
-	0x8d, 0x52, 0xf6, 0xe4, 0x63, 0xd7, 0x1a, 0x7f, 0x33, 0x1c, 0xb3, 0xc8, 0x69, 0x9c, 0x28, 0xeb,
-	0x8e, 0xbf, 0x59, 0x9d, 0x0a, 0x71, 0x22, 0xfd, 0x1a, 0xcb, 0x02, 0xae, 0x83, 0xdc, 0xab, 0x70,
-	0xa5, 0x40, 0x3d, 0x71, 0xa9, 0x1d, 0x6f, 0xbc, 0x97, 0xde, 0xc7, 0xc9, 0x6b, 0x12, 0xb2, 0x74,
-	0xbf, 0x22, 0x21, 0xe8, 0x8a, 0x16, 0xec, 0xe6, 0xf6, 0xda, 0x71, 0x8a, 0x50, 0x52, 0xe6, 0xbf,
-	0xcb, 0x50, 0x15, 0x16, 0x54, 0x32, 0x7f, 0x00, 0x8b, 0x3d, 0x42, 0x8f, 0x50, 0x5b, 0xe3, 0xd2,
-	0xd6, 0x70, 0xce, 0x46, 0x0e, 0x3e, 0xae, 0x3d, 0x2b, 0x72, 0x9d, 0x66, 0x28, 0x63, 0xee, 0xe8,
-	0x0c, 0x65, 0xa6, 0x97, 0x75, 0x1e, 0x54, 0x8d, 0x55, 0x1a, 0xba, 0x91, 0xdf, 0x70, 0x19, 0xfb,
-	0x39, 0x67, 0x6b, 0x36, 0x81, 0x94, 0xb9, 0x0b, 0x25, 0xb5, 0x1b, 0x43, 0x4e, 0xe1, 0xc2, 0x4c,
-	0x48, 0xba, 0x3a, 0x67, 0x99, 0xc6, 0xae, 0xa6, 0x56, 0x4d, 0xfa, 0xd5, 0xcc, 0xf9, 0xda, 0xb8,
-	0xda, 0xf4, 0x28, 0xfc, 0x12, 0xea, 0x53, 0x13, 0x19, 0xba, 0xa9, 0x91, 0x17, 0x0f, 0xb2, 0x8e,
-	0x3b, 0x8f, 0x44, 0x4a, 0x1e, 0x41, 0x67, 0x56, 0x5b, 0x80, 0xee, 0x14, 0x57, 0xe1, 0xa2, 0xdc,
-	0xeb, 0x7c, 0x70, 0x21, 0x5a, 0x71, 0xe8, 0x5d, 0x0b, 0xc5, 0xd0, 0x2e, 0xae, 0x29, 0xe8, 0xf6,
-	0x05, 0xca, 0x8e, 0x38, 0xf2, 0xfd, 0x0b, 0x17, 0xa8, 0xbb, 0x16, 0x22, 0x93, 0x15, 0xad, 0x71,
-	0xdc, 0x7b, 0x05, 0x2e, 0x50, 0x74, 0xd8, 0xad, 0x73, 0xe9, 0xc6, 0x47, 0x7d, 0x05, 0x8d, 0xe9,
-	0x29, 0x0e, 0xb9, 0xe7, 0x0f, 0x9d, 0xce, 0xdb, 0x73, 0x69, 0x26, 0x4e, 0x6e, 0xec, 0xf1, 0x0c,
-	0x27, 0x2f, 0xda, 0x1d, 0x1a, 0x4e, 0x5e, 0xb8, 0x02, 0x44, 0x4f, 0xa0, 0xac, 0x6d, 0xea, 0xd0,
-	0xe6, 0xf4, 0xee, 0xcc, 0x94, 0x77, 0x7d, 0x16, 0x7a, 0x4a, 0x9a, 0xcc, 0x76, 0x9b, 0x73, 0x37,
-	0x71, 0x79, 0x69, 0x53, 0x3b, 0xb5, 0xaf, 0xa0, 0x31, 0xbd, 0xa3, 0x32, 0x8c, 0x39, 0x63, 0xab,
-	0x66, 0x18, 0x73, 0xd6, 0x92, 0x8b, 0x85, 0xd5, 0xd4, 0x44, 0x68, 0x84, 0x55, 0xf1, 0xb8, 0x6d,
-	0x84, 0xd5, 0xac, 0x71, 0xf4, 0x25, 0xd4, 0xa7, 0xc6, 0x0d, 0x43, 0x72, 0xf1, 0x80, 0x64, 0x48,
-	0x9e, 0x35, 0xad, 0x04, 0x80, 0xf2, 0x93, 0x00, 0xd2, 0xff, 0x03, 0x9b, 0x39, 0x74, 0x38, 0xef,
-	0x9e, 0x43, 0x25, 0xb3, 0xfa, 0xdf, 0x6d, 0x55, 0x2e, 0x9f, 0xc4, 0x41, 0x84, 0x13, 0x95, 0xdb,
-	0x9f, 0x42, 0x45, 0x2f, 0x97, 0x48, 0x7f, 0xbb, 0x82, 0xf2, 0xea, 0xdc, 0x98, 0x89, 0x97, 0x77,
-	0x79, 0x0a, 0x15, 0xbd, 0x67, 0x30, 0x04, 0x16, 0xf4, 0x34, 0x86, 0xc0, 0xa2, 0x66, 0x03, 0x75,
-	0x01, 0x26, 0xad, 0x02, 0xba, 0xa6, 0x91, 0xe7, 0x7a, 0x10, 0x67, 0x73, 0x06, 0x76, 0xe2, 0xc6,
-	0x5a, 0x27, 0x61, 0xb8, 0x71, 0xbe, 0xef, 0x30, 0xdc, 0xb8, 0xa0, 0x01, 0x41, 0xbf, 0x84, 0x66,
-	0xae, 0x32, 0x23, 0xdd, 0x47, 0x67, 0xb5, 0x15, 0xce, 0x3b, 0xf3, 0x89, 0x84, 0xfc, 0x83, 0x65,
-	0xfe, 0x37, 0xf4, 0xf7, 0xfe, 0x17, 0x00, 0x00, 0xff, 0xff, 0xd8, 0x84, 0xf1, 0xd3, 0x93, 0x1e,
-	0x00, 0x00,
+func (m *FundPsbtRequest) Reset()         { *m = FundPsbtRequest{} }
+func (m *FundPsbtRequest) String() string { return proto.CompactTextString(m) }
+func (*FundPsbtRequest) ProtoMessage()    {}
+func (*FundPsbtRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{49}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// VersionServiceClient is the client API for VersionService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type VersionServiceClient interface {
-	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
+func (m *FundPsbtRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FundPsbtRequest.Unmarshal(m, b)
 }
-
-type versionServiceClient struct {
-	cc *grpc.ClientConn
+func (m *FundPsbtRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FundPsbtRequest.Marshal(b, m, deterministic)
 }
-
-func NewVersionServiceClient(cc *grpc.ClientConn) VersionServiceClient {
-	return &versionServiceClient{cc}
+func (m *FundPsbtRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FundPsbtRequest.Merge(m, src)
+}
+func (m *FundPsbtRequest) XXX_Size() int {
+	return xxx_messageInfo_FundPsbtRequest.Size(m)
+}
+func (m *FundPsbtRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FundPsbtRequest.DiscardUnknown(m)
 }
 
-func (c *versionServiceClient) Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error) {
-	out := new(VersionResponse)
-	err := c.cc.Invoke(ctx, "/walletrpc.VersionService/Version", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_FundPsbtRequest proto.InternalMessageInfo
+
+func (m *FundPsbtRequest) GetAccount() uint32 {
+	if m != nil {
+		return m.Account
 	}
-	return out, nil
+	return 0
 }
 
-// VersionServiceServer is the server API for VersionService service.
-type VersionServiceServer interface {
-	Version(context.Context, *VersionRequest) (*VersionResponse, error)
+func (m *FundPsbtRequest) GetInputs() []*FundPsbtRequest_Input {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
 }
 
-// UnimplementedVersionServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedVersionServiceServer struct {
+func (m *FundPsbtRequest) GetOutputs() []*FundPsbtRequest_Output {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
 }
 
-func (*UnimplementedVersionServiceServer) Version(ctx context.Context, req *VersionRequest) (*VersionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+func (m *FundPsbtRequest) GetLockTime() uint32 {
+	if m != nil {
+		return m.LockTime
+	}
+	return 0
 }
 
-func RegisterVersionServiceServer(s *grpc.Server, srv VersionServiceServer) {
-	s.RegisterService(&_VersionService_serviceDesc, srv)
+func (m *FundPsbtRequest) GetRequiredConfirmations() int32 {
+	if m != nil {
+		return m.RequiredConfirmations
+	}
+	return 0
 }
 
-func _VersionService_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VersionRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(VersionServiceServer).Version(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/walletrpc.VersionService/Version",
+func (m *FundPsbtRequest) GetFeeRate() int64 {
+	if m != nil {
+		return m.FeeRate
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VersionServiceServer).Version(ctx, req.(*VersionRequest))
+	return 0
+}
+
+func (m *FundPsbtRequest) GetLockUnspents() bool {
+	if m != nil {
+		return m.LockUnspents
 	}
-	return interceptor(ctx, in, info, handler)
+	return false
 }
 
-var _VersionService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "walletrpc.VersionService",
-	HandlerType: (*VersionServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Version",
-			Handler:    _VersionService_Version_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "api.proto",
+func (m *FundPsbtRequest) GetCoinControl() *CoinControl {
+	if m != nil {
+		return m.CoinControl
+	}
+	return nil
 }
 
-// WalletServiceClient is the client API for WalletService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type WalletServiceClient interface {
-	// Queries
-	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
-	Network(ctx context.Context, in *NetworkRequest, opts ...grpc.CallOption) (*NetworkResponse, error)
-	AccountNumber(ctx context.Context, in *AccountNumberRequest, opts ...grpc.CallOption) (*AccountNumberResponse, error)
-	Accounts(ctx context.Context, in *AccountsRequest, opts ...grpc.CallOption) (*AccountsResponse, error)
-	Balance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error)
-	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error)
-	// Notifications
-	TransactionNotifications(ctx context.Context, in *TransactionNotificationsRequest, opts ...grpc.CallOption) (WalletService_TransactionNotificationsClient, error)
-	SpentnessNotifications(ctx context.Context, in *SpentnessNotificationsRequest, opts ...grpc.CallOption) (WalletService_SpentnessNotificationsClient, error)
-	AccountNotifications(ctx context.Context, in *AccountNotificationsRequest, opts ...grpc.CallOption) (WalletService_AccountNotificationsClient, error)
-	// Control
-	ChangePassphrase(ctx context.Context, in *ChangePassphraseRequest, opts ...grpc.CallOption) (*ChangePassphraseResponse, error)
-	RenameAccount(ctx context.Context, in *RenameAccountRequest, opts ...grpc.CallOption) (*RenameAccountResponse, error)
-	NextAccount(ctx context.Context, in *NextAccountRequest, opts ...grpc.CallOption) (*NextAccountResponse, error)
-	NextAddress(ctx context.Context, in *NextAddressRequest, opts ...grpc.CallOption) (*NextAddressResponse, error)
-	ImportPrivateKey(ctx context.Context, in *ImportPrivateKeyRequest, opts ...grpc.CallOption) (*ImportPrivateKeyResponse, error)
-	FundTransaction(ctx context.Context, in *FundTransactionRequest, opts ...grpc.CallOption) (*FundTransactionResponse, error)
-	SignTransaction(ctx context.Context, in *SignTransactionRequest, opts ...grpc.CallOption) (*SignTransactionResponse, error)
-	PublishTransaction(ctx context.Context, in *PublishTransactionRequest, opts ...grpc.CallOption) (*PublishTransactionResponse, error)
+type FundPsbtRequest_Input struct {
+	TransactionHash      []byte   `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	OutputIndex          uint32   `protobuf:"varint,2,opt,name=output_index,json=outputIndex,proto3" json:"output_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type walletServiceClient struct {
-	cc *grpc.ClientConn
+func (m *FundPsbtRequest_Input) Reset()         { *m = FundPsbtRequest_Input{} }
+func (m *FundPsbtRequest_Input) String() string { return proto.CompactTextString(m) }
+func (*FundPsbtRequest_Input) ProtoMessage()    {}
+func (*FundPsbtRequest_Input) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{49, 0}
 }
 
-func NewWalletServiceClient(cc *grpc.ClientConn) WalletServiceClient {
-	return &walletServiceClient{cc}
+func (m *FundPsbtRequest_Input) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FundPsbtRequest_Input.Unmarshal(m, b)
+}
+func (m *FundPsbtRequest_Input) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FundPsbtRequest_Input.Marshal(b, m, deterministic)
+}
+func (m *FundPsbtRequest_Input) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FundPsbtRequest_Input.Merge(m, src)
+}
+func (m *FundPsbtRequest_Input) XXX_Size() int {
+	return xxx_messageInfo_FundPsbtRequest_Input.Size(m)
+}
+func (m *FundPsbtRequest_Input) XXX_DiscardUnknown() {
+	xxx_messageInfo_FundPsbtRequest_Input.DiscardUnknown(m)
 }
 
-func (c *walletServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
-	out := new(PingResponse)
-	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/Ping", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_FundPsbtRequest_Input proto.InternalMessageInfo
+
+func (m *FundPsbtRequest_Input) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
 	}
-	return out, nil
+	return nil
 }
 
-func (c *walletServiceClient) Network(ctx context.Context, in *NetworkRequest, opts ...grpc.CallOption) (*NetworkResponse, error) {
-	out := new(NetworkResponse)
-	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/Network", in, out, opts...)
+func (m *FundPsbtRequest_Input) GetOutputIndex() uint32 {
+	if m != nil {
+		return m.OutputIndex
+	}
+	return 0
+}
+
+type FundPsbtRequest_Output struct {
+	PkScript             []byte   `protobuf:"bytes,1,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
+	Amount               int64    `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FundPsbtRequest_Output) Reset()         { *m = FundPsbtRequest_Output{} }
+func (m *FundPsbtRequest_Output) String() string { return proto.CompactTextString(m) }
+func (*FundPsbtRequest_Output) ProtoMessage()    {}
+func (*FundPsbtRequest_Output) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{49, 1}
+}
+
+func (m *FundPsbtRequest_Output) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FundPsbtRequest_Output.Unmarshal(m, b)
+}
+func (m *FundPsbtRequest_Output) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FundPsbtRequest_Output.Marshal(b, m, deterministic)
+}
+func (m *FundPsbtRequest_Output) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FundPsbtRequest_Output.Merge(m, src)
+}
+func (m *FundPsbtRequest_Output) XXX_Size() int {
+	return xxx_messageInfo_FundPsbtRequest_Output.Size(m)
+}
+func (m *FundPsbtRequest_Output) XXX_DiscardUnknown() {
+	xxx_messageInfo_FundPsbtRequest_Output.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FundPsbtRequest_Output proto.InternalMessageInfo
+
+func (m *FundPsbtRequest_Output) GetPkScript() []byte {
+	if m != nil {
+		return m.PkScript
+	}
+	return nil
+}
+
+func (m *FundPsbtRequest_Output) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+type FundPsbtResponse struct {
+	Psbt                 []byte   `protobuf:"bytes,1,opt,name=psbt,proto3" json:"psbt,omitempty"`
+	ChangeIndex          int32    `protobuf:"varint,2,opt,name=change_index,json=changeIndex,proto3" json:"change_index,omitempty"`
+	Fee                  int64    `protobuf:"varint,3,opt,name=fee,proto3" json:"fee,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FundPsbtResponse) Reset()         { *m = FundPsbtResponse{} }
+func (m *FundPsbtResponse) String() string { return proto.CompactTextString(m) }
+func (*FundPsbtResponse) ProtoMessage()    {}
+func (*FundPsbtResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{50}
+}
+
+func (m *FundPsbtResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FundPsbtResponse.Unmarshal(m, b)
+}
+func (m *FundPsbtResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FundPsbtResponse.Marshal(b, m, deterministic)
+}
+func (m *FundPsbtResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FundPsbtResponse.Merge(m, src)
+}
+func (m *FundPsbtResponse) XXX_Size() int {
+	return xxx_messageInfo_FundPsbtResponse.Size(m)
+}
+func (m *FundPsbtResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FundPsbtResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FundPsbtResponse proto.InternalMessageInfo
+
+func (m *FundPsbtResponse) GetPsbt() []byte {
+	if m != nil {
+		return m.Psbt
+	}
+	return nil
+}
+
+func (m *FundPsbtResponse) GetChangeIndex() int32 {
+	if m != nil {
+		return m.ChangeIndex
+	}
+	return 0
+}
+
+func (m *FundPsbtResponse) GetFee() int64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+type ProcessPsbtRequest struct {
+	Passphrase           []byte   `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Psbt                 []byte   `protobuf:"bytes,2,opt,name=psbt,proto3" json:"psbt,omitempty"`
+	Sign                 bool     `protobuf:"varint,3,opt,name=sign,proto3" json:"sign,omitempty"`
+	SighashType          uint32   `protobuf:"varint,4,opt,name=sighash_type,json=sighashType,proto3" json:"sighash_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProcessPsbtRequest) Reset()         { *m = ProcessPsbtRequest{} }
+func (m *ProcessPsbtRequest) String() string { return proto.CompactTextString(m) }
+func (*ProcessPsbtRequest) ProtoMessage()    {}
+func (*ProcessPsbtRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{51}
+}
+
+func (m *ProcessPsbtRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProcessPsbtRequest.Unmarshal(m, b)
+}
+func (m *ProcessPsbtRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProcessPsbtRequest.Marshal(b, m, deterministic)
+}
+func (m *ProcessPsbtRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProcessPsbtRequest.Merge(m, src)
+}
+func (m *ProcessPsbtRequest) XXX_Size() int {
+	return xxx_messageInfo_ProcessPsbtRequest.Size(m)
+}
+func (m *ProcessPsbtRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProcessPsbtRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProcessPsbtRequest proto.InternalMessageInfo
+
+func (m *ProcessPsbtRequest) GetPassphrase() []byte {
+	if m != nil {
+		return m.Passphrase
+	}
+	return nil
+}
+
+func (m *ProcessPsbtRequest) GetPsbt() []byte {
+	if m != nil {
+		return m.Psbt
+	}
+	return nil
+}
+
+func (m *ProcessPsbtRequest) GetSign() bool {
+	if m != nil {
+		return m.Sign
+	}
+	return false
+}
+
+func (m *ProcessPsbtRequest) GetSighashType() uint32 {
+	if m != nil {
+		return m.SighashType
+	}
+	return 0
+}
+
+type ProcessPsbtResponse struct {
+	Psbt                 []byte   `protobuf:"bytes,1,opt,name=psbt,proto3" json:"psbt,omitempty"`
+	Complete             bool     `protobuf:"varint,2,opt,name=complete,proto3" json:"complete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProcessPsbtResponse) Reset()         { *m = ProcessPsbtResponse{} }
+func (m *ProcessPsbtResponse) String() string { return proto.CompactTextString(m) }
+func (*ProcessPsbtResponse) ProtoMessage()    {}
+func (*ProcessPsbtResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{52}
+}
+
+func (m *ProcessPsbtResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProcessPsbtResponse.Unmarshal(m, b)
+}
+func (m *ProcessPsbtResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProcessPsbtResponse.Marshal(b, m, deterministic)
+}
+func (m *ProcessPsbtResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProcessPsbtResponse.Merge(m, src)
+}
+func (m *ProcessPsbtResponse) XXX_Size() int {
+	return xxx_messageInfo_ProcessPsbtResponse.Size(m)
+}
+func (m *ProcessPsbtResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProcessPsbtResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProcessPsbtResponse proto.InternalMessageInfo
+
+func (m *ProcessPsbtResponse) GetPsbt() []byte {
+	if m != nil {
+		return m.Psbt
+	}
+	return nil
+}
+
+func (m *ProcessPsbtResponse) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+type FinalizePsbtRequest struct {
+	Psbt                 []byte   `protobuf:"bytes,1,opt,name=psbt,proto3" json:"psbt,omitempty"`
+	Extract              bool     `protobuf:"varint,2,opt,name=extract,proto3" json:"extract,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FinalizePsbtRequest) Reset()         { *m = FinalizePsbtRequest{} }
+func (m *FinalizePsbtRequest) String() string { return proto.CompactTextString(m) }
+func (*FinalizePsbtRequest) ProtoMessage()    {}
+func (*FinalizePsbtRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{53}
+}
+
+func (m *FinalizePsbtRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FinalizePsbtRequest.Unmarshal(m, b)
+}
+func (m *FinalizePsbtRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FinalizePsbtRequest.Marshal(b, m, deterministic)
+}
+func (m *FinalizePsbtRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FinalizePsbtRequest.Merge(m, src)
+}
+func (m *FinalizePsbtRequest) XXX_Size() int {
+	return xxx_messageInfo_FinalizePsbtRequest.Size(m)
+}
+func (m *FinalizePsbtRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FinalizePsbtRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FinalizePsbtRequest proto.InternalMessageInfo
+
+func (m *FinalizePsbtRequest) GetPsbt() []byte {
+	if m != nil {
+		return m.Psbt
+	}
+	return nil
+}
+
+func (m *FinalizePsbtRequest) GetExtract() bool {
+	if m != nil {
+		return m.Extract
+	}
+	return false
+}
+
+type FinalizePsbtResponse struct {
+	Psbt                 []byte   `protobuf:"bytes,1,opt,name=psbt,proto3" json:"psbt,omitempty"`
+	Transaction          []byte   `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Complete             bool     `protobuf:"varint,3,opt,name=complete,proto3" json:"complete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FinalizePsbtResponse) Reset()         { *m = FinalizePsbtResponse{} }
+func (m *FinalizePsbtResponse) String() string { return proto.CompactTextString(m) }
+func (*FinalizePsbtResponse) ProtoMessage()    {}
+func (*FinalizePsbtResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{54}
+}
+
+func (m *FinalizePsbtResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FinalizePsbtResponse.Unmarshal(m, b)
+}
+func (m *FinalizePsbtResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FinalizePsbtResponse.Marshal(b, m, deterministic)
+}
+func (m *FinalizePsbtResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FinalizePsbtResponse.Merge(m, src)
+}
+func (m *FinalizePsbtResponse) XXX_Size() int {
+	return xxx_messageInfo_FinalizePsbtResponse.Size(m)
+}
+func (m *FinalizePsbtResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FinalizePsbtResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FinalizePsbtResponse proto.InternalMessageInfo
+
+func (m *FinalizePsbtResponse) GetPsbt() []byte {
+	if m != nil {
+		return m.Psbt
+	}
+	return nil
+}
+
+func (m *FinalizePsbtResponse) GetTransaction() []byte {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *FinalizePsbtResponse) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+type CombinePsbtRequest struct {
+	Psbts                [][]byte `protobuf:"bytes,1,rep,name=psbts,proto3" json:"psbts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CombinePsbtRequest) Reset()         { *m = CombinePsbtRequest{} }
+func (m *CombinePsbtRequest) String() string { return proto.CompactTextString(m) }
+func (*CombinePsbtRequest) ProtoMessage()    {}
+func (*CombinePsbtRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{55}
+}
+
+func (m *CombinePsbtRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CombinePsbtRequest.Unmarshal(m, b)
+}
+func (m *CombinePsbtRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CombinePsbtRequest.Marshal(b, m, deterministic)
+}
+func (m *CombinePsbtRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CombinePsbtRequest.Merge(m, src)
+}
+func (m *CombinePsbtRequest) XXX_Size() int {
+	return xxx_messageInfo_CombinePsbtRequest.Size(m)
+}
+func (m *CombinePsbtRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CombinePsbtRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CombinePsbtRequest proto.InternalMessageInfo
+
+func (m *CombinePsbtRequest) GetPsbts() [][]byte {
+	if m != nil {
+		return m.Psbts
+	}
+	return nil
+}
+
+type CombinePsbtResponse struct {
+	Psbt                 []byte   `protobuf:"bytes,1,opt,name=psbt,proto3" json:"psbt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CombinePsbtResponse) Reset()         { *m = CombinePsbtResponse{} }
+func (m *CombinePsbtResponse) String() string { return proto.CompactTextString(m) }
+func (*CombinePsbtResponse) ProtoMessage()    {}
+func (*CombinePsbtResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{56}
+}
+
+func (m *CombinePsbtResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CombinePsbtResponse.Unmarshal(m, b)
+}
+func (m *CombinePsbtResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CombinePsbtResponse.Marshal(b, m, deterministic)
+}
+func (m *CombinePsbtResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CombinePsbtResponse.Merge(m, src)
+}
+func (m *CombinePsbtResponse) XXX_Size() int {
+	return xxx_messageInfo_CombinePsbtResponse.Size(m)
+}
+func (m *CombinePsbtResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CombinePsbtResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CombinePsbtResponse proto.InternalMessageInfo
+
+func (m *CombinePsbtResponse) GetPsbt() []byte {
+	if m != nil {
+		return m.Psbt
+	}
+	return nil
+}
+
+type OutPoint struct {
+	TransactionHash      []byte   `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	OutputIndex          uint32   `protobuf:"varint,2,opt,name=output_index,json=outputIndex,proto3" json:"output_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OutPoint) Reset()         { *m = OutPoint{} }
+func (m *OutPoint) String() string { return proto.CompactTextString(m) }
+func (*OutPoint) ProtoMessage()    {}
+func (*OutPoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{57}
+}
+
+func (m *OutPoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutPoint.Unmarshal(m, b)
+}
+func (m *OutPoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutPoint.Marshal(b, m, deterministic)
+}
+func (m *OutPoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutPoint.Merge(m, src)
+}
+func (m *OutPoint) XXX_Size() int {
+	return xxx_messageInfo_OutPoint.Size(m)
+}
+func (m *OutPoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutPoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutPoint proto.InternalMessageInfo
+
+func (m *OutPoint) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return nil
+}
+
+func (m *OutPoint) GetOutputIndex() uint32 {
+	if m != nil {
+		return m.OutputIndex
+	}
+	return 0
+}
+
+type CoinControl struct {
+	// Outputs of the wallet that are always spent.
+	Inputs []*OutPoint `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// Whether only the inputs are spent, without adding other outputs of
+	// the account when they are not enough.
+	InputsOnly bool `protobuf:"varint,2,opt,name=inputs_only,json=inputsOnly,proto3" json:"inputs_only,omitempty"`
+	// Outputs that are never added to the inputs.
+	Exclude []*OutPoint `protobuf:"bytes,3,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	// Whether outputs with a label, or paying to an address with a label,
+	// are never added to the inputs.
+	ExcludeLabeled bool                 `protobuf:"varint,4,opt,name=exclude_labeled,json=excludeLabeled,proto3" json:"exclude_labeled,omitempty"`
+	Strategy       CoinControl_Strategy `protobuf:"varint,5,opt,name=strategy,proto3,enum=walletrpc.CoinControl_Strategy" json:"strategy,omitempty"`
+	// Where change goes instead of a new change address of the account, if
+	// this is not empty.
+	ChangeAddress string `protobuf:"bytes,6,opt,name=change_address,json=changeAddress,proto3" json:"change_address,omitempty"`
+	// The most inputs the transaction may have, there is no maximum if this
+	// is zero.
+	MaxInputs            uint32   `protobuf:"varint,7,opt,name=max_inputs,json=maxInputs,proto3" json:"max_inputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CoinControl) Reset()         { *m = CoinControl{} }
+func (m *CoinControl) String() string { return proto.CompactTextString(m) }
+func (*CoinControl) ProtoMessage()    {}
+func (*CoinControl) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{58}
+}
+
+func (m *CoinControl) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CoinControl.Unmarshal(m, b)
+}
+func (m *CoinControl) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CoinControl.Marshal(b, m, deterministic)
+}
+func (m *CoinControl) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CoinControl.Merge(m, src)
+}
+func (m *CoinControl) XXX_Size() int {
+	return xxx_messageInfo_CoinControl.Size(m)
+}
+func (m *CoinControl) XXX_DiscardUnknown() {
+	xxx_messageInfo_CoinControl.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CoinControl proto.InternalMessageInfo
+
+func (m *CoinControl) GetInputs() []*OutPoint {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *CoinControl) GetInputsOnly() bool {
+	if m != nil {
+		return m.InputsOnly
+	}
+	return false
+}
+
+func (m *CoinControl) GetExclude() []*OutPoint {
+	if m != nil {
+		return m.Exclude
+	}
+	return nil
+}
+
+func (m *CoinControl) GetExcludeLabeled() bool {
+	if m != nil {
+		return m.ExcludeLabeled
+	}
+	return false
+}
+
+func (m *CoinControl) GetStrategy() CoinControl_Strategy {
+	if m != nil {
+		return m.Strategy
+	}
+	return CoinControl_LARGEST_FIRST
+}
+
+func (m *CoinControl) GetChangeAddress() string {
+	if m != nil {
+		return m.ChangeAddress
+	}
+	return ""
+}
+
+func (m *CoinControl) GetMaxInputs() uint32 {
+	if m != nil {
+		return m.MaxInputs
+	}
+	return 0
+}
+
+type ListUnspentRequest struct {
+	MinConfirmations int32 `protobuf:"varint,1,opt,name=min_confirmations,json=minConfirmations,proto3" json:"min_confirmations,omitempty"`
+	// Outputs with more confirmations are not listed, there is no maximum
+	// if this is zero.
+	MaxConfirmations int32 `protobuf:"varint,2,opt,name=max_confirmations,json=maxConfirmations,proto3" json:"max_confirmations,omitempty"`
+	// Only outputs paying to these addresses are listed if any are given.
+	Addresses            []string `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListUnspentRequest) Reset()         { *m = ListUnspentRequest{} }
+func (m *ListUnspentRequest) String() string { return proto.CompactTextString(m) }
+func (*ListUnspentRequest) ProtoMessage()    {}
+func (*ListUnspentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{59}
+}
+
+func (m *ListUnspentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUnspentRequest.Unmarshal(m, b)
+}
+func (m *ListUnspentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUnspentRequest.Marshal(b, m, deterministic)
+}
+func (m *ListUnspentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUnspentRequest.Merge(m, src)
+}
+func (m *ListUnspentRequest) XXX_Size() int {
+	return xxx_messageInfo_ListUnspentRequest.Size(m)
+}
+func (m *ListUnspentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUnspentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUnspentRequest proto.InternalMessageInfo
+
+func (m *ListUnspentRequest) GetMinConfirmations() int32 {
+	if m != nil {
+		return m.MinConfirmations
+	}
+	return 0
+}
+
+func (m *ListUnspentRequest) GetMaxConfirmations() int32 {
+	if m != nil {
+		return m.MaxConfirmations
+	}
+	return 0
+}
+
+func (m *ListUnspentRequest) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+type ListUnspentResponse struct {
+	Outputs              []*ListUnspentResponse_Output `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *ListUnspentResponse) Reset()         { *m = ListUnspentResponse{} }
+func (m *ListUnspentResponse) String() string { return proto.CompactTextString(m) }
+func (*ListUnspentResponse) ProtoMessage()    {}
+func (*ListUnspentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{60}
+}
+
+func (m *ListUnspentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUnspentResponse.Unmarshal(m, b)
+}
+func (m *ListUnspentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUnspentResponse.Marshal(b, m, deterministic)
+}
+func (m *ListUnspentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUnspentResponse.Merge(m, src)
+}
+func (m *ListUnspentResponse) XXX_Size() int {
+	return xxx_messageInfo_ListUnspentResponse.Size(m)
+}
+func (m *ListUnspentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUnspentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUnspentResponse proto.InternalMessageInfo
+
+func (m *ListUnspentResponse) GetOutputs() []*ListUnspentResponse_Output {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+type ListUnspentResponse_Output struct {
+	TransactionHash      []byte   `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	OutputIndex          uint32   `protobuf:"varint,2,opt,name=output_index,json=outputIndex,proto3" json:"output_index,omitempty"`
+	Address              string   `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	AccountName          string   `protobuf:"bytes,4,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	PkScript             []byte   `protobuf:"bytes,5,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
+	RedeemScript         []byte   `protobuf:"bytes,6,opt,name=redeem_script,json=redeemScript,proto3" json:"redeem_script,omitempty"`
+	Amount               int64    `protobuf:"varint,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	Confirmations        int64    `protobuf:"varint,8,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	Spendable            bool     `protobuf:"varint,9,opt,name=spendable,proto3" json:"spendable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListUnspentResponse_Output) Reset()         { *m = ListUnspentResponse_Output{} }
+func (m *ListUnspentResponse_Output) String() string { return proto.CompactTextString(m) }
+func (*ListUnspentResponse_Output) ProtoMessage()    {}
+func (*ListUnspentResponse_Output) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{60, 0}
+}
+
+func (m *ListUnspentResponse_Output) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUnspentResponse_Output.Unmarshal(m, b)
+}
+func (m *ListUnspentResponse_Output) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUnspentResponse_Output.Marshal(b, m, deterministic)
+}
+func (m *ListUnspentResponse_Output) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUnspentResponse_Output.Merge(m, src)
+}
+func (m *ListUnspentResponse_Output) XXX_Size() int {
+	return xxx_messageInfo_ListUnspentResponse_Output.Size(m)
+}
+func (m *ListUnspentResponse_Output) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUnspentResponse_Output.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUnspentResponse_Output proto.InternalMessageInfo
+
+func (m *ListUnspentResponse_Output) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return nil
+}
+
+func (m *ListUnspentResponse_Output) GetOutputIndex() uint32 {
+	if m != nil {
+		return m.OutputIndex
+	}
+	return 0
+}
+
+func (m *ListUnspentResponse_Output) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ListUnspentResponse_Output) GetAccountName() string {
+	if m != nil {
+		return m.AccountName
+	}
+	return ""
+}
+
+func (m *ListUnspentResponse_Output) GetPkScript() []byte {
+	if m != nil {
+		return m.PkScript
+	}
+	return nil
+}
+
+func (m *ListUnspentResponse_Output) GetRedeemScript() []byte {
+	if m != nil {
+		return m.RedeemScript
+	}
+	return nil
+}
+
+func (m *ListUnspentResponse_Output) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *ListUnspentResponse_Output) GetConfirmations() int64 {
+	if m != nil {
+		return m.Confirmations
+	}
+	return 0
+}
+
+func (m *ListUnspentResponse_Output) GetSpendable() bool {
+	if m != nil {
+		return m.Spendable
+	}
+	return false
+}
+
+type LockedOutputsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LockedOutputsRequest) Reset()         { *m = LockedOutputsRequest{} }
+func (m *LockedOutputsRequest) String() string { return proto.CompactTextString(m) }
+func (*LockedOutputsRequest) ProtoMessage()    {}
+func (*LockedOutputsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{61}
+}
+
+func (m *LockedOutputsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LockedOutputsRequest.Unmarshal(m, b)
+}
+func (m *LockedOutputsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LockedOutputsRequest.Marshal(b, m, deterministic)
+}
+func (m *LockedOutputsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockedOutputsRequest.Merge(m, src)
+}
+func (m *LockedOutputsRequest) XXX_Size() int {
+	return xxx_messageInfo_LockedOutputsRequest.Size(m)
+}
+func (m *LockedOutputsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockedOutputsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockedOutputsRequest proto.InternalMessageInfo
+
+type LockedOutputsResponse struct {
+	Outputs              []*OutPoint `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *LockedOutputsResponse) Reset()         { *m = LockedOutputsResponse{} }
+func (m *LockedOutputsResponse) String() string { return proto.CompactTextString(m) }
+func (*LockedOutputsResponse) ProtoMessage()    {}
+func (*LockedOutputsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{62}
+}
+
+func (m *LockedOutputsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LockedOutputsResponse.Unmarshal(m, b)
+}
+func (m *LockedOutputsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LockedOutputsResponse.Marshal(b, m, deterministic)
+}
+func (m *LockedOutputsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockedOutputsResponse.Merge(m, src)
+}
+func (m *LockedOutputsResponse) XXX_Size() int {
+	return xxx_messageInfo_LockedOutputsResponse.Size(m)
+}
+func (m *LockedOutputsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockedOutputsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockedOutputsResponse proto.InternalMessageInfo
+
+func (m *LockedOutputsResponse) GetOutputs() []*OutPoint {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+type LockOutputsRequest struct {
+	Unlock bool `protobuf:"varint,1,opt,name=unlock,proto3" json:"unlock,omitempty"`
+	// Unlocking with no outputs unlocks every locked output.
+	Outputs              []*OutPoint `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *LockOutputsRequest) Reset()         { *m = LockOutputsRequest{} }
+func (m *LockOutputsRequest) String() string { return proto.CompactTextString(m) }
+func (*LockOutputsRequest) ProtoMessage()    {}
+func (*LockOutputsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{63}
+}
+
+func (m *LockOutputsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LockOutputsRequest.Unmarshal(m, b)
+}
+func (m *LockOutputsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LockOutputsRequest.Marshal(b, m, deterministic)
+}
+func (m *LockOutputsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockOutputsRequest.Merge(m, src)
+}
+func (m *LockOutputsRequest) XXX_Size() int {
+	return xxx_messageInfo_LockOutputsRequest.Size(m)
+}
+func (m *LockOutputsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockOutputsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockOutputsRequest proto.InternalMessageInfo
+
+func (m *LockOutputsRequest) GetUnlock() bool {
+	if m != nil {
+		return m.Unlock
+	}
+	return false
+}
+
+func (m *LockOutputsRequest) GetOutputs() []*OutPoint {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+type LockOutputsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LockOutputsResponse) Reset()         { *m = LockOutputsResponse{} }
+func (m *LockOutputsResponse) String() string { return proto.CompactTextString(m) }
+func (*LockOutputsResponse) ProtoMessage()    {}
+func (*LockOutputsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{64}
+}
+
+func (m *LockOutputsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LockOutputsResponse.Unmarshal(m, b)
+}
+func (m *LockOutputsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LockOutputsResponse.Marshal(b, m, deterministic)
+}
+func (m *LockOutputsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockOutputsResponse.Merge(m, src)
+}
+func (m *LockOutputsResponse) XXX_Size() int {
+	return xxx_messageInfo_LockOutputsResponse.Size(m)
+}
+func (m *LockOutputsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockOutputsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockOutputsResponse proto.InternalMessageInfo
+
+type SendManyRequest struct {
+	Passphrase            []byte                    `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Account               uint32                    `protobuf:"varint,2,opt,name=account,proto3" json:"account,omitempty"`
+	Outputs               []*SendManyRequest_Output `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	RequiredConfirmations int32                     `protobuf:"varint,4,opt,name=required_confirmations,json=requiredConfirmations,proto3" json:"required_confirmations,omitempty"`
+	// Fee rate in atoms per kilobyte, the wallet's relay fee is used if
+	// this is zero.
+	FeeRate              int64        `protobuf:"varint,5,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"`
+	Comment              string       `protobuf:"bytes,6,opt,name=comment,proto3" json:"comment,omitempty"`
+	CommentTo            string       `protobuf:"bytes,7,opt,name=comment_to,json=commentTo,proto3" json:"comment_to,omitempty"`
+	CoinControl          *CoinControl `protobuf:"bytes,8,opt,name=coin_control,json=coinControl,proto3" json:"coin_control,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SendManyRequest) Reset()         { *m = SendManyRequest{} }
+func (m *SendManyRequest) String() string { return proto.CompactTextString(m) }
+func (*SendManyRequest) ProtoMessage()    {}
+func (*SendManyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{65}
+}
+
+func (m *SendManyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendManyRequest.Unmarshal(m, b)
+}
+func (m *SendManyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendManyRequest.Marshal(b, m, deterministic)
+}
+func (m *SendManyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendManyRequest.Merge(m, src)
+}
+func (m *SendManyRequest) XXX_Size() int {
+	return xxx_messageInfo_SendManyRequest.Size(m)
+}
+func (m *SendManyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendManyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendManyRequest proto.InternalMessageInfo
+
+func (m *SendManyRequest) GetPassphrase() []byte {
+	if m != nil {
+		return m.Passphrase
+	}
+	return nil
+}
+
+func (m *SendManyRequest) GetAccount() uint32 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *SendManyRequest) GetOutputs() []*SendManyRequest_Output {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+func (m *SendManyRequest) GetRequiredConfirmations() int32 {
+	if m != nil {
+		return m.RequiredConfirmations
+	}
+	return 0
+}
+
+func (m *SendManyRequest) GetFeeRate() int64 {
+	if m != nil {
+		return m.FeeRate
+	}
+	return 0
+}
+
+func (m *SendManyRequest) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+func (m *SendManyRequest) GetCommentTo() string {
+	if m != nil {
+		return m.CommentTo
+	}
+	return ""
+}
+
+func (m *SendManyRequest) GetCoinControl() *CoinControl {
+	if m != nil {
+		return m.CoinControl
+	}
+	return nil
+}
+
+type SendManyRequest_Output struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Amount               int64    `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendManyRequest_Output) Reset()         { *m = SendManyRequest_Output{} }
+func (m *SendManyRequest_Output) String() string { return proto.CompactTextString(m) }
+func (*SendManyRequest_Output) ProtoMessage()    {}
+func (*SendManyRequest_Output) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{65, 0}
+}
+
+func (m *SendManyRequest_Output) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendManyRequest_Output.Unmarshal(m, b)
+}
+func (m *SendManyRequest_Output) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendManyRequest_Output.Marshal(b, m, deterministic)
+}
+func (m *SendManyRequest_Output) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendManyRequest_Output.Merge(m, src)
+}
+func (m *SendManyRequest_Output) XXX_Size() int {
+	return xxx_messageInfo_SendManyRequest_Output.Size(m)
+}
+func (m *SendManyRequest_Output) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendManyRequest_Output.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendManyRequest_Output proto.InternalMessageInfo
+
+func (m *SendManyRequest_Output) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SendManyRequest_Output) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+type SendManyResponse struct {
+	TransactionHash      []byte   `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendManyResponse) Reset()         { *m = SendManyResponse{} }
+func (m *SendManyResponse) String() string { return proto.CompactTextString(m) }
+func (*SendManyResponse) ProtoMessage()    {}
+func (*SendManyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{66}
+}
+
+func (m *SendManyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendManyResponse.Unmarshal(m, b)
+}
+func (m *SendManyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendManyResponse.Marshal(b, m, deterministic)
+}
+func (m *SendManyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendManyResponse.Merge(m, src)
+}
+func (m *SendManyResponse) XXX_Size() int {
+	return xxx_messageInfo_SendManyResponse.Size(m)
+}
+func (m *SendManyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendManyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendManyResponse proto.InternalMessageInfo
+
+func (m *SendManyResponse) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return nil
+}
+
+type SignMessageRequest struct {
+	Passphrase           []byte   `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignMessageRequest) Reset()         { *m = SignMessageRequest{} }
+func (m *SignMessageRequest) String() string { return proto.CompactTextString(m) }
+func (*SignMessageRequest) ProtoMessage()    {}
+func (*SignMessageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{67}
+}
+
+func (m *SignMessageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignMessageRequest.Unmarshal(m, b)
+}
+func (m *SignMessageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignMessageRequest.Marshal(b, m, deterministic)
+}
+func (m *SignMessageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignMessageRequest.Merge(m, src)
+}
+func (m *SignMessageRequest) XXX_Size() int {
+	return xxx_messageInfo_SignMessageRequest.Size(m)
+}
+func (m *SignMessageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignMessageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignMessageRequest proto.InternalMessageInfo
+
+func (m *SignMessageRequest) GetPassphrase() []byte {
+	if m != nil {
+		return m.Passphrase
+	}
+	return nil
+}
+
+func (m *SignMessageRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SignMessageRequest) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type SignMessageResponse struct {
+	Signature            []byte   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignMessageResponse) Reset()         { *m = SignMessageResponse{} }
+func (m *SignMessageResponse) String() string { return proto.CompactTextString(m) }
+func (*SignMessageResponse) ProtoMessage()    {}
+func (*SignMessageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{68}
+}
+
+func (m *SignMessageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignMessageResponse.Unmarshal(m, b)
+}
+func (m *SignMessageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignMessageResponse.Marshal(b, m, deterministic)
+}
+func (m *SignMessageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignMessageResponse.Merge(m, src)
+}
+func (m *SignMessageResponse) XXX_Size() int {
+	return xxx_messageInfo_SignMessageResponse.Size(m)
+}
+func (m *SignMessageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignMessageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignMessageResponse proto.InternalMessageInfo
+
+func (m *SignMessageResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type DumpPrivateKeyRequest struct {
+	Passphrase           []byte   `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DumpPrivateKeyRequest) Reset()         { *m = DumpPrivateKeyRequest{} }
+func (m *DumpPrivateKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*DumpPrivateKeyRequest) ProtoMessage()    {}
+func (*DumpPrivateKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{69}
+}
+
+func (m *DumpPrivateKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DumpPrivateKeyRequest.Unmarshal(m, b)
+}
+func (m *DumpPrivateKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DumpPrivateKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *DumpPrivateKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DumpPrivateKeyRequest.Merge(m, src)
+}
+func (m *DumpPrivateKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_DumpPrivateKeyRequest.Size(m)
+}
+func (m *DumpPrivateKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DumpPrivateKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DumpPrivateKeyRequest proto.InternalMessageInfo
+
+func (m *DumpPrivateKeyRequest) GetPassphrase() []byte {
+	if m != nil {
+		return m.Passphrase
+	}
+	return nil
+}
+
+func (m *DumpPrivateKeyRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type DumpPrivateKeyResponse struct {
+	PrivateKeyWif        string   `protobuf:"bytes,1,opt,name=private_key_wif,json=privateKeyWif,proto3" json:"private_key_wif,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DumpPrivateKeyResponse) Reset()         { *m = DumpPrivateKeyResponse{} }
+func (m *DumpPrivateKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*DumpPrivateKeyResponse) ProtoMessage()    {}
+func (*DumpPrivateKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{70}
+}
+
+func (m *DumpPrivateKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DumpPrivateKeyResponse.Unmarshal(m, b)
+}
+func (m *DumpPrivateKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DumpPrivateKeyResponse.Marshal(b, m, deterministic)
+}
+func (m *DumpPrivateKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DumpPrivateKeyResponse.Merge(m, src)
+}
+func (m *DumpPrivateKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_DumpPrivateKeyResponse.Size(m)
+}
+func (m *DumpPrivateKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DumpPrivateKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DumpPrivateKeyResponse proto.InternalMessageInfo
+
+func (m *DumpPrivateKeyResponse) GetPrivateKeyWif() string {
+	if m != nil {
+		return m.PrivateKeyWif
+	}
+	return ""
+}
+
+type AddressLabelRequest struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddressLabelRequest) Reset()         { *m = AddressLabelRequest{} }
+func (m *AddressLabelRequest) String() string { return proto.CompactTextString(m) }
+func (*AddressLabelRequest) ProtoMessage()    {}
+func (*AddressLabelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{71}
+}
+
+func (m *AddressLabelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddressLabelRequest.Unmarshal(m, b)
+}
+func (m *AddressLabelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddressLabelRequest.Marshal(b, m, deterministic)
+}
+func (m *AddressLabelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddressLabelRequest.Merge(m, src)
+}
+func (m *AddressLabelRequest) XXX_Size() int {
+	return xxx_messageInfo_AddressLabelRequest.Size(m)
+}
+func (m *AddressLabelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddressLabelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddressLabelRequest proto.InternalMessageInfo
+
+func (m *AddressLabelRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type AddressLabelResponse struct {
+	Label                string   `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddressLabelResponse) Reset()         { *m = AddressLabelResponse{} }
+func (m *AddressLabelResponse) String() string { return proto.CompactTextString(m) }
+func (*AddressLabelResponse) ProtoMessage()    {}
+func (*AddressLabelResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{72}
+}
+
+func (m *AddressLabelResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddressLabelResponse.Unmarshal(m, b)
+}
+func (m *AddressLabelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddressLabelResponse.Marshal(b, m, deterministic)
+}
+func (m *AddressLabelResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddressLabelResponse.Merge(m, src)
+}
+func (m *AddressLabelResponse) XXX_Size() int {
+	return xxx_messageInfo_AddressLabelResponse.Size(m)
+}
+func (m *AddressLabelResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddressLabelResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddressLabelResponse proto.InternalMessageInfo
+
+func (m *AddressLabelResponse) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+type SetAddressLabelRequest struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Label                string   `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetAddressLabelRequest) Reset()         { *m = SetAddressLabelRequest{} }
+func (m *SetAddressLabelRequest) String() string { return proto.CompactTextString(m) }
+func (*SetAddressLabelRequest) ProtoMessage()    {}
+func (*SetAddressLabelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{73}
+}
+
+func (m *SetAddressLabelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetAddressLabelRequest.Unmarshal(m, b)
+}
+func (m *SetAddressLabelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetAddressLabelRequest.Marshal(b, m, deterministic)
+}
+func (m *SetAddressLabelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetAddressLabelRequest.Merge(m, src)
+}
+func (m *SetAddressLabelRequest) XXX_Size() int {
+	return xxx_messageInfo_SetAddressLabelRequest.Size(m)
+}
+func (m *SetAddressLabelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetAddressLabelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetAddressLabelRequest proto.InternalMessageInfo
+
+func (m *SetAddressLabelRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SetAddressLabelRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+type SetAddressLabelResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetAddressLabelResponse) Reset()         { *m = SetAddressLabelResponse{} }
+func (m *SetAddressLabelResponse) String() string { return proto.CompactTextString(m) }
+func (*SetAddressLabelResponse) ProtoMessage()    {}
+func (*SetAddressLabelResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{74}
+}
+
+func (m *SetAddressLabelResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetAddressLabelResponse.Unmarshal(m, b)
+}
+func (m *SetAddressLabelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetAddressLabelResponse.Marshal(b, m, deterministic)
+}
+func (m *SetAddressLabelResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetAddressLabelResponse.Merge(m, src)
+}
+func (m *SetAddressLabelResponse) XXX_Size() int {
+	return xxx_messageInfo_SetAddressLabelResponse.Size(m)
+}
+func (m *SetAddressLabelResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetAddressLabelResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetAddressLabelResponse proto.InternalMessageInfo
+
+type SetOutputLabelRequest struct {
+	Output               *OutPoint `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
+	Label                string    `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *SetOutputLabelRequest) Reset()         { *m = SetOutputLabelRequest{} }
+func (m *SetOutputLabelRequest) String() string { return proto.CompactTextString(m) }
+func (*SetOutputLabelRequest) ProtoMessage()    {}
+func (*SetOutputLabelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{75}
+}
+
+func (m *SetOutputLabelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetOutputLabelRequest.Unmarshal(m, b)
+}
+func (m *SetOutputLabelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetOutputLabelRequest.Marshal(b, m, deterministic)
+}
+func (m *SetOutputLabelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetOutputLabelRequest.Merge(m, src)
+}
+func (m *SetOutputLabelRequest) XXX_Size() int {
+	return xxx_messageInfo_SetOutputLabelRequest.Size(m)
+}
+func (m *SetOutputLabelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetOutputLabelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetOutputLabelRequest proto.InternalMessageInfo
+
+func (m *SetOutputLabelRequest) GetOutput() *OutPoint {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *SetOutputLabelRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+type SetOutputLabelResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetOutputLabelResponse) Reset()         { *m = SetOutputLabelResponse{} }
+func (m *SetOutputLabelResponse) String() string { return proto.CompactTextString(m) }
+func (*SetOutputLabelResponse) ProtoMessage()    {}
+func (*SetOutputLabelResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{76}
+}
+
+func (m *SetOutputLabelResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetOutputLabelResponse.Unmarshal(m, b)
+}
+func (m *SetOutputLabelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetOutputLabelResponse.Marshal(b, m, deterministic)
+}
+func (m *SetOutputLabelResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetOutputLabelResponse.Merge(m, src)
+}
+func (m *SetOutputLabelResponse) XXX_Size() int {
+	return xxx_messageInfo_SetOutputLabelResponse.Size(m)
+}
+func (m *SetOutputLabelResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetOutputLabelResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetOutputLabelResponse proto.InternalMessageInfo
+
+type SetTransactionCommentRequest struct {
+	TransactionHash      []byte   `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	Comment              string   `protobuf:"bytes,2,opt,name=comment,proto3" json:"comment,omitempty"`
+	CommentTo            string   `protobuf:"bytes,3,opt,name=comment_to,json=commentTo,proto3" json:"comment_to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetTransactionCommentRequest) Reset()         { *m = SetTransactionCommentRequest{} }
+func (m *SetTransactionCommentRequest) String() string { return proto.CompactTextString(m) }
+func (*SetTransactionCommentRequest) ProtoMessage()    {}
+func (*SetTransactionCommentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{77}
+}
+
+func (m *SetTransactionCommentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetTransactionCommentRequest.Unmarshal(m, b)
+}
+func (m *SetTransactionCommentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetTransactionCommentRequest.Marshal(b, m, deterministic)
+}
+func (m *SetTransactionCommentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetTransactionCommentRequest.Merge(m, src)
+}
+func (m *SetTransactionCommentRequest) XXX_Size() int {
+	return xxx_messageInfo_SetTransactionCommentRequest.Size(m)
+}
+func (m *SetTransactionCommentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetTransactionCommentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetTransactionCommentRequest proto.InternalMessageInfo
+
+func (m *SetTransactionCommentRequest) GetTransactionHash() []byte {
+	if m != nil {
+		return m.TransactionHash
+	}
+	return nil
+}
+
+func (m *SetTransactionCommentRequest) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+func (m *SetTransactionCommentRequest) GetCommentTo() string {
+	if m != nil {
+		return m.CommentTo
+	}
+	return ""
+}
+
+type SetTransactionCommentResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetTransactionCommentResponse) Reset()         { *m = SetTransactionCommentResponse{} }
+func (m *SetTransactionCommentResponse) String() string { return proto.CompactTextString(m) }
+func (*SetTransactionCommentResponse) ProtoMessage()    {}
+func (*SetTransactionCommentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{78}
+}
+
+func (m *SetTransactionCommentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetTransactionCommentResponse.Unmarshal(m, b)
+}
+func (m *SetTransactionCommentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetTransactionCommentResponse.Marshal(b, m, deterministic)
+}
+func (m *SetTransactionCommentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetTransactionCommentResponse.Merge(m, src)
+}
+func (m *SetTransactionCommentResponse) XXX_Size() int {
+	return xxx_messageInfo_SetTransactionCommentResponse.Size(m)
+}
+func (m *SetTransactionCommentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetTransactionCommentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetTransactionCommentResponse proto.InternalMessageInfo
+
+type RescanRequest struct {
+	BeginHeight          int32    `protobuf:"varint,1,opt,name=begin_height,json=beginHeight,proto3" json:"begin_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RescanRequest) Reset()         { *m = RescanRequest{} }
+func (m *RescanRequest) String() string { return proto.CompactTextString(m) }
+func (*RescanRequest) ProtoMessage()    {}
+func (*RescanRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{79}
+}
+
+func (m *RescanRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RescanRequest.Unmarshal(m, b)
+}
+func (m *RescanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RescanRequest.Marshal(b, m, deterministic)
+}
+func (m *RescanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RescanRequest.Merge(m, src)
+}
+func (m *RescanRequest) XXX_Size() int {
+	return xxx_messageInfo_RescanRequest.Size(m)
+}
+func (m *RescanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RescanRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RescanRequest proto.InternalMessageInfo
+
+func (m *RescanRequest) GetBeginHeight() int32 {
+	if m != nil {
+		return m.BeginHeight
+	}
+	return 0
+}
+
+type RescanResponse struct {
+	BlockHash            []byte   `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockHeight          int32    `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	BlockTimestamp       int64    `protobuf:"varint,3,opt,name=block_timestamp,json=blockTimestamp,proto3" json:"block_timestamp,omitempty"`
+	Finished             bool     `protobuf:"varint,4,opt,name=finished,proto3" json:"finished,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RescanResponse) Reset()         { *m = RescanResponse{} }
+func (m *RescanResponse) String() string { return proto.CompactTextString(m) }
+func (*RescanResponse) ProtoMessage()    {}
+func (*RescanResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{80}
+}
+
+func (m *RescanResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RescanResponse.Unmarshal(m, b)
+}
+func (m *RescanResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RescanResponse.Marshal(b, m, deterministic)
+}
+func (m *RescanResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RescanResponse.Merge(m, src)
+}
+func (m *RescanResponse) XXX_Size() int {
+	return xxx_messageInfo_RescanResponse.Size(m)
+}
+func (m *RescanResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RescanResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RescanResponse proto.InternalMessageInfo
+
+func (m *RescanResponse) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *RescanResponse) GetBlockHeight() int32 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *RescanResponse) GetBlockTimestamp() int64 {
+	if m != nil {
+		return m.BlockTimestamp
+	}
+	return 0
+}
+
+func (m *RescanResponse) GetFinished() bool {
+	if m != nil {
+		return m.Finished
+	}
+	return false
+}
+
+type NotificationsRequest struct {
+	Type        NotificationsRequest_Type `protobuf:"varint,1,opt,name=type,proto3,enum=walletrpc.NotificationsRequest_Type" json:"type,omitempty"`
+	Unsubscribe bool                      `protobuf:"varint,2,opt,name=unsubscribe,proto3" json:"unsubscribe,omitempty"`
+	// The account spentness notifications are sent for.
+	Account              uint32   `protobuf:"varint,3,opt,name=account,proto3" json:"account,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NotificationsRequest) Reset()         { *m = NotificationsRequest{} }
+func (m *NotificationsRequest) String() string { return proto.CompactTextString(m) }
+func (*NotificationsRequest) ProtoMessage()    {}
+func (*NotificationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{81}
+}
+
+func (m *NotificationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotificationsRequest.Unmarshal(m, b)
+}
+func (m *NotificationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotificationsRequest.Marshal(b, m, deterministic)
+}
+func (m *NotificationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotificationsRequest.Merge(m, src)
+}
+func (m *NotificationsRequest) XXX_Size() int {
+	return xxx_messageInfo_NotificationsRequest.Size(m)
+}
+func (m *NotificationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotificationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NotificationsRequest proto.InternalMessageInfo
+
+func (m *NotificationsRequest) GetType() NotificationsRequest_Type {
+	if m != nil {
+		return m.Type
+	}
+	return NotificationsRequest_TRANSACTIONS
+}
+
+func (m *NotificationsRequest) GetUnsubscribe() bool {
+	if m != nil {
+		return m.Unsubscribe
+	}
+	return false
+}
+
+func (m *NotificationsRequest) GetAccount() uint32 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+type NotificationsResponse struct {
+	Transactions         *TransactionNotificationsResponse `protobuf:"bytes,1,opt,name=transactions,proto3" json:"transactions,omitempty"`
+	Spentness            *SpentnessNotificationsResponse   `protobuf:"bytes,2,opt,name=spentness,proto3" json:"spentness,omitempty"`
+	Accounts             *AccountNotificationsResponse     `protobuf:"bytes,3,opt,name=accounts,proto3" json:"accounts,omitempty"`
+	Rescan               *RescanResponse                   `protobuf:"bytes,4,opt,name=rescan,proto3" json:"rescan,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *NotificationsResponse) Reset()         { *m = NotificationsResponse{} }
+func (m *NotificationsResponse) String() string { return proto.CompactTextString(m) }
+func (*NotificationsResponse) ProtoMessage()    {}
+func (*NotificationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{82}
+}
+
+func (m *NotificationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotificationsResponse.Unmarshal(m, b)
+}
+func (m *NotificationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotificationsResponse.Marshal(b, m, deterministic)
+}
+func (m *NotificationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotificationsResponse.Merge(m, src)
+}
+func (m *NotificationsResponse) XXX_Size() int {
+	return xxx_messageInfo_NotificationsResponse.Size(m)
+}
+func (m *NotificationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotificationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NotificationsResponse proto.InternalMessageInfo
+
+func (m *NotificationsResponse) GetTransactions() *TransactionNotificationsResponse {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+func (m *NotificationsResponse) GetSpentness() *SpentnessNotificationsResponse {
+	if m != nil {
+		return m.Spentness
+	}
+	return nil
+}
+
+func (m *NotificationsResponse) GetAccounts() *AccountNotificationsResponse {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
+}
+
+func (m *NotificationsResponse) GetRescan() *RescanResponse {
+	if m != nil {
+		return m.Rescan
+	}
+	return nil
+}
+
+type ListWalletsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWalletsRequest) Reset()         { *m = ListWalletsRequest{} }
+func (m *ListWalletsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWalletsRequest) ProtoMessage()    {}
+func (*ListWalletsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{83}
+}
+
+func (m *ListWalletsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWalletsRequest.Unmarshal(m, b)
+}
+func (m *ListWalletsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWalletsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWalletsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWalletsRequest.Merge(m, src)
+}
+func (m *ListWalletsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWalletsRequest.Size(m)
+}
+func (m *ListWalletsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWalletsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWalletsRequest proto.InternalMessageInfo
+
+type ListWalletsResponse struct {
+	// The default wallet has the empty name.
+	Names                []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWalletsResponse) Reset()         { *m = ListWalletsResponse{} }
+func (m *ListWalletsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListWalletsResponse) ProtoMessage()    {}
+func (*ListWalletsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00212fb1f9d3bf1c, []int{84}
+}
+
+func (m *ListWalletsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWalletsResponse.Unmarshal(m, b)
+}
+func (m *ListWalletsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWalletsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListWalletsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWalletsResponse.Merge(m, src)
+}
+func (m *ListWalletsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListWalletsResponse.Size(m)
+}
+func (m *ListWalletsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWalletsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWalletsResponse proto.InternalMessageInfo
+
+func (m *ListWalletsResponse) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("walletrpc.NextAddressRequest_Kind", NextAddressRequest_Kind_name, NextAddressRequest_Kind_value)
+	proto.RegisterEnum("walletrpc.ChangePassphraseRequest_Key", ChangePassphraseRequest_Key_name, ChangePassphraseRequest_Key_value)
+	proto.RegisterEnum("walletrpc.CoinControl_Strategy", CoinControl_Strategy_name, CoinControl_Strategy_value)
+	proto.RegisterEnum("walletrpc.NotificationsRequest_Type", NotificationsRequest_Type_name, NotificationsRequest_Type_value)
+	proto.RegisterType((*VersionRequest)(nil), "walletrpc.VersionRequest")
+	proto.RegisterType((*VersionResponse)(nil), "walletrpc.VersionResponse")
+	proto.RegisterType((*TransactionDetails)(nil), "walletrpc.TransactionDetails")
+	proto.RegisterType((*TransactionDetails_Input)(nil), "walletrpc.TransactionDetails.Input")
+	proto.RegisterType((*TransactionDetails_Output)(nil), "walletrpc.TransactionDetails.Output")
+	proto.RegisterType((*BlockDetails)(nil), "walletrpc.BlockDetails")
+	proto.RegisterType((*AccountBalance)(nil), "walletrpc.AccountBalance")
+	proto.RegisterType((*PingRequest)(nil), "walletrpc.PingRequest")
+	proto.RegisterType((*PingResponse)(nil), "walletrpc.PingResponse")
+	proto.RegisterType((*NetworkRequest)(nil), "walletrpc.NetworkRequest")
+	proto.RegisterType((*NetworkResponse)(nil), "walletrpc.NetworkResponse")
+	proto.RegisterType((*AccountNumberRequest)(nil), "walletrpc.AccountNumberRequest")
+	proto.RegisterType((*AccountNumberResponse)(nil), "walletrpc.AccountNumberResponse")
+	proto.RegisterType((*AccountsRequest)(nil), "walletrpc.AccountsRequest")
+	proto.RegisterType((*AccountsResponse)(nil), "walletrpc.AccountsResponse")
+	proto.RegisterType((*AccountsResponse_Account)(nil), "walletrpc.AccountsResponse.Account")
+	proto.RegisterType((*RenameAccountRequest)(nil), "walletrpc.RenameAccountRequest")
+	proto.RegisterType((*RenameAccountResponse)(nil), "walletrpc.RenameAccountResponse")
+	proto.RegisterType((*NextAccountRequest)(nil), "walletrpc.NextAccountRequest")
+	proto.RegisterType((*NextAccountResponse)(nil), "walletrpc.NextAccountResponse")
+	proto.RegisterType((*NextAddressRequest)(nil), "walletrpc.NextAddressRequest")
+	proto.RegisterType((*NextAddressResponse)(nil), "walletrpc.NextAddressResponse")
+	proto.RegisterType((*ImportPrivateKeyRequest)(nil), "walletrpc.ImportPrivateKeyRequest")
+	proto.RegisterType((*ImportPrivateKeyResponse)(nil), "walletrpc.ImportPrivateKeyResponse")
+	proto.RegisterType((*BalanceRequest)(nil), "walletrpc.BalanceRequest")
+	proto.RegisterType((*BalanceResponse)(nil), "walletrpc.BalanceResponse")
+	proto.RegisterType((*GetTransactionsRequest)(nil), "walletrpc.GetTransactionsRequest")
+	proto.RegisterType((*GetTransactionsResponse)(nil), "walletrpc.GetTransactionsResponse")
+	proto.RegisterType((*ChangePassphraseRequest)(nil), "walletrpc.ChangePassphraseRequest")
+	proto.RegisterType((*ChangePassphraseResponse)(nil), "walletrpc.ChangePassphraseResponse")
+	proto.RegisterType((*FundTransactionRequest)(nil), "walletrpc.FundTransactionRequest")
+	proto.RegisterType((*FundTransactionResponse)(nil), "walletrpc.FundTransactionResponse")
+	proto.RegisterType((*FundTransactionResponse_PreviousOutput)(nil), "walletrpc.FundTransactionResponse.PreviousOutput")
+	proto.RegisterType((*SignTransactionRequest)(nil), "walletrpc.SignTransactionRequest")
+	proto.RegisterType((*SignTransactionResponse)(nil), "walletrpc.SignTransactionResponse")
+	proto.RegisterType((*PublishTransactionRequest)(nil), "walletrpc.PublishTransactionRequest")
+	proto.RegisterType((*PublishTransactionResponse)(nil), "walletrpc.PublishTransactionResponse")
+	proto.RegisterType((*TransactionNotificationsRequest)(nil), "walletrpc.TransactionNotificationsRequest")
+	proto.RegisterType((*TransactionNotificationsResponse)(nil), "walletrpc.TransactionNotificationsResponse")
+	proto.RegisterType((*SpentnessNotificationsRequest)(nil), "walletrpc.SpentnessNotificationsRequest")
+	proto.RegisterType((*SpentnessNotificationsResponse)(nil), "walletrpc.SpentnessNotificationsResponse")
+	proto.RegisterType((*SpentnessNotificationsResponse_Spender)(nil), "walletrpc.SpentnessNotificationsResponse.Spender")
+	proto.RegisterType((*AccountNotificationsRequest)(nil), "walletrpc.AccountNotificationsRequest")
+	proto.RegisterType((*AccountNotificationsResponse)(nil), "walletrpc.AccountNotificationsResponse")
+	proto.RegisterType((*CreateWalletRequest)(nil), "walletrpc.CreateWalletRequest")
+	proto.RegisterType((*CreateWalletResponse)(nil), "walletrpc.CreateWalletResponse")
+	proto.RegisterType((*OpenWalletRequest)(nil), "walletrpc.OpenWalletRequest")
+	proto.RegisterType((*OpenWalletResponse)(nil), "walletrpc.OpenWalletResponse")
+	proto.RegisterType((*CloseWalletRequest)(nil), "walletrpc.CloseWalletRequest")
+	proto.RegisterType((*CloseWalletResponse)(nil), "walletrpc.CloseWalletResponse")
+	proto.RegisterType((*WalletExistsRequest)(nil), "walletrpc.WalletExistsRequest")
+	proto.RegisterType((*WalletExistsResponse)(nil), "walletrpc.WalletExistsResponse")
+	proto.RegisterType((*StartConsensusRPCRequest)(nil), "walletrpc.StartConsensusRPCRequest")
+	proto.RegisterType((*StartConsensusRPCResponse)(nil), "walletrpc.StartConsensusRPCResponse")
+	proto.RegisterType((*FundPsbtRequest)(nil), "walletrpc.FundPsbtRequest")
+	proto.RegisterType((*FundPsbtRequest_Input)(nil), "walletrpc.FundPsbtRequest.Input")
+	proto.RegisterType((*FundPsbtRequest_Output)(nil), "walletrpc.FundPsbtRequest.Output")
+	proto.RegisterType((*FundPsbtResponse)(nil), "walletrpc.FundPsbtResponse")
+	proto.RegisterType((*ProcessPsbtRequest)(nil), "walletrpc.ProcessPsbtRequest")
+	proto.RegisterType((*ProcessPsbtResponse)(nil), "walletrpc.ProcessPsbtResponse")
+	proto.RegisterType((*FinalizePsbtRequest)(nil), "walletrpc.FinalizePsbtRequest")
+	proto.RegisterType((*FinalizePsbtResponse)(nil), "walletrpc.FinalizePsbtResponse")
+	proto.RegisterType((*CombinePsbtRequest)(nil), "walletrpc.CombinePsbtRequest")
+	proto.RegisterType((*CombinePsbtResponse)(nil), "walletrpc.CombinePsbtResponse")
+	proto.RegisterType((*OutPoint)(nil), "walletrpc.OutPoint")
+	proto.RegisterType((*CoinControl)(nil), "walletrpc.CoinControl")
+	proto.RegisterType((*ListUnspentRequest)(nil), "walletrpc.ListUnspentRequest")
+	proto.RegisterType((*ListUnspentResponse)(nil), "walletrpc.ListUnspentResponse")
+	proto.RegisterType((*ListUnspentResponse_Output)(nil), "walletrpc.ListUnspentResponse.Output")
+	proto.RegisterType((*LockedOutputsRequest)(nil), "walletrpc.LockedOutputsRequest")
+	proto.RegisterType((*LockedOutputsResponse)(nil), "walletrpc.LockedOutputsResponse")
+	proto.RegisterType((*LockOutputsRequest)(nil), "walletrpc.LockOutputsRequest")
+	proto.RegisterType((*LockOutputsResponse)(nil), "walletrpc.LockOutputsResponse")
+	proto.RegisterType((*SendManyRequest)(nil), "walletrpc.SendManyRequest")
+	proto.RegisterType((*SendManyRequest_Output)(nil), "walletrpc.SendManyRequest.Output")
+	proto.RegisterType((*SendManyResponse)(nil), "walletrpc.SendManyResponse")
+	proto.RegisterType((*SignMessageRequest)(nil), "walletrpc.SignMessageRequest")
+	proto.RegisterType((*SignMessageResponse)(nil), "walletrpc.SignMessageResponse")
+	proto.RegisterType((*DumpPrivateKeyRequest)(nil), "walletrpc.DumpPrivateKeyRequest")
+	proto.RegisterType((*DumpPrivateKeyResponse)(nil), "walletrpc.DumpPrivateKeyResponse")
+	proto.RegisterType((*AddressLabelRequest)(nil), "walletrpc.AddressLabelRequest")
+	proto.RegisterType((*AddressLabelResponse)(nil), "walletrpc.AddressLabelResponse")
+	proto.RegisterType((*SetAddressLabelRequest)(nil), "walletrpc.SetAddressLabelRequest")
+	proto.RegisterType((*SetAddressLabelResponse)(nil), "walletrpc.SetAddressLabelResponse")
+	proto.RegisterType((*SetOutputLabelRequest)(nil), "walletrpc.SetOutputLabelRequest")
+	proto.RegisterType((*SetOutputLabelResponse)(nil), "walletrpc.SetOutputLabelResponse")
+	proto.RegisterType((*SetTransactionCommentRequest)(nil), "walletrpc.SetTransactionCommentRequest")
+	proto.RegisterType((*SetTransactionCommentResponse)(nil), "walletrpc.SetTransactionCommentResponse")
+	proto.RegisterType((*RescanRequest)(nil), "walletrpc.RescanRequest")
+	proto.RegisterType((*RescanResponse)(nil), "walletrpc.RescanResponse")
+	proto.RegisterType((*NotificationsRequest)(nil), "walletrpc.NotificationsRequest")
+	proto.RegisterType((*NotificationsResponse)(nil), "walletrpc.NotificationsResponse")
+	proto.RegisterType((*ListWalletsRequest)(nil), "walletrpc.ListWalletsRequest")
+	proto.RegisterType((*ListWalletsResponse)(nil), "walletrpc.ListWalletsResponse")
+}
+
+func init() { proto.RegisterFile("api.proto", fileDescriptor_00212fb1f9d3bf1c) }
+
+var fileDescriptor_00212fb1f9d3bf1c = []byte{
+	// 3932 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x3b, 0xdb, 0x72, 0x1b, 0x47,
+	0x76, 0x1e, 0x80, 0x24, 0xc0, 0x83, 0x2b, 0x1b, 0xbc, 0x40, 0x23, 0xf1, 0xa2, 0x91, 0x6d, 0xc9,
+	0x96, 0xcd, 0x68, 0xb9, 0xde, 0xc4, 0x1b, 0xef, 0x7a, 0x97, 0x82, 0x28, 0x9b, 0x91, 0x0c, 0x32,
+	0x03, 0xca, 0x56, 0xc5, 0xa9, 0xa0, 0x06, 0x40, 0x93, 0x1c, 0x0b, 0xe8, 0x81, 0x67, 0x06, 0x12,
+	0x99, 0x97, 0x24, 0xfb, 0x92, 0xaa, 0xad, 0xa4, 0x52, 0x95, 0xa4, 0x2a, 0xb7, 0xda, 0x97, 0xbc,
+	0xe5, 0x31, 0x95, 0x97, 0xbc, 0xee, 0x17, 0xe4, 0x03, 0xf2, 0x9e, 0x0f, 0xc8, 0x07, 0xa4, 0x52,
+	0x7d, 0x9b, 0xe9, 0x9e, 0x0b, 0x48, 0xee, 0xea, 0x0d, 0x7d, 0xfa, 0xf4, 0xe9, 0x33, 0xdd, 0xe7,
+	0x7e, 0x1a, 0xb0, 0xec, 0x4c, 0xdd, 0xdd, 0xa9, 0xef, 0x85, 0x1e, 0x5a, 0x7e, 0xe3, 0x8c, 0xc7,
+	0x38, 0xf4, 0xa7, 0x43, 0xab, 0x09, 0xf5, 0xaf, 0xb1, 0x1f, 0xb8, 0x1e, 0xb1, 0xf1, 0xf7, 0x33,
+	0x1c, 0x84, 0xd6, 0xaf, 0x0d, 0x68, 0x44, 0xa0, 0x60, 0xea, 0x91, 0x00, 0xa3, 0xf7, 0xa0, 0xfe,
+	0x9a, 0x83, 0xfa, 0x41, 0xe8, 0xbb, 0xe4, 0xac, 0x6d, 0xec, 0x18, 0x0f, 0x96, 0xed, 0x9a, 0x80,
+	0xf6, 0x18, 0x10, 0xad, 0xc2, 0xe2, 0xc4, 0xf9, 0xce, 0xf3, 0xdb, 0x85, 0x1d, 0xe3, 0x41, 0xcd,
+	0xe6, 0x03, 0x06, 0x75, 0x89, 0xe7, 0xb7, 0x8b, 0x02, 0xea, 0x12, 0x0e, 0x9d, 0x3a, 0xe1, 0xf0,
+	0xbc, 0xbd, 0xc0, 0xa1, 0x6c, 0x80, 0xb6, 0x00, 0xa6, 0x3e, 0xf6, 0xf1, 0x18, 0x3b, 0x01, 0x6e,
+	0x2f, 0xb2, 0x4d, 0x14, 0x08, 0x65, 0x64, 0x30, 0x73, 0xc7, 0xa3, 0xfe, 0x04, 0x87, 0xce, 0xc8,
+	0x09, 0x9d, 0xf6, 0x12, 0x67, 0x84, 0x41, 0xbf, 0x12, 0x40, 0xeb, 0x6f, 0x16, 0x00, 0x9d, 0xf8,
+	0x0e, 0x09, 0x9c, 0x61, 0xe8, 0x7a, 0xe4, 0x09, 0x0e, 0x1d, 0x77, 0x1c, 0x20, 0x04, 0x0b, 0xe7,
+	0x4e, 0x70, 0xce, 0x98, 0xaf, 0xda, 0xec, 0x37, 0xda, 0x81, 0x4a, 0x18, 0x63, 0x32, 0xce, 0xab,
+	0xb6, 0x0a, 0x42, 0x9f, 0xc1, 0xd2, 0x08, 0x0f, 0xdc, 0x30, 0x68, 0x17, 0x77, 0x8a, 0x0f, 0x2a,
+	0x7b, 0xf7, 0x76, 0xa3, 0xe3, 0xdb, 0x4d, 0x6f, 0xb2, 0x7b, 0x48, 0xa6, 0xb3, 0xd0, 0x16, 0x4b,
+	0xd0, 0xe7, 0x50, 0x1a, 0xfa, 0x78, 0x44, 0x57, 0x2f, 0xb0, 0xd5, 0xef, 0xce, 0x5f, 0x7d, 0x34,
+	0x0b, 0xe9, 0x72, 0xb9, 0x08, 0x35, 0xa1, 0x78, 0x8a, 0xf9, 0x49, 0x14, 0x6d, 0xfa, 0x13, 0xdd,
+	0x81, 0xe5, 0xd0, 0x9d, 0xe0, 0x20, 0x74, 0x26, 0x53, 0xf6, 0xf5, 0x45, 0x3b, 0x06, 0xa0, 0x36,
+	0x94, 0x86, 0xde, 0x64, 0x82, 0x49, 0xd8, 0x2e, 0xb1, 0x93, 0x91, 0x43, 0xb4, 0x09, 0x20, 0x7e,
+	0xf6, 0x43, 0xaf, 0x5d, 0x66, 0x93, 0xcb, 0x02, 0x72, 0xe2, 0x99, 0xdf, 0xc3, 0x22, 0xe3, 0x9c,
+	0x5e, 0x8c, 0x4b, 0x46, 0xf8, 0x82, 0x9d, 0x52, 0xcd, 0xe6, 0x03, 0xf4, 0x01, 0x34, 0xa7, 0x3e,
+	0x7e, 0xed, 0x7a, 0xb3, 0xa0, 0xef, 0x0c, 0x87, 0xde, 0x8c, 0x84, 0xe2, 0x96, 0x1b, 0x12, 0xbe,
+	0xcf, 0xc1, 0xe8, 0x3e, 0x34, 0x62, 0xd4, 0x09, 0xc3, 0x2c, 0x32, 0x36, 0xeb, 0x11, 0x26, 0x83,
+	0x9a, 0xdf, 0xc1, 0x12, 0xff, 0xdc, 0x9c, 0x3d, 0xdb, 0x50, 0xd2, 0xb7, 0x92, 0x43, 0x64, 0x42,
+	0xd9, 0x25, 0x21, 0xf6, 0x89, 0x33, 0x66, 0xb4, 0xcb, 0x76, 0x34, 0xa6, 0xb4, 0xc6, 0xce, 0x00,
+	0x8f, 0x99, 0x60, 0x2d, 0xdb, 0x7c, 0x60, 0xfd, 0x8b, 0x01, 0xd5, 0xc7, 0x63, 0x6f, 0xf8, 0x6a,
+	0x9e, 0x2c, 0xac, 0xc3, 0xd2, 0x39, 0x76, 0xcf, 0xce, 0xf9, 0x7e, 0x8b, 0xb6, 0x18, 0xe9, 0x47,
+	0x5e, 0x4c, 0x1e, 0xf9, 0x3e, 0x54, 0x15, 0x71, 0x91, 0xf7, 0xbc, 0x39, 0xf7, 0x9e, 0x6d, 0x6d,
+	0x89, 0x75, 0x04, 0x75, 0x71, 0x7a, 0x8f, 0x9d, 0xb1, 0x43, 0x86, 0x58, 0xfd, 0x76, 0x43, 0xff,
+	0xf6, 0x7b, 0x50, 0x0b, 0xbd, 0xd0, 0x19, 0xf7, 0x07, 0x1c, 0x95, 0xf1, 0x5a, 0xb4, 0xab, 0x0c,
+	0x28, 0x96, 0x5b, 0x35, 0xa8, 0x1c, 0xbb, 0xe4, 0x4c, 0xea, 0x74, 0x1d, 0xaa, 0x7c, 0xc8, 0xf5,
+	0x99, 0x6a, 0x7d, 0x17, 0x87, 0x6f, 0x3c, 0xff, 0x95, 0xc4, 0xf8, 0x14, 0x1a, 0x11, 0x24, 0x56,
+	0x7a, 0xca, 0xdf, 0x6b, 0xdc, 0x27, 0x7c, 0x46, 0x70, 0x52, 0xe3, 0x50, 0x81, 0x6e, 0xfd, 0x18,
+	0x56, 0x05, 0xef, 0xdd, 0xd9, 0x64, 0x80, 0x7d, 0x41, 0x11, 0xdd, 0x85, 0xaa, 0x60, 0xb9, 0x4f,
+	0x9c, 0x09, 0x16, 0x16, 0xa3, 0x22, 0x60, 0x5d, 0x67, 0x82, 0xad, 0xcf, 0x61, 0x2d, 0xb1, 0x54,
+	0xdd, 0x5a, 0xac, 0x65, 0x33, 0xf1, 0xd6, 0x0a, 0xba, 0xb5, 0x02, 0x0d, 0xb1, 0x3e, 0x90, 0xdf,
+	0xf1, 0x9f, 0x45, 0x68, 0xc6, 0x30, 0x41, 0xee, 0x67, 0x50, 0x16, 0x0b, 0x83, 0xb6, 0x91, 0xd2,
+	0xe1, 0x24, 0xba, 0x04, 0xd8, 0xd1, 0x22, 0xf4, 0x11, 0xa0, 0xe1, 0xcc, 0xf7, 0xa9, 0xee, 0x0c,
+	0xa8, 0x10, 0xf5, 0x99, 0xe8, 0x70, 0x5b, 0xd1, 0x14, 0x33, 0x4c, 0xba, 0xbe, 0xa4, 0x62, 0xf4,
+	0x08, 0x56, 0x13, 0xd8, 0x5c, 0xa8, 0x8a, 0x4c, 0xa8, 0x90, 0x86, 0xcf, 0x66, 0xcc, 0x5f, 0x14,
+	0xa0, 0x24, 0xd5, 0xe7, 0x7a, 0xdf, 0x9e, 0x3a, 0xde, 0x42, 0xea, 0x78, 0xd3, 0x92, 0x52, 0x4c,
+	0x4b, 0x0a, 0xfd, 0x34, 0x7c, 0xc1, 0x55, 0xa7, 0xff, 0x0a, 0x5f, 0xf6, 0xb9, 0xcc, 0x71, 0xa3,
+	0xdc, 0x94, 0x33, 0xcf, 0xf0, 0x65, 0x87, 0x31, 0xf7, 0x11, 0x20, 0x97, 0xa4, 0xb0, 0x17, 0x39,
+	0xb6, 0x4b, 0x32, 0xb0, 0x27, 0x53, 0xcf, 0x0f, 0xf1, 0x48, 0xc1, 0x5e, 0x12, 0xd8, 0x62, 0x46,
+	0x62, 0x5b, 0x2f, 0x61, 0xd5, 0xc6, 0xf4, 0x5b, 0xe4, 0xf9, 0x0b, 0x41, 0xba, 0xe6, 0x81, 0xdc,
+	0x82, 0x32, 0xc1, 0x6f, 0xd4, 0xc3, 0x28, 0x11, 0xfc, 0x86, 0xc9, 0xd9, 0x06, 0xac, 0x25, 0x28,
+	0x0b, 0x3d, 0xf8, 0x06, 0x50, 0x17, 0x5f, 0x84, 0x89, 0x0d, 0xa9, 0x13, 0x72, 0x82, 0x60, 0x7a,
+	0xee, 0x53, 0x27, 0xc4, 0x0d, 0x84, 0x02, 0xb9, 0xc6, 0xd1, 0x5b, 0x3f, 0x81, 0x96, 0x46, 0xf8,
+	0x66, 0x72, 0xfd, 0xcf, 0x86, 0xe0, 0x6b, 0x34, 0xf2, 0x71, 0x20, 0x65, 0x7b, 0x8e, 0x4d, 0xf8,
+	0x5d, 0x58, 0x78, 0xe5, 0x92, 0x11, 0xe3, 0xa4, 0xbe, 0x67, 0x29, 0xc2, 0x9d, 0x26, 0xb3, 0xfb,
+	0xcc, 0x25, 0x23, 0x9b, 0xe1, 0x5b, 0x7b, 0xb0, 0x40, 0x47, 0x68, 0x15, 0x9a, 0x8f, 0x0f, 0x8f,
+	0x1f, 0x3d, 0xfa, 0xe4, 0x93, 0xfe, 0xc1, 0xcb, 0x93, 0x03, 0xbb, 0xbb, 0xff, 0xbc, 0xf9, 0x8e,
+	0x0a, 0x3d, 0xec, 0x0a, 0xa8, 0x61, 0xfd, 0x0e, 0xb4, 0x34, 0xa2, 0xe2, 0xd3, 0x28, 0x73, 0x1c,
+	0x24, 0x34, 0x5d, 0x0e, 0xad, 0xbf, 0x33, 0x60, 0xe3, 0x90, 0x5d, 0xf6, 0xb1, 0xef, 0xbe, 0x76,
+	0x42, 0xfc, 0x0c, 0x5f, 0x5e, 0xf7, 0xa8, 0xf3, 0x5d, 0xc0, 0xfb, 0xd4, 0xcb, 0x30, 0x72, 0x4c,
+	0xb4, 0xde, 0xb8, 0xa7, 0x4c, 0xbc, 0x97, 0xed, 0xda, 0x34, 0xda, 0xe5, 0x1b, 0xf7, 0x94, 0xda,
+	0x74, 0x1f, 0x07, 0x43, 0x87, 0x30, 0x99, 0x2e, 0xdb, 0x62, 0x64, 0x99, 0xd0, 0x4e, 0x33, 0x25,
+	0xc4, 0x82, 0x40, 0x5d, 0xa8, 0xc7, 0x0d, 0x65, 0xf0, 0x47, 0xb0, 0xee, 0xe3, 0xef, 0x67, 0xae,
+	0x8f, 0x47, 0xfd, 0xa1, 0x47, 0x4e, 0x5d, 0x7f, 0xe2, 0x70, 0xa7, 0xc0, 0x1d, 0xca, 0x9a, 0x9c,
+	0xed, 0xa8, 0x93, 0x16, 0x81, 0x46, 0xb4, 0x9f, 0x38, 0xce, 0x55, 0x58, 0x64, 0x6a, 0xca, 0xf6,
+	0x29, 0xda, 0x7c, 0x40, 0x1d, 0x51, 0x30, 0xc5, 0x64, 0xe4, 0x0c, 0xc6, 0xd2, 0xee, 0xc7, 0x00,
+	0xea, 0x78, 0xdd, 0xc9, 0xc4, 0x09, 0x67, 0x3e, 0xee, 0xfb, 0xf8, 0x8d, 0xe3, 0x8f, 0xa4, 0xe3,
+	0x95, 0x60, 0x9b, 0x41, 0xad, 0x7f, 0x2c, 0xc0, 0xfa, 0x17, 0x38, 0x54, 0xdc, 0x52, 0x24, 0x63,
+	0xbb, 0xd0, 0x0a, 0x42, 0xc7, 0x0f, 0x5d, 0x72, 0xa6, 0x9a, 0x3a, 0x7e, 0x33, 0x2b, 0x72, 0x2a,
+	0xb6, 0x75, 0x7b, 0xb0, 0x96, 0xc4, 0x8f, 0x3d, 0xe8, 0x8a, 0xdd, 0xd2, 0x57, 0xb0, 0x29, 0xf4,
+	0x21, 0xac, 0x60, 0x32, 0x4a, 0xec, 0x50, 0x64, 0x3b, 0x34, 0xf8, 0x44, 0x4c, 0x7f, 0x17, 0x5a,
+	0x3a, 0x2e, 0xa7, 0xbe, 0xc0, 0x8e, 0x73, 0x45, 0xc5, 0xe6, 0xb4, 0x3f, 0x87, 0xdb, 0x13, 0x97,
+	0xb8, 0x93, 0xd9, 0xa4, 0xef, 0xe3, 0x21, 0x0b, 0x76, 0x54, 0xdf, 0xbc, 0xc8, 0xd6, 0xdd, 0x12,
+	0x28, 0x36, 0xc3, 0x50, 0x8f, 0xc1, 0xfa, 0x0f, 0x03, 0x36, 0x52, 0x47, 0x23, 0xee, 0xe4, 0x29,
+	0xa0, 0x89, 0x4b, 0xf0, 0x48, 0x27, 0xc9, 0x1d, 0xca, 0x86, 0xa2, 0x73, 0x6a, 0x9c, 0x61, 0xaf,
+	0xb0, 0x25, 0x2a, 0x3d, 0x74, 0x0c, 0xab, 0x33, 0x92, 0x41, 0xa9, 0x70, 0x9d, 0xc0, 0xa1, 0x25,
+	0x96, 0x6a, 0x5c, 0xff, 0xda, 0x80, 0x8d, 0xce, 0xb9, 0x43, 0xce, 0xf0, 0x71, 0xa4, 0x3b, 0xf2,
+	0x46, 0x3f, 0x85, 0xe2, 0x2b, 0x7c, 0xc9, 0x6e, 0xb0, 0xbe, 0xf7, 0xbe, 0x42, 0x3c, 0x67, 0xc1,
+	0x2e, 0xd5, 0x04, 0xba, 0x84, 0x0a, 0xbd, 0x37, 0x1e, 0xf5, 0x15, 0x05, 0xe5, 0x1e, 0xaf, 0xe6,
+	0x8d, 0x47, 0xf1, 0x32, 0x8a, 0x46, 0x0d, 0xaf, 0x82, 0xc6, 0xef, 0xb2, 0x46, 0xf0, 0x9b, 0x18,
+	0xcd, 0xda, 0x82, 0xe2, 0x33, 0x7c, 0x89, 0x2a, 0x50, 0x3a, 0xb6, 0x0f, 0xbf, 0xde, 0x3f, 0x39,
+	0x68, 0xbe, 0x83, 0x00, 0x96, 0x8e, 0x5f, 0x3c, 0x7e, 0x7e, 0xd8, 0x69, 0x1a, 0x54, 0x21, 0xd3,
+	0x1c, 0x09, 0x85, 0xfc, 0xf3, 0x02, 0xac, 0x3f, 0x9d, 0x11, 0xf5, 0xa3, 0xaf, 0x36, 0x8a, 0xd4,
+	0xfd, 0x39, 0xfe, 0x19, 0x0e, 0x65, 0x14, 0x2a, 0x03, 0x25, 0x06, 0xe4, 0x31, 0xe8, 0x1c, 0x8d,
+	0x2d, 0xce, 0xd1, 0x58, 0xf4, 0x13, 0x30, 0x5d, 0x32, 0x1c, 0xcf, 0x46, 0xb8, 0x1f, 0xa9, 0xdc,
+	0xd0, 0x73, 0xc9, 0xc0, 0x09, 0x70, 0x20, 0x2c, 0x4d, 0x5b, 0x60, 0x1c, 0x0a, 0x84, 0x8e, 0x9c,
+	0xa7, 0x4a, 0x23, 0x57, 0x0f, 0xd9, 0x27, 0xf7, 0x83, 0xa1, 0xef, 0x4e, 0xb9, 0x23, 0x2d, 0xdb,
+	0x2d, 0x31, 0xc9, 0x8f, 0xa3, 0xc7, 0xa6, 0xac, 0x7f, 0x2d, 0xc2, 0x46, 0xea, 0x08, 0x84, 0x60,
+	0xfe, 0x31, 0x34, 0x03, 0x3c, 0xc6, 0x43, 0xea, 0x67, 0x3d, 0x16, 0x51, 0x4b, 0xb1, 0xfc, 0x81,
+	0x72, 0xdf, 0x39, 0xab, 0x77, 0x8f, 0x45, 0x54, 0x2e, 0x52, 0x8f, 0x86, 0x24, 0xc5, 0xc7, 0x01,
+	0x75, 0x77, 0x3c, 0x8c, 0xd0, 0x8e, 0xb1, 0xc2, 0x60, 0xe2, 0x14, 0x1f, 0x40, 0x53, 0x7c, 0xc8,
+	0xf4, 0x95, 0xfc, 0x16, 0x2e, 0x04, 0x75, 0x0e, 0x3f, 0x7e, 0xc5, 0x3f, 0xc3, 0xfc, 0x6f, 0x03,
+	0xea, 0xfa, 0x86, 0x34, 0xb5, 0x50, 0xd4, 0x40, 0xb5, 0x37, 0x0d, 0x05, 0xce, 0xac, 0xc1, 0x5d,
+	0xa8, 0xf2, 0xef, 0xeb, 0xf3, 0x74, 0x81, 0xfb, 0x84, 0x0a, 0x87, 0x1d, 0x52, 0x10, 0xb5, 0xf7,
+	0x5a, 0xd2, 0x21, 0x46, 0xe8, 0x36, 0x2c, 0xc7, 0xbc, 0x2d, 0x30, 0xf2, 0xe5, 0xa9, 0xe0, 0x8a,
+	0xd2, 0xa5, 0xd6, 0x82, 0xc6, 0xba, 0x34, 0xae, 0x17, 0xe9, 0x56, 0x45, 0xc0, 0x4e, 0x5c, 0x1e,
+	0x4c, 0x9d, 0xfa, 0xde, 0x24, 0xba, 0x65, 0x16, 0xc6, 0x94, 0xed, 0x2a, 0x05, 0xca, 0x9b, 0xb5,
+	0xfe, 0xde, 0x80, 0xf5, 0x9e, 0x7b, 0x46, 0x32, 0xe4, 0xf4, 0x2a, 0x4f, 0xf7, 0x23, 0x58, 0x0f,
+	0xb0, 0xef, 0x3a, 0x63, 0xf7, 0x4f, 0x75, 0xbb, 0x20, 0x94, 0x6e, 0x2d, 0x9e, 0x55, 0xa8, 0x53,
+	0xb6, 0x5c, 0x12, 0x1d, 0x08, 0xe6, 0x39, 0x6a, 0xcd, 0xae, 0xba, 0x44, 0x9e, 0x08, 0x0e, 0xac,
+	0xef, 0x61, 0x23, 0xc5, 0x95, 0x10, 0x9d, 0x44, 0xfa, 0x6b, 0xa4, 0xd3, 0xdf, 0x4f, 0x60, 0x7d,
+	0x46, 0x02, 0xf7, 0x8c, 0x9a, 0x2b, 0x7d, 0xab, 0x02, 0xdb, 0x6a, 0x55, 0xce, 0x1e, 0xaa, 0x5b,
+	0xfe, 0x01, 0xdc, 0x3a, 0x9e, 0x0d, 0xc6, 0x6e, 0x70, 0x9e, 0x71, 0x16, 0x1f, 0x03, 0x12, 0x04,
+	0xd3, 0x7b, 0xaf, 0xf0, 0x19, 0x65, 0x95, 0x75, 0x07, 0xcc, 0x2c, 0x5a, 0xc2, 0x36, 0xdc, 0x85,
+	0x6d, 0x05, 0xdc, 0xf5, 0x42, 0xf7, 0xd4, 0x1d, 0x3a, 0xaa, 0x53, 0xb3, 0x7e, 0x55, 0x80, 0x9d,
+	0x7c, 0x1c, 0x71, 0x12, 0x3f, 0x87, 0x86, 0x13, 0x86, 0xce, 0xf0, 0x1c, 0x8f, 0xb8, 0xaf, 0xb9,
+	0xd2, 0xb4, 0xd7, 0x25, 0x3e, 0x83, 0x06, 0xd4, 0xff, 0x8e, 0xb0, 0x4e, 0x81, 0x1e, 0x51, 0xd5,
+	0xae, 0x8f, 0xb0, 0x86, 0x98, 0xe7, 0x00, 0x8a, 0xbf, 0xa9, 0x03, 0xa0, 0xf6, 0x28, 0x83, 0x22,
+	0xd3, 0x25, 0xcc, 0x33, 0xd2, 0xaa, 0xdd, 0x4e, 0x2f, 0xfc, 0x92, 0xcd, 0x5b, 0x7f, 0x6d, 0xc0,
+	0x66, 0x6f, 0x8a, 0x49, 0x48, 0x70, 0x10, 0x64, 0x9d, 0xe0, 0x1c, 0x2b, 0xfb, 0x21, 0xac, 0x10,
+	0xaf, 0x4f, 0xe8, 0xa2, 0xcb, 0xfe, 0x8c, 0x04, 0x94, 0x0c, 0x13, 0xd9, 0xb2, 0xdd, 0x20, 0x1e,
+	0x23, 0x76, 0xf9, 0x82, 0x83, 0x69, 0xcc, 0x16, 0xe3, 0x72, 0x4c, 0x9e, 0xbd, 0xd7, 0x24, 0x26,
+	0xe3, 0xc2, 0xfa, 0xdb, 0x02, 0x6c, 0xe5, 0xf1, 0x23, 0x6e, 0xeb, 0xed, 0x1a, 0x8d, 0x67, 0x50,
+	0x62, 0x61, 0x14, 0xe6, 0x45, 0x2a, 0xdd, 0x6e, 0xce, 0xe7, 0x84, 0x4d, 0x8f, 0xb0, 0x6f, 0x4b,
+	0x0a, 0xe6, 0x0b, 0x28, 0x09, 0xd8, 0x4d, 0xb8, 0xdc, 0x86, 0x8a, 0x4b, 0x92, 0x4c, 0x42, 0xac,
+	0xc6, 0xd6, 0x26, 0xdc, 0x96, 0xc9, 0x72, 0x96, 0x8c, 0xff, 0xaf, 0x01, 0x77, 0xb2, 0xe7, 0x6f,
+	0x94, 0x7b, 0x5c, 0x27, 0xaf, 0xcc, 0x4e, 0x19, 0x8b, 0x37, 0x4a, 0x19, 0x17, 0x6e, 0x94, 0x32,
+	0x2e, 0xe6, 0xa4, 0x8c, 0xff, 0x64, 0x40, 0xab, 0xe3, 0x63, 0x27, 0xc4, 0xdf, 0xb0, 0xeb, 0x92,
+	0xe2, 0xfa, 0x10, 0x56, 0xa6, 0xd4, 0x62, 0x0c, 0xfb, 0x29, 0x9b, 0xdb, 0xe4, 0x13, 0x4a, 0xfc,
+	0xf2, 0x31, 0x20, 0x99, 0x49, 0xa4, 0x42, 0x9d, 0x15, 0x31, 0xa3, 0xa0, 0x23, 0x58, 0x08, 0x30,
+	0x1e, 0x09, 0xff, 0xc6, 0x7e, 0x53, 0x18, 0x3b, 0x2c, 0x5e, 0x72, 0x62, 0xbf, 0xad, 0x75, 0x58,
+	0xd5, 0x59, 0x13, 0xf6, 0xea, 0x04, 0x56, 0x8e, 0xa6, 0x98, 0xfc, 0x16, 0x0c, 0xcb, 0xdd, 0x0a,
+	0xca, 0x6e, 0xab, 0x80, 0x54, 0xaa, 0x62, 0xaf, 0x07, 0x80, 0x3a, 0x63, 0x2f, 0x48, 0x9c, 0x8e,
+	0x5c, 0x6f, 0x28, 0xeb, 0xd7, 0xa0, 0xa5, 0x61, 0x0a, 0x02, 0x1f, 0x40, 0x8b, 0x43, 0x0e, 0x2e,
+	0xdc, 0x20, 0x0c, 0xe6, 0x51, 0xd8, 0x85, 0x55, 0x1d, 0x55, 0xc8, 0xdd, 0x3a, 0x2c, 0x61, 0x06,
+	0x61, 0xd8, 0x65, 0x5b, 0x8c, 0xac, 0x5f, 0x19, 0xd0, 0xee, 0x85, 0x8e, 0x1f, 0x76, 0x28, 0x1a,
+	0x09, 0x66, 0x81, 0x7d, 0xdc, 0x91, 0x1b, 0xdc, 0x87, 0x86, 0x28, 0x3a, 0xf5, 0xf5, 0xac, 0xb2,
+	0x2e, 0xc0, 0x22, 0xfd, 0xa4, 0x95, 0xc0, 0x59, 0x80, 0x7d, 0xe5, 0x3c, 0xa2, 0x31, 0x9d, 0xa3,
+	0xa7, 0xf9, 0xc6, 0xf3, 0xe5, 0x6d, 0x45, 0x63, 0xea, 0xf7, 0x86, 0xd8, 0x17, 0x7a, 0x82, 0x45,
+	0x40, 0xa0, 0x82, 0xac, 0xdb, 0x70, 0x2b, 0x83, 0x3d, 0x71, 0x2e, 0x7f, 0xb9, 0x00, 0x0d, 0x1a,
+	0x4f, 0x1d, 0x07, 0x83, 0xf0, 0x6a, 0x1b, 0xf9, 0x29, 0x2c, 0x31, 0x45, 0x96, 0x21, 0xfe, 0x4e,
+	0x22, 0x2a, 0x53, 0xa8, 0xc8, 0xf2, 0x31, 0xc7, 0x47, 0x9f, 0x41, 0x49, 0x06, 0x74, 0xdc, 0x39,
+	0xdc, 0x9d, 0xb3, 0x54, 0xd6, 0x8e, 0xc5, 0x0a, 0x1a, 0xf2, 0xb0, 0x9c, 0x89, 0x85, 0x34, 0x5c,
+	0xe1, 0xca, 0x14, 0xc0, 0xe2, 0x99, 0xfc, 0xc0, 0x77, 0x71, 0x5e, 0xe0, 0x7b, 0x0b, 0xca, 0xa7,
+	0x18, 0xf7, 0x7d, 0x27, 0xe4, 0x11, 0x50, 0xd1, 0x2e, 0x9d, 0x62, 0x6c, 0x3b, 0x21, 0x8b, 0x90,
+	0xd8, 0x76, 0xc2, 0x09, 0x04, 0xac, 0x00, 0x5d, 0xb6, 0xab, 0x14, 0x28, 0x3c, 0x40, 0x80, 0x7e,
+	0x0c, 0x55, 0x1a, 0x41, 0xd1, 0x2d, 0x43, 0xdf, 0x1b, 0xb3, 0x3a, 0x74, 0x65, 0x6f, 0x5d, 0x4d,
+	0x4b, 0x3c, 0x97, 0x74, 0xf8, 0xac, 0x5d, 0x19, 0xc6, 0x03, 0xf3, 0x85, 0xac, 0x50, 0xbf, 0x55,
+	0xdb, 0x6f, 0xfe, 0x34, 0xaa, 0x42, 0x6b, 0x21, 0xa2, 0x91, 0x08, 0x11, 0xe3, 0xb8, 0xb2, 0xa0,
+	0xc6, 0x95, 0xd6, 0xb7, 0xd0, 0x8c, 0xef, 0x41, 0x88, 0x3c, 0x82, 0x85, 0x69, 0x30, 0x90, 0x34,
+	0xd8, 0x6f, 0xca, 0x89, 0x08, 0x91, 0x63, 0x4e, 0x16, 0xed, 0x0a, 0x87, 0x71, 0x2f, 0x24, 0x6a,
+	0xfd, 0xc5, 0xa8, 0xd6, 0x6f, 0xfd, 0x19, 0xa0, 0x63, 0xdf, 0x1b, 0xe2, 0x20, 0x50, 0x05, 0xed,
+	0xaa, 0x50, 0x52, 0x6e, 0x5f, 0x50, 0xb6, 0xa7, 0x56, 0xcb, 0x3d, 0x23, 0xc2, 0xdf, 0xb2, 0xdf,
+	0x94, 0xa5, 0xc0, 0x3d, 0xa3, 0xc7, 0xd7, 0x0f, 0x2f, 0xa7, 0x52, 0x44, 0x2a, 0x02, 0x76, 0x72,
+	0x39, 0xc5, 0xd6, 0x01, 0xb4, 0x34, 0x06, 0xe6, 0x7c, 0xa0, 0x09, 0xe5, 0xa1, 0x37, 0x99, 0x8e,
+	0x71, 0x88, 0x85, 0xff, 0x8f, 0xc6, 0x56, 0x07, 0x5a, 0x4f, 0x5d, 0xc2, 0xa2, 0x57, 0xf5, 0x43,
+	0xb2, 0xc8, 0xb4, 0xa1, 0x84, 0x2f, 0x42, 0xdf, 0x19, 0xca, 0x28, 0x42, 0x0e, 0xad, 0x73, 0x58,
+	0xd5, 0x89, 0xcc, 0x61, 0xe6, 0xea, 0xae, 0x8e, 0xca, 0x6e, 0x31, 0xc1, 0xee, 0x87, 0x80, 0x3a,
+	0xde, 0x64, 0xe0, 0x12, 0x8d, 0x5b, 0xda, 0xb1, 0x0a, 0x06, 0x22, 0xb5, 0xaa, 0xda, 0x7c, 0x40,
+	0x2d, 0xa4, 0x86, 0x9b, 0xcf, 0x94, 0xf5, 0x12, 0xca, 0x47, 0xb3, 0xf0, 0xd8, 0x73, 0xc9, 0x5b,
+	0x96, 0x61, 0xeb, 0x2f, 0x8a, 0x50, 0x51, 0xf4, 0x06, 0x3d, 0x8c, 0x0c, 0x0e, 0x0f, 0x61, 0x5b,
+	0x8a, 0x7e, 0x49, 0x16, 0x22, 0x1b, 0x23, 0x23, 0x8f, 0xa0, 0xef, 0x91, 0xf1, 0xa5, 0x38, 0x75,
+	0x1e, 0x79, 0x04, 0x47, 0x64, 0x7c, 0x89, 0x3e, 0xa6, 0x57, 0xc2, 0x32, 0xd2, 0x76, 0x31, 0x9f,
+	0x9c, 0xc4, 0xa1, 0xb6, 0x5b, 0xfc, 0xec, 0xb3, 0xde, 0x0b, 0x1e, 0x89, 0x84, 0xb8, 0x2e, 0xc0,
+	0xcf, 0x39, 0x14, 0x7d, 0x06, 0xe5, 0x20, 0xa4, 0x96, 0xe4, 0xec, 0x92, 0x19, 0x9d, 0xfa, 0xde,
+	0x76, 0xb6, 0x1d, 0xd8, 0xed, 0x09, 0x34, 0x3b, 0x5a, 0x40, 0xc3, 0x19, 0xa1, 0x4f, 0xd2, 0x41,
+	0x88, 0x4e, 0x20, 0x87, 0x4a, 0xff, 0xb0, 0x09, 0x30, 0x71, 0x2e, 0xfa, 0xe2, 0x34, 0x4a, 0xec,
+	0xe8, 0x96, 0x27, 0xce, 0x05, 0xb3, 0x24, 0x81, 0x75, 0x08, 0x65, 0x49, 0x1b, 0xad, 0x40, 0xed,
+	0xf9, 0xbe, 0xfd, 0xc5, 0x41, 0xef, 0xa4, 0xff, 0xf4, 0xd0, 0xee, 0x9d, 0x88, 0x0a, 0xa8, 0xbd,
+	0xdf, 0xed, 0x7c, 0xd9, 0xdf, 0xef, 0x3e, 0xe9, 0x3f, 0x3e, 0x7a, 0xd1, 0x7d, 0xd2, 0x34, 0x28,
+	0xb4, 0x7b, 0xd4, 0xdf, 0x7f, 0xf2, 0xc4, 0x3e, 0xe8, 0xf5, 0xfa, 0xf6, 0xc1, 0x8b, 0xde, 0x41,
+	0xb3, 0x60, 0xfd, 0x95, 0x01, 0xe8, 0xb9, 0x1b, 0x84, 0xc2, 0xd4, 0x29, 0x9e, 0x7d, 0xc2, 0xed,
+	0x9d, 0x62, 0x62, 0x0d, 0xa6, 0xfc, 0xcd, 0x09, 0xfb, 0xc2, 0x18, 0xce, 0x90, 0x9d, 0x8b, 0xcc,
+	0xd2, 0x61, 0x73, 0xe2, 0x5c, 0xe8, 0xc8, 0x77, 0x60, 0x59, 0x7c, 0xba, 0x48, 0xfb, 0x96, 0xed,
+	0x18, 0x60, 0xfd, 0xb2, 0x08, 0x2d, 0x8d, 0x9d, 0xa8, 0x17, 0x52, 0xd2, 0x4b, 0x04, 0xef, 0x29,
+	0x67, 0x9e, 0xb1, 0x20, 0xe9, 0x55, 0xcc, 0x7f, 0x2f, 0x44, 0x06, 0xf3, 0xed, 0x06, 0xe1, 0x4a,
+	0x05, 0xb9, 0xa8, 0x55, 0x90, 0x53, 0x31, 0xe9, 0x42, 0x3a, 0x26, 0xd5, 0x6c, 0xf7, 0x62, 0xc2,
+	0x76, 0xdf, 0x83, 0x9a, 0x8f, 0x47, 0x18, 0x4f, 0x24, 0xc2, 0x12, 0x43, 0xa8, 0x72, 0x60, 0xca,
+	0xc0, 0x97, 0xb4, 0xc2, 0xc1, 0xbb, 0x50, 0xd3, 0xef, 0xa3, 0xcc, 0xa6, 0x6b, 0xc3, 0xe4, 0x65,
+	0xc4, 0x95, 0xd9, 0x65, 0x26, 0xee, 0x31, 0x80, 0xc6, 0x82, 0xcf, 0xbd, 0xe1, 0xab, 0xa8, 0xa6,
+	0x22, 0x83, 0xf6, 0xa7, 0xb0, 0x96, 0x80, 0x8b, 0x5b, 0xfa, 0x38, 0x79, 0x4b, 0xd9, 0x2a, 0x27,
+	0x70, 0xac, 0x6f, 0x01, 0x51, 0x3a, 0x3a, 0x75, 0xfa, 0x45, 0x33, 0x42, 0xbd, 0xaf, 0x8c, 0xbc,
+	0xf8, 0x48, 0x25, 0x5e, 0xb8, 0x06, 0xf1, 0x35, 0x68, 0x69, 0xc4, 0x45, 0x08, 0xf4, 0xcb, 0x22,
+	0x34, 0x7a, 0x98, 0x8c, 0xbe, 0x72, 0xc8, 0x5b, 0x28, 0xe7, 0xcf, 0x0d, 0x74, 0x12, 0xdb, 0xa4,
+	0x02, 0x9d, 0xfc, 0x58, 0x66, 0xe1, 0xba, 0xb1, 0xcc, 0xa2, 0x1e, 0xcb, 0x28, 0x6d, 0xf4, 0xa5,
+	0x79, 0x6d, 0xf4, 0x52, 0xa2, 0x8d, 0xfe, 0xdb, 0xc4, 0x37, 0xbf, 0x1f, 0xe9, 0x55, 0x6e, 0x2f,
+	0x25, 0x37, 0x0a, 0xf9, 0x29, 0x34, 0xe3, 0x43, 0xba, 0x71, 0x8a, 0x6c, 0x9d, 0x03, 0xa2, 0x05,
+	0xa2, 0xaf, 0x70, 0x10, 0x38, 0x67, 0xf8, 0x26, 0xb7, 0x29, 0xd8, 0x2c, 0xe8, 0x6c, 0xb6, 0xa1,
+	0x34, 0xe1, 0xb4, 0xa4, 0x2a, 0x8b, 0xa1, 0xf5, 0x43, 0x68, 0x69, 0x3b, 0x09, 0x5e, 0xa9, 0xfa,
+	0xb8, 0x67, 0x84, 0xd5, 0x49, 0xc5, 0x4e, 0x31, 0xc0, 0xfa, 0x43, 0x58, 0x7b, 0x32, 0x9b, 0x4c,
+	0x7f, 0xb3, 0xf6, 0x51, 0x26, 0x87, 0xd6, 0xcf, 0x61, 0x3d, 0x49, 0x52, 0xb0, 0x92, 0xd1, 0x58,
+	0x32, 0x32, 0x1a, 0x4b, 0xb4, 0x0f, 0x26, 0x9c, 0x0c, 0xf3, 0x67, 0x6a, 0x16, 0x90, 0xdd, 0x07,
+	0xfb, 0x08, 0x56, 0xf5, 0x05, 0x71, 0xab, 0x87, 0x3f, 0x58, 0x30, 0xd4, 0x07, 0x0b, 0x5f, 0xc2,
+	0x7a, 0x0f, 0x87, 0x37, 0xda, 0x21, 0xa6, 0x54, 0x50, 0x29, 0xdd, 0x82, 0x8d, 0x14, 0x25, 0xa1,
+	0xc3, 0x7f, 0x04, 0x6b, 0x3d, 0x1c, 0x72, 0xa9, 0xd3, 0xf6, 0x78, 0x08, 0x4b, 0x5c, 0xb9, 0xd8,
+	0x16, 0x79, 0x01, 0x84, 0x17, 0xbd, 0xde, 0xc8, 0xd8, 0xb6, 0x0d, 0xeb, 0x49, 0xda, 0x62, 0xd7,
+	0x5f, 0x18, 0x70, 0xa7, 0xa7, 0xf5, 0x58, 0x3a, 0x5c, 0x7f, 0xe4, 0xee, 0x37, 0xf0, 0x2b, 0x8a,
+	0xa2, 0x16, 0xe6, 0x29, 0x6a, 0x31, 0xa1, 0xa8, 0xd6, 0x36, 0x6c, 0xe6, 0xf0, 0x20, 0xb8, 0xdc,
+	0x83, 0x9a, 0xcd, 0x5a, 0x85, 0xca, 0x83, 0x86, 0x01, 0x3e, 0x73, 0x89, 0xec, 0x41, 0x71, 0x27,
+	0x5e, 0x61, 0x30, 0xde, 0x7d, 0xb2, 0xfe, 0xc1, 0x80, 0xba, 0x5c, 0x24, 0x6e, 0x77, 0x13, 0x20,
+	0xd5, 0x47, 0x5b, 0x1e, 0x44, 0xfd, 0x2d, 0x4a, 0x34, 0xd9, 0x36, 0xa3, 0x44, 0x95, 0x96, 0xd6,
+	0x7d, 0x68, 0x0c, 0xa2, 0x3c, 0x4e, 0x7d, 0x83, 0x52, 0x1f, 0xc8, 0x6c, 0x8e, 0x41, 0x69, 0x48,
+	0x7b, 0xea, 0x12, 0x37, 0x38, 0x8f, 0x22, 0xae, 0x68, 0x6c, 0xfd, 0x97, 0x01, 0xab, 0x99, 0x95,
+	0xbd, 0x4f, 0x61, 0x81, 0x05, 0xff, 0xbc, 0x3f, 0xa4, 0xbe, 0x4e, 0xca, 0x42, 0xdf, 0xa5, 0x59,
+	0x81, 0xcd, 0x56, 0xd0, 0x18, 0x7b, 0x46, 0x82, 0xd9, 0x80, 0xfa, 0xd4, 0x81, 0x8c, 0xf9, 0x55,
+	0x90, 0x6a, 0xee, 0x8b, 0x9a, 0xb9, 0xb7, 0xf6, 0x61, 0x81, 0x52, 0x42, 0x4d, 0xa8, 0x9e, 0xd8,
+	0xfb, 0xdd, 0xde, 0x7e, 0xe7, 0xe4, 0xf0, 0xa8, 0xdb, 0x6b, 0xbe, 0x83, 0x6a, 0xb0, 0xdc, 0x3b,
+	0x3e, 0xe8, 0x9e, 0x74, 0x0f, 0x7a, 0xbd, 0xa6, 0x81, 0xaa, 0x50, 0xde, 0xef, 0x74, 0x8e, 0x5e,
+	0x74, 0x4f, 0x7a, 0xcd, 0x02, 0xed, 0x17, 0xd9, 0x07, 0xbd, 0xce, 0x7e, 0xb7, 0x59, 0xb4, 0xfe,
+	0xad, 0x00, 0x6b, 0xd9, 0x95, 0xae, 0xa3, 0xc4, 0x83, 0x1c, 0x2e, 0xc2, 0x0f, 0xb3, 0xcb, 0xaa,
+	0x99, 0x24, 0xf4, 0xe7, 0x39, 0xe8, 0x0b, 0xee, 0xdc, 0x59, 0x11, 0x90, 0x7d, 0x67, 0x65, 0xef,
+	0x83, 0x6b, 0x17, 0x08, 0xed, 0x78, 0x2d, 0xea, 0x28, 0x0f, 0x51, 0x78, 0xa1, 0xf1, 0x7e, 0xfa,
+	0x21, 0x4a, 0x36, 0x95, 0x68, 0x21, 0xfa, 0x81, 0xd6, 0xd1, 0xae, 0xec, 0xdd, 0x52, 0x48, 0xe8,
+	0xc2, 0x17, 0x35, 0xbb, 0x57, 0x79, 0x68, 0xca, 0xeb, 0x33, 0x51, 0xf4, 0xf1, 0x10, 0x5a, 0x1a,
+	0x34, 0xb6, 0x47, 0x34, 0xca, 0xe2, 0x91, 0xc7, 0xb2, 0xcd, 0x07, 0x7b, 0x76, 0xf4, 0x50, 0xb0,
+	0x87, 0xfd, 0xd7, 0xee, 0x90, 0x16, 0xcc, 0x4b, 0x02, 0x82, 0x54, 0x16, 0xf4, 0xe7, 0x84, 0xa6,
+	0x99, 0x35, 0xc5, 0x77, 0xda, 0xfb, 0x9f, 0x35, 0xa8, 0xf1, 0xdd, 0x25, 0xcd, 0xdf, 0x83, 0x05,
+	0xfa, 0x50, 0x09, 0xa9, 0x0e, 0x53, 0x79, 0xc8, 0x64, 0x6e, 0xa4, 0xe0, 0x51, 0xf5, 0xbe, 0x24,
+	0x1e, 0x24, 0x69, 0xcc, 0xe8, 0xaf, 0x9c, 0x4c, 0x33, 0x6b, 0x4a, 0x50, 0xb0, 0xa1, 0xa6, 0x3d,
+	0x46, 0x42, 0xdb, 0x19, 0x57, 0xa3, 0xbe, 0x70, 0x32, 0x77, 0xf2, 0x11, 0x04, 0xcd, 0x0e, 0x94,
+	0xf7, 0xe5, 0xb5, 0x99, 0x99, 0x4f, 0x8e, 0x38, 0xa5, 0xdb, 0x73, 0x9e, 0x23, 0xd1, 0x4f, 0x93,
+	0x8f, 0x75, 0xd4, 0x4f, 0xd3, 0x5f, 0x28, 0x98, 0x66, 0xd6, 0x94, 0xa0, 0xf0, 0x12, 0x1a, 0x89,
+	0x9e, 0x36, 0x52, 0xc3, 0xab, 0xec, 0xa7, 0x00, 0xa6, 0x35, 0x0f, 0x45, 0x50, 0x7e, 0x0e, 0x15,
+	0x25, 0x67, 0x40, 0x9b, 0x79, 0xb9, 0x04, 0xa7, 0xb8, 0x35, 0x3f, 0xd5, 0xa0, 0x57, 0xa0, 0x85,
+	0xc3, 0xda, 0x15, 0x64, 0x05, 0xd0, 0xe6, 0x4e, 0x3e, 0x42, 0x6c, 0x0c, 0x54, 0xd7, 0x87, 0x54,
+	0x1e, 0x32, 0xbc, 0xab, 0xb9, 0x9d, 0x3b, 0x2f, 0x08, 0xce, 0xa0, 0x9d, 0x67, 0x3e, 0xd0, 0x87,
+	0xd7, 0xb2, 0x31, 0x7c, 0xa3, 0x9b, 0xd8, 0xa3, 0x47, 0x06, 0xf2, 0x60, 0x3d, 0xdb, 0xce, 0xa0,
+	0x07, 0xd7, 0x30, 0x45, 0x7c, 0xcb, 0xeb, 0x1b, 0xad, 0x47, 0x06, 0x72, 0xe3, 0x77, 0x7d, 0xda,
+	0x76, 0xef, 0x5f, 0x69, 0xb1, 0xf8, 0x66, 0xd7, 0xb5, 0x6c, 0x8f, 0x0c, 0xf4, 0x33, 0x58, 0xe2,
+	0x86, 0x0b, 0xb5, 0x33, 0x6c, 0x19, 0x27, 0x97, 0x6f, 0xe5, 0x1e, 0x19, 0xe8, 0x6b, 0xa8, 0xe9,
+	0x4c, 0x6e, 0x5f, 0xe1, 0xc7, 0xcc, 0x9d, 0x7c, 0x04, 0x4e, 0xf5, 0x81, 0xf1, 0xc8, 0x40, 0xdf,
+	0x42, 0x33, 0xf9, 0x26, 0x01, 0x59, 0x57, 0x3f, 0xa1, 0x30, 0xef, 0xcd, 0xc5, 0x89, 0xa5, 0x5d,
+	0x7b, 0x95, 0xa6, 0x31, 0x9d, 0xf5, 0x12, 0xce, 0xdc, 0xc9, 0x47, 0x88, 0xf5, 0x51, 0x79, 0x77,
+	0xa6, 0xe9, 0x63, 0xfa, 0xa1, 0x9b, 0xb9, 0x95, 0x37, 0x9d, 0xa0, 0x26, 0x6b, 0x29, 0x73, 0xdf,
+	0x95, 0x99, 0x5b, 0x79, 0xd3, 0x82, 0xda, 0xb7, 0xd0, 0x4c, 0xbe, 0xb8, 0xd2, 0x0e, 0x33, 0xe7,
+	0x8d, 0x98, 0x79, 0x6f, 0x2e, 0x4e, 0x6c, 0xe2, 0x12, 0xef, 0x1b, 0xd0, 0xdd, 0x79, 0x6f, 0x1f,
+	0xd2, 0x26, 0x2e, 0xef, 0x71, 0xc5, 0x4b, 0x68, 0x24, 0x9a, 0xe7, 0x1a, 0xe5, 0xec, 0x76, 0xbf,
+	0x69, 0xcd, 0x43, 0x11, 0x94, 0x1d, 0x40, 0xe9, 0xbe, 0x36, 0x52, 0x43, 0xb0, 0xdc, 0x16, 0xba,
+	0xf9, 0xde, 0x15, 0x58, 0xb1, 0x03, 0x92, 0xd5, 0x69, 0xcd, 0x01, 0x25, 0x5a, 0x07, 0xe6, 0xed,
+	0xcc, 0xb9, 0x58, 0x0c, 0x94, 0x22, 0xb0, 0x26, 0x06, 0xe9, 0xea, 0xb4, 0xb9, 0x95, 0x37, 0x1d,
+	0x1b, 0x64, 0xb5, 0x8c, 0xab, 0x19, 0xe4, 0x8c, 0x22, 0xb1, 0xb9, 0x9d, 0x3b, 0x1f, 0xb3, 0xa7,
+	0x54, 0x60, 0x35, 0xf6, 0xd2, 0x55, 0x5c, 0x73, 0x2b, 0x6f, 0x5a, 0xf1, 0x68, 0x71, 0xb5, 0x43,
+	0xf7, 0x68, 0xa9, 0x12, 0x8b, 0xb9, 0x95, 0x37, 0x1d, 0x9f, 0xbf, 0xcc, 0xcb, 0xb5, 0xf3, 0x4f,
+	0x54, 0x34, 0xcc, 0xdb, 0x99, 0x73, 0x31, 0x4b, 0x4a, 0xce, 0xac, 0xb1, 0x94, 0xce, 0xda, 0xcd,
+	0xad, 0xbc, 0x69, 0x41, 0xed, 0x05, 0xd4, 0xf5, 0xcc, 0x17, 0xa9, 0x66, 0x25, 0x33, 0xcf, 0x36,
+	0xef, 0xce, 0xc1, 0x50, 0xd4, 0x44, 0xcf, 0x32, 0x75, 0x35, 0xc9, 0xcc, 0x65, 0x4d, 0x6b, 0x1e,
+	0x4a, 0xcc, 0xb0, 0x9e, 0x48, 0x6a, 0x0c, 0x67, 0xe6, 0xaf, 0xe6, 0xdd, 0x39, 0x18, 0x82, 0xec,
+	0x77, 0x2c, 0xf7, 0x4d, 0x27, 0x80, 0xe8, 0xbe, 0xbe, 0x36, 0x37, 0x4d, 0x35, 0x1f, 0x5c, 0x8d,
+	0x28, 0x02, 0xdd, 0xff, 0x2b, 0xca, 0x3e, 0xea, 0x73, 0xcf, 0x19, 0x61, 0x5f, 0x86, 0xbb, 0x47,
+	0x50, 0x55, 0x7b, 0xa6, 0x9a, 0x2e, 0x64, 0xf4, 0x5d, 0xcd, 0xed, 0xdc, 0xf9, 0x58, 0xb9, 0xd4,
+	0xa6, 0xb3, 0x46, 0x30, 0xa3, 0x51, 0x6e, 0x6e, 0xe7, 0xce, 0x0b, 0x82, 0x87, 0x00, 0x71, 0x5f,
+	0x19, 0xdd, 0x51, 0xcb, 0x00, 0xc9, 0x26, 0xb6, 0xb9, 0x99, 0x33, 0xab, 0xe8, 0x69, 0xdc, 0x62,
+	0xd6, 0xf5, 0x34, 0xd5, 0xa4, 0x36, 0xb7, 0xf2, 0xa6, 0x05, 0xb5, 0x3f, 0x81, 0x95, 0x54, 0x7b,
+	0x16, 0xa9, 0xae, 0x22, 0xaf, 0xb7, 0x6c, 0xbe, 0x3b, 0x1f, 0x49, 0x8f, 0x6c, 0xf9, 0xae, 0x41,
+	0x2a, 0xb2, 0xd5, 0x53, 0x29, 0x73, 0x2b, 0x6f, 0x9a, 0x53, 0x1b, 0x2c, 0xb1, 0x3f, 0x5e, 0xfd,
+	0xf0, 0xff, 0x07, 0x00, 0xdf, 0xbc, 0x88, 0xe0, 0x85, 0x35, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ *grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// VersionServiceClient is the client API for VersionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type VersionServiceClient interface {
+	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
+}
+
+type versionServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewVersionServiceClient(cc *grpc.ClientConn) VersionServiceClient {
+	return &versionServiceClient{cc}
+}
+
+func (c *versionServiceClient) Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error) {
+	out := new(VersionResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.VersionService/Version", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VersionServiceServer is the server API for VersionService service.
+type VersionServiceServer interface {
+	Version(context.Context, *VersionRequest) (*VersionResponse, error)
+}
+
+// UnimplementedVersionServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedVersionServiceServer struct {
+}
+
+func (*UnimplementedVersionServiceServer) Version(ctx context.Context, req *VersionRequest) (*VersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
+
+func RegisterVersionServiceServer(s *grpc.Server, srv VersionServiceServer) {
+	s.RegisterService(&_VersionService_serviceDesc, srv)
+}
+
+func _VersionService_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VersionServiceServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.VersionService/Version",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VersionServiceServer).Version(ctx, req.(*VersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _VersionService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "walletrpc.VersionService",
+	HandlerType: (*VersionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Version",
+			Handler:    _VersionService_Version_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api.proto",
+}
+
+// WalletServiceClient is the client API for WalletService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WalletServiceClient interface {
+	// Queries
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	Network(ctx context.Context, in *NetworkRequest, opts ...grpc.CallOption) (*NetworkResponse, error)
+	AccountNumber(ctx context.Context, in *AccountNumberRequest, opts ...grpc.CallOption) (*AccountNumberResponse, error)
+	Accounts(ctx context.Context, in *AccountsRequest, opts ...grpc.CallOption) (*AccountsResponse, error)
+	Balance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error)
+	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error)
+	ListUnspent(ctx context.Context, in *ListUnspentRequest, opts ...grpc.CallOption) (*ListUnspentResponse, error)
+	LockedOutputs(ctx context.Context, in *LockedOutputsRequest, opts ...grpc.CallOption) (*LockedOutputsResponse, error)
+	AddressLabel(ctx context.Context, in *AddressLabelRequest, opts ...grpc.CallOption) (*AddressLabelResponse, error)
+	// Notifications
+	TransactionNotifications(ctx context.Context, in *TransactionNotificationsRequest, opts ...grpc.CallOption) (WalletService_TransactionNotificationsClient, error)
+	SpentnessNotifications(ctx context.Context, in *SpentnessNotificationsRequest, opts ...grpc.CallOption) (WalletService_SpentnessNotificationsClient, error)
+	AccountNotifications(ctx context.Context, in *AccountNotificationsRequest, opts ...grpc.CallOption) (WalletService_AccountNotificationsClient, error)
+	Rescan(ctx context.Context, in *RescanRequest, opts ...grpc.CallOption) (WalletService_RescanClient, error)
+	Notifications(ctx context.Context, opts ...grpc.CallOption) (WalletService_NotificationsClient, error)
+	// Control
+	ChangePassphrase(ctx context.Context, in *ChangePassphraseRequest, opts ...grpc.CallOption) (*ChangePassphraseResponse, error)
+	RenameAccount(ctx context.Context, in *RenameAccountRequest, opts ...grpc.CallOption) (*RenameAccountResponse, error)
+	NextAccount(ctx context.Context, in *NextAccountRequest, opts ...grpc.CallOption) (*NextAccountResponse, error)
+	NextAddress(ctx context.Context, in *NextAddressRequest, opts ...grpc.CallOption) (*NextAddressResponse, error)
+	ImportPrivateKey(ctx context.Context, in *ImportPrivateKeyRequest, opts ...grpc.CallOption) (*ImportPrivateKeyResponse, error)
+	FundTransaction(ctx context.Context, in *FundTransactionRequest, opts ...grpc.CallOption) (*FundTransactionResponse, error)
+	SignTransaction(ctx context.Context, in *SignTransactionRequest, opts ...grpc.CallOption) (*SignTransactionResponse, error)
+	PublishTransaction(ctx context.Context, in *PublishTransactionRequest, opts ...grpc.CallOption) (*PublishTransactionResponse, error)
+	FundPsbt(ctx context.Context, in *FundPsbtRequest, opts ...grpc.CallOption) (*FundPsbtResponse, error)
+	ProcessPsbt(ctx context.Context, in *ProcessPsbtRequest, opts ...grpc.CallOption) (*ProcessPsbtResponse, error)
+	FinalizePsbt(ctx context.Context, in *FinalizePsbtRequest, opts ...grpc.CallOption) (*FinalizePsbtResponse, error)
+	CombinePsbt(ctx context.Context, in *CombinePsbtRequest, opts ...grpc.CallOption) (*CombinePsbtResponse, error)
+	LockOutputs(ctx context.Context, in *LockOutputsRequest, opts ...grpc.CallOption) (*LockOutputsResponse, error)
+	SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error)
+	SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error)
+	DumpPrivateKey(ctx context.Context, in *DumpPrivateKeyRequest, opts ...grpc.CallOption) (*DumpPrivateKeyResponse, error)
+	SetAddressLabel(ctx context.Context, in *SetAddressLabelRequest, opts ...grpc.CallOption) (*SetAddressLabelResponse, error)
+	SetOutputLabel(ctx context.Context, in *SetOutputLabelRequest, opts ...grpc.CallOption) (*SetOutputLabelResponse, error)
+	SetTransactionComment(ctx context.Context, in *SetTransactionCommentRequest, opts ...grpc.CallOption) (*SetTransactionCommentResponse, error)
+}
+
+type walletServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWalletServiceClient(cc *grpc.ClientConn) WalletServiceClient {
+	return &walletServiceClient{cc}
+}
+
+func (c *walletServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Network(ctx context.Context, in *NetworkRequest, opts ...grpc.CallOption) (*NetworkResponse, error) {
+	out := new(NetworkResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/Network", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -3016,6 +5290,33 @@ func (c *walletServiceClient) GetTransactions(ctx context.Context, in *GetTransa
 	return out, nil
 }
 
+func (c *walletServiceClient) ListUnspent(ctx context.Context, in *ListUnspentRequest, opts ...grpc.CallOption) (*ListUnspentResponse, error) {
+	out := new(ListUnspentResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/ListUnspent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) LockedOutputs(ctx context.Context, in *LockedOutputsRequest, opts ...grpc.CallOption) (*LockedOutputsResponse, error) {
+	out := new(LockedOutputsResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/LockedOutputs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) AddressLabel(ctx context.Context, in *AddressLabelRequest, opts ...grpc.CallOption) (*AddressLabelResponse, error) {
+	out := new(AddressLabelResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/AddressLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletServiceClient) TransactionNotifications(ctx context.Context, in *TransactionNotificationsRequest, opts ...grpc.CallOption) (WalletService_TransactionNotificationsClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_WalletService_serviceDesc.Streams[0], "/walletrpc.WalletService/TransactionNotifications", opts...)
 	if err != nil {
@@ -3112,6 +5413,69 @@ func (x *walletServiceAccountNotificationsClient) Recv() (*AccountNotificationsR
 	return m, nil
 }
 
+func (c *walletServiceClient) Rescan(ctx context.Context, in *RescanRequest, opts ...grpc.CallOption) (WalletService_RescanClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WalletService_serviceDesc.Streams[3], "/walletrpc.WalletService/Rescan", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &walletServiceRescanClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WalletService_RescanClient interface {
+	Recv() (*RescanResponse, error)
+	grpc.ClientStream
+}
+
+type walletServiceRescanClient struct {
+	grpc.ClientStream
+}
+
+func (x *walletServiceRescanClient) Recv() (*RescanResponse, error) {
+	m := new(RescanResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *walletServiceClient) Notifications(ctx context.Context, opts ...grpc.CallOption) (WalletService_NotificationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_WalletService_serviceDesc.Streams[4], "/walletrpc.WalletService/Notifications", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &walletServiceNotificationsClient{stream}
+	return x, nil
+}
+
+type WalletService_NotificationsClient interface {
+	Send(*NotificationsRequest) error
+	Recv() (*NotificationsResponse, error)
+	grpc.ClientStream
+}
+
+type walletServiceNotificationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *walletServiceNotificationsClient) Send(m *NotificationsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *walletServiceNotificationsClient) Recv() (*NotificationsResponse, error) {
+	m := new(NotificationsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *walletServiceClient) ChangePassphrase(ctx context.Context, in *ChangePassphraseRequest, opts ...grpc.CallOption) (*ChangePassphraseResponse, error) {
 	out := new(ChangePassphraseResponse)
 	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/ChangePassphrase", in, out, opts...)
@@ -3184,6 +5548,105 @@ func (c *walletServiceClient) PublishTransaction(ctx context.Context, in *Publis
 	return out, nil
 }
 
+func (c *walletServiceClient) FundPsbt(ctx context.Context, in *FundPsbtRequest, opts ...grpc.CallOption) (*FundPsbtResponse, error) {
+	out := new(FundPsbtResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/FundPsbt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ProcessPsbt(ctx context.Context, in *ProcessPsbtRequest, opts ...grpc.CallOption) (*ProcessPsbtResponse, error) {
+	out := new(ProcessPsbtResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/ProcessPsbt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) FinalizePsbt(ctx context.Context, in *FinalizePsbtRequest, opts ...grpc.CallOption) (*FinalizePsbtResponse, error) {
+	out := new(FinalizePsbtResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/FinalizePsbt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CombinePsbt(ctx context.Context, in *CombinePsbtRequest, opts ...grpc.CallOption) (*CombinePsbtResponse, error) {
+	out := new(CombinePsbtResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/CombinePsbt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) LockOutputs(ctx context.Context, in *LockOutputsRequest, opts ...grpc.CallOption) (*LockOutputsResponse, error) {
+	out := new(LockOutputsResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/LockOutputs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error) {
+	out := new(SendManyResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/SendMany", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error) {
+	out := new(SignMessageResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/SignMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) DumpPrivateKey(ctx context.Context, in *DumpPrivateKeyRequest, opts ...grpc.CallOption) (*DumpPrivateKeyResponse, error) {
+	out := new(DumpPrivateKeyResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/DumpPrivateKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SetAddressLabel(ctx context.Context, in *SetAddressLabelRequest, opts ...grpc.CallOption) (*SetAddressLabelResponse, error) {
+	out := new(SetAddressLabelResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/SetAddressLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SetOutputLabel(ctx context.Context, in *SetOutputLabelRequest, opts ...grpc.CallOption) (*SetOutputLabelResponse, error) {
+	out := new(SetOutputLabelResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/SetOutputLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SetTransactionComment(ctx context.Context, in *SetTransactionCommentRequest, opts ...grpc.CallOption) (*SetTransactionCommentResponse, error) {
+	out := new(SetTransactionCommentResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletService/SetTransactionComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WalletServiceServer is the server API for WalletService service.
 type WalletServiceServer interface {
 	// Queries
@@ -3193,10 +5656,15 @@ type WalletServiceServer interface {
 	Accounts(context.Context, *AccountsRequest) (*AccountsResponse, error)
 	Balance(context.Context, *BalanceRequest) (*BalanceResponse, error)
 	GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error)
+	ListUnspent(context.Context, *ListUnspentRequest) (*ListUnspentResponse, error)
+	LockedOutputs(context.Context, *LockedOutputsRequest) (*LockedOutputsResponse, error)
+	AddressLabel(context.Context, *AddressLabelRequest) (*AddressLabelResponse, error)
 	// Notifications
 	TransactionNotifications(*TransactionNotificationsRequest, WalletService_TransactionNotificationsServer) error
 	SpentnessNotifications(*SpentnessNotificationsRequest, WalletService_SpentnessNotificationsServer) error
 	AccountNotifications(*AccountNotificationsRequest, WalletService_AccountNotificationsServer) error
+	Rescan(*RescanRequest, WalletService_RescanServer) error
+	Notifications(WalletService_NotificationsServer) error
 	// Control
 	ChangePassphrase(context.Context, *ChangePassphraseRequest) (*ChangePassphraseResponse, error)
 	RenameAccount(context.Context, *RenameAccountRequest) (*RenameAccountResponse, error)
@@ -3206,6 +5674,17 @@ type WalletServiceServer interface {
 	FundTransaction(context.Context, *FundTransactionRequest) (*FundTransactionResponse, error)
 	SignTransaction(context.Context, *SignTransactionRequest) (*SignTransactionResponse, error)
 	PublishTransaction(context.Context, *PublishTransactionRequest) (*PublishTransactionResponse, error)
+	FundPsbt(context.Context, *FundPsbtRequest) (*FundPsbtResponse, error)
+	ProcessPsbt(context.Context, *ProcessPsbtRequest) (*ProcessPsbtResponse, error)
+	FinalizePsbt(context.Context, *FinalizePsbtRequest) (*FinalizePsbtResponse, error)
+	CombinePsbt(context.Context, *CombinePsbtRequest) (*CombinePsbtResponse, error)
+	LockOutputs(context.Context, *LockOutputsRequest) (*LockOutputsResponse, error)
+	SendMany(context.Context, *SendManyRequest) (*SendManyResponse, error)
+	SignMessage(context.Context, *SignMessageRequest) (*SignMessageResponse, error)
+	DumpPrivateKey(context.Context, *DumpPrivateKeyRequest) (*DumpPrivateKeyResponse, error)
+	SetAddressLabel(context.Context, *SetAddressLabelRequest) (*SetAddressLabelResponse, error)
+	SetOutputLabel(context.Context, *SetOutputLabelRequest) (*SetOutputLabelResponse, error)
+	SetTransactionComment(context.Context, *SetTransactionCommentRequest) (*SetTransactionCommentResponse, error)
 }
 
 // UnimplementedWalletServiceServer can be embedded to have forward compatible implementations.
@@ -3230,6 +5709,15 @@ func (*UnimplementedWalletServiceServer) Balance(ctx context.Context, req *Balan
 func (*UnimplementedWalletServiceServer) GetTransactions(ctx context.Context, req *GetTransactionsRequest) (*GetTransactionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTransactions not implemented")
 }
+func (*UnimplementedWalletServiceServer) ListUnspent(ctx context.Context, req *ListUnspentRequest) (*ListUnspentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUnspent not implemented")
+}
+func (*UnimplementedWalletServiceServer) LockedOutputs(ctx context.Context, req *LockedOutputsRequest) (*LockedOutputsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockedOutputs not implemented")
+}
+func (*UnimplementedWalletServiceServer) AddressLabel(ctx context.Context, req *AddressLabelRequest) (*AddressLabelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddressLabel not implemented")
+}
 func (*UnimplementedWalletServiceServer) TransactionNotifications(req *TransactionNotificationsRequest, srv WalletService_TransactionNotificationsServer) error {
 	return status.Errorf(codes.Unimplemented, "method TransactionNotifications not implemented")
 }
@@ -3239,6 +5727,12 @@ func (*UnimplementedWalletServiceServer) SpentnessNotifications(req *SpentnessNo
 func (*UnimplementedWalletServiceServer) AccountNotifications(req *AccountNotificationsRequest, srv WalletService_AccountNotificationsServer) error {
 	return status.Errorf(codes.Unimplemented, "method AccountNotifications not implemented")
 }
+func (*UnimplementedWalletServiceServer) Rescan(req *RescanRequest, srv WalletService_RescanServer) error {
+	return status.Errorf(codes.Unimplemented, "method Rescan not implemented")
+}
+func (*UnimplementedWalletServiceServer) Notifications(srv WalletService_NotificationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method Notifications not implemented")
+}
 func (*UnimplementedWalletServiceServer) ChangePassphrase(ctx context.Context, req *ChangePassphraseRequest) (*ChangePassphraseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangePassphrase not implemented")
 }
@@ -3263,6 +5757,39 @@ func (*UnimplementedWalletServiceServer) SignTransaction(ctx context.Context, re
 func (*UnimplementedWalletServiceServer) PublishTransaction(ctx context.Context, req *PublishTransactionRequest) (*PublishTransactionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PublishTransaction not implemented")
 }
+func (*UnimplementedWalletServiceServer) FundPsbt(ctx context.Context, req *FundPsbtRequest) (*FundPsbtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FundPsbt not implemented")
+}
+func (*UnimplementedWalletServiceServer) ProcessPsbt(ctx context.Context, req *ProcessPsbtRequest) (*ProcessPsbtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessPsbt not implemented")
+}
+func (*UnimplementedWalletServiceServer) FinalizePsbt(ctx context.Context, req *FinalizePsbtRequest) (*FinalizePsbtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinalizePsbt not implemented")
+}
+func (*UnimplementedWalletServiceServer) CombinePsbt(ctx context.Context, req *CombinePsbtRequest) (*CombinePsbtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CombinePsbt not implemented")
+}
+func (*UnimplementedWalletServiceServer) LockOutputs(ctx context.Context, req *LockOutputsRequest) (*LockOutputsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockOutputs not implemented")
+}
+func (*UnimplementedWalletServiceServer) SendMany(ctx context.Context, req *SendManyRequest) (*SendManyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendMany not implemented")
+}
+func (*UnimplementedWalletServiceServer) SignMessage(ctx context.Context, req *SignMessageRequest) (*SignMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignMessage not implemented")
+}
+func (*UnimplementedWalletServiceServer) DumpPrivateKey(ctx context.Context, req *DumpPrivateKeyRequest) (*DumpPrivateKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DumpPrivateKey not implemented")
+}
+func (*UnimplementedWalletServiceServer) SetAddressLabel(ctx context.Context, req *SetAddressLabelRequest) (*SetAddressLabelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAddressLabel not implemented")
+}
+func (*UnimplementedWalletServiceServer) SetOutputLabel(ctx context.Context, req *SetOutputLabelRequest) (*SetOutputLabelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOutputLabel not implemented")
+}
+func (*UnimplementedWalletServiceServer) SetTransactionComment(ctx context.Context, req *SetTransactionCommentRequest) (*SetTransactionCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTransactionComment not implemented")
+}
 
 func RegisterWalletServiceServer(s *grpc.Server, srv WalletServiceServer) {
 	s.RegisterService(&_WalletService_serviceDesc, srv)
@@ -3299,79 +5826,133 @@ func _WalletService_Network_Handler(srv interface{}, ctx context.Context, dec fu
 		FullMethod: "/walletrpc.WalletService/Network",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).Network(ctx, req.(*NetworkRequest))
+		return srv.(WalletServiceServer).Network(ctx, req.(*NetworkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_AccountNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).AccountNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/AccountNumber",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).AccountNumber(ctx, req.(*AccountNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Accounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Accounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/Accounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Accounts(ctx, req.(*AccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Balance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Balance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/Balance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Balance(ctx, req.(*BalanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_AccountNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AccountNumberRequest)
+func _WalletService_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).AccountNumber(ctx, in)
+		return srv.(WalletServiceServer).GetTransactions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/walletrpc.WalletService/AccountNumber",
+		FullMethod: "/walletrpc.WalletService/GetTransactions",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).AccountNumber(ctx, req.(*AccountNumberRequest))
+		return srv.(WalletServiceServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_Accounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AccountsRequest)
+func _WalletService_ListUnspent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnspentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).Accounts(ctx, in)
+		return srv.(WalletServiceServer).ListUnspent(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/walletrpc.WalletService/Accounts",
+		FullMethod: "/walletrpc.WalletService/ListUnspent",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).Accounts(ctx, req.(*AccountsRequest))
+		return srv.(WalletServiceServer).ListUnspent(ctx, req.(*ListUnspentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_Balance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BalanceRequest)
+func _WalletService_LockedOutputs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockedOutputsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).Balance(ctx, in)
+		return srv.(WalletServiceServer).LockedOutputs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/walletrpc.WalletService/Balance",
+		FullMethod: "/walletrpc.WalletService/LockedOutputs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).Balance(ctx, req.(*BalanceRequest))
+		return srv.(WalletServiceServer).LockedOutputs(ctx, req.(*LockedOutputsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WalletService_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTransactionsRequest)
+func _WalletService_AddressLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressLabelRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WalletServiceServer).GetTransactions(ctx, in)
+		return srv.(WalletServiceServer).AddressLabel(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/walletrpc.WalletService/GetTransactions",
+		FullMethod: "/walletrpc.WalletService/AddressLabel",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletServiceServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+		return srv.(WalletServiceServer).AddressLabel(ctx, req.(*AddressLabelRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -3439,6 +6020,53 @@ func (x *walletServiceAccountNotificationsServer) Send(m *AccountNotificationsRe
 	return x.ServerStream.SendMsg(m)
 }
 
+func _WalletService_Rescan_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RescanRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WalletServiceServer).Rescan(m, &walletServiceRescanServer{stream})
+}
+
+type WalletService_RescanServer interface {
+	Send(*RescanResponse) error
+	grpc.ServerStream
+}
+
+type walletServiceRescanServer struct {
+	grpc.ServerStream
+}
+
+func (x *walletServiceRescanServer) Send(m *RescanResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _WalletService_Notifications_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WalletServiceServer).Notifications(&walletServiceNotificationsServer{stream})
+}
+
+type WalletService_NotificationsServer interface {
+	Send(*NotificationsResponse) error
+	Recv() (*NotificationsRequest, error)
+	grpc.ServerStream
+}
+
+type walletServiceNotificationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *walletServiceNotificationsServer) Send(m *NotificationsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *walletServiceNotificationsServer) Recv() (*NotificationsRequest, error) {
+	m := new(NotificationsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _WalletService_ChangePassphrase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ChangePassphraseRequest)
 	if err := dec(in); err != nil {
@@ -3583,6 +6211,204 @@ func _WalletService_PublishTransaction_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletService_FundPsbt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FundPsbtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).FundPsbt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/FundPsbt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).FundPsbt(ctx, req.(*FundPsbtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ProcessPsbt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessPsbtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ProcessPsbt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/ProcessPsbt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ProcessPsbt(ctx, req.(*ProcessPsbtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_FinalizePsbt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalizePsbtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).FinalizePsbt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/FinalizePsbt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).FinalizePsbt(ctx, req.(*FinalizePsbtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CombinePsbt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CombinePsbtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CombinePsbt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/CombinePsbt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CombinePsbt(ctx, req.(*CombinePsbtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_LockOutputs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockOutputsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).LockOutputs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/LockOutputs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).LockOutputs(ctx, req.(*LockOutputsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SendMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendManyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SendMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/SendMany",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SendMany(ctx, req.(*SendManyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SignMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SignMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/SignMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SignMessage(ctx, req.(*SignMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_DumpPrivateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DumpPrivateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).DumpPrivateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/DumpPrivateKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).DumpPrivateKey(ctx, req.(*DumpPrivateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SetAddressLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAddressLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SetAddressLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/SetAddressLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SetAddressLabel(ctx, req.(*SetAddressLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SetOutputLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOutputLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SetOutputLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/SetOutputLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SetOutputLabel(ctx, req.(*SetOutputLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SetTransactionComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTransactionCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SetTransactionComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/SetTransactionComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SetTransactionComment(ctx, req.(*SetTransactionCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _WalletService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "walletrpc.WalletService",
 	HandlerType: (*WalletServiceServer)(nil),
@@ -3611,6 +6437,18 @@ var _WalletService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransactions",
 			Handler:    _WalletService_GetTransactions_Handler,
 		},
+		{
+			MethodName: "ListUnspent",
+			Handler:    _WalletService_ListUnspent_Handler,
+		},
+		{
+			MethodName: "LockedOutputs",
+			Handler:    _WalletService_LockedOutputs_Handler,
+		},
+		{
+			MethodName: "AddressLabel",
+			Handler:    _WalletService_AddressLabel_Handler,
+		},
 		{
 			MethodName: "ChangePassphrase",
 			Handler:    _WalletService_ChangePassphrase_Handler,
@@ -3643,6 +6481,50 @@ var _WalletService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PublishTransaction",
 			Handler:    _WalletService_PublishTransaction_Handler,
 		},
+		{
+			MethodName: "FundPsbt",
+			Handler:    _WalletService_FundPsbt_Handler,
+		},
+		{
+			MethodName: "ProcessPsbt",
+			Handler:    _WalletService_ProcessPsbt_Handler,
+		},
+		{
+			MethodName: "FinalizePsbt",
+			Handler:    _WalletService_FinalizePsbt_Handler,
+		},
+		{
+			MethodName: "CombinePsbt",
+			Handler:    _WalletService_CombinePsbt_Handler,
+		},
+		{
+			MethodName: "LockOutputs",
+			Handler:    _WalletService_LockOutputs_Handler,
+		},
+		{
+			MethodName: "SendMany",
+			Handler:    _WalletService_SendMany_Handler,
+		},
+		{
+			MethodName: "SignMessage",
+			Handler:    _WalletService_SignMessage_Handler,
+		},
+		{
+			MethodName: "DumpPrivateKey",
+			Handler:    _WalletService_DumpPrivateKey_Handler,
+		},
+		{
+			MethodName: "SetAddressLabel",
+			Handler:    _WalletService_SetAddressLabel_Handler,
+		},
+		{
+			MethodName: "SetOutputLabel",
+			Handler:    _WalletService_SetOutputLabel_Handler,
+		},
+		{
+			MethodName: "SetTransactionComment",
+			Handler:    _WalletService_SetTransactionComment_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -3660,6 +6542,17 @@ var _WalletService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _WalletService_AccountNotifications_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Rescan",
+			Handler:       _WalletService_Rescan_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Notifications",
+			Handler:       _WalletService_Notifications_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "api.proto",
 }
@@ -3673,6 +6566,7 @@ type WalletLoaderServiceClient interface {
 	OpenWallet(ctx context.Context, in *OpenWalletRequest, opts ...grpc.CallOption) (*OpenWalletResponse, error)
 	CloseWallet(ctx context.Context, in *CloseWalletRequest, opts ...grpc.CallOption) (*CloseWalletResponse, error)
 	StartConsensusRPC(ctx context.Context, in *StartConsensusRPCRequest, opts ...grpc.CallOption) (*StartConsensusRPCResponse, error)
+	ListWallets(ctx context.Context, in *ListWalletsRequest, opts ...grpc.CallOption) (*ListWalletsResponse, error)
 }
 
 type walletLoaderServiceClient struct {
@@ -3728,6 +6622,15 @@ func (c *walletLoaderServiceClient) StartConsensusRPC(ctx context.Context, in *S
 	return out, nil
 }
 
+func (c *walletLoaderServiceClient) ListWallets(ctx context.Context, in *ListWalletsRequest, opts ...grpc.CallOption) (*ListWalletsResponse, error) {
+	out := new(ListWalletsResponse)
+	err := c.cc.Invoke(ctx, "/walletrpc.WalletLoaderService/ListWallets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WalletLoaderServiceServer is the server API for WalletLoaderService service.
 type WalletLoaderServiceServer interface {
 	WalletExists(context.Context, *WalletExistsRequest) (*WalletExistsResponse, error)
@@ -3735,6 +6638,7 @@ type WalletLoaderServiceServer interface {
 	OpenWallet(context.Context, *OpenWalletRequest) (*OpenWalletResponse, error)
 	CloseWallet(context.Context, *CloseWalletRequest) (*CloseWalletResponse, error)
 	StartConsensusRPC(context.Context, *StartConsensusRPCRequest) (*StartConsensusRPCResponse, error)
+	ListWallets(context.Context, *ListWalletsRequest) (*ListWalletsResponse, error)
 }
 
 // UnimplementedWalletLoaderServiceServer can be embedded to have forward compatible implementations.
@@ -3756,6 +6660,9 @@ func (*UnimplementedWalletLoaderServiceServer) CloseWallet(ctx context.Context,
 func (*UnimplementedWalletLoaderServiceServer) StartConsensusRPC(ctx context.Context, req *StartConsensusRPCRequest) (*StartConsensusRPCResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartConsensusRPC not implemented")
 }
+func (*UnimplementedWalletLoaderServiceServer) ListWallets(ctx context.Context, req *ListWalletsRequest) (*ListWalletsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWallets not implemented")
+}
 
 func RegisterWalletLoaderServiceServer(s *grpc.Server, srv WalletLoaderServiceServer) {
 	s.RegisterService(&_WalletLoaderService_serviceDesc, srv)
@@ -3851,6 +6758,24 @@ func _WalletLoaderService_StartConsensusRPC_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletLoaderService_ListWallets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWalletsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletLoaderServiceServer).ListWallets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletLoaderService/ListWallets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletLoaderServiceServer).ListWallets(ctx, req.(*ListWalletsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _WalletLoaderService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "walletrpc.WalletLoaderService",
 	HandlerType: (*WalletLoaderServiceServer)(nil),
@@ -3875,6 +6800,10 @@ var _WalletLoaderService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "StartConsensusRPC",
 			Handler:    _WalletLoaderService_StartConsensusRPC_Handler,
 		},
+		{
+			MethodName: "ListWallets",
+			Handler:    _WalletLoaderService_ListWallets_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
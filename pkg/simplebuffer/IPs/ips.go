@@ -82,9 +82,9 @@ func GetListenable() simplebuffer.Serializer {
 	var ipslice []*net.IP
 	for i := range lA {
 		addIP := net.ParseIP(strings.Split(lA[i].String(), "/")[0])
-		if addIP.To4() != nil {
-			// we only want ipv4 addresses because even ipv6 on lans is still
-			// rare
+		// link local ipv6 addresses are left out as they can't be used
+		// without knowing the interface they belong to
+		if addIP.To4() != nil || !addIP.IsLinkLocalUnicast() {
 			ipslice = append(ipslice, &addIP)
 		}
 	}
@@ -0,0 +1,385 @@
+package stratum
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/fork"
+	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/util"
+)
+
+const (
+	// TargetShareTime is how often the share difficulty of each connection
+	// is adjusted for it to find a share
+	TargetShareTime = time.Second * 10
+	// RetargetInterval is how often the share difficulty is adjusted
+	RetargetInterval = time.Minute
+	// RetargetShares is how many shares cause the share difficulty to be
+	// adjusted before RetargetInterval has passed
+	RetargetShares = 30
+	// MinDifficulty is the lowest share difficulty
+	MinDifficulty = 1.0 / (1 << 16)
+	// IdleTimeout is how long a connection may go without sending anything
+	IdleTimeout = time.Minute * 10
+	// WriteTimeout is how long a write to a connection may take
+	WriteTimeout = time.Second * 10
+)
+
+// request is a Stratum request from a miner
+type request struct {
+	ID     interface{}     `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// response is the reply to a request
+type response struct {
+	ID     interface{} `json:"id"`
+	Result interface{} `json:"result"`
+	Error  interface{} `json:"error"`
+}
+
+// notification is a message sent to a miner that isn't a reply
+type notification struct {
+	ID     interface{} `json:"id"`
+	Method string      `json:"method"`
+	Params interface{} `json:"params"`
+}
+
+// stratumError is an error in a response, sent as the code, message and
+// traceback
+type stratumError struct {
+	Code    int
+	Message string
+}
+
+func (e *stratumError) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{e.Code, e.Message, nil})
+}
+
+// The errors pools commonly return
+var (
+	errOther         = func(msg string) *stratumError { return &stratumError{20, msg} }
+	errJobNotFound   = &stratumError{21, "Job not found"}
+	errDuplicate     = &stratumError{22, "Duplicate share"}
+	errLowDifficulty = &stratumError{23, "Low difficulty share"}
+	errUnauthorized  = &stratumError{24, "Unauthorized worker"}
+	errNotSubscribed = &stratumError{25, "Not subscribed"}
+)
+
+// client is the state of a miner connection
+type client struct {
+	s           *Server
+	conn        net.Conn
+	extraNonce1 []byte
+	wmx         sync.Mutex
+	closeOnce   sync.Once
+	// the rest is protected by the mutex
+	mx           sync.Mutex
+	user         string
+	algo         string
+	subscribed   bool
+	authorized   bool
+	difficulty   float64
+	jobDiffs     map[string]float64
+	shares       int
+	lastRetarget time.Time
+}
+
+func (s *Server) newClient(conn net.Conn) *client {
+	difficulty := s.cx.StateCfg.StratumDifficulty
+	if difficulty < MinDifficulty {
+		difficulty = MinDifficulty
+	}
+	return &client{
+		s:            s,
+		conn:         conn,
+		extraNonce1:  s.nextExtraNonce1(),
+		difficulty:   difficulty,
+		jobDiffs:     make(map[string]float64),
+		lastRetarget: time.Now(),
+	}
+}
+
+// serve handles the requests of the miner until the connection fails
+func (c *client) serve() {
+	log.DEBUG("stratum connection from", c.conn.RemoteAddr())
+	defer func() {
+		c.close()
+		c.s.removeClient(c)
+		log.DEBUG("stratum connection from", c.conn.RemoteAddr(), "closed")
+	}()
+	scanner := newScanner(c.conn)
+	for {
+		if err := c.conn.SetReadDeadline(time.Now().
+			Add(IdleTimeout)); err != nil {
+			log.ERROR(err)
+			return
+		}
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil && c.s.ctx.Err() == nil {
+				log.DEBUG(err)
+			}
+			return
+		}
+		var req request
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			log.DEBUG("invalid stratum request from", c.conn.RemoteAddr(),
+				err)
+			return
+		}
+		c.handle(&req)
+	}
+}
+
+// handle answers a request
+func (c *client) handle(req *request) {
+	var result interface{}
+	var err *stratumError
+	switch req.Method {
+	case "mining.subscribe":
+		c.mx.Lock()
+		c.subscribed = true
+		c.mx.Unlock()
+		id := hex.EncodeToString(c.extraNonce1)
+		result = []interface{}{
+			[][]string{
+				{"mining.set_difficulty", id},
+				{"mining.notify", id},
+			},
+			id,
+			ExtraNonce2Size,
+		}
+	case "mining.extranonce.subscribe":
+		result = true
+	case "mining.configure":
+		// no extensions, such as version rolling, are supported
+		result = map[string]interface{}{}
+	case "mining.authorize":
+		result, err = c.authorize(req.Params)
+	case "mining.submit":
+		result, err = c.submit(req.Params)
+	default:
+		err = errOther("unknown method " + req.Method)
+	}
+	r := response{ID: req.ID, Result: result}
+	if err != nil {
+		r.Error = err
+	}
+	c.write(r)
+	if req.Method == "mining.authorize" && err == nil {
+		c.mx.Lock()
+		difficulty := c.difficulty
+		c.mx.Unlock()
+		c.write(notification{Method: "mining.set_difficulty",
+			Params: []interface{}{difficulty}})
+		c.notify(true)
+	}
+}
+
+// authorize sets the worker name and the algorithm from the password
+func (c *client) authorize(params json.RawMessage) (result interface{},
+	err *stratumError) {
+	var p []string
+	if e := json.Unmarshal(params, &p); e != nil || len(p) < 1 {
+		return false, errOther("invalid parameters")
+	}
+	var password string
+	if len(p) > 1 {
+		password = p[1]
+	}
+	algo := parseAlgo(password)
+	height := c.s.generator.BestSnapshot().Height + 1
+	if _, ok := fork.List[fork.GetCurrent(height)].Algos[algo]; !ok {
+		return false, errOther("unknown algorithm " + algo)
+	}
+	c.mx.Lock()
+	c.user = p[0]
+	c.algo = algo
+	c.authorized = true
+	c.mx.Unlock()
+	log.INFO("stratum miner", p[0], "at", c.conn.RemoteAddr(),
+		"authorized for", algo)
+	return true, nil
+}
+
+// submit checks a share and submits it as a block if it also meets the
+// target of the block
+func (c *client) submit(params json.RawMessage) (result interface{},
+	err *stratumError) {
+	c.mx.Lock()
+	subscribed, authorized, algo := c.subscribed, c.authorized, c.algo
+	c.mx.Unlock()
+	switch {
+	case !subscribed:
+		return false, errNotSubscribed
+	case !authorized:
+		return false, errUnauthorized
+	}
+	var p []string
+	if e := json.Unmarshal(params, &p); e != nil || len(p) < 5 {
+		return false, errOther("invalid parameters")
+	}
+	j := c.s.job(p[1])
+	if j == nil {
+		return false, errJobNotFound
+	}
+	if j.algo != algo {
+		return false, errOther("job is for another algorithm")
+	}
+	extraNonce2, e := hex.DecodeString(p[2])
+	if e != nil || len(extraNonce2) != ExtraNonce2Size {
+		return false, errOther("invalid extranonce2")
+	}
+	nTime, e := strconv.ParseUint(p[3], 16, 32)
+	if e != nil {
+		return false, errOther("invalid ntime")
+	}
+	if int64(nTime) < j.block.Header.Timestamp.Unix() || int64(nTime) >
+		time.Now().Unix()+blockchain.MaxTimeOffsetSeconds {
+		return false, errOther("ntime out of range")
+	}
+	nonce, e := strconv.ParseUint(p[4], 16, 32)
+	if e != nil {
+		return false, errOther("invalid nonce")
+	}
+	if !c.s.addShare(j, hex.EncodeToString(c.extraNonce1)+p[2]+p[3]+p[4]) {
+		return false, errDuplicate
+	}
+	header, coinbase, e := j.header(c.extraNonce1, extraNonce2,
+		uint32(nTime), uint32(nonce))
+	if e != nil {
+		log.ERROR(e)
+		return false, errOther("invalid coinbase")
+	}
+	hash := header.BlockHashWithAlgos(j.height)
+	hashNum := blockchain.HashToBig(&hash)
+	if hashNum.Cmp(shareTarget(j.algo, j.height, c.jobDifficulty(j.id))) > 0 {
+		return false, errLowDifficulty
+	}
+	c.retarget(true)
+	if hashNum.Cmp(fork.CompactToBig(header.Bits)) <= 0 {
+		log.INFO("stratum miner", p[0], "found a block", hash)
+		go c.s.submitBlock(c, j, util.NewBlock(j.solvedBlock(header,
+			coinbase)))
+	}
+	return true, nil
+}
+
+// jobDifficulty returns the share difficulty for the job
+func (c *client) jobDifficulty(id string) float64 {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	if d, ok := c.jobDiffs[id]; ok {
+		return d
+	}
+	return c.difficulty
+}
+
+// notify sends the current job for the algorithm of the miner
+func (c *client) notify(clean bool) {
+	c.mx.Lock()
+	algo, authorized := c.algo, c.authorized
+	c.mx.Unlock()
+	if !authorized {
+		return
+	}
+	j, err := c.s.currentJob(algo)
+	if err != nil {
+		log.ERROR("could not get stratum job for", algo, err)
+		return
+	}
+	c.mx.Lock()
+	if clean {
+		c.jobDiffs = make(map[string]float64)
+	}
+	// shares for a job that is sent again at a higher difficulty may still
+	// be in flight, so the lower difficulty is kept
+	if d, ok := c.jobDiffs[j.id]; !ok || c.difficulty < d {
+		c.jobDiffs[j.id] = c.difficulty
+	}
+	c.mx.Unlock()
+	c.write(notification{Method: "mining.notify",
+		Params: j.notifyParams(clean)})
+}
+
+// retarget adjusts the share difficulty towards one share every
+// TargetShareTime, it is called for each accepted share and periodically
+func (c *client) retarget(share bool) {
+	c.mx.Lock()
+	if !c.authorized {
+		c.mx.Unlock()
+		return
+	}
+	if share {
+		c.shares++
+	}
+	elapsed := time.Since(c.lastRetarget)
+	if elapsed < RetargetInterval && c.shares < RetargetShares {
+		c.mx.Unlock()
+		return
+	}
+	factor := 0.5
+	if c.shares > 0 {
+		factor = float64(TargetShareTime) * float64(c.shares) /
+			float64(elapsed)
+	}
+	factor = math.Max(0.25, math.Min(4, factor))
+	c.shares = 0
+	c.lastRetarget = time.Now()
+	difficulty := math.Max(MinDifficulty, c.difficulty*factor)
+	// small changes are not worth a new job
+	if math.Abs(difficulty/c.difficulty-1) < 0.2 {
+		c.mx.Unlock()
+		return
+	}
+	c.difficulty = difficulty
+	c.mx.Unlock()
+	log.DEBUG("stratum difficulty for", c.conn.RemoteAddr(), "set to",
+		difficulty)
+	c.write(notification{Method: "mining.set_difficulty",
+		Params: []interface{}{difficulty}})
+	c.notify(false)
+}
+
+// getAlgo returns the algorithm the miner is mining
+func (c *client) getAlgo() string {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.algo
+}
+
+// write sends a message to the miner, closing the connection if it fails
+func (c *client) write(v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	b = append(b, '\n')
+	c.wmx.Lock()
+	defer c.wmx.Unlock()
+	if err = c.conn.SetWriteDeadline(time.Now().Add(WriteTimeout)); err == nil {
+		_, err = c.conn.Write(b)
+	}
+	if err != nil {
+		log.DEBUG("writing to stratum connection", c.conn.RemoteAddr(), err)
+		c.close()
+	}
+}
+
+// close closes the connection of the miner
+func (c *client) close() {
+	c.closeOnce.Do(func() {
+		if err := c.conn.Close(); err != nil {
+			log.TRACE(err)
+		}
+	})
+}
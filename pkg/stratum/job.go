@@ -0,0 +1,203 @@
+package stratum
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/mining"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+const (
+	// ExtraNonce1Size is the size of the part of the coinbase extra nonce the
+	// server assigns to each connection
+	ExtraNonce1Size = 4
+	// ExtraNonce2Size is the size of the part of the coinbase extra nonce the
+	// miner rolls
+	ExtraNonce2Size = 4
+)
+
+// job is the work for one algorithm sent out in a mining.notify message.
+// The coinbase is split around the extra nonce so miners can build their
+// own coinbase, and the merkle root from it with the branch.
+type job struct {
+	id     string
+	algo   string
+	height int32
+	block  *wire.MsgBlock
+	coinb1 []byte
+	coinb2 []byte
+	branch []*chainhash.Hash
+	// shares holds the shares already submitted for the job, so duplicates
+	// are rejected
+	shares map[string]struct{}
+}
+
+// newJob prepares a block template for miners. The bits of the header are
+// replaced with the target for the algorithm from the difficulty
+// adjustment, which is the same one kopach workers are sent.
+func newJob(id, algo string, template *mining.BlockTemplate,
+	bits uint32) (j *job, err error) {
+	msgBlock := *template.Block
+	msgBlock.Header.Version = fork.GetAlgoVer(algo, template.Height)
+	msgBlock.Header.Bits = bits
+	// the coinbase is copied as its script is replaced
+	coinbase := msgBlock.Transactions[0].Copy()
+	msgBlock.Transactions = append([]*wire.MsgTx{coinbase},
+		msgBlock.Transactions[1:]...)
+	heightScript, err := txscript.NewScriptBuilder().
+		AddInt64(int64(template.Height)).Script()
+	if err != nil {
+		return
+	}
+	script, err := txscript.NewScriptBuilder().
+		AddInt64(int64(template.Height)).
+		AddData(make([]byte, ExtraNonce1Size+ExtraNonce2Size)).
+		AddData([]byte(mining.CoinbaseFlags)).Script()
+	if err != nil {
+		return
+	}
+	if len(script) > blockchain.MaxCoinbaseScriptLen {
+		err = fmt.Errorf("coinbase script is %d bytes, more than the"+
+			" maximum of %d", len(script), blockchain.MaxCoinbaseScriptLen)
+		return
+	}
+	coinbase.TxIn[0].SignatureScript = script
+	var buf bytes.Buffer
+	if err = coinbase.SerializeNoWitness(&buf); err != nil {
+		return
+	}
+	serialized := buf.Bytes()
+	i := bytes.Index(serialized, script)
+	if i < 0 {
+		err = errors.New("coinbase script not found in coinbase")
+		return
+	}
+	// the extra nonce follows the height and the push of its size
+	i += len(heightScript) + 1
+	j = &job{
+		id:     id,
+		algo:   algo,
+		height: template.Height,
+		block:  &msgBlock,
+		coinb1: serialized[:i],
+		coinb2: serialized[i+ExtraNonce1Size+ExtraNonce2Size:],
+		branch: merkleBranch(msgBlock.Transactions),
+		shares: make(map[string]struct{}),
+	}
+	return
+}
+
+// notifyParams returns the parameters of the mining.notify message for the
+// job
+func (j *job) notifyParams(clean bool) []interface{} {
+	branch := make([]string, len(j.branch))
+	for i := range j.branch {
+		branch[i] = hex.EncodeToString(j.branch[i][:])
+	}
+	h := &j.block.Header
+	return []interface{}{
+		j.id,
+		hex.EncodeToString(swapWords(h.PrevBlock[:])),
+		hex.EncodeToString(j.coinb1),
+		hex.EncodeToString(j.coinb2),
+		branch,
+		fmt.Sprintf("%08x", uint32(h.Version)),
+		fmt.Sprintf("%08x", h.Bits),
+		fmt.Sprintf("%08x", uint32(h.Timestamp.Unix())),
+		clean,
+	}
+}
+
+// header returns the block header a miner solved with the extra nonces, time
+// and nonce it submitted, along with the coinbase it built
+func (j *job) header(extraNonce1, extraNonce2 []byte, nTime,
+	nonce uint32) (header wire.BlockHeader, coinbase *wire.MsgTx, err error) {
+	cb := make([]byte, 0, len(j.coinb1)+len(extraNonce1)+
+		len(extraNonce2)+len(j.coinb2))
+	cb = append(cb, j.coinb1...)
+	cb = append(cb, extraNonce1...)
+	cb = append(cb, extraNonce2...)
+	cb = append(cb, j.coinb2...)
+	coinbase = &wire.MsgTx{}
+	if err = coinbase.DeserializeNoWitness(bytes.NewReader(cb)); err != nil {
+		return
+	}
+	root := coinbase.TxHash()
+	header = j.block.Header
+	header.MerkleRoot = merkleRoot(&root, j.branch)
+	header.Timestamp = time.Unix(int64(nTime), 0)
+	header.Nonce = nonce
+	return
+}
+
+// solvedBlock returns the block for a header and coinbase from header
+func (j *job) solvedBlock(header wire.BlockHeader,
+	coinbase *wire.MsgTx) *wire.MsgBlock {
+	return &wire.MsgBlock{
+		Header: header,
+		Transactions: append([]*wire.MsgTx{coinbase},
+			j.block.Transactions[1:]...),
+	}
+}
+
+// merkleBranch returns the hashes that are combined in turn with the hash of
+// the coinbase, the first transaction, to make the merkle root
+func merkleBranch(txs []*wire.MsgTx) (branch []*chainhash.Hash) {
+	// the coinbase is left empty as it is different for every miner
+	level := make([]*chainhash.Hash, len(txs))
+	for i := 1; i < len(txs); i++ {
+		h := txs[i].TxHash()
+		level[i] = &h
+	}
+	for len(level) > 1 {
+		branch = append(branch, level[1])
+		if len(level)%2 != 0 {
+			level = append(level, level[len(level)-1])
+		}
+		next := []*chainhash.Hash{nil}
+		for i := 2; i < len(level); i += 2 {
+			next = append(next, blockchain.HashMerkleBranches(level[i],
+				level[i+1]))
+		}
+		level = next
+	}
+	return
+}
+
+// merkleRoot returns the merkle root for a coinbase hash and merkle branch
+func merkleRoot(coinbase *chainhash.Hash,
+	branch []*chainhash.Hash) chainhash.Hash {
+	root := coinbase
+	for i := range branch {
+		root = blockchain.HashMerkleBranches(root, branch[i])
+	}
+	return *root
+}
+
+// swapWords reverses the bytes of each 32 bit word, stratum sends the
+// previous block hash this way
+func swapWords(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := 0; i+4 <= len(b); i += 4 {
+		binary.BigEndian.PutUint32(out[i:], binary.LittleEndian.Uint32(b[i:]))
+	}
+	return out
+}
+
+// shareTarget returns the target a hash must meet for a share at the
+// difficulty, where difficulty 1 is the minimum difficulty of the algorithm
+func shareTarget(algo string, height int32, difficulty float64) *big.Int {
+	limit := new(big.Float).SetInt(fork.GetMinDiff(algo, height))
+	target, _ := limit.Quo(limit, big.NewFloat(difficulty)).Int(nil)
+	return target
+}
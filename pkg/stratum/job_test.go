@@ -0,0 +1,116 @@
+package stratum
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/mining"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// testTemplate returns a block template with a coinbase and the given number
+// of other transactions
+func testTemplate(others int) *mining.BlockTemplate {
+	coinbase := wire.NewMsgTx(wire.TxVersion)
+	coinbase.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: *wire.NewOutPoint(&chainhash.Hash{},
+			wire.MaxPrevOutIndex),
+		SignatureScript: []byte{0x51},
+		Sequence:        wire.MaxTxInSequenceNum,
+	})
+	coinbase.AddTxOut(&wire.TxOut{Value: 5000, PkScript: []byte{0x51}})
+	txs := []*wire.MsgTx{coinbase}
+	for i := 0; i < others; i++ {
+		tx := wire.NewMsgTx(wire.TxVersion)
+		tx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: *wire.NewOutPoint(&chainhash.Hash{byte(i)}, 0),
+		})
+		tx.AddTxOut(&wire.TxOut{Value: int64(i), PkScript: []byte{0x51}})
+		txs = append(txs, tx)
+	}
+	return &mining.BlockTemplate{
+		Block: &wire.MsgBlock{
+			Header: wire.BlockHeader{
+				Timestamp: time.Unix(1600000000, 0),
+			},
+			Transactions: txs,
+		},
+		Height: 1234,
+	}
+}
+
+func TestMerkleBranch(t *testing.T) {
+	for others := 0; others < 9; others++ {
+		txs := testTemplate(others).Block.Transactions
+		var utxs []*util.Tx
+		for i := range txs {
+			utxs = append(utxs, util.NewTx(txs[i]))
+		}
+		merkles := blockchain.BuildMerkleTreeStore(utxs, false)
+		want := merkles[len(merkles)-1]
+		coinbase := txs[0].TxHash()
+		if got := merkleRoot(&coinbase, merkleBranch(txs)); !got.IsEqual(want) {
+			t.Errorf("%d transactions: merkle root %v, want %v", others+1,
+				got, want)
+		}
+	}
+}
+
+func TestJobHeader(t *testing.T) {
+	template := testTemplate(3)
+	j, err := newJob("1", "sha256d", template, 0x1d00ffff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if j.block.Header.Bits != 0x1d00ffff {
+		t.Errorf("bits %08x were not set", j.block.Header.Bits)
+	}
+	extraNonce1 := []byte{1, 2, 3, 4}
+	extraNonce2 := []byte{5, 6, 7, 8}
+	header, coinbase, err := j.header(extraNonce1, extraNonce2, 1600000001,
+		42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(coinbase.TxIn[0].SignatureScript,
+		append(extraNonce1, extraNonce2...)) {
+		t.Error("extra nonce is not in the coinbase script")
+	}
+	block := util.NewBlock(j.solvedBlock(header, coinbase))
+	merkles := blockchain.BuildMerkleTreeStore(block.Transactions(), false)
+	if !header.MerkleRoot.IsEqual(merkles[len(merkles)-1]) {
+		t.Error("merkle root does not match the solved block")
+	}
+	if header.Nonce != 42 || header.Timestamp.Unix() != 1600000001 {
+		t.Error("nonce or time were not set")
+	}
+	// the template the job was made from must not be changed
+	if len(template.Block.Transactions[0].TxIn[0].SignatureScript) != 1 {
+		t.Error("template coinbase was modified")
+	}
+}
+
+func TestSwapWords(t *testing.T) {
+	got := swapWords([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	if !bytes.Equal(got, []byte{4, 3, 2, 1, 8, 7, 6, 5}) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestParseAlgo(t *testing.T) {
+	for pass, want := range map[string]string{
+		"":                  DefaultAlgo,
+		"x":                 DefaultAlgo,
+		"algo=scrypt":       "scrypt",
+		"d=8, algo=keccak":  "keccak",
+		"algo=sha256d,d=16": "sha256d",
+	} {
+		if got := parseAlgo(pass); got != want {
+			t.Errorf("%q: got %s, want %s", pass, got, want)
+		}
+	}
+}
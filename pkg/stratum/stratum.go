@@ -0,0 +1,357 @@
+// Package stratum is a Stratum v1 server so that mining hardware and pool
+// software that only speak Stratum can mine on the node. Each connection
+// picks the algorithm it mines with the password it authorizes with, in the
+// form algo=<name>, and gets its own share difficulty adjusted to how fast
+// it finds shares. The user name is only used in logs, blocks always pay to
+// the mining addresses of the node.
+package stratum
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/mining"
+	"github.com/p9c/pod/pkg/conte"
+	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/util"
+)
+
+const (
+	// DefaultAlgo is the algorithm mined by connections that don't name one
+	DefaultAlgo = "sha256d"
+	// RefreshInterval is how often the server checks if there is a new best
+	// block to send new work for
+	RefreshInterval = time.Second
+	// TxRefreshInterval is how old work has to be before it is replaced to
+	// include new transactions from the mempool
+	TxRefreshInterval = time.Minute
+	// MaxLineLength is the longest request a miner may send
+	MaxLineLength = 1 << 14
+)
+
+// Server is the Stratum server, it keeps the current job for every algorithm
+// that is being mined and the connected miners
+type Server struct {
+	cx        *conte.Xt
+	ctx       context.Context
+	generator *mining.BlkTmplGenerator
+	listener  net.Listener
+	mx        sync.Mutex
+	clients   map[*client]struct{}
+	// jobs are all of the jobs for the current best block by ID, current
+	// the newest one for each algorithm
+	jobs          map[string]*job
+	current       map[string]*job
+	jobCounter    uint64
+	extraNonce1   uint32
+	prevHash      chainhash.Hash
+	lastTxUpdate  time.Time
+	lastGenerated time.Time
+}
+
+// Run starts the Stratum server on the configured listener, it stops when
+// the returned cancel function is called
+func Run(cx *conte.Xt) (cancel context.CancelFunc) {
+	if len(cx.StateCfg.ActiveMiningAddrs) < 1 && cx.StateCfg.MiningXpub == "" {
+		log.WARN("no mining addresses, not starting stratum server")
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	l, err := net.Listen("tcp", cx.StateCfg.StratumListener)
+	if err != nil {
+		log.ERROR(err)
+		cancel()
+		return
+	}
+	s := &Server{
+		cx:        cx,
+		ctx:       ctx,
+		generator: getBlkTemplateGenerator(cx),
+		listener:  l,
+		clients:   make(map[*client]struct{}),
+		jobs:      make(map[string]*job),
+		current:   make(map[string]*job),
+	}
+	log.INFO("stratum server listening on", l.Addr())
+	go func() {
+		<-ctx.Done()
+		if err := l.Close(); err != nil {
+			log.ERROR(err)
+		}
+	}()
+	go s.accept()
+	go s.refresher()
+	return
+}
+
+// accept starts a client for every connection until the listener is closed
+func (s *Server) accept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				log.ERROR(err)
+				continue
+			}
+			if s.ctx.Err() == nil {
+				log.ERROR(err)
+			}
+			return
+		}
+		c := s.newClient(conn)
+		s.mx.Lock()
+		s.clients[c] = struct{}{}
+		s.mx.Unlock()
+		go c.serve()
+	}
+}
+
+// refresher sends new jobs when the best block changes, or when the jobs
+// are old and there are new transactions, and lowers the difficulty of
+// miners that have stopped finding shares
+func (s *Server) refresher() {
+	ticker := time.NewTicker(RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			best := s.generator.BestSnapshot()
+			s.mx.Lock()
+			newBlock := !s.prevHash.IsEqual(&best.Hash)
+			newTxs := s.lastTxUpdate != s.generator.GetTxSource().
+				LastUpdated() && time.Now().After(s.lastGenerated.
+				Add(TxRefreshInterval))
+			s.mx.Unlock()
+			if newBlock || newTxs {
+				s.refresh(newBlock)
+			}
+			s.mx.Lock()
+			var clients []*client
+			for c := range s.clients {
+				clients = append(clients, c)
+			}
+			s.mx.Unlock()
+			for _, c := range clients {
+				c.retarget(false)
+			}
+		case <-s.ctx.Done():
+			s.mx.Lock()
+			for c := range s.clients {
+				c.close()
+			}
+			s.mx.Unlock()
+			return
+		}
+	}
+}
+
+// refresh makes new jobs for the algorithms being mined and sends them out.
+// If there is a new best block the old jobs are dropped and miners are told
+// to abandon them.
+func (s *Server) refresh(clean bool) {
+	s.mx.Lock()
+	algos := make(map[string]struct{})
+	for c := range s.clients {
+		if algo := c.getAlgo(); algo != "" {
+			algos[algo] = struct{}{}
+		}
+	}
+	s.mx.Unlock()
+	if clean {
+		s.mx.Lock()
+		s.jobs = make(map[string]*job)
+		s.current = make(map[string]*job)
+		s.mx.Unlock()
+	}
+	for algo := range algos {
+		if _, err := s.newJob(algo); err != nil {
+			log.ERROR("could not make stratum job for", algo, err)
+		}
+	}
+	s.mx.Lock()
+	s.prevHash = s.generator.BestSnapshot().Hash
+	s.lastTxUpdate = s.generator.GetTxSource().LastUpdated()
+	s.lastGenerated = time.Now()
+	var clients []*client
+	for c := range s.clients {
+		clients = append(clients, c)
+	}
+	s.mx.Unlock()
+	for _, c := range clients {
+		c.notify(clean)
+	}
+}
+
+// newJob makes a new job for the algorithm from a new block template
+func (s *Server) newJob(algo string) (j *job, err error) {
+	payToAddr, err := s.generator.PayToAddress()
+	if err != nil {
+		return
+	}
+	template, err := s.generator.NewBlockTemplate(0, payToAddr, algo)
+	if err != nil {
+		return
+	}
+	bits, err := s.bits(template.Height, algo)
+	if err != nil {
+		return
+	}
+	s.mx.Lock()
+	s.jobCounter++
+	id := fmt.Sprintf("%x", s.jobCounter)
+	s.mx.Unlock()
+	if j, err = newJob(id, algo, template, bits); err != nil {
+		return
+	}
+	s.mx.Lock()
+	s.jobs[id] = j
+	s.current[algo] = j
+	s.mx.Unlock()
+	log.DEBUG("new stratum job", id, "for", algo, "at height", j.height)
+	return
+}
+
+// bits returns the target for the algorithm at the height from the
+// difficulty adjustment, the same way as for kopach jobs
+func (s *Server) bits(height int32, algo string) (bits uint32, err error) {
+	tip := s.cx.RealNode.Chain.BestChain.Tip()
+	tip.DiffMx.Lock()
+	bitsMap := tip.Diffs
+	tip.DiffMx.Unlock()
+	if bitsMap == nil || len(*bitsMap) != len(fork.List[1].AlgoVers) {
+		bitsMap, err = s.cx.RealNode.Chain.
+			CalcNextRequiredDifficultyPlan9Controller(tip)
+		if err != nil {
+			return
+		}
+		tip.DiffMx.Lock()
+		tip.Diffs = bitsMap
+		tip.DiffMx.Unlock()
+	}
+	var ok bool
+	if bits, ok = (*bitsMap)[fork.GetAlgoVer(algo, height)]; !ok {
+		err = fmt.Errorf("no target for algorithm %s", algo)
+	}
+	return
+}
+
+// currentJob returns the current job for the algorithm, making one if it is
+// the first miner for it
+func (s *Server) currentJob(algo string) (j *job, err error) {
+	s.mx.Lock()
+	j = s.current[algo]
+	s.mx.Unlock()
+	if j != nil {
+		return
+	}
+	return s.newJob(algo)
+}
+
+// nextExtraNonce1 returns a unique extra nonce prefix for a connection
+func (s *Server) nextExtraNonce1() []byte {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.extraNonce1++
+	b := make([]byte, ExtraNonce1Size)
+	binary.BigEndian.PutUint32(b, s.extraNonce1)
+	return b
+}
+
+// submitBlock processes a solved block and sends new work when it is
+// accepted
+func (s *Server) submitBlock(c *client, j *job, block *util.Block) {
+	block.SetHeight(j.height)
+	isOrphan, err := s.cx.RealNode.SyncManager.ProcessBlock(block,
+		blockchain.BFNone)
+	if err != nil {
+		// Anything other than a rule violation is an unexpected error, so
+		// log that error as an internal error.
+		if _, ok := err.(blockchain.RuleError); !ok {
+			log.WARN("unexpected error while processing block submitted"+
+				" via stratum:", err)
+		} else {
+			log.WARN("block submitted via stratum rejected:", err)
+		}
+		if isOrphan {
+			log.WARN("block is an orphan")
+		}
+		return
+	}
+	log.WARNF("new block height %d %s %s found by stratum miner %s",
+		j.height, block.MsgBlock().BlockHashWithAlgos(j.height), j.algo,
+		c.user)
+	s.refresh(true)
+}
+
+// addShare records a share for the job, it returns false if the share was
+// already submitted
+func (s *Server) addShare(j *job, key string) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if _, ok := j.shares[key]; ok {
+		return false
+	}
+	j.shares[key] = struct{}{}
+	return true
+}
+
+// removeClient forgets a client after its connection closed
+func (s *Server) removeClient(c *client) {
+	s.mx.Lock()
+	delete(s.clients, c)
+	s.mx.Unlock()
+}
+
+// job returns the job with the ID if it is for the current best block
+func (s *Server) job(id string) *job {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.jobs[id]
+}
+
+// parseAlgo returns the algorithm named in a password of the form
+// algo=<name>, possibly among other comma separated options
+func parseAlgo(password string) (algo string) {
+	algo = DefaultAlgo
+	for _, opt := range strings.Split(password, ",") {
+		opt = strings.TrimSpace(opt)
+		if strings.HasPrefix(opt, "algo=") {
+			algo = strings.TrimPrefix(opt, "algo=")
+		}
+	}
+	return
+}
+
+// newScanner returns a scanner for the newline separated requests of a
+// connection
+func newScanner(conn net.Conn) *bufio.Scanner {
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 4096), MaxLineLength)
+	return scanner
+}
+
+func getBlkTemplateGenerator(cx *conte.Xt) *mining.BlkTmplGenerator {
+	policy := mining.Policy{
+		BlockMinWeight:    uint32(*cx.Config.BlockMinWeight),
+		BlockMaxWeight:    uint32(*cx.Config.BlockMaxWeight),
+		BlockMinSize:      uint32(*cx.Config.BlockMinSize),
+		BlockMaxSize:      uint32(*cx.Config.BlockMaxSize),
+		BlockPrioritySize: uint32(*cx.Config.BlockPrioritySize),
+		TxMinFreeFee:      cx.StateCfg.ActiveMinRelayTxFee,
+	}
+	s := cx.RealNode
+	g := mining.NewBlkTmplGenerator(&policy,
+		s.ChainParams, s.TxMemPool, s.Chain, s.TimeSource,
+		s.SigCache, s.HashCache, s.Algo)
+	g.PayToAddrs = s.PayToAddrs
+	return g
+}
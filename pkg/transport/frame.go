@@ -0,0 +1,246 @@
+package transport
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/p9c/pod/pkg/gcm"
+	"github.com/p9c/pod/pkg/log"
+)
+
+// A frame is one datagram carrying one encrypted FEC shard. The current
+// (version 1) layout is:
+//
+//	magic     4 bytes  message type, used to pick the handler
+//	version   1 byte   FrameVersion
+//	session  16 bytes  random per sending connection, salts the session key
+//	sequence  8 bytes  per frame counter, also the GCM nonce
+//	timestamp 8 bytes  unix nanoseconds when the frame was sealed
+//	message  12 bytes  random message ID, shared by the shards of a message
+//	shard              the sealed shard, with the header as additional data
+//
+// The legacy layout is the magic, the message ID, which is also used as the
+// nonce for every shard of the message, and the sealed shard, without any
+// additional data. It is only sent and accepted in compatibility mode, and
+// never for subscriptions, as a legacy frame can be replayed.
+const (
+	// FrameVersion is the version of the frame layout that is sent
+	FrameVersion = 1
+	// MaxFrameAge is how far the timestamp of a frame may be from the local
+	// clock before the frame is rejected. Sequence numbers can only be
+	// checked against senders that have been seen before, the timestamp stops
+	// old frames being replayed to a receiver that was restarted.
+	MaxFrameAge = time.Second * 30
+	// ReplayWindowSize is how many sequence numbers behind the newest frame
+	// received from a session are still accepted if they arrive out of order
+	ReplayWindowSize = 64
+	// maxSessions is the number of sending sessions a receiver keeps replay
+	// state for, the least recently heard from is dropped first
+	maxSessions = 256
+
+	magicLen       = 4
+	versionOffset  = magicLen
+	sessionOffset  = versionOffset + 1
+	sessionLen     = 16
+	sequenceOffset = sessionOffset + sessionLen
+	timeOffset     = sequenceOffset + 8
+	messageOffset  = timeOffset + 8
+	messageIDLen   = 12
+	headerLen      = messageOffset + messageIDLen
+	nonceLen       = 12
+	// shardOffset is where the shard starts in the unsealed form returned by
+	// CreateShards, which is the magic, the message ID and the shard
+	shardOffset = magicLen + messageIDLen
+)
+
+// ErrReplay is returned for a frame that has already been received or is too
+// far behind the newest frame from its sender
+var ErrReplay = errors.New("frame is a replay")
+
+// ErrStale is returned for a frame with a timestamp outside of MaxFrameAge
+var ErrStale = errors.New("frame timestamp is outside of the replay window")
+
+// replayWindow tracks the sequence numbers received from one sending session
+type replayWindow struct {
+	top    uint64
+	bitmap uint64
+}
+
+// check returns whether the sequence number has not been seen before and is
+// not too old, and records it if so
+func (w *replayWindow) check(seq uint64) bool {
+	switch {
+	case seq > w.top:
+		shift := seq - w.top
+		if shift >= ReplayWindowSize {
+			w.bitmap = 0
+		} else {
+			w.bitmap <<= shift
+		}
+		w.bitmap |= 1
+		w.top = seq
+		return true
+	case w.top-seq >= ReplayWindowSize:
+		return false
+	default:
+		bit := uint64(1) << (w.top - seq)
+		if w.bitmap&bit != 0 {
+			return false
+		}
+		w.bitmap |= bit
+		return true
+	}
+}
+
+// peerSession is the receiving state for one remote sending session
+type peerSession struct {
+	ciph     cipher.AEAD
+	window   replayWindow
+	lastSeen time.Time
+}
+
+// seal encrypts an unsealed shard from CreateShards into the frames that are
+// put on the wire. A new sequence number and timestamp is used every time, so
+// repeated sends of the same shards are not mistaken for replays.
+func (c *Connection) seal(shard []byte) (frames [][]byte) {
+	if len(shard) < shardOffset {
+		log.ERROR("shard is too short to seal")
+		return
+	}
+	seq := atomic.AddUint64(&c.sequence, 1)
+	frame := make([]byte, headerLen, headerLen+len(shard)-shardOffset+
+		c.ciph.Overhead())
+	copy(frame, shard[:magicLen])
+	frame[versionOffset] = FrameVersion
+	copy(frame[sessionOffset:], c.session[:])
+	binary.BigEndian.PutUint64(frame[sequenceOffset:], seq)
+	binary.BigEndian.PutUint64(frame[timeOffset:],
+		uint64(time.Now().UnixNano()))
+	copy(frame[messageOffset:], shard[magicLen:shardOffset])
+	frame = c.ciph.Seal(frame, sequenceNonce(seq), shard[shardOffset:],
+		frame[:headerLen])
+	frames = append(frames, frame)
+	if c.legacy != nil && !isSubscription(shard) {
+		legacy := make([]byte, shardOffset, shardOffset+len(shard)+
+			c.legacy.Overhead())
+		copy(legacy, shard[:shardOffset])
+		legacy = c.legacy.Seal(legacy, shard[magicLen:shardOffset],
+			shard[shardOffset:], nil)
+		frames = append(frames, legacy)
+	}
+	return
+}
+
+// open authenticates and decrypts a frame received from the network,
+// returning the message ID and the FEC shard it carries. Frames that fail
+// authentication, are stale or have been received before are rejected.
+func (c *Connection) open(frame []byte) (messageID string, shard []byte,
+	err error) {
+	if len(frame) > headerLen && frame[versionOffset] == FrameVersion {
+		if messageID, shard, err = c.openFrame(frame); err == nil ||
+			err == ErrReplay || err == ErrStale || c.legacy == nil ||
+			isSubscription(frame) {
+			return
+		}
+		// the version byte of a legacy frame is the first byte of its
+		// random nonce, so it may still be one of those
+	}
+	if isSubscription(frame) {
+		err = errors.New("subscriptions are only accepted as version 1" +
+			" frames")
+		return
+	}
+	if c.legacy == nil {
+		err = errors.New("legacy frame received but compatibility mode" +
+			" is disabled")
+		return
+	}
+	if len(frame) <= shardOffset {
+		err = errors.New("frame is too short")
+		return
+	}
+	nonce := frame[magicLen:shardOffset]
+	if shard, err = c.legacy.Open(nil, nonce, frame[shardOffset:],
+		nil); err != nil {
+		return
+	}
+	messageID = string(nonce)
+	return
+}
+
+// isSubscription returns whether a frame or shard is a subscription
+func isSubscription(frame []byte) bool {
+	return len(frame) >= magicLen &&
+		string(frame[:magicLen]) == string(SubscribeMagic)
+}
+
+// openFrame opens a version 1 frame
+func (c *Connection) openFrame(frame []byte) (messageID string,
+	shard []byte, err error) {
+	var session [sessionLen]byte
+	copy(session[:], frame[sessionOffset:sequenceOffset])
+	seq := binary.BigEndian.Uint64(frame[sequenceOffset:timeOffset])
+	sent := time.Unix(0,
+		int64(binary.BigEndian.Uint64(frame[timeOffset:messageOffset])))
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	peer, ok := c.peers[session]
+	ciph := c.ciph
+	switch {
+	case ok:
+		ciph = peer.ciph
+	case session != c.session:
+		if ciph, err = gcm.GetSessionCipher(c.masterKey,
+			session[:]); err != nil {
+			return
+		}
+	}
+	if shard, err = ciph.Open(nil, sequenceNonce(seq), frame[headerLen:],
+		frame[:headerLen]); err != nil {
+		return
+	}
+	// the frame is authentic, so now it is safe to keep state for its sender
+	now := time.Now()
+	if age := now.Sub(sent); age > MaxFrameAge || age < -MaxFrameAge {
+		err = ErrStale
+		return
+	}
+	if !ok {
+		peer = &peerSession{ciph: ciph}
+		c.addPeer(session, peer)
+	}
+	if !peer.window.check(seq) {
+		err = ErrReplay
+		return
+	}
+	peer.lastSeen = now
+	messageID = string(frame[messageOffset:headerLen])
+	return
+}
+
+// addPeer stores the state for a new sending session, dropping the one heard
+// from least recently if there are too many. This function MUST be called
+// with the connection mutex held.
+func (c *Connection) addPeer(session [sessionLen]byte, peer *peerSession) {
+	if len(c.peers) >= maxSessions {
+		var oldest [sessionLen]byte
+		var oldestSeen time.Time
+		for i := range c.peers {
+			if oldestSeen.IsZero() || c.peers[i].lastSeen.Before(oldestSeen) {
+				oldest, oldestSeen = i, c.peers[i].lastSeen
+			}
+		}
+		delete(c.peers, oldest)
+	}
+	c.peers[session] = peer
+}
+
+// sequenceNonce returns the GCM nonce for a sequence number
+func sequenceNonce(seq uint64) []byte {
+	nonce := make([]byte, nonceLen)
+	binary.BigEndian.PutUint64(nonce[nonceLen-8:], seq)
+	return nonce
+}
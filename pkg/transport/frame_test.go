@@ -0,0 +1,182 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/gcm"
+)
+
+const testPass = "pa55word"
+
+func newTestConnection(t *testing.T, compat bool) *Connection {
+	c, err := NewConnection(nil, nil, testPass, 1024, context.Background(),
+		nil, compat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestFrameRoundTrip(t *testing.T) {
+	sender := newTestConnection(t, false)
+	receiver := newTestConnection(t, false)
+	shards, err := sender.CreateShards([]byte("some work"), []byte("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range shards {
+		frames := sender.seal(shards[i])
+		if len(frames) != 1 {
+			t.Fatalf("expected 1 frame, got %d", len(frames))
+		}
+		messageID, shard, err := receiver.open(frames[0])
+		if err != nil {
+			t.Fatalf("shard %d: %v", i, err)
+		}
+		if messageID != string(shards[i][magicLen:shardOffset]) {
+			t.Errorf("shard %d: wrong message ID", i)
+		}
+		if !bytes.Equal(shard, shards[i][shardOffset:]) {
+			t.Errorf("shard %d: decrypted shard does not match", i)
+		}
+		// the same frame must not be accepted twice
+		if _, _, err = receiver.open(frames[0]); err != ErrReplay {
+			t.Errorf("shard %d: expected replay error, got %v", i, err)
+		}
+	}
+	// sending the shards again produces fresh frames that are accepted
+	if _, _, err = receiver.open(sender.seal(shards[0])[0]); err != nil {
+		t.Errorf("resent shard rejected: %v", err)
+	}
+}
+
+func TestFrameTampered(t *testing.T) {
+	sender := newTestConnection(t, false)
+	receiver := newTestConnection(t, false)
+	shards, err := sender.CreateShards([]byte("some work"), []byte("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	frame := sender.seal(shards[0])[0]
+	// move the timestamp, which is authenticated as additional data
+	frame[timeOffset+7]++
+	if _, _, err = receiver.open(frame); err == nil {
+		t.Error("tampered frame was accepted")
+	}
+	other, err := NewConnection(nil, nil, "wrong password", 1024,
+		context.Background(), nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = other.open(sender.seal(shards[0])[0]); err == nil {
+		t.Error("frame was accepted with the wrong password")
+	}
+}
+
+func TestFrameStale(t *testing.T) {
+	sender := newTestConnection(t, false)
+	receiver := newTestConnection(t, false)
+	shards, err := sender.CreateShards([]byte("some work"), []byte("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// reseal a frame with a timestamp from before the replay window
+	frame := sender.seal(shards[0])[0]
+	seq := binary.BigEndian.Uint64(frame[sequenceOffset:timeOffset])
+	binary.BigEndian.PutUint64(frame[timeOffset:],
+		uint64(time.Now().Add(-2*MaxFrameAge).UnixNano()))
+	frame = sender.ciph.Seal(frame[:headerLen], sequenceNonce(seq),
+		shards[0][shardOffset:], frame[:headerLen])
+	if _, _, err = receiver.open(frame); err != ErrStale {
+		t.Errorf("expected stale error, got %v", err)
+	}
+}
+
+func TestFrameCompat(t *testing.T) {
+	sender := newTestConnection(t, true)
+	shards, err := sender.CreateShards([]byte("some work"), []byte("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	frames := sender.seal(shards[0])
+	if len(frames) != 2 {
+		t.Fatalf("expected 2 frames in compatibility mode, got %d",
+			len(frames))
+	}
+	// the legacy frame is what workers before the versioned framing send
+	legacy := frames[1]
+	nonce := legacy[magicLen:shardOffset]
+	if _, err = gcm.GetCipher(testPass).Open(nil, nonce,
+		legacy[shardOffset:], nil); err != nil {
+		t.Fatalf("legacy frame cannot be opened with the legacy cipher: %v",
+			err)
+	}
+	compat := newTestConnection(t, true)
+	for i := range frames {
+		messageID, _, err := compat.open(frames[i])
+		if err != nil {
+			t.Fatalf("frame %d rejected in compatibility mode: %v", i, err)
+		}
+		if messageID != string(nonce) {
+			t.Errorf("frame %d: wrong message ID", i)
+		}
+	}
+	strict := newTestConnection(t, false)
+	if _, _, err = strict.open(legacy); err == nil {
+		t.Error("legacy frame accepted without compatibility mode")
+	}
+}
+
+func TestSubscriptionCompat(t *testing.T) {
+	sender := newTestConnection(t, true)
+	shard := make([]byte, shardOffset)
+	copy(shard, SubscribeMagic)
+	copy(shard[magicLen:], "subscription")
+	frames := sender.seal(shard)
+	if len(frames) != 1 {
+		t.Fatalf("expected only a version 1 frame for a subscription, got %d",
+			len(frames))
+	}
+	receiver := newTestConnection(t, true)
+	if _, _, err := receiver.open(frames[0]); err != nil {
+		t.Fatalf("subscription rejected: %v", err)
+	}
+	if _, _, err := receiver.open(frames[0]); err != ErrReplay {
+		t.Errorf("expected replay error, got %v", err)
+	}
+	// a legacy subscription can be replayed, so it is never accepted
+	legacy := gcm.GetCipher(testPass).Seal(append([]byte{}, shard...),
+		shard[magicLen:], nil, nil)
+	for i := 0; i < 2; i++ {
+		if _, _, err := receiver.open(legacy); err == nil {
+			t.Errorf("legacy subscription accepted in compatibility mode")
+		}
+	}
+}
+
+func TestReplayWindow(t *testing.T) {
+	var w replayWindow
+	for _, test := range []struct {
+		seq    uint64
+		accept bool
+	}{
+		{1, true},
+		{3, true},
+		{2, true},
+		{2, false},
+		{3, false},
+		{3 + ReplayWindowSize, true},
+		{3, false},
+		{4, true},
+		{4, false},
+	} {
+		if got := w.check(test.seq); got != test.accept {
+			t.Errorf("sequence %d: got %v, want %v", test.seq, got,
+				test.accept)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package transport
+
+import (
+	"errors"
+	"net"
+	"strconv"
+
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+
+	"github.com/p9c/pod/pkg/log"
+)
+
+// interfaceAddr returns the first address of the interface in the same family
+// as the given IP, or nil if it has none
+func interfaceAddr(ifi *net.Interface, ip net.IP) net.IP {
+	addrs, err := ifi.Addrs()
+	if err != nil {
+		log.ERROR(err)
+		return nil
+	}
+	for i := range addrs {
+		ipNet, ok := addrs[i].(*net.IPNet)
+		if !ok {
+			continue
+		}
+		if (ipNet.IP.To4() != nil) == (ip.To4() != nil) {
+			return ipNet.IP
+		}
+	}
+	return nil
+}
+
+// listenMulticast opens a listener on the port of the multicast group and
+// joins the group on each of the interfaces that has an address in the
+// family of the group
+func listenMulticast(group *net.UDPAddr, interfaces []*net.Interface) (
+	conn net.PacketConn, err error) {
+	network := "udp6"
+	if group.IP.To4() != nil {
+		network = "udp4"
+	}
+	conn, err = net.ListenPacket(network,
+		net.JoinHostPort("", strconv.Itoa(group.Port)))
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	var joined int
+	for _, ifi := range interfaces {
+		if interfaceAddr(ifi, group.IP) == nil {
+			continue
+		}
+		if network == "udp4" {
+			err = ipv4.NewPacketConn(conn).JoinGroup(ifi, group)
+		} else {
+			err = ipv6.NewPacketConn(conn).JoinGroup(ifi, group)
+		}
+		if err != nil {
+			log.ERROR("joining", group.IP, "on", ifi.Name, err)
+			continue
+		}
+		log.DEBUG("joined multicast group", group.IP, "on", ifi.Name)
+		joined++
+	}
+	if joined < 1 {
+		err = errors.New("could not join multicast group " +
+			group.IP.String() + " on any interface")
+		log.ERROR(err)
+		if cErr := conn.Close(); cErr != nil {
+			log.ERROR(cErr)
+		}
+		conn = nil
+		return
+	}
+	err = nil
+	return
+}
+
+// dialMulticast returns a connection sending to the multicast group through
+// each of the interfaces that has an address in the family of the group.
+// Listeners on several of those networks receive every shard more than once,
+// handle keeps only the first copy of each share
+func dialMulticast(group *net.UDPAddr, interfaces []*net.Interface) (
+	conns []*net.UDPConn, err error) {
+	for _, ifi := range interfaces {
+		local := interfaceAddr(ifi, group.IP)
+		if local == nil {
+			continue
+		}
+		var conn *net.UDPConn
+		if group.IP.To4() != nil {
+			conn, err = net.DialUDP("udp4", &net.UDPAddr{IP: local}, group)
+			if err == nil {
+				err = ipv4.NewPacketConn(conn).SetMulticastInterface(ifi)
+			}
+		} else {
+			// the zone picks the interface for link local groups
+			dst := *group
+			dst.Zone = ifi.Name
+			conn, err = net.DialUDP("udp6", nil, &dst)
+			if err == nil {
+				err = ipv6.NewPacketConn(conn).SetMulticastInterface(ifi)
+			}
+		}
+		if err != nil {
+			log.ERROR("sending to", group.IP, "on", ifi.Name, err)
+			if conn != nil {
+				if cErr := conn.Close(); cErr != nil {
+					log.ERROR(cErr)
+				}
+			}
+			continue
+		}
+		log.DEBUG("sending to multicast group", group.IP, "on", ifi.Name)
+		conns = append(conns, conn)
+	}
+	if len(conns) < 1 {
+		err = errors.New("could not send to multicast group " +
+			group.IP.String() + " on any interface")
+		log.ERROR(err)
+		return
+	}
+	err = nil
+	return
+}
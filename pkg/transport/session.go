@@ -0,0 +1,455 @@
+package transport
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/pkg/log"
+)
+
+// SubscribeMagic marks the frames a worker outside of the multicast lan sends
+// to ask for the messages sent on a connection to also be sent to it. They
+// are handled by the connection itself rather than by a HandleFunc.
+var SubscribeMagic = []byte{'s', 'u', 'b', 's'}
+
+const (
+	// SubscribeInterval is how often a dialed connection renews its
+	// subscription, and how long it waits before dialing again after the
+	// session failed
+	SubscribeInterval = time.Second * 3
+	// SubscriptionTimeout is how long messages are still sent to a remote
+	// after its last subscription, and how long a TCP session may be silent
+	SubscriptionTimeout = SubscribeInterval * 3
+	// streamLenSize is the size of the length prefix of frames sent over TCP
+	streamLenSize = 4
+	// remoteQueueLen is how many messages may be waiting to be sent to a
+	// remote, further messages are dropped for it until it catches up
+	remoteQueueLen = 16
+)
+
+// remote is a peer outside of the multicast lan that frames are sent to
+// directly, either a worker that subscribed or the controller that was dialed
+type remote struct {
+	addr     net.Addr
+	write    func(frame []byte) error
+	close    func() error
+	lastSeen time.Time
+	// expires is false for a dialed controller, which is only dropped when
+	// the session fails
+	expires bool
+	// done is closed when a TCP session has ended
+	done chan struct{}
+	// queue holds the messages waiting to be sent by the goroutine of the
+	// remote, which stops when stop is closed on dropping the remote
+	queue chan [][]byte
+	stop  chan struct{}
+}
+
+// remoteKey is the key of a remote in the remotes of a connection
+func remoteKey(addr net.Addr) string {
+	return addr.Network() + "://" + addr.String()
+}
+
+// newStreamRemote returns a remote that sends length prefixed frames over a
+// TCP session
+func newStreamRemote(conn net.Conn, expires bool) *remote {
+	var mx sync.Mutex
+	var once sync.Once
+	return &remote{
+		addr: conn.RemoteAddr(),
+		write: func(frame []byte) (err error) {
+			b := make([]byte, streamLenSize+len(frame))
+			binary.BigEndian.PutUint32(b, uint32(len(frame)))
+			copy(b[streamLenSize:], frame)
+			mx.Lock()
+			defer mx.Unlock()
+			if err = conn.SetWriteDeadline(time.Now().
+				Add(SubscribeInterval)); err != nil {
+				return
+			}
+			_, err = conn.Write(b)
+			return
+		},
+		close: func() (err error) {
+			once.Do(func() {
+				err = conn.Close()
+			})
+			return
+		},
+		expires: expires,
+		done:    make(chan struct{}),
+	}
+}
+
+// AcceptRemote lets workers outside of the multicast lan subscribe to the
+// messages sent on the connection, by unicast UDP to the listeners of the
+// connection or over TCP on the given address if it is not empty. Frames
+// received from them go to the handlers passed to Listen, the same as those
+// received from the lan. It must be called before Listen.
+func (c *Connection) AcceptRemote(tcpAddress string) (err error) {
+	c.mx.Lock()
+	c.acceptRemote = true
+	c.mx.Unlock()
+	if tcpAddress == "" {
+		return
+	}
+	var l net.Listener
+	if l, err = net.Listen("tcp", tcpAddress); err != nil {
+		log.ERROR(err)
+		return
+	}
+	log.INFO("accepting remote kopach workers on", l.Addr())
+	c.mx.Lock()
+	c.tcpListeners = append(c.tcpListeners, l)
+	c.mx.Unlock()
+	go func() {
+		<-c.ctx.Done()
+		if err := l.Close(); err != nil {
+			log.ERROR(err)
+		}
+	}()
+	go c.accept(l)
+	return
+}
+
+// accept starts reading from the TCP sessions opened with the listener until
+// it is closed
+func (c *Connection) accept(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				log.ERROR(err)
+				continue
+			}
+			if c.ctx.Err() == nil {
+				log.ERROR(err)
+			}
+			return
+		}
+		// every session may have a frame of up to maxDatagramSize being
+		// read, so only so many are kept open
+		c.mx.Lock()
+		full := c.streams >= maxSessions
+		if !full {
+			c.streams++
+		}
+		c.mx.Unlock()
+		if full {
+			log.WARN("too many remote sessions, closing session from",
+				conn.RemoteAddr())
+			if err := conn.Close(); err != nil {
+				log.TRACE(err)
+			}
+			continue
+		}
+		log.DEBUG("remote session opened from", conn.RemoteAddr())
+		// the remote is only added once it has sent an authentic
+		// subscription, see subscribe
+		go func() {
+			c.readStream(conn, newStreamRemote(conn, true))
+			c.mx.Lock()
+			c.streams--
+			c.mx.Unlock()
+		}()
+	}
+}
+
+// readStream passes the frames received over a TCP session to the handler
+// goroutine until the session fails or the context is cancelled
+func (c *Connection) readStream(conn net.Conn, r *remote) {
+	defer func() {
+		c.dropRemote(r)
+		close(r.done)
+	}()
+	go func() {
+		select {
+		case <-c.ctx.Done():
+			if err := r.close(); err != nil {
+				log.TRACE(err)
+			}
+		case <-r.done:
+		}
+	}()
+	header := make([]byte, streamLenSize)
+	for {
+		// sessions that stop sending, or never authenticate, are dropped
+		err := conn.SetReadDeadline(time.Now().Add(SubscriptionTimeout))
+		if err != nil {
+			log.ERROR(err)
+			return
+		}
+		if _, err = io.ReadFull(conn, header); err != nil {
+			if c.ctx.Err() == nil {
+				log.DEBUG("remote session with", r.addr, "ended:", err)
+			}
+			return
+		}
+		n := binary.BigEndian.Uint32(header)
+		if n < magicLen || n > uint32(c.maxDatagramSize) {
+			log.WARN("invalid frame length", n, "from", r.addr)
+			return
+		}
+		data := make([]byte, n)
+		if _, err = io.ReadFull(conn, data); err != nil {
+			log.DEBUG("remote session with", r.addr, "ended:", err)
+			return
+		}
+		select {
+		case c.packets <- packet{data: data, src: r.addr, stream: r}:
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// subscribe records the sender of an authentic subscription so the messages
+// sent on the connection are also sent to it
+func (c *Connection) subscribe(p packet) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	if !c.acceptRemote {
+		log.TRACE("ignoring subscription from", p.src)
+		return
+	}
+	key := remoteKey(p.src)
+	if r, ok := c.remotes[key]; ok {
+		r.lastSeen = time.Now()
+		return
+	}
+	if len(c.remotes) >= maxSessions {
+		log.WARN("too many remote workers, ignoring subscription from", key)
+		return
+	}
+	r := p.stream
+	if r == nil {
+		conn, src := p.conn, p.src
+		r = &remote{
+			addr: src,
+			write: func(frame []byte) (err error) {
+				_, err = conn.WriteTo(frame, src)
+				return
+			},
+			expires: true,
+		}
+	}
+	r.lastSeen = time.Now()
+	c.addRemote(r)
+	log.INFO("remote kopach worker subscribed from", key)
+}
+
+// addRemote adds a remote to the connection and starts the goroutine sending
+// to it. It must be called with the lock held.
+func (c *Connection) addRemote(r *remote) {
+	r.queue = make(chan [][]byte, remoteQueueLen)
+	r.stop = make(chan struct{})
+	c.remotes[remoteKey(r.addr)] = r
+	go c.sendRemote(r, r.queue, r.stop)
+}
+
+// sendRemote sends the messages queued for a remote until it is dropped or
+// the context is cancelled, so a slow remote only holds up itself
+func (c *Connection) sendRemote(r *remote, queue chan [][]byte,
+	stop chan struct{}) {
+	for {
+		var shards [][]byte
+		select {
+		case shards = <-queue:
+		case <-stop:
+			return
+		case <-c.ctx.Done():
+			return
+		}
+	send:
+		for i := range shards {
+			for _, frame := range c.seal(shards[i]) {
+				if err := r.write(frame); err != nil {
+					log.ERROR("sending to", remoteKey(r.addr), err)
+					// a UDP remote may only be unreachable for a moment,
+					// its subscription expires if it is gone
+					if r.close != nil {
+						c.dropRemote(r)
+						return
+					}
+					break send
+				}
+			}
+		}
+	}
+}
+
+// dropRemote stops sending to a remote and closes its session
+func (c *Connection) dropRemote(r *remote) {
+	key := remoteKey(r.addr)
+	c.mx.Lock()
+	if c.remotes[key] == r {
+		delete(c.remotes, key)
+		close(r.stop)
+	}
+	c.mx.Unlock()
+	if r.close != nil {
+		if err := r.close(); err != nil {
+			log.TRACE(err)
+		}
+	}
+}
+
+// sendRemotes queues the shards to be sent to every remote, dropping those
+// that have not renewed their subscription, and skipping those that are too
+// far behind to take another message
+func (c *Connection) sendRemotes(shards [][]byte) {
+	now := time.Now()
+	var expired []*remote
+	c.mx.Lock()
+	for _, r := range c.remotes {
+		if r.expires && now.Sub(r.lastSeen) > SubscriptionTimeout {
+			expired = append(expired, r)
+			continue
+		}
+		// the queue is replaced if the remote subscribes again after being
+		// dropped, so it is only used with the lock held
+		select {
+		case r.queue <- shards:
+		default:
+			log.WARN("remote", remoteKey(r.addr),
+				"is falling behind, dropping message")
+		}
+	}
+	c.mx.Unlock()
+	for _, r := range expired {
+		log.INFO("subscription of remote kopach worker", remoteKey(r.addr),
+			"expired")
+		c.dropRemote(r)
+	}
+}
+
+// sendSubscription sends a subscription to the remotes
+func (c *Connection) sendSubscription() {
+	// a subscription carries no message so it is not split into shards
+	shard := make([]byte, shardOffset)
+	copy(shard, SubscribeMagic)
+	if _, err := io.ReadFull(rand.Reader, shard[magicLen:]); err != nil {
+		log.ERROR(err)
+		return
+	}
+	c.sendRemotes([][]byte{shard})
+}
+
+// Dial connects to a controller outside of the multicast lan at an address in
+// the form tcp://host:port or udp://host:port, TCP being used if no scheme is
+// given, and subscribes to the messages it sends. Send and SendShards on the
+// returned connection go to the controller over the same session, the
+// subscription is renewed and TCP sessions are dialed again if they fail,
+// until the context is cancelled.
+func Dial(address, preSharedKey string, maxDatagramSize int,
+	ctx context.Context, compat bool) (c *Connection, err error) {
+	network := "tcp"
+	if i := strings.Index(address, "://"); i >= 0 {
+		network, address = address[:i], address[i+3:]
+	}
+	if network != "tcp" && network != "udp" {
+		err = errors.New("cannot dial controller with network " + network +
+			", it must be tcp or udp")
+		log.ERROR(err)
+		return
+	}
+	if c, err = NewConnection(nil, nil, preSharedKey, maxDatagramSize, ctx,
+		nil, compat); err != nil {
+		return
+	}
+	var r *remote
+	if r, err = c.dial(network, address); err != nil {
+		return
+	}
+	if network == "tcp" {
+		go c.redial(network, address, r)
+	}
+	go func() {
+		ticker := time.NewTicker(SubscribeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.sendSubscription()
+			case <-c.ctx.Done():
+				return
+			}
+		}
+	}()
+	return
+}
+
+// dial opens a session with the controller and subscribes to it
+func (c *Connection) dial(network, address string) (r *remote, err error) {
+	d := net.Dialer{Timeout: SubscribeInterval}
+	var conn net.Conn
+	if conn, err = d.DialContext(c.ctx, network, address); err != nil {
+		log.ERROR(err)
+		return
+	}
+	if network == "tcp" {
+		r = newStreamRemote(conn, false)
+		go c.readStream(conn, r)
+	} else {
+		uc := conn.(*net.UDPConn)
+		r = &remote{
+			addr: uc.RemoteAddr(),
+			write: func(frame []byte) (err error) {
+				_, err = uc.Write(frame)
+				return
+			},
+		}
+	}
+	c.mx.Lock()
+	if uc, ok := conn.(*net.UDPConn); ok {
+		// the controller answers on the same socket
+		c.listenConns = append(c.listenConns, uc)
+	}
+	c.addRemote(r)
+	c.mx.Unlock()
+	log.INFO("connected to controller at", network+"://"+address)
+	c.sendSubscription()
+	return
+}
+
+// redial opens a new TCP session with the controller whenever the previous
+// one ended, until the context is cancelled
+func (c *Connection) redial(network, address string, r *remote) {
+	for {
+		if r != nil {
+			select {
+			case <-r.done:
+				log.WARN("lost connection to controller at", address)
+			case <-c.ctx.Done():
+				return
+			}
+		}
+		select {
+		case <-time.After(SubscribeInterval):
+		case <-c.ctx.Done():
+			return
+		}
+		// errors are logged by dial, it is tried again after the interval
+		r, _ = c.dial(network, address)
+	}
+}
+
+// LocalAddrs returns the addresses the connection is listening on
+func (c *Connection) LocalAddrs() (addrs []net.Addr) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	for i := range c.listenConns {
+		addrs = append(addrs, c.listenConns[i].LocalAddr())
+	}
+	for i := range c.tcpListeners {
+		addrs = append(addrs, c.tcpListeners[i].Addr())
+	}
+	return
+}
@@ -0,0 +1,179 @@
+package transport
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+var (
+	testWorkMagic     = []byte("work")
+	testSolutionMagic = []byte("solv")
+)
+
+// testHandlers returns handlers that pass the messages with the magic on to
+// the channel
+func testHandlers(magic []byte, received chan<- []byte) HandleFunc {
+	return HandleFunc{
+		string(magic): func(ctx interface{},
+			src net.Addr) func(b []byte) (err error) {
+			return func(b []byte) (err error) {
+				received <- b
+				return
+			}
+		},
+	}
+}
+
+func testSession(t *testing.T, network string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrl, err := NewConnection(nil, []string{"127.0.0.1:0"}, testPass, 1024,
+		ctx, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ctrl.AcceptRemote("127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	var address string
+	for _, addr := range ctrl.LocalAddrs() {
+		if addr.Network() == network {
+			address = addr.String()
+		}
+	}
+	if address == "" {
+		t.Fatal("controller is not listening on", network)
+	}
+	solutions := make(chan []byte, 1)
+	if err = ctrl.Listen(testHandlers(testSolutionMagic, solutions), nil,
+		nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	worker, err := Dial(network+"://"+address, testPass, 1024, ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobs := make(chan []byte, 1)
+	if err = worker.Listen(testHandlers(testWorkMagic, jobs), nil, nil,
+		nil); err != nil {
+		t.Fatal(err)
+	}
+	// work is sent until the subscription has arrived
+	timeout := time.After(time.Second * 5)
+	ticker := time.NewTicker(time.Millisecond * 50)
+	defer ticker.Stop()
+out:
+	for {
+		select {
+		case b := <-jobs:
+			if string(b) != "some work" {
+				t.Fatalf("received %q instead of the work", b)
+			}
+			break out
+		case <-ticker.C:
+			if err = ctrl.Send([]byte("some work"), testWorkMagic); err != nil {
+				t.Fatal(err)
+			}
+		case <-timeout:
+			t.Fatal("work was not received over", network)
+		}
+	}
+	if err = worker.Send([]byte("a solution"), testSolutionMagic); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case b := <-solutions:
+		if string(b) != "a solution" {
+			t.Fatalf("received %q instead of the solution", b)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("solution was not received over", network)
+	}
+}
+
+func TestSessionTCP(t *testing.T) {
+	testSession(t, "tcp")
+}
+
+func TestSessionUDP(t *testing.T) {
+	testSession(t, "udp")
+}
+
+func TestSubscriptionNotAccepted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrl, err := NewConnection(nil, []string{"127.0.0.1:0"}, testPass, 1024,
+		ctx, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := net.DialUDP("udp", nil,
+		ctrl.LocalAddrs()[0].(*net.UDPAddr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctrl.subscribe(packet{src: conn.LocalAddr(), conn: ctrl.listenConns[0]})
+	if len(ctrl.remotes) != 0 {
+		t.Error("subscription accepted without AcceptRemote")
+	}
+	if err = ctrl.AcceptRemote(""); err != nil {
+		t.Fatal(err)
+	}
+	ctrl.subscribe(packet{src: conn.LocalAddr(), conn: ctrl.listenConns[0]})
+	if len(ctrl.remotes) != 1 {
+		t.Error("subscription not accepted with AcceptRemote")
+	}
+}
+
+func TestSlowRemote(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, err := NewConnection(nil, nil, testPass, 1024, ctx, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocked := make(chan struct{})
+	defer close(blocked)
+	received := make(chan []byte, remoteQueueLen*2)
+	c.mx.Lock()
+	c.addRemote(&remote{
+		addr: &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1},
+		write: func(frame []byte) (err error) {
+			<-blocked
+			return
+		},
+	})
+	c.addRemote(&remote{
+		addr: &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 2},
+		write: func(frame []byte) (err error) {
+			received <- frame
+			return
+		},
+	})
+	c.mx.Unlock()
+	shards, err := c.CreateShards([]byte("some work"), testWorkMagic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a remote that doesn't take its frames must not hold up the sender or
+	// the other remotes
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < remoteQueueLen*2; i++ {
+			c.sendRemotes(shards[:1])
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("sending is held up by a slow remote")
+	}
+	select {
+	case <-received:
+	case <-time.After(time.Second * 5):
+		t.Fatal("nothing was sent to the remote that keeps up")
+	}
+}
@@ -9,18 +9,20 @@ import (
 	"io"
 	"net"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
-	"golang.org/x/net/ipv4"
-
 	"github.com/p9c/pod/pkg/fec"
 	"github.com/p9c/pod/pkg/gcm"
 	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/routeable"
 )
 
-type HandleFunc map[string]func(ctx interface{}) func(b []byte) (err error)
+// HandleFunc is the handlers of the messages received on a connection by
+// their magic, each is given the context passed to Listen and the address the
+// first shard of the message came from
+type HandleFunc map[string]func(ctx interface{},
+	src net.Addr) func(b []byte) (err error)
 
 type MsgBuffer struct {
 	Buffers [][]byte
@@ -33,109 +35,131 @@ type MsgBuffer struct {
 // reliable UDP lan transport, encrypted by a GCM AES cipher,
 // with the simple protocol of sending out 9 packets containing encrypted FEC
 // shards containing a slice of bytes.
+// Every packet is a versioned frame carrying a sequence number and timestamp
+// that Listen checks to reject replayed packets, see frame.go.
 // This protocol probably won't work well outside of a multicast lan in
-// adverse conditions but it is designed for local network control systems
+// adverse conditions but it is designed for local network control systems,
+// for workers on other networks see AcceptRemote and Dial in session.go.
 type Connection struct {
+	// sequence is used atomically and must stay first for alignment
+	sequence        uint64
 	maxDatagramSize int
 	buffers         map[string]*MsgBuffer
-	sendAddress     *net.UDPAddr
 	SendConn        []*net.UDPConn
-	listenAddress   *net.UDPAddr
-	listenConn      net.PacketConn
+	listenConns     []net.PacketConn
+	tcpListeners    []net.Listener
+	packets         chan packet
+	remotes         map[string]*remote
+	acceptRemote    bool
+	masterKey       []byte
+	session         [sessionLen]byte
 	ciph            cipher.AEAD
+	legacy          cipher.AEAD
+	peers           map[[sessionLen]byte]*peerSession
 	ctx             context.Context
 	mx              *sync.Mutex
+	// streams is the number of accepted TCP sessions that are open, they
+	// are limited to maxSessions including those not yet authenticated
+	streams int
 }
 
-// NewConnection creates a new connection with a defined default send
-// connection and listener and pre shared key password for encryption on the
-// local network
-func NewConnection(send, listen, preSharedKey string, maxDatagramSize int, ctx context.Context, multicast bool) (c *Connection, err error) {
-	var sendAddr *net.UDPAddr
-	sendConn := []*net.UDPConn{}
-	var sC *net.UDPConn
-	var listenAddr *net.UDPAddr
-	var listenConn net.PacketConn
-	var mcInterface net.Interface
-	var ifi []net.Interface
-	ifi, err = net.Interfaces()
-	if err != nil {
-		log.ERROR(err)
+// NewConnection creates a new connection with a defined set of default send
+// addresses and listeners and pre shared key password for encryption on the
+// local network. Multicast addresses of either family are joined and sent to
+// on every one of the interfaces that has an address in that family, if no
+// interfaces are given all of the multicast capable interfaces are used.
+// In compatibility mode every shard is also sent in the legacy frame format
+// and legacy frames are accepted, so kopach workers that have not been
+// upgraded yet keep working while they are migrated.
+func NewConnection(send, listen []string, preSharedKey string,
+	maxDatagramSize int, ctx context.Context, interfaces []*net.Interface,
+	compat bool) (c *Connection, err error) {
+	if len(interfaces) < 1 {
+		interfaces = routeable.GetMulticastInterfaces()
 	}
-	for i := range ifi {
-		ad, _ := ifi[i].Addrs()
-		if ifi[i].Flags&net.FlagMulticast != 0 &&
-			ifi[i].HardwareAddr != nil &&
-			ad != nil {
-			mcInterface = ifi[i]
-			break
+	var listenConns []net.PacketConn
+	for i := range listen {
+		addr := GetUDPAddr(listen[i])
+		if addr == nil {
+			err = errors.New("invalid listen address " + listen[i])
+			log.ERROR(err)
+			return
 		}
-	}
-	if listen != "" {
-		if multicast {
-			var conn net.PacketConn
-			conn, err = net.ListenPacket("udp", listen)
-			if err != nil {
-				log.ERROR(err)
-			}
-			pc := ipv4.NewPacketConn(conn)
-			err = pc.JoinGroup(&mcInterface, &net.UDPAddr{IP: net.IPv4(
-				224, 0, 0, 1)})
-			if err != nil {
-				log.ERROR(err)
-				err = conn.Close()
-				if err != nil {
-					log.ERROR(err)
-					return
-				}
-			}
-			listenConn = conn
-		} else {
-			listenAddr = GetUDPAddr(listen)
-			listenConn, err = net.ListenUDP("udp", listenAddr)
-			if err != nil {
-				log.ERROR(err)
-				return
+		var conn net.PacketConn
+		if addr.IP.IsMulticast() {
+			// a family may not be available on any of the interfaces, that
+			// is only a problem if no group can be joined at all
+			if conn, err = listenMulticast(addr, interfaces); err != nil {
+				continue
 			}
-			log.SPEW(listenConn)
+		} else if conn, err = net.ListenUDP("udp", addr); err != nil {
+			log.ERROR(err)
+			return
 		}
+		listenConns = append(listenConns, conn)
 	}
-	if send != "" {
-		mI, _ := mcInterface.Addrs()
-		log.SPEW(mI)
-		var listenWithoutEveryInterface string
-		for i := range mI {
-			_ = mI[i]
-			log.DEBUG("ADDRESSS", mI[i])
-			a := strings.Split(mI[i].String(), "/")[0]
-			if strings.Count(a, ":") == 0 {
-				listenWithoutEveryInterface = net.JoinHostPort(a, "0")
-			}
-		}
-		log.DEBUG(listenWithoutEveryInterface)
-		var laddr *net.UDPAddr
-		laddr, err = net.ResolveUDPAddr("udp", listenWithoutEveryInterface)
-		if err != nil {
+	if len(listen) > 0 && len(listenConns) < 1 {
+		err = errors.New("could not listen on any of the addresses")
+		log.ERROR(err)
+		return
+	}
+	var sendConns []*net.UDPConn
+	for i := range send {
+		addr := GetUDPAddr(send[i])
+		if addr == nil {
+			err = errors.New("invalid send address " + send[i])
 			log.ERROR(err)
 			return
 		}
-		sendAddr = GetUDPAddr(send)
-		sC, err = net.DialUDP("udp", laddr, sendAddr)
+		if addr.IP.IsMulticast() {
+			var conns []*net.UDPConn
+			if conns, err = dialMulticast(addr, interfaces); err == nil {
+				sendConns = append(sendConns, conns...)
+			}
+			continue
+		}
+		var sC *net.UDPConn
+		sC, err = net.DialUDP("udp", nil, addr)
 		if err != nil {
 			log.ERROR(err)
 			return
 		}
-		sendConn = append(sendConn, sC)
+		sendConns = append(sendConns, sC)
+	}
+	if len(send) > 0 && len(sendConns) < 1 {
+		err = errors.New("could not send to any of the addresses")
+		log.ERROR(err)
+		return
+	}
+	err = nil
+	var session [sessionLen]byte
+	if _, err = io.ReadFull(rand.Reader, session[:]); err != nil {
+		log.ERROR(err)
+		return
+	}
+	masterKey := gcm.DeriveKey(preSharedKey)
+	var ciph, legacy cipher.AEAD
+	if ciph, err = gcm.GetSessionCipher(masterKey, session[:]); err != nil {
+		log.ERROR(err)
+		return
+	}
+	if compat {
+		log.WARN("kopach transport compatibility mode enabled, legacy" +
+			" frames are not protected against replay")
+		legacy = gcm.GetCipher(preSharedKey)
 	}
-	ciph := gcm.GetCipher(preSharedKey)
 	return &Connection{
 		maxDatagramSize: maxDatagramSize,
 		buffers:         make(map[string]*MsgBuffer),
-		sendAddress:     sendAddr,
-		SendConn:        sendConn,
-		listenAddress:   listenAddr,
-		listenConn:      listenConn,
-		ciph:            ciph, // gcm.GetCipher(*cx.Config.MinerPass),
+		SendConn:        sendConns,
+		listenConns:     listenConns,
+		packets:         make(chan packet),
+		remotes:         make(map[string]*remote),
+		masterKey:       masterKey,
+		session:         session,
+		ciph:            ciph,
+		legacy:          legacy,
+		peers:           make(map[[sessionLen]byte]*peerSession),
 		ctx:             ctx,
 		mx:              &sync.Mutex{},
 	}, err
@@ -155,36 +179,38 @@ func (c *Connection) SetSendConn(ad ...string) (err error) {
 	return
 }
 
+// CreateShards splits a message into FEC shards prefixed with the magic and a
+// random message ID. The shards are sealed when they are sent, so they can be
+// stored and sent again later without being rejected as replays.
 func (c *Connection) CreateShards(b, magic []byte) (shards [][]byte,
 	err error) {
-	magicLen := 4
-	// get a nonce for the packet, it is both message ID and salt
-	nonceLen := c.ciph.NonceSize()
-	nonce := make([]byte, nonceLen)
-	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+	// get a random message ID so receivers can tell the shards of the
+	// message apart from other messages
+	messageID := make([]byte, messageIDLen)
+	if _, err = io.ReadFull(rand.Reader, messageID); err != nil {
 		log.ERROR(err)
 		return
 	}
 	// generate the shards
 	shards, err = fec.Encode(b)
 	for i := range shards {
-		encryptedShard := c.ciph.Seal(nil, nonce, shards[i], nil)
-		shardLen := len(encryptedShard)
-		// assemble the packet: magic, nonce, and encrypted shard
-		outBytes := make([]byte, shardLen+magicLen+nonceLen)
+		// assemble the unsealed shard: magic, message ID and shard
+		outBytes := make([]byte, shardOffset+len(shards[i]))
 		copy(outBytes, magic[:magicLen])
-		copy(outBytes[magicLen:], nonce)
-		copy(outBytes[magicLen+nonceLen:], encryptedShard)
+		copy(outBytes[magicLen:], messageID)
+		copy(outBytes[shardOffset:], shards[i])
 		shards[i] = outBytes
 	}
 	return
 }
 
-func send(shards [][]byte, sendConn *net.UDPConn) (err error) {
+func (c *Connection) send(shards [][]byte, sendConn *net.UDPConn) (err error) {
 	for i := range shards {
-		_, err = sendConn.Write(shards[i])
-		if err != nil {
-			log.ERROR(err)
+		for _, frame := range c.seal(shards[i]) {
+			_, err = sendConn.Write(frame)
+			if err != nil {
+				log.ERROR(err)
+			}
 		}
 	}
 	return
@@ -199,11 +225,12 @@ func (c *Connection) Send(b, magic []byte) (err error) {
 	var shards [][]byte
 	shards, err = c.CreateShards(b, magic)
 	for _, sC := range c.SendConn {
-		err = send(shards, sC)
+		err = c.send(shards, sC)
 		if err != nil {
 			log.ERROR(err)
 		}
 	}
+	c.sendRemotes(shards)
 	return
 }
 
@@ -219,7 +246,7 @@ func (c *Connection) SendTo(addr *net.UDPAddr, b, magic []byte) (err error) {
 		return
 	}
 	shards, err := c.CreateShards(b, magic)
-	err = send(shards, sendConn)
+	err = c.send(shards, sendConn)
 	if err != nil {
 		log.ERROR(err)
 	}
@@ -228,11 +255,12 @@ func (c *Connection) SendTo(addr *net.UDPAddr, b, magic []byte) (err error) {
 
 func (c *Connection) SendShards(shards [][]byte) (err error) {
 	for _, sC := range c.SendConn {
-		err = send(shards, sC)
+		err = c.send(shards, sC)
 		if err != nil {
 			log.ERROR(err)
 		}
 	}
+	c.sendRemotes(shards)
 	return
 }
 
@@ -242,110 +270,186 @@ func (c *Connection) SendShardsTo(shards [][]byte, addr *net.UDPAddr) (err error
 		log.ERROR(err)
 		return
 	}
-	err = send(shards, sendConn)
+	err = c.send(shards, sendConn)
 	if err != nil {
 		log.ERROR(err)
 	}
 	return
 }
 
+// Listen starts reading from all of the listeners of the connection and
+// calls the handler for the magic of each message that is received. If
+// lastSent and firstSender are given, lastSent is updated every time an
+// authentic packet arrives so the caller can tell if the sender went away.
 func (c *Connection) Listen(handlers HandleFunc, ifc interface{},
 	lastSent *time.Time, firstSender *string) (err error) {
-	log.TRACE("setting read buffer")
-	//err = c.listenConn.SetReadBuffer(c.maxDatagramSize)
-	//if err != nil {
-	//	log.ERROR(err)
-	//	return
-	//}
-	buffer := make([]byte, c.maxDatagramSize)
+	c.mx.Lock()
+	remotes := len(c.remotes)
+	listenConns := append([]net.PacketConn(nil), c.listenConns...)
+	tcpListeners := len(c.tcpListeners)
+	c.mx.Unlock()
+	if len(listenConns) < 1 && tcpListeners < 1 && remotes < 1 {
+		err = errors.New("connection has no listeners")
+		log.ERROR(err)
+		return
+	}
+	for i := range listenConns {
+		go c.read(listenConns[i])
+	}
+	go func() {
+		<-c.ctx.Done()
+		for i := range listenConns {
+			if err := listenConns[i].Close(); err != nil {
+				log.ERROR(err)
+			}
+		}
+	}()
 	go func() {
 		log.TRACE("starting connection handler")
-	out:
-		// read from socket until context is cancelled
+		// handle packets until context is cancelled, this is the only
+		// goroutine that touches the message buffers
 		for {
-			n, src, err := c.listenConn.ReadFrom(buffer)
-			buf := buffer[:n]
-			if err != nil {
-				log.ERROR("ReadFromUDP failed:", err)
-				continue
-			}
-			log.DEBUG("received message on UDP connection")
-			log.SPEW(handlers)
-			magic := string(buf[:4])
-			if _, ok := handlers[magic]; ok {
-				// if caller needs to know the liveness status of the
-				// controller it is working on, the code below
-				if lastSent != nil && firstSender != nil {
-					//log.DEBUG("src", src.String(), "last", *firstSender)
-					*lastSent = time.Now()
-				}
-				//log.DEBUG("received packet with magic:", magic)
-				nonceBytes := buf[4:16]
-				nonce := string(nonceBytes)
-				// decipher
-				shard, err := c.ciph.Open(nil, nonceBytes,
-					buf[16:], nil)
-				if err != nil {
-					log.ERROR(err)
-					continue
-				}
-				if bn, ok := c.buffers[nonce]; ok {
-					//log.DEBUG("new shard for",
-					//	hex.EncodeToString([]byte(nonce)))
-					if !bn.Decoded {
-						bn.Buffers = append(bn.Buffers, shard)
-						if len(bn.Buffers) >= 3 {
-							// try to decode it
-							var cipherText []byte
-							//log.SPEW(bn.Buffers)
-							cipherText, err = fec.Decode(bn.Buffers)
-							if err != nil {
-								log.ERROR(err)
-								continue
-							}
-							//log.DEBUG("magic", magic, handlers[magic])
-							//log.SPEW(cipherText)
-							bn.Decoded = true
-							err = handlers[magic](ifc)(cipherText)
-							if err != nil {
-								log.ERROR(err)
-								continue
-							}
-							//log.DEBUG("called handler", magic)
-						}
-					} else {
-						for i := range c.buffers {
-							if i != nonce {
-								// superseded messages can be deleted from the
-								// buffers,
-								// we don't add more data for the already
-								// decoded.
-								log.TRACE("deleting superseded buffer",
-									hex.EncodeToString([]byte(i)))
-								delete(c.buffers, i)
-							}
-						}
-					}
-				} else {
-					log.TRACE("new message arriving",
-						hex.EncodeToString([]byte(nonce)))
-					c.buffers[nonce] = &MsgBuffer{[][]byte{},
-						time.Now(), false, &src}
-					c.buffers[nonce].Buffers = append(c.buffers[nonce].
-						Buffers, shard)
-				}
-			}
 			select {
+			case p := <-c.packets:
+				c.handle(handlers, ifc, lastSent, firstSender, p)
 			case <-c.ctx.Done():
-				break out
-			default:
+				return
 			}
 		}
 	}()
-
 	return
 }
 
+// packet is a frame received on one of the listeners or remote sessions,
+// with what is needed to send frames back to where it came from
+type packet struct {
+	data   []byte
+	src    net.Addr
+	conn   net.PacketConn
+	stream *remote
+}
+
+// read passes the packets received by a listener to the handler goroutine
+// until the context is cancelled
+func (c *Connection) read(conn net.PacketConn) {
+	buffer := make([]byte, c.maxDatagramSize)
+	for {
+		n, src, err := conn.ReadFrom(buffer)
+		if err != nil {
+			if c.ctx.Err() != nil {
+				return
+			}
+			log.ERROR("ReadFromUDP failed:", err)
+			continue
+		}
+		data := make([]byte, n)
+		copy(data, buffer[:n])
+		select {
+		case c.packets <- packet{data: data, src: src, conn: conn}:
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// handle authenticates a received packet, collects the shards of its
+// message and calls the handler for the message once it can be decoded
+func (c *Connection) handle(handlers HandleFunc, ifc interface{},
+	lastSent *time.Time, firstSender *string, p packet) {
+	log.DEBUG("received message on UDP connection")
+	buf, src := p.data, p.src
+	if len(buf) < magicLen {
+		return
+	}
+	magic := string(buf[:magicLen])
+	subscribe := magic == string(SubscribeMagic)
+	if _, ok := handlers[magic]; !ok && !subscribe {
+		return
+	}
+	//log.DEBUG("received packet with magic:", magic)
+	// authenticate, decipher and check for replay
+	messageID, shard, err := c.open(buf)
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	if subscribe {
+		c.subscribe(p)
+		return
+	}
+	if len(shard) < 1 {
+		return
+	}
+	// if caller needs to know the liveness status of the
+	// controller it is working on, the code below
+	if lastSent != nil && firstSender != nil {
+		//log.DEBUG("src", src.String(), "last", *firstSender)
+		*lastSent = time.Now()
+	}
+	bn, ok := c.buffers[messageID]
+	if !ok {
+		log.TRACE("new message arriving",
+			hex.EncodeToString([]byte(messageID)))
+		c.buffers[messageID] = &MsgBuffer{[][]byte{shard},
+			time.Now(), false, &src}
+		return
+	}
+	//log.DEBUG("new shard for",
+	//	hex.EncodeToString([]byte(messageID)))
+	if bn.Decoded {
+		for i := range c.buffers {
+			if i != messageID {
+				// superseded messages can be deleted from the
+				// buffers,
+				// we don't add more data for the already
+				// decoded.
+				log.TRACE("deleting superseded buffer",
+					hex.EncodeToString([]byte(i)))
+				delete(c.buffers, i)
+			}
+		}
+		return
+	}
+	// the same shard arrives more than once when it is sent in both frame
+	// formats or over several interfaces, and the decoder needs distinct
+	// shares
+	if hasShare(bn.Buffers, shard[0]) {
+		return
+	}
+	bn.Buffers = append(bn.Buffers, shard)
+	if len(bn.Buffers) < 3 {
+		return
+	}
+	// try to decode it
+	var cipherText []byte
+	//log.SPEW(bn.Buffers)
+	cipherText, err = fec.Decode(bn.Buffers)
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	//log.DEBUG("magic", magic, handlers[magic])
+	//log.SPEW(cipherText)
+	bn.Decoded = true
+	err = handlers[magic](ifc, *bn.Source)(cipherText)
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	//log.DEBUG("called handler", magic)
+}
+
+// hasShare returns whether one of the shards carries the given share number,
+// which is the first byte of a shard
+func hasShare(shards [][]byte, number byte) bool {
+	for _, shard := range shards {
+		if len(shard) > 0 && shard[0] == number {
+			return true
+		}
+	}
+	return false
+}
+
 func GetUDPAddr(address string) (sendAddr *net.UDPAddr) {
 	sendHost, sendPort, err := net.SplitHostPort(address)
 	if err != nil {
@@ -0,0 +1,70 @@
+package transport
+
+import (
+	"net"
+	"testing"
+)
+
+func TestHasShare(t *testing.T) {
+	shards := [][]byte{{0, 1, 2}, {4, 5, 6}}
+	for _, test := range []struct {
+		number byte
+		want   bool
+	}{
+		{0, true},
+		{4, true},
+		{1, false},
+		{8, false},
+	} {
+		if got := hasShare(shards, test.number); got != test.want {
+			t.Errorf("share %d: got %v, want %v", test.number, got,
+				test.want)
+		}
+	}
+	if hasShare(nil, 0) || hasShare([][]byte{{}}, 0) {
+		t.Error("share found where there are no shares")
+	}
+}
+
+func TestHandleRepeatedShards(t *testing.T) {
+	sender := newTestConnection(t, false)
+	receiver := newTestConnection(t, false)
+	shards, err := sender.CreateShards([]byte("some work"), []byte("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded [][]byte
+	handlers := HandleFunc{
+		"test": func(ctx interface{},
+			src net.Addr) func(b []byte) (err error) {
+			return func(b []byte) (err error) {
+				decoded = append(decoded, b)
+				return
+			}
+		},
+	}
+	src := &net.UDPAddr{IP: net.IPv4(192, 168, 0, 2), Port: 11049}
+	receive := func(shard []byte) {
+		for _, frame := range sender.seal(shard) {
+			receiver.handle(handlers, nil, nil, nil,
+				packet{data: frame, src: src})
+		}
+	}
+	// the first shard arriving on three interfaces is still one share
+	for i := 0; i < 3; i++ {
+		receive(shards[0])
+	}
+	if len(decoded) != 0 {
+		t.Fatal("message decoded from copies of a single shard")
+	}
+	receive(shards[1])
+	receive(shards[1])
+	if len(decoded) != 0 {
+		t.Fatal("message decoded from copies of two shards")
+	}
+	receive(shards[2])
+	if len(decoded) != 1 {
+		t.Fatalf("message decoded %d times from three shards, want once",
+			len(decoded))
+	}
+}
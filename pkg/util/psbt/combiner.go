@@ -0,0 +1,118 @@
+package psbt
+
+import (
+	"bytes"
+)
+
+// Combine merges PSBTs of the same transaction into one with all of the information in any of them, such as when each has been signed by a different party.  This is the Combiner role of BIP 174.  Where two packets have different values for the same key the first one is kept.  The packets passed in are not changed.
+func Combine(packets ...*Packet) (*Packet, error) {
+	if len(packets) == 0 {
+		return nil, ErrInvalidPsbtFormat
+	}
+	// the first packet is copied by serializing it
+	var buf bytes.Buffer
+	if err := packets[0].Serialize(&buf); err != nil {
+		return nil, err
+	}
+	c, err := NewFromRawBytes(&buf, false)
+	if err != nil {
+		return nil, err
+	}
+	txHash := c.UnsignedTx.TxHash()
+	for _, p := range packets[1:] {
+		if p.UnsignedTx.TxHash() != txHash {
+			return nil, ErrDifferentTransactions
+		}
+		c.Unknowns = combineUnknowns(c.Unknowns, p.Unknowns)
+		for i := range c.Inputs {
+			c.Inputs[i].combine(&p.Inputs[i])
+		}
+		for i := range c.Outputs {
+			c.Outputs[i].combine(&p.Outputs[i])
+		}
+	}
+	return c, nil
+}
+
+// combine adds what is in another section for the same input that this one doesn't have.  Once either is finalized the final scripts are all that is kept.
+func (pi *PInput) combine(o *PInput) {
+	if pi.NonWitnessUtxo == nil {
+		pi.NonWitnessUtxo = o.NonWitnessUtxo
+	}
+	if pi.WitnessUtxo == nil {
+		pi.WitnessUtxo = o.WitnessUtxo
+	}
+	pi.Unknowns = combineUnknowns(pi.Unknowns, o.Unknowns)
+	if pi.isFinalized() {
+		return
+	}
+	if o.isFinalized() {
+		pi.FinalScriptSig = o.FinalScriptSig
+		pi.FinalScriptWitness = o.FinalScriptWitness
+		pi.PartialSigs, pi.SighashType = nil, 0
+		pi.RedeemScript, pi.WitnessScript = nil, nil
+		pi.Bip32Derivation = nil
+		return
+	}
+next:
+	for _, s := range o.PartialSigs {
+		for _, have := range pi.PartialSigs {
+			if bytes.Equal(have.PubKey, s.PubKey) {
+				continue next
+			}
+		}
+		pi.PartialSigs = append(pi.PartialSigs, s)
+	}
+	if pi.SighashType == 0 {
+		pi.SighashType = o.SighashType
+	}
+	if pi.RedeemScript == nil {
+		pi.RedeemScript = o.RedeemScript
+	}
+	if pi.WitnessScript == nil {
+		pi.WitnessScript = o.WitnessScript
+	}
+	pi.Bip32Derivation = combineDerivations(pi.Bip32Derivation,
+		o.Bip32Derivation)
+}
+
+// combine adds what is in another section for the same output that this one doesn't have.
+func (po *POutput) combine(o *POutput) {
+	if po.RedeemScript == nil {
+		po.RedeemScript = o.RedeemScript
+	}
+	if po.WitnessScript == nil {
+		po.WitnessScript = o.WitnessScript
+	}
+	po.Bip32Derivation = combineDerivations(po.Bip32Derivation,
+		o.Bip32Derivation)
+	po.Unknowns = combineUnknowns(po.Unknowns, o.Unknowns)
+}
+
+// combineDerivations adds the derivations for public keys that aren't already in a section.
+func combineDerivations(have, add []*Bip32Derivation) []*Bip32Derivation {
+next:
+	for _, d := range add {
+		for _, h := range have {
+			if bytes.Equal(h.PubKey, d.PubKey) {
+				continue next
+			}
+		}
+		have = append(have, d)
+	}
+	return have
+}
+
+// combineUnknowns adds the unknown keys that aren't already in a section.
+func combineUnknowns(have, add []*Unknown) []*Unknown {
+next:
+	for _, u := range add {
+		for _, h := range have {
+			if bytes.Equal(h.Key, u.Key) {
+				continue next
+			}
+		}
+		have = append(have, u)
+	}
+	return have
+}
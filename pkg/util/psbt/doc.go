@@ -0,0 +1,8 @@
+/*
+Package psbt is an implementation of Partially Signed Bitcoin Transactions (PSBT) as specified in BIP 174.
+
+A PSBT carries an unsigned transaction along with what each party needs to sign its inputs, the outputs being spent, redeem scripts and key derivation paths, so transactions that need several signers such as multisig spends can be passed between them, signed in any order, combined and finally turned into a network transaction. More info: https://github.com/bitcoin/bips/blob/master/bip-0174.mediawiki
+
+The package covers the roles of the BIP: New and NewFromUnsignedTx are the Creator, the Updater adds input and output data and signatures, Combine merges packets signed separately, Finalize builds the final signature scripts and Extract returns the signed transaction. Finalizing is supported for pay to public key hash, bare and pay to script hash multisig and their segregated witness forms.
+*/
+package psbt
@@ -0,0 +1,25 @@
+package psbt
+
+import (
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// Extract returns the signed transaction of a PSBT with all of its inputs finalized.  This is the Transaction Extractor role of BIP 174.
+func Extract(p *Packet) (*wire.MsgTx, error) {
+	if !p.IsComplete() {
+		return nil, ErrIncompletePSBT
+	}
+	tx := p.UnsignedTx.Copy()
+	for i, in := range tx.TxIn {
+		pi := &p.Inputs[i]
+		in.SignatureScript = pi.FinalScriptSig
+		if pi.FinalScriptWitness != nil {
+			witness, err := readTxWitness(pi.FinalScriptWitness)
+			if err != nil {
+				return nil, err
+			}
+			in.Witness = witness
+		}
+	}
+	return tx, nil
+}
@@ -0,0 +1,220 @@
+package psbt
+
+import (
+	"bytes"
+	"sort"
+
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// MaybeFinalize finalizes an input if it has the signatures and scripts needed, returning whether it is finalized.  An input that can't be finalized yet is not an error.
+func MaybeFinalize(p *Packet, inIndex int) (bool, error) {
+	if p.Inputs[inIndex].isFinalized() {
+		return true, nil
+	}
+	err := Finalize(p, inIndex)
+	switch err {
+	case nil:
+		return true, nil
+	case ErrNotFinalizable:
+		return false, nil
+	}
+	return false, err
+}
+
+// MaybeFinalizeAll finalizes every input that can be finalized, returning whether all of them are.
+func MaybeFinalizeAll(p *Packet) (complete bool, err error) {
+	complete = true
+	for i := range p.Inputs {
+		var ok bool
+		if ok, err = MaybeFinalize(p, i); err != nil {
+			return false, err
+		}
+		complete = complete && ok
+	}
+	return
+}
+
+// Finalize builds the final signature script and witness of an input from its partial signatures and scripts and drops everything else but the output it spends.  This is the Input Finalizer role of BIP 174.
+func Finalize(p *Packet, inIndex int) (err error) {
+	in := &p.Inputs[inIndex]
+	if in.isFinalized() {
+		return ErrInputAlreadyFinalized
+	}
+	spent := p.SpentOutput(inIndex)
+	if spent == nil || len(in.PartialSigs) == 0 {
+		return ErrNotFinalizable
+	}
+	for _, ps := range in.PartialSigs {
+		if !checkSigHashFlags(ps.Signature, in) {
+			return ErrInvalidSigHashFlags
+		}
+	}
+	script := spent.PkScript
+	var sigScript []byte
+	var witness wire.TxWitness
+	if txscript.IsPayToScriptHash(script) {
+		if in.RedeemScript == nil {
+			return ErrNotFinalizable
+		}
+		script = in.RedeemScript
+	}
+	switch {
+	case txscript.IsPayToWitnessScriptHash(script):
+		if in.WitnessScript == nil {
+			return ErrNotFinalizable
+		}
+		var sigs [][]byte
+		if sigs, err = multiSigSigs(in.WitnessScript, in.PartialSigs); err != nil {
+			return
+		}
+		witness = append(wire.TxWitness{nil}, sigs...)
+		witness = append(witness, in.WitnessScript)
+	case txscript.IsPayToWitnessPubKeyHash(script):
+		if len(in.PartialSigs) != 1 {
+			return ErrNotFinalizable
+		}
+		witness = wire.TxWitness{in.PartialSigs[0].Signature,
+			in.PartialSigs[0].PubKey}
+	default:
+		if sigScript, err = nonWitnessSigScript(script,
+			in.PartialSigs); err != nil {
+			return
+		}
+	}
+	// a redeem script goes last in the signature script, for a nested
+	// witness program it is all there is in it
+	if in.RedeemScript != nil {
+		b := txscript.NewScriptBuilder()
+		if len(sigScript) > 0 {
+			b.AddOps(sigScript)
+		}
+		if sigScript, err = b.AddData(in.RedeemScript).Script(); err != nil {
+			return
+		}
+	}
+	final := PInput{
+		NonWitnessUtxo: in.NonWitnessUtxo,
+		WitnessUtxo:    in.WitnessUtxo,
+		FinalScriptSig: sigScript,
+	}
+	if witness != nil {
+		// only the witness output is needed to check a witness input
+		if final.WitnessUtxo != nil {
+			final.NonWitnessUtxo = nil
+		}
+		var buf bytes.Buffer
+		if err = writeTxWitness(&buf, witness); err != nil {
+			return
+		}
+		final.FinalScriptWitness = buf.Bytes()
+	}
+	*in = final
+	return p.SanityCheck()
+}
+
+// nonWitnessSigScript returns the signature script for a pay to public key, pay to public key hash or multisig script.
+func nonWitnessSigScript(script []byte, partialSigs []*PartialSig) ([]byte,
+	error) {
+	b := txscript.NewScriptBuilder()
+	switch txscript.GetScriptClass(script) {
+	case txscript.PubKeyTy:
+		if len(partialSigs) != 1 {
+			return nil, ErrNotFinalizable
+		}
+		b.AddData(partialSigs[0].Signature)
+	case txscript.PubKeyHashTy:
+		if len(partialSigs) != 1 {
+			return nil, ErrNotFinalizable
+		}
+		b.AddData(partialSigs[0].Signature).AddData(partialSigs[0].PubKey)
+	case txscript.MultiSigTy:
+		sigs, err := multiSigSigs(script, partialSigs)
+		if err != nil {
+			return nil, err
+		}
+		// OP_CHECKMULTISIG pops one more item than it needs
+		b.AddOp(txscript.OP_FALSE)
+		for _, sig := range sigs {
+			b.AddData(sig)
+		}
+	default:
+		return nil, ErrUnsupportedScriptType
+	}
+	return b.Script()
+}
+
+// multiSigSigs returns as many of the signatures as a multisig script requires in the order of their public keys in the script.
+func multiSigSigs(script []byte, partialSigs []*PartialSig) (sigs [][]byte,
+	err error) {
+	if txscript.GetScriptClass(script) != txscript.MultiSigTy {
+		return nil, ErrUnsupportedScriptType
+	}
+	_, nRequired, err := txscript.CalcMultiSigStats(script)
+	if err != nil {
+		return
+	}
+	if len(partialSigs) < nRequired {
+		return nil, ErrNotFinalizable
+	}
+	pushes, err := txscript.PushedData(script)
+	if err != nil {
+		return
+	}
+	position := make(map[string]int)
+	for i, push := range pushes {
+		position[string(push)] = i
+	}
+	ordered := make([]*PartialSig, 0, len(partialSigs))
+	for _, ps := range partialSigs {
+		if _, ok := position[string(ps.PubKey)]; !ok {
+			return nil, ErrInvalidSignatureForInput
+		}
+		ordered = append(ordered, ps)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return position[string(ordered[i].PubKey)] <
+			position[string(ordered[j].PubKey)]
+	})
+	for _, ps := range ordered[:nRequired] {
+		sigs = append(sigs, ps.Signature)
+	}
+	return
+}
+
+// writeTxWitness writes a witness stack as it is in a final script witness, the number of items followed by each of them.
+func writeTxWitness(w *bytes.Buffer, witness wire.TxWitness) (err error) {
+	if err = wire.WriteVarInt(w, 0, uint64(len(witness))); err != nil {
+		return
+	}
+	for _, item := range witness {
+		if err = wire.WriteVarBytes(w, 0, item); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// readTxWitness reads a witness stack written by writeTxWitness.
+func readTxWitness(b []byte) (witness wire.TxWitness, err error) {
+	r := bytes.NewReader(b)
+	count, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, ErrInvalidPsbtFormat
+	}
+	if count > uint64(len(b)) {
+		return nil, ErrInvalidPsbtFormat
+	}
+	witness = make(wire.TxWitness, count)
+	for i := range witness {
+		if witness[i], err = wire.ReadVarBytes(r, 0, txscript.MaxScriptSize,
+			"witness item"); err != nil {
+			return nil, ErrInvalidPsbtFormat
+		}
+	}
+	if r.Len() != 0 {
+		return nil, ErrInvalidPsbtFormat
+	}
+	return
+}
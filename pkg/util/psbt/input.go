@@ -0,0 +1,331 @@
+package psbt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sort"
+
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/log"
+	ec "github.com/p9c/pod/pkg/util/elliptic"
+)
+
+// PartialSig is a signature for an input with the public key it was made with, kept as bytes as they are serialized.
+type PartialSig struct {
+	PubKey    []byte
+	Signature []byte
+}
+
+// checkValid returns whether the public key and signature can be parsed, the signature is not checked against the transaction.
+func (ps *PartialSig) checkValid() bool {
+	if len(ps.Signature) < 1 || !validatePubKey(ps.PubKey) {
+		return false
+	}
+	// the signature hash type is appended to the signature
+	_, err := ec.ParseDERSignature(ps.Signature[:len(ps.Signature)-1],
+		ec.S256())
+	return err == nil
+}
+
+// Bip32Derivation is the derivation path of a public key from a master key given by its fingerprint.
+type Bip32Derivation struct {
+	PubKey               []byte
+	MasterKeyFingerprint uint32
+	Bip32Path            []uint32
+}
+
+// ReadBip32Derivation reads a master key fingerprint and derivation path serialized as 32 bit little endian integers.
+func ReadBip32Derivation(value []byte) (fingerprint uint32, path []uint32,
+	err error) {
+	if len(value) < 4 || len(value)%4 != 0 {
+		return 0, nil, ErrInvalidPsbtFormat
+	}
+	fingerprint = binary.LittleEndian.Uint32(value)
+	for i := 4; i < len(value); i += 4 {
+		path = append(path, binary.LittleEndian.Uint32(value[i:]))
+	}
+	return
+}
+
+// SerializeBIP32Derivation serializes a master key fingerprint and derivation path as 32 bit little endian integers.
+func SerializeBIP32Derivation(fingerprint uint32, path []uint32) []byte {
+	out := make([]byte, 4*(len(path)+1))
+	binary.LittleEndian.PutUint32(out, fingerprint)
+	for i, index := range path {
+		binary.LittleEndian.PutUint32(out[4*(i+1):], index)
+	}
+	return out
+}
+
+// validatePubKey returns whether the bytes are a serialized public key.
+func validatePubKey(pubKey []byte) bool {
+	_, err := ec.ParsePubKey(pubKey, ec.S256())
+	return err == nil
+}
+
+// PInput is the section of a PSBT for an input, with everything needed to sign and finalize it.
+type PInput struct {
+	NonWitnessUtxo     *wire.MsgTx
+	WitnessUtxo        *wire.TxOut
+	PartialSigs        []*PartialSig
+	SighashType        txscript.SigHashType
+	RedeemScript       []byte
+	WitnessScript      []byte
+	Bip32Derivation    []*Bip32Derivation
+	FinalScriptSig     []byte
+	FinalScriptWitness []byte
+	Unknowns           []*Unknown
+}
+
+// isFinalized returns whether the input has a final signature script or witness.
+func (pi *PInput) isFinalized() bool {
+	return pi.FinalScriptSig != nil || pi.FinalScriptWitness != nil
+}
+
+// deserialize reads the section of an input up to its separator.
+func (pi *PInput) deserialize(r io.Reader) error {
+	for {
+		keyType, keyData, err := getKey(r)
+		if err != nil {
+			return err
+		}
+		if keyType == -1 {
+			return nil
+		}
+		value, err := wire.ReadVarBytes(r, 0, MaxPsbtValueLength,
+			"PSBT value")
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		// all of the keys but the ones for public keys have no data
+		switch InputType(keyType) {
+		case PartialSigType, Bip32DerivationInputType:
+		default:
+			if InputType(keyType) <= FinalScriptWitnessType &&
+				keyData != nil {
+				return ErrInvalidKeyData
+			}
+		}
+		switch InputType(keyType) {
+		case NonWitnessUtxoType:
+			if pi.NonWitnessUtxo != nil {
+				return ErrDuplicateKey
+			}
+			tx := wire.NewMsgTx(wire.TxVersion)
+			if err = tx.Deserialize(bytes.NewReader(value)); err != nil {
+				log.ERROR(err)
+				return err
+			}
+			pi.NonWitnessUtxo = tx
+		case WitnessUtxoType:
+			if pi.WitnessUtxo != nil {
+				return ErrDuplicateKey
+			}
+			if pi.WitnessUtxo, err = readTxOut(value); err != nil {
+				return err
+			}
+		case PartialSigType:
+			sig := &PartialSig{PubKey: keyData, Signature: value}
+			if !sig.checkValid() {
+				return ErrInvalidPsbtFormat
+			}
+			for _, s := range pi.PartialSigs {
+				if bytes.Equal(s.PubKey, sig.PubKey) {
+					return ErrDuplicateKey
+				}
+			}
+			pi.PartialSigs = append(pi.PartialSigs, sig)
+		case SighashType:
+			if pi.SighashType != 0 {
+				return ErrDuplicateKey
+			}
+			if len(value) != 4 {
+				return ErrInvalidKeyData
+			}
+			pi.SighashType = txscript.SigHashType(
+				binary.LittleEndian.Uint32(value))
+		case RedeemScriptInputType:
+			if pi.RedeemScript != nil {
+				return ErrDuplicateKey
+			}
+			pi.RedeemScript = value
+		case WitnessScriptInputType:
+			if pi.WitnessScript != nil {
+				return ErrDuplicateKey
+			}
+			pi.WitnessScript = value
+		case Bip32DerivationInputType:
+			d, err := readDerivation(keyData, value, pi.Bip32Derivation)
+			if err != nil {
+				return err
+			}
+			pi.Bip32Derivation = append(pi.Bip32Derivation, d)
+		case FinalScriptSigType:
+			if pi.FinalScriptSig != nil {
+				return ErrDuplicateKey
+			}
+			pi.FinalScriptSig = value
+		case FinalScriptWitnessType:
+			if pi.FinalScriptWitness != nil {
+				return ErrDuplicateKey
+			}
+			pi.FinalScriptWitness = value
+		default:
+			if pi.Unknowns, err = addUnknown(pi.Unknowns, keyType, keyData,
+				value); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// serialize writes the section of an input without its separator, in order of key type.  Once an input is finalized only its final scripts and UTXO are written.
+func (pi *PInput) serialize(w io.Writer) (err error) {
+	if pi.NonWitnessUtxo != nil {
+		var buf bytes.Buffer
+		if err = pi.NonWitnessUtxo.Serialize(&buf); err != nil {
+			log.ERROR(err)
+			return
+		}
+		if err = serializeKVPairWithType(w, uint8(NonWitnessUtxoType), nil,
+			buf.Bytes()); err != nil {
+			return
+		}
+	}
+	if pi.WitnessUtxo != nil {
+		var buf bytes.Buffer
+		if err = wire.WriteTxOut(&buf, 0, 0, pi.WitnessUtxo); err != nil {
+			log.ERROR(err)
+			return
+		}
+		if err = serializeKVPairWithType(w, uint8(WitnessUtxoType), nil,
+			buf.Bytes()); err != nil {
+			return
+		}
+	}
+	if !pi.isFinalized() {
+		sort.Slice(pi.PartialSigs, func(i, j int) bool {
+			return bytes.Compare(pi.PartialSigs[i].PubKey,
+				pi.PartialSigs[j].PubKey) < 0
+		})
+		for _, ps := range pi.PartialSigs {
+			if err = serializeKVPairWithType(w, uint8(PartialSigType),
+				ps.PubKey, ps.Signature); err != nil {
+				return
+			}
+		}
+		if pi.SighashType != 0 {
+			var sht [4]byte
+			binary.LittleEndian.PutUint32(sht[:], uint32(pi.SighashType))
+			if err = serializeKVPairWithType(w, uint8(SighashType), nil,
+				sht[:]); err != nil {
+				return
+			}
+		}
+		if pi.RedeemScript != nil {
+			if err = serializeKVPairWithType(w, uint8(RedeemScriptInputType),
+				nil, pi.RedeemScript); err != nil {
+				return
+			}
+		}
+		if pi.WitnessScript != nil {
+			if err = serializeKVPairWithType(w,
+				uint8(WitnessScriptInputType), nil,
+				pi.WitnessScript); err != nil {
+				return
+			}
+		}
+		if err = serializeDerivations(w, uint8(Bip32DerivationInputType),
+			pi.Bip32Derivation); err != nil {
+			return
+		}
+	}
+	if pi.FinalScriptSig != nil {
+		if err = serializeKVPairWithType(w, uint8(FinalScriptSigType), nil,
+			pi.FinalScriptSig); err != nil {
+			return
+		}
+	}
+	if pi.FinalScriptWitness != nil {
+		if err = serializeKVPairWithType(w, uint8(FinalScriptWitnessType),
+			nil, pi.FinalScriptWitness); err != nil {
+			return
+		}
+	}
+	for _, u := range pi.Unknowns {
+		if err = serializeKVPair(w, u.Key, u.Value); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// readTxOut reads an output serialized as its value followed by its script.
+func readTxOut(value []byte) (*wire.TxOut, error) {
+	r := bytes.NewReader(value)
+	var amount [8]byte
+	if _, err := io.ReadFull(r, amount[:]); err != nil {
+		return nil, ErrInvalidPsbtFormat
+	}
+	pkScript, err := wire.ReadVarBytes(r, 0, txscript.MaxScriptSize,
+		"pkScript")
+	if err != nil || r.Len() != 0 {
+		return nil, ErrInvalidPsbtFormat
+	}
+	return wire.NewTxOut(int64(binary.LittleEndian.Uint64(amount[:])),
+		pkScript), nil
+}
+
+// readDerivation reads a key derivation, checking it's for a public key that isn't already in the section.
+func readDerivation(pubKey, value []byte,
+	existing []*Bip32Derivation) (*Bip32Derivation, error) {
+	if !validatePubKey(pubKey) {
+		return nil, ErrInvalidKeyData
+	}
+	for _, d := range existing {
+		if bytes.Equal(d.PubKey, pubKey) {
+			return nil, ErrDuplicateKey
+		}
+	}
+	fingerprint, path, err := ReadBip32Derivation(value)
+	if err != nil {
+		return nil, err
+	}
+	return &Bip32Derivation{
+		PubKey:               pubKey,
+		MasterKeyFingerprint: fingerprint,
+		Bip32Path:            path,
+	}, nil
+}
+
+// serializeDerivations writes key derivations in order of their public keys.
+func serializeDerivations(w io.Writer, keyType uint8,
+	derivations []*Bip32Derivation) (err error) {
+	sort.Slice(derivations, func(i, j int) bool {
+		return bytes.Compare(derivations[i].PubKey,
+			derivations[j].PubKey) < 0
+	})
+	for _, d := range derivations {
+		if err = serializeKVPairWithType(w, keyType, d.PubKey,
+			SerializeBIP32Derivation(d.MasterKeyFingerprint,
+				d.Bip32Path)); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// addUnknown adds a key of a type the package doesn't know to a section.
+func addUnknown(unknowns []*Unknown, keyType int, keyData,
+	value []byte) ([]*Unknown, error) {
+	key := append([]byte{byte(keyType)}, keyData...)
+	for _, u := range unknowns {
+		if bytes.Equal(u.Key, key) {
+			return nil, ErrDuplicateKey
+		}
+	}
+	return append(unknowns, &Unknown{Key: key, Value: value}), nil
+}
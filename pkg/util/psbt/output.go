@@ -0,0 +1,90 @@
+package psbt
+
+import (
+	"io"
+
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/log"
+)
+
+// POutput is the section of a PSBT for an output, with what is needed to spend it later.
+type POutput struct {
+	RedeemScript    []byte
+	WitnessScript   []byte
+	Bip32Derivation []*Bip32Derivation
+	Unknowns        []*Unknown
+}
+
+// deserialize reads the section of an output up to its separator.
+func (po *POutput) deserialize(r io.Reader) error {
+	for {
+		keyType, keyData, err := getKey(r)
+		if err != nil {
+			return err
+		}
+		if keyType == -1 {
+			return nil
+		}
+		value, err := wire.ReadVarBytes(r, 0, MaxPsbtValueLength,
+			"PSBT value")
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		switch OutputType(keyType) {
+		case RedeemScriptOutputType:
+			if po.RedeemScript != nil {
+				return ErrDuplicateKey
+			}
+			if keyData != nil {
+				return ErrInvalidKeyData
+			}
+			po.RedeemScript = value
+		case WitnessScriptOutputType:
+			if po.WitnessScript != nil {
+				return ErrDuplicateKey
+			}
+			if keyData != nil {
+				return ErrInvalidKeyData
+			}
+			po.WitnessScript = value
+		case Bip32DerivationOutputType:
+			d, err := readDerivation(keyData, value, po.Bip32Derivation)
+			if err != nil {
+				return err
+			}
+			po.Bip32Derivation = append(po.Bip32Derivation, d)
+		default:
+			if po.Unknowns, err = addUnknown(po.Unknowns, keyType, keyData,
+				value); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// serialize writes the section of an output without its separator, in order of key type.
+func (po *POutput) serialize(w io.Writer) (err error) {
+	if po.RedeemScript != nil {
+		if err = serializeKVPairWithType(w, uint8(RedeemScriptOutputType),
+			nil, po.RedeemScript); err != nil {
+			return
+		}
+	}
+	if po.WitnessScript != nil {
+		if err = serializeKVPairWithType(w, uint8(WitnessScriptOutputType),
+			nil, po.WitnessScript); err != nil {
+			return
+		}
+	}
+	if err = serializeDerivations(w, uint8(Bip32DerivationOutputType),
+		po.Bip32Derivation); err != nil {
+		return
+	}
+	for _, u := range po.Unknowns {
+		if err = serializeKVPair(w, u.Key, u.Value); err != nil {
+			return
+		}
+	}
+	return
+}
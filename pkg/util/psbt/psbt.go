@@ -0,0 +1,345 @@
+package psbt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// magic is the start of every serialized PSBT, "psbt" followed by 0xff
+var magic = [5]byte{0x70, 0x73, 0x62, 0x74, 0xff}
+
+const (
+	// MaxPsbtValueLength is the largest value that is read from a serialized PSBT, which is more than any transaction in a NonWitnessUtxo can be.
+	MaxPsbtValueLength = 4000000
+	// MaxPsbtKeyLength is the largest key that is read from a serialized PSBT.
+	MaxPsbtKeyLength = 10000
+	// MinTxVersion is the lowest transaction version a PSBT is created with.
+	MinTxVersion = 1
+)
+
+var (
+	// ErrInvalidPsbtFormat describes a serialized PSBT that does not follow BIP 174.
+	ErrInvalidPsbtFormat = errors.New("invalid PSBT serialization format")
+	// ErrDuplicateKey describes a PSBT with the same key twice in a section.
+	ErrDuplicateKey = errors.New("invalid PSBT due to duplicate key")
+	// ErrInvalidKeyData describes a key with data where there should be none or with data that is not valid.
+	ErrInvalidKeyData = errors.New("invalid PSBT key data")
+	// ErrInvalidMagicBytes describes data that does not start with the PSBT magic bytes.
+	ErrInvalidMagicBytes = errors.New("invalid PSBT magic bytes")
+	// ErrInvalidRawTxSigned describes a PSBT whose unsigned transaction has signature scripts or witnesses.
+	ErrInvalidRawTxSigned = errors.New("invalid PSBT, the transaction must be unsigned")
+	// ErrInvalidPrevOutNonWitnessTransaction describes a NonWitnessUtxo that is not the transaction the input spends from.
+	ErrInvalidPrevOutNonWitnessTransaction = errors.New("previous transaction does not match the input's previous outpoint")
+	// ErrInvalidSignatureForInput describes a signature that can't be for the input, as the scripts given don't match the output it spends.  The signature itself is not checked.
+	ErrInvalidSignatureForInput = errors.New("signature does not correspond to this input")
+	// ErrInputAlreadyFinalized describes an attempt to finalize an input that already has a final signature script or witness.
+	ErrInputAlreadyFinalized = errors.New("input is already finalized")
+	// ErrIncompletePSBT describes an attempt to extract the transaction of a PSBT with inputs that aren't finalized.
+	ErrIncompletePSBT = errors.New("PSBT is incomplete")
+	// ErrNotFinalizable describes an input that doesn't have the signatures and scripts needed to finalize it.
+	ErrNotFinalizable = errors.New("PSBT input is not finalizable")
+	// ErrInvalidSigHashFlags describes a signature that doesn't use the signature hash type of the input.
+	ErrInvalidSigHashFlags = errors.New("invalid signature hash flags")
+	// ErrUnsupportedScriptType describes an input spending a script that can't be finalized.
+	ErrUnsupportedScriptType = errors.New("unsupported script type")
+	// ErrDifferentTransactions describes an attempt to combine PSBTs of different transactions.
+	ErrDifferentTransactions = errors.New("PSBTs are for different transactions")
+)
+
+// Unknown is a key and value of a type the package doesn't know, which are kept so they are passed on unchanged.
+type Unknown struct {
+	Key   []byte
+	Value []byte
+}
+
+// Packet is a decoded PSBT, the unsigned transaction with the key-value sections of each of its inputs and outputs.
+type Packet struct {
+	UnsignedTx *wire.MsgTx
+	Inputs     []PInput
+	Outputs    []POutput
+	// Unknowns are the global keys the package doesn't know.
+	Unknowns []*Unknown
+}
+
+// New creates a PSBT spending the outpoints to the outputs, with a sequence number for each input.  This is the Creator role of BIP 174.
+func New(inputs []*wire.OutPoint, outputs []*wire.TxOut, version int32,
+	lockTime uint32, sequences []uint32) (*Packet, error) {
+	if version < MinTxVersion || len(sequences) != len(inputs) {
+		return nil, ErrInvalidPsbtFormat
+	}
+	tx := wire.NewMsgTx(version)
+	tx.LockTime = lockTime
+	for i, in := range inputs {
+		tx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: *in,
+			Sequence:         sequences[i],
+		})
+	}
+	for _, out := range outputs {
+		tx.AddTxOut(out)
+	}
+	return NewFromUnsignedTx(tx)
+}
+
+// NewFromUnsignedTx creates a PSBT with empty input and output sections for an unsigned transaction.
+func NewFromUnsignedTx(tx *wire.MsgTx) (*Packet, error) {
+	if !validateUnsignedTx(tx) {
+		return nil, ErrInvalidRawTxSigned
+	}
+	return &Packet{
+		UnsignedTx: tx,
+		Inputs:     make([]PInput, len(tx.TxIn)),
+		Outputs:    make([]POutput, len(tx.TxOut)),
+	}, nil
+}
+
+// validateUnsignedTx returns whether none of the inputs of the transaction have a signature script or witness.
+func validateUnsignedTx(tx *wire.MsgTx) bool {
+	for _, in := range tx.TxIn {
+		if len(in.SignatureScript) != 0 || len(in.Witness) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// NewFromRawBytes reads a serialized PSBT, which is first decoded from base64 if b64 is set.
+func NewFromRawBytes(r io.Reader, b64 bool) (p *Packet, err error) {
+	if b64 {
+		r = base64.NewDecoder(base64.StdEncoding, r)
+	}
+	var m [5]byte
+	if _, err = io.ReadFull(r, m[:]); err != nil {
+		log.ERROR(err)
+		return
+	}
+	if m != magic {
+		return nil, ErrInvalidMagicBytes
+	}
+	// the unsigned transaction must come first in the global section
+	keyType, keyData, err := getKey(r)
+	if err != nil {
+		return
+	}
+	if GlobalType(keyType) != UnsignedTxType || keyData != nil {
+		return nil, ErrInvalidPsbtFormat
+	}
+	value, err := wire.ReadVarBytes(r, 0, MaxPsbtValueLength, "PSBT value")
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	tx := wire.NewMsgTx(wire.TxVersion)
+	if err = tx.DeserializeNoWitness(bytes.NewReader(value)); err != nil {
+		log.ERROR(err)
+		return
+	}
+	if !validateUnsignedTx(tx) {
+		return nil, ErrInvalidRawTxSigned
+	}
+	p = &Packet{
+		UnsignedTx: tx,
+		Inputs:     make([]PInput, len(tx.TxIn)),
+		Outputs:    make([]POutput, len(tx.TxOut)),
+	}
+	for {
+		if keyType, keyData, err = getKey(r); err != nil {
+			return nil, err
+		}
+		if keyType == -1 {
+			break
+		}
+		if value, err = wire.ReadVarBytes(r, 0, MaxPsbtValueLength,
+			"PSBT value"); err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+		key := append([]byte{byte(keyType)}, keyData...)
+		for _, u := range p.Unknowns {
+			if bytes.Equal(u.Key, key) {
+				return nil, ErrDuplicateKey
+			}
+		}
+		p.Unknowns = append(p.Unknowns, &Unknown{Key: key, Value: value})
+	}
+	for i := range p.Inputs {
+		if err = p.Inputs[i].deserialize(r); err != nil {
+			return nil, err
+		}
+	}
+	for i := range p.Outputs {
+		if err = p.Outputs[i].deserialize(r); err != nil {
+			return nil, err
+		}
+	}
+	if err = p.SanityCheck(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Decode reads a base64 encoded PSBT, as it is passed between wallets as text.
+func Decode(b64 string) (*Packet, error) {
+	return NewFromRawBytes(strings.NewReader(strings.TrimSpace(b64)), true)
+}
+
+// Serialize writes the PSBT in the binary format of BIP 174.
+func (p *Packet) Serialize(w io.Writer) (err error) {
+	if _, err = w.Write(magic[:]); err != nil {
+		return
+	}
+	var tx bytes.Buffer
+	if err = p.UnsignedTx.SerializeNoWitness(&tx); err != nil {
+		log.ERROR(err)
+		return
+	}
+	if err = serializeKVPairWithType(w, uint8(UnsignedTxType), nil,
+		tx.Bytes()); err != nil {
+		return
+	}
+	for _, u := range p.Unknowns {
+		if err = serializeKVPair(w, u.Key, u.Value); err != nil {
+			return
+		}
+	}
+	separator := []byte{0}
+	if _, err = w.Write(separator); err != nil {
+		return
+	}
+	for i := range p.Inputs {
+		if err = p.Inputs[i].serialize(w); err != nil {
+			return
+		}
+		if _, err = w.Write(separator); err != nil {
+			return
+		}
+	}
+	for i := range p.Outputs {
+		if err = p.Outputs[i].serialize(w); err != nil {
+			return
+		}
+		if _, err = w.Write(separator); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// B64Encode returns the PSBT serialized and encoded in base64.
+func (p *Packet) B64Encode() (string, error) {
+	var b bytes.Buffer
+	if err := p.Serialize(&b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b.Bytes()), nil
+}
+
+// IsComplete returns whether all of the inputs are finalized, so the signed transaction can be extracted.
+func (p *Packet) IsComplete() bool {
+	for i := range p.UnsignedTx.TxIn {
+		if !p.Inputs[i].isFinalized() {
+			return false
+		}
+	}
+	return true
+}
+
+// SanityCheck returns an error if the PSBT breaks the rules of BIP 174.
+func (p *Packet) SanityCheck() error {
+	if p.UnsignedTx == nil || len(p.Inputs) != len(p.UnsignedTx.TxIn) ||
+		len(p.Outputs) != len(p.UnsignedTx.TxOut) {
+		return ErrInvalidPsbtFormat
+	}
+	if !validateUnsignedTx(p.UnsignedTx) {
+		return ErrInvalidRawTxSigned
+	}
+	for i := range p.Inputs {
+		prev := p.Inputs[i].NonWitnessUtxo
+		if prev == nil {
+			continue
+		}
+		op := p.UnsignedTx.TxIn[i].PreviousOutPoint
+		if prev.TxHash() != op.Hash || op.Index >= uint32(len(prev.TxOut)) {
+			return ErrInvalidPrevOutNonWitnessTransaction
+		}
+	}
+	return nil
+}
+
+// SpentOutput returns the output spent by an input from its UTXO fields, or nil if it has none.
+func (p *Packet) SpentOutput(i int) *wire.TxOut {
+	in := &p.Inputs[i]
+	switch {
+	case in.WitnessUtxo != nil:
+		return in.WitnessUtxo
+	case in.NonWitnessUtxo != nil:
+		idx := p.UnsignedTx.TxIn[i].PreviousOutPoint.Index
+		if idx < uint32(len(in.NonWitnessUtxo.TxOut)) {
+			return in.NonWitnessUtxo.TxOut[idx]
+		}
+	}
+	return nil
+}
+
+// GetTxFee returns the fee of the transaction, which needs the outputs spent by all of the inputs.
+func (p *Packet) GetTxFee() (fee util.Amount, err error) {
+	for i := range p.UnsignedTx.TxIn {
+		out := p.SpentOutput(i)
+		if out == nil {
+			return 0, errors.New("input without UTXO information")
+		}
+		fee += util.Amount(out.Value)
+	}
+	for _, out := range p.UnsignedTx.TxOut {
+		fee -= util.Amount(out.Value)
+	}
+	return
+}
+
+// serializeKVPair writes a key and value each prefixed with its length.
+func serializeKVPair(w io.Writer, key, value []byte) (err error) {
+	if err = wire.WriteVarBytes(w, 0, key); err != nil {
+		log.ERROR(err)
+		return
+	}
+	if err = wire.WriteVarBytes(w, 0, value); err != nil {
+		log.ERROR(err)
+	}
+	return
+}
+
+// serializeKVPairWithType writes a key made of its type and data with a value.
+func serializeKVPairWithType(w io.Writer, keyType uint8, keyData,
+	value []byte) error {
+	return serializeKVPair(w, append([]byte{keyType}, keyData...), value)
+}
+
+// getKey reads a key, returning its type and data, or -1 at the separator at the end of a section.
+func getKey(r io.Reader) (keyType int, keyData []byte, err error) {
+	count, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return -1, nil, ErrInvalidPsbtFormat
+	}
+	if count == 0 {
+		return -1, nil, nil
+	}
+	if count > MaxPsbtKeyLength {
+		return -1, nil, ErrInvalidKeyData
+	}
+	key := make([]byte, count)
+	if _, err = io.ReadFull(r, key); err != nil {
+		log.ERROR(err)
+		return -1, nil, err
+	}
+	if len(key) == 1 {
+		return int(key[0]), nil, nil
+	}
+	return int(key[0]), key[1:], nil
+}
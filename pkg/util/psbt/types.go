@@ -0,0 +1,53 @@
+package psbt
+
+// GlobalType is the type of a key in the global section of a PSBT.
+type GlobalType uint8
+
+const (
+	// UnsignedTxType is the key of the unsigned transaction, in network serialization without witnesses.  A PSBT must have one.
+	UnsignedTxType GlobalType = 0
+	// XpubType is the key of a global extended public key, followed by the serialized key.
+	XpubType GlobalType = 1
+	// VersionType is the key of the version of the PSBT, zero if not given.
+	VersionType GlobalType = 0xFB
+	// ProprietaryGlobalType is the key of proprietary global data.
+	ProprietaryGlobalType GlobalType = 0xFC
+)
+
+// InputType is the type of a key in the section of an input of a PSBT.
+type InputType uint8
+
+const (
+	// NonWitnessUtxoType is the key of the whole transaction the input spends from.
+	NonWitnessUtxoType InputType = 0
+	// WitnessUtxoType is the key of the output the input spends, for inputs spending segregated witness outputs.
+	WitnessUtxoType InputType = 1
+	// PartialSigType is the key of a signature, followed by the public key it is for.
+	PartialSigType InputType = 2
+	// SighashType is the key of the signature hash type signatures for the input must use.
+	SighashType InputType = 3
+	// RedeemScriptInputType is the key of the redeem script of a pay to script hash input.
+	RedeemScriptInputType InputType = 4
+	// WitnessScriptInputType is the key of the witness script of a pay to witness script hash input.
+	WitnessScriptInputType InputType = 5
+	// Bip32DerivationInputType is the key of the derivation path of a key needed to sign the input, followed by the public key.
+	Bip32DerivationInputType InputType = 6
+	// FinalScriptSigType is the key of the finished signature script of the input.
+	FinalScriptSigType InputType = 7
+	// FinalScriptWitnessType is the key of the finished witness of the input.
+	FinalScriptWitnessType InputType = 8
+	// ProprietaryInputType is the key of proprietary input data.
+	ProprietaryInputType InputType = 0xFC
+)
+
+// OutputType is the type of a key in the section of an output of a PSBT.
+type OutputType uint8
+
+const (
+	// RedeemScriptOutputType is the key of the redeem script of a pay to script hash output.
+	RedeemScriptOutputType OutputType = 0
+	// WitnessScriptOutputType is the key of the witness script of a pay to witness script hash output.
+	WitnessScriptOutputType OutputType = 1
+	// Bip32DerivationOutputType is the key of the derivation path of a key needed to spend the output, followed by the public key.
+	Bip32DerivationOutputType OutputType = 2
+)
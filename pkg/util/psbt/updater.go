@@ -0,0 +1,241 @@
+package psbt
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// Updater adds information to a PSBT, the Updater and Signer roles of BIP 174.
+type Updater struct {
+	Upsbt *Packet
+}
+
+// NewUpdater returns an Updater for a PSBT, which is checked first.
+func NewUpdater(p *Packet) (*Updater, error) {
+	if err := p.SanityCheck(); err != nil {
+		return nil, err
+	}
+	return &Updater{Upsbt: p}, nil
+}
+
+// checkInput returns an error if the index is not an input of the PSBT or the input is already finalized.
+func (u *Updater) checkInput(inIndex int) error {
+	if inIndex < 0 || inIndex >= len(u.Upsbt.Inputs) {
+		return ErrInvalidPsbtFormat
+	}
+	if u.Upsbt.Inputs[inIndex].isFinalized() {
+		return ErrInputAlreadyFinalized
+	}
+	return nil
+}
+
+// AddInNonWitnessUtxo adds the transaction an input spends from, which must be the one in its previous outpoint.
+func (u *Updater) AddInNonWitnessUtxo(tx *wire.MsgTx, inIndex int) error {
+	if err := u.checkInput(inIndex); err != nil {
+		return err
+	}
+	op := u.Upsbt.UnsignedTx.TxIn[inIndex].PreviousOutPoint
+	if tx.TxHash() != op.Hash || op.Index >= uint32(len(tx.TxOut)) {
+		return ErrInvalidPrevOutNonWitnessTransaction
+	}
+	u.Upsbt.Inputs[inIndex].NonWitnessUtxo = tx
+	return nil
+}
+
+// AddInWitnessUtxo adds the output a segregated witness input spends.
+func (u *Updater) AddInWitnessUtxo(txOut *wire.TxOut, inIndex int) error {
+	if err := u.checkInput(inIndex); err != nil {
+		return err
+	}
+	u.Upsbt.Inputs[inIndex].WitnessUtxo = txOut
+	return nil
+}
+
+// AddInSighashType sets the signature hash type the signatures for an input must use.
+func (u *Updater) AddInSighashType(sighashType txscript.SigHashType,
+	inIndex int) error {
+	if err := u.checkInput(inIndex); err != nil {
+		return err
+	}
+	u.Upsbt.Inputs[inIndex].SighashType = sighashType
+	return nil
+}
+
+// AddInRedeemScript adds the redeem script of a pay to script hash input.
+func (u *Updater) AddInRedeemScript(redeemScript []byte, inIndex int) error {
+	if err := u.checkInput(inIndex); err != nil {
+		return err
+	}
+	u.Upsbt.Inputs[inIndex].RedeemScript = redeemScript
+	return nil
+}
+
+// AddInWitnessScript adds the witness script of a pay to witness script hash input.
+func (u *Updater) AddInWitnessScript(witnessScript []byte, inIndex int) error {
+	if err := u.checkInput(inIndex); err != nil {
+		return err
+	}
+	u.Upsbt.Inputs[inIndex].WitnessScript = witnessScript
+	return nil
+}
+
+// AddInBip32Derivation adds the derivation path of a public key needed to sign an input.
+func (u *Updater) AddInBip32Derivation(masterKeyFingerprint uint32,
+	bip32Path []uint32, pubKey []byte, inIndex int) error {
+	if err := u.checkInput(inIndex); err != nil {
+		return err
+	}
+	if !validatePubKey(pubKey) {
+		return ErrInvalidKeyData
+	}
+	in := &u.Upsbt.Inputs[inIndex]
+	for _, d := range in.Bip32Derivation {
+		if bytes.Equal(d.PubKey, pubKey) {
+			return ErrDuplicateKey
+		}
+	}
+	in.Bip32Derivation = append(in.Bip32Derivation, &Bip32Derivation{
+		PubKey:               pubKey,
+		MasterKeyFingerprint: masterKeyFingerprint,
+		Bip32Path:            bip32Path,
+	})
+	return nil
+}
+
+// AddOutRedeemScript adds the redeem script of a pay to script hash output.
+func (u *Updater) AddOutRedeemScript(redeemScript []byte, outIndex int) error {
+	if outIndex < 0 || outIndex >= len(u.Upsbt.Outputs) {
+		return ErrInvalidPsbtFormat
+	}
+	u.Upsbt.Outputs[outIndex].RedeemScript = redeemScript
+	return nil
+}
+
+// AddOutWitnessScript adds the witness script of a pay to witness script hash output.
+func (u *Updater) AddOutWitnessScript(witnessScript []byte,
+	outIndex int) error {
+	if outIndex < 0 || outIndex >= len(u.Upsbt.Outputs) {
+		return ErrInvalidPsbtFormat
+	}
+	u.Upsbt.Outputs[outIndex].WitnessScript = witnessScript
+	return nil
+}
+
+// AddOutBip32Derivation adds the derivation path of a public key that can spend an output.
+func (u *Updater) AddOutBip32Derivation(masterKeyFingerprint uint32,
+	bip32Path []uint32, pubKey []byte, outIndex int) error {
+	if outIndex < 0 || outIndex >= len(u.Upsbt.Outputs) {
+		return ErrInvalidPsbtFormat
+	}
+	if !validatePubKey(pubKey) {
+		return ErrInvalidKeyData
+	}
+	out := &u.Upsbt.Outputs[outIndex]
+	for _, d := range out.Bip32Derivation {
+		if bytes.Equal(d.PubKey, pubKey) {
+			return ErrDuplicateKey
+		}
+	}
+	out.Bip32Derivation = append(out.Bip32Derivation, &Bip32Derivation{
+		PubKey:               pubKey,
+		MasterKeyFingerprint: masterKeyFingerprint,
+		Bip32Path:            bip32Path,
+	})
+	return nil
+}
+
+// Sign adds a signature for an input made with the public key, adding the redeem and witness scripts first if they are given.  The scripts are checked against the output being spent but the signature itself is not verified.  Signing an input that is already finalized does nothing.
+func (u *Updater) Sign(inIndex int, sig, pubKey, redeemScript,
+	witnessScript []byte) error {
+	if inIndex < 0 || inIndex >= len(u.Upsbt.Inputs) {
+		return ErrInvalidPsbtFormat
+	}
+	in := &u.Upsbt.Inputs[inIndex]
+	if in.isFinalized() {
+		return nil
+	}
+	if redeemScript != nil {
+		in.RedeemScript = redeemScript
+	}
+	if witnessScript != nil {
+		in.WitnessScript = witnessScript
+	}
+	ps := &PartialSig{PubKey: pubKey, Signature: sig}
+	if !ps.checkValid() {
+		return ErrInvalidPsbtFormat
+	}
+	for _, s := range in.PartialSigs {
+		if bytes.Equal(s.PubKey, pubKey) {
+			return ErrDuplicateKey
+		}
+	}
+	if in.SighashType != 0 && !checkSigHashFlags(sig, in) {
+		return ErrInvalidSigHashFlags
+	}
+	spent := u.Upsbt.SpentOutput(inIndex)
+	if spent == nil {
+		return errors.New("cannot sign an input without UTXO information")
+	}
+	if err := checkScripts(spent.PkScript, in, pubKey); err != nil {
+		return err
+	}
+	in.PartialSigs = append(in.PartialSigs, ps)
+	return nil
+}
+
+// checkScripts returns an error if the redeem and witness scripts of an input don't lead to the script of the output it spends, or for a pay to witness public key hash output if the public key isn't the one paid to.
+func checkScripts(pkScript []byte, in *PInput, pubKey []byte) error {
+	script := pkScript
+	if in.RedeemScript != nil {
+		p2sh, err := payToScriptHash(in.RedeemScript)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(p2sh, pkScript) {
+			return ErrInvalidSignatureForInput
+		}
+		script = in.RedeemScript
+	}
+	switch {
+	case in.WitnessScript != nil:
+		hash := sha256.Sum256(in.WitnessScript)
+		p2wsh, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).
+			AddData(hash[:]).Script()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(p2wsh, script) {
+			return ErrInvalidSignatureForInput
+		}
+	case txscript.IsPayToWitnessPubKeyHash(script):
+		p2wkh, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).
+			AddData(util.Hash160(pubKey)).Script()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(p2wkh, script) {
+			return ErrInvalidSignatureForInput
+		}
+	}
+	return nil
+}
+
+// payToScriptHash returns the pay to script hash output script for a redeem script.
+func payToScriptHash(redeemScript []byte) ([]byte, error) {
+	return txscript.NewScriptBuilder().AddOp(txscript.OP_HASH160).
+		AddData(util.Hash160(redeemScript)).AddOp(txscript.OP_EQUAL).Script()
+}
+
+// checkSigHashFlags returns whether a signature uses the signature hash type of the input, or the default type if it has none.
+func checkSigHashFlags(sig []byte, in *PInput) bool {
+	want := txscript.SigHashAll
+	if in.SighashType != 0 {
+		want = in.SighashType
+	}
+	return len(sig) > 0 && txscript.SigHashType(sig[len(sig)-1]) == want
+}
@@ -259,6 +259,11 @@ func (a *managedAddress) PrivKey() (*ec.PrivateKey, error) {
 	}
 	a.manager.mtx.Lock()
 	defer a.manager.mtx.Unlock()
+	// Addresses of watch-only accounts have no private key.
+	if acctInfo, ok := a.manager.acctInfo[a.derivationPath.Account]; ok &&
+		!a.imported && acctInfo.watchOnly() {
+		return nil, managerError(ErrWatchingOnly, errWatchingOnly, nil)
+	}
 	// Account manager must be unlocked to decrypt the private key.
 	if a.manager.rootManager.IsLocked() {
 		return nil, managerError(ErrLocked, errLocked, nil)
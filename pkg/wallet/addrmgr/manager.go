@@ -138,6 +138,12 @@ type accountInfo struct {
 	nextInternalIndex uint32
 }
 
+// watchOnly returns whether the account was created from an extended public
+// key, in which case there is no account private key to derive keys with.
+func (a *accountInfo) watchOnly() bool {
+	return len(a.acctKeyEncrypted) == 0
+}
+
 // AccountProperties contains properties associated with each account, such as
 // the account name, number, and the nubmer of derived and imported keys.
 type AccountProperties struct {
@@ -146,6 +152,7 @@ type AccountProperties struct {
 	ExternalKeyCount uint32
 	InternalKeyCount uint32
 	ImportedKeyCount uint32
+	WatchOnly        bool
 }
 
 // unlockDeriveInfo houses the information needed to derive a private key for a
@@ -996,6 +1003,10 @@ func (m *Manager) Unlock(ns walletdb.ReadBucket, passphrase []byte) error {
 	// extended keys.
 	for _, manager := range m.scopedManagers {
 		for account, acctInfo := range manager.acctInfo {
+			// Watch-only accounts have no private key to decrypt.
+			if acctInfo.watchOnly() {
+				continue
+			}
 			decrypted, err := m.cryptoKeyPriv.Decrypt(acctInfo.acctKeyEncrypted)
 			if err != nil {
 				log.ERROR(err)
@@ -1018,6 +1029,21 @@ func (m *Manager) Unlock(ns walletdb.ReadBucket, passphrase []byte) error {
 		// We'll also derive any private keys that are pending due to
 		// them being created while the address manager was locked.
 		for _, info := range manager.deriveOnUnlock {
+			// Addresses of watch-only accounts were derived from the
+			// account public key and have no private key to add.
+			acctInfo, err := manager.loadAccountInfo(
+				ns, info.managedAddr.Account(),
+			)
+			if err != nil {
+				log.ERROR(err)
+				m.lock()
+				return err
+			}
+			if acctInfo.watchOnly() {
+				manager.deriveOnUnlock[0] = nil
+				manager.deriveOnUnlock = manager.deriveOnUnlock[1:]
+				continue
+			}
 			addressKey, err := manager.deriveKeyFromPath(
 				ns, info.managedAddr.Account(), info.branch,
 				info.index, true,
@@ -15,6 +15,7 @@ import (
 	chaincfg "github.com/p9c/pod/pkg/chain/config"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
 	"github.com/p9c/pod/pkg/util/snacl"
 	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
 	walletdb "github.com/p9c/pod/pkg/wallet/db"
@@ -2274,3 +2275,121 @@ func TestNewRawAccount(t *testing.T) {
 			accountTargetAddr.AddrHash())
 	}
 }
+
+// TestNewAccountWatchingOnly tests that an account created from an extended
+// public key derives the same addresses as the account it was exported from
+// but never gives out private keys.
+func TestNewAccountWatchingOnly(t *testing.T) {
+	t.Parallel()
+	teardown, db := emptyDB(t)
+	defer teardown()
+	var mgr *waddrmgr.Manager
+	err := walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns, err := tx.CreateTopLevelBucket(waddrmgrNamespaceKey)
+		if err != nil {
+			return err
+		}
+		err = waddrmgr.Create(
+			ns, seed, pubPassphrase, privPassphrase,
+			&chaincfg.MainNetParams, fastScrypt, time.Time{},
+		)
+		if err != nil {
+			return err
+		}
+		mgr, err = waddrmgr.Open(
+			ns, pubPassphrase, &chaincfg.MainNetParams,
+		)
+		if err != nil {
+			return err
+		}
+		return mgr.Unlock(ns, privPassphrase)
+	})
+	if err != nil {
+		t.Fatalf("create/open: unexpected error: %v", err)
+	}
+	defer mgr.Close()
+	scopedMgr, err := mgr.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to fetch scope %v: %v", waddrmgr.KeyScopeBIP0084, err)
+	}
+	// Derive the extended public key of the default account, which is what
+	// a cold storage wallet would hand out.
+	acctKey, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create master key: %v", err)
+	}
+	for _, index := range []uint32{
+		waddrmgr.KeyScopeBIP0084.Purpose + hdkeychain.HardenedKeyStart,
+		waddrmgr.KeyScopeBIP0084.Coin + hdkeychain.HardenedKeyStart,
+		waddrmgr.DefaultAccountNum + hdkeychain.HardenedKeyStart,
+	} {
+		if acctKey, err = acctKey.Child(index); err != nil {
+			t.Fatalf("unable to derive account key: %v", err)
+		}
+	}
+	acctPubKey, err := acctKey.Neuter()
+	if err != nil {
+		t.Fatalf("unable to neuter account key: %v", err)
+	}
+	// Private keys can't be used to create watch-only accounts.
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		_, err := scopedMgr.NewAccountWatchingOnly(ns, "cold", acctKey)
+		return err
+	})
+	checkManagerError(t, "private key", err, waddrmgr.ErrKeyChain)
+	var account uint32
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		account, err = scopedMgr.NewAccountWatchingOnly(ns, "cold", acctPubKey)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to create watch-only account: %v", err)
+	}
+	// Lock the manager so the first addresses are derived from public keys,
+	// then unlock it again, which must skip the watch-only account.
+	if err := mgr.Lock(); err != nil {
+		t.Fatalf("unable to lock manager: %v", err)
+	}
+	var watchAddr, spendAddr waddrmgr.ManagedAddress
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		addrs, err := scopedMgr.NextExternalAddresses(ns, account, 1)
+		if err != nil {
+			return err
+		}
+		watchAddr = addrs[0]
+		if err := mgr.Unlock(ns, privPassphrase); err != nil {
+			return err
+		}
+		spendAddr, err = scopedMgr.DeriveFromKeyPath(ns, waddrmgr.DerivationPath{
+			Account: waddrmgr.DefaultAccountNum,
+			Branch:  waddrmgr.ExternalBranch,
+			Index:   0,
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to derive addresses: %v", err)
+	}
+	if !bytes.Equal(watchAddr.AddrHash(), spendAddr.AddrHash()) {
+		t.Fatalf("wrong pubkey hash: %x vs %x", watchAddr.AddrHash(),
+			spendAddr.AddrHash())
+	}
+	_, err = watchAddr.(waddrmgr.ManagedPubKeyAddress).PrivKey()
+	checkManagerError(t, "watch-only private key", err, waddrmgr.ErrWatchingOnly)
+	var props *waddrmgr.AccountProperties
+	err = walletdb.View(db, func(tx walletdb.ReadTx) error {
+		ns := tx.ReadBucket(waddrmgrNamespaceKey)
+		props, err = scopedMgr.AccountProperties(ns, account)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to fetch account properties: %v", err)
+	}
+	if !props.WatchOnly || props.AccountName != "cold" ||
+		props.ExternalKeyCount != 1 {
+		t.Fatalf("wrong account properties: %v", spew.Sdump(props))
+	}
+}
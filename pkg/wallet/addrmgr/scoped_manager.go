@@ -244,9 +244,10 @@ func (s *ScopedKeyManager) deriveKey(acctInfo *accountInfo, branch,
 	index uint32, private bool) (*hdkeychain.ExtendedKey, error) {
 	// Choose the public or private extended key based on whether or not
 	// the private flag was specified.  This, in turn, allows for public or
-	// private child derivation.
+	// private child derivation.  Watch-only accounts only have the public
+	// key, so they always derive public children.
 	acctKey := acctInfo.acctKeyPub
-	if private {
+	if private && !acctInfo.watchOnly() {
 		acctKey = acctInfo.acctKeyPriv
 	}
 	// Derive and return the key.
@@ -318,7 +319,7 @@ func (s *ScopedKeyManager) loadAccountInfo(ns walletdb.ReadBucket,
 		nextExternalIndex: row.nextExternalIndex,
 		nextInternalIndex: row.nextInternalIndex,
 	}
-	if !s.rootManager.isLocked() {
+	if !s.rootManager.isLocked() && !acctInfo.watchOnly() {
 		// Use the crypto private key to decrypt the account private
 		// extended keys.
 		decrypted, err := s.rootManager.cryptoKeyPriv.Decrypt(acctInfo.acctKeyEncrypted)
@@ -405,6 +406,7 @@ func (s *ScopedKeyManager) AccountProperties(ns walletdb.ReadBucket,
 		props.AccountName = acctInfo.acctName
 		props.ExternalKeyCount = acctInfo.nextExternalIndex
 		props.InternalKeyCount = acctInfo.nextInternalIndex
+		props.WatchOnly = acctInfo.watchOnly()
 	} else {
 		props.AccountName = ImportedAddrAccountName // reserved, nonchangable
 		// Could be more efficient if this was tracked by the db.
@@ -639,9 +641,9 @@ func (s *ScopedKeyManager) nextAddresses(ns walletdb.ReadWriteBucket,
 		return nil, err
 	}
 	// Choose the account key to used based on whether the address manager
-	// is locked.
+	// is locked and whether the account has a private key at all.
 	acctKey := acctInfo.acctKeyPub
-	if !s.rootManager.IsLocked() {
+	if !s.rootManager.IsLocked() && !acctInfo.watchOnly() {
 		acctKey = acctInfo.acctKeyPriv
 	}
 	// Choose the branch key and index depending on whether or not this is
@@ -808,9 +810,9 @@ func (s *ScopedKeyManager) extendAddresses(ns walletdb.ReadWriteBucket,
 		return err
 	}
 	// Choose the account key to used based on whether the address manager
-	// is locked.
+	// is locked and whether the account has a private key at all.
 	acctKey := acctInfo.acctKeyPub
-	if !s.rootManager.IsLocked() {
+	if !s.rootManager.IsLocked() && !acctInfo.watchOnly() {
 		acctKey = acctInfo.acctKeyPriv
 	}
 	// Choose the branch key and index depending on whether or not this is
@@ -1215,6 +1217,61 @@ func (s *ScopedKeyManager) newAccount(ns walletdb.ReadWriteBucket,
 	return putLastAccount(ns, &s.scope, account)
 }
 
+// NewAccountWatchingOnly creates and returns a new account stored in the
+// manager based on the given account name and extended public key.  The
+// account can derive addresses but not their private keys, so transactions
+// spending from it have to be signed elsewhere.  Since only the account public
+// key is stored, the manager doesn't need to be unlocked.  If an account with
+// the same name already exists, ErrDuplicateAccount will be returned.
+func (s *ScopedKeyManager) NewAccountWatchingOnly(ns walletdb.ReadWriteBucket,
+	name string, pubKey *hdkeychain.ExtendedKey) (uint32, error) {
+	if pubKey.IsPrivate() {
+		str := "watch-only accounts must be created from a public key"
+		return 0, managerError(ErrKeyChain, str, nil)
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	// Validate the account name.
+	if err := ValidateAccountName(name); err != nil {
+		return 0, err
+	}
+	// Check that account with the same name does not exist
+	_, err := s.lookupAccount(ns, name)
+	if err == nil {
+		str := fmt.Sprintf("account with the same name already exists")
+		return 0, managerError(ErrDuplicateAccount, str, err)
+	}
+	// Fetch the latest account number to generate the next account number.
+	account, err := fetchLastAccount(ns, &s.scope)
+	if err != nil {
+		log.ERROR(err)
+		return 0, err
+	}
+	account++
+	// Encrypt the account public key with the crypto public key.  There is
+	// no private key, so an empty one is stored in its place.
+	acctPubEnc, err := s.rootManager.cryptoKeyPub.Encrypt(
+		[]byte(pubKey.String()),
+	)
+	if err != nil {
+		log.ERROR(err)
+		str := "failed to encrypt public key for account"
+		return 0, managerError(ErrCrypto, str, err)
+	}
+	err = putAccountInfo(
+		ns, &s.scope, account, acctPubEnc, nil, 0, 0, name,
+	)
+	if err != nil {
+		log.ERROR(err)
+		return 0, err
+	}
+	// Save last account metadata
+	if err := putLastAccount(ns, &s.scope, account); err != nil {
+		return 0, err
+	}
+	return account, nil
+}
+
 // RenameAccount renames an account stored in the manager based on the given
 // account number with the given name.  If an account with the same name
 // already exists, ErrDuplicateAccount will be returned.
@@ -0,0 +1,386 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txauthor "github.com/p9c/pod/pkg/chain/tx/author"
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	txrules "github.com/p9c/pod/pkg/chain/tx/rules"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	txsizes "github.com/p9c/pod/pkg/chain/tx/sizes"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/util"
+	walletdb "github.com/p9c/pod/pkg/wallet/db"
+)
+
+// Errors returned when the fee of a transaction can't be bumped.
+var (
+	ErrBumpTxNotFound    = errors.New("transaction is not in the wallet")
+	ErrBumpTxConfirmed   = errors.New("transaction is already confirmed")
+	ErrBumpForeignInputs = errors.New("transaction spends outputs the " +
+		"wallet does not control")
+	ErrBumpNoChange = errors.New("transaction has no unspent change " +
+		"output to pay the fee from")
+	ErrBumpChangeTooSmall = errors.New("change output is too small to pay " +
+		"the fee")
+	ErrBumpForeignDescendants = errors.New("transaction is spent by " +
+		"transactions paying fees the wallet does not know")
+)
+
+// BumpedTx describes the transaction created to get a stuck transaction
+// mined sooner.
+type BumpedTx struct {
+	// Tx is the replacement of the stuck transaction, or the child spending
+	// its change if CPFP is true.
+	Tx *wire.MsgTx
+	// CPFP is whether Tx is a child paying for its parent rather than a
+	// replacement.
+	CPFP bool
+	// OrigFee is the fee paid by the stuck transaction.
+	OrigFee util.Amount
+	// Fee is the fee paid by Tx.
+	Fee util.Amount
+}
+
+// BumpFee raises the fee paid to mine an unconfirmed transaction of the
+// wallet. If the transaction signals that it can be replaced and cpfp is
+// false it is replaced by a transaction paying the higher fee from its change
+// output, which is signed again and recorded as the replacement, otherwise a
+// child transaction spending the change output is created to pay for both.
+// The fee rate of the replacement, or of the transaction and its child
+// together, is feeSatPerKb, or the fee rate of the transaction plus the
+// default relay fee if it is zero, and is raised to the minimum that will be
+// accepted in its place if needed. A replacement also pays the fees of the
+// transactions of the wallet spending the outputs of the one it replaces, as
+// they are evicted along with it. The wallet must be unlocked.
+func (w *Wallet) BumpFee(txHash *chainhash.Hash, feeSatPerKb util.Amount,
+	cpfp bool) (*BumpedTx, error) {
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	var details *wtxmgr.TxDetails
+	var prevScripts [][]byte
+	err = walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		var err error
+		details, err = w.TxStore.TxDetails(txmgrNs, txHash)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		if details == nil {
+			return ErrBumpTxNotFound
+		}
+		if details.Block.Height != -1 {
+			return ErrBumpTxConfirmed
+		}
+		prevScripts, err = w.TxStore.PreviousPkScripts(txmgrNs,
+			&details.TxRecord, nil)
+		return err
+	})
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	// The fee is only known if every input spends an output of the wallet,
+	// which is also needed to sign a replacement.
+	msgTx := &details.MsgTx
+	if len(details.Debits) != len(msgTx.TxIn) ||
+		len(prevScripts) != len(msgTx.TxIn) {
+		return nil, ErrBumpForeignInputs
+	}
+	inputValues := make([]util.Amount, len(msgTx.TxIn))
+	var origFee util.Amount
+	for _, debit := range details.Debits {
+		inputValues[debit.Index] = debit.Amount
+		origFee += debit.Amount
+	}
+	for _, txOut := range msgTx.TxOut {
+		origFee -= util.Amount(txOut.Value)
+	}
+	origSize := msgTx.SerializeSize()
+	if feeSatPerKb == 0 {
+		feeSatPerKb = origFee*1000/util.Amount(origSize) +
+			txrules.DefaultRelayFeePerKb
+	}
+	// A child can only spend change that isn't spent yet, while spending
+	// it doesn't matter to a replacement.
+	cpfp = cpfp || !signalsReplacement(msgTx)
+	change := -1
+	for _, credit := range details.Credits {
+		if credit.Change && (!cpfp || !credit.Spent) {
+			change = int(credit.Index)
+			break
+		}
+	}
+	if change < 0 {
+		return nil, ErrBumpNoChange
+	}
+	if cpfp {
+		return w.payForParent(details, change, origFee, feeSatPerKb)
+	}
+	var descendants []*wtxmgr.TxDetails
+	var descendantFees util.Amount
+	err = walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+		var err error
+		descendants, descendantFees, err = w.unminedDescendants(
+			dbtx.ReadBucket(wtxmgrNamespaceKey), txHash)
+		return err
+	})
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	// The signatures of the replacement may each be a byte longer. It has to
+	// pay for every transaction it evicts as well as for its own relay.
+	size := origSize + len(msgTx.TxIn)
+	fee := txrules.FeeForSerializeSize(feeSatPerKb, size)
+	minFee := origFee + descendantFees + txrules.FeeForSerializeSize(
+		txrules.DefaultRelayFeePerKb, size)
+	if fee < minFee {
+		fee = minFee
+	}
+	tx := msgTx.Copy()
+	changeOut := tx.TxOut[change]
+	changeOut.Value -= int64(fee - origFee)
+	if changeOut.Value < 0 || txrules.IsDustOutput(changeOut,
+		txrules.DefaultRelayFeePerKb) {
+		return nil, ErrBumpChangeTooSmall
+	}
+	for _, txIn := range tx.TxIn {
+		txIn.SignatureScript = nil
+		txIn.Witness = nil
+	}
+	err = w.signBumpTx(tx, prevScripts, inputValues)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	// The original must be removed before the replacement is added as they
+	// spend the same outputs. Its comment is kept with the replacement.
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		txmgrNs := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+		err := w.TxStore.ReplaceUnminedTx(txmgrNs, &details.TxRecord,
+			&rec.Hash)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		if label := fetchTxLabel(dbtx, txHash); label != nil {
+			if err := putTxLabel(dbtx, &rec.Hash, label); err != nil {
+				log.ERROR(err)
+				return err
+			}
+		}
+		return w.addRelevantTx(dbtx, rec, nil)
+	})
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	_, err = chainClient.SendRawTransaction(tx, false)
+	if err != nil {
+		log.ERROR(err)
+		// Put the original and the transactions spending it back so they
+		// are still rebroadcast.
+		dbErr := walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+			txmgrNs := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+			err := w.TxStore.RemoveUnminedTx(txmgrNs, rec)
+			if err != nil {
+				log.ERROR(err)
+				return err
+			}
+			if err := putTxLabel(dbtx, &rec.Hash, nil); err != nil {
+				log.ERROR(err)
+				return err
+			}
+			if err := w.addRelevantTx(dbtx, &details.TxRecord,
+				nil); err != nil {
+				log.ERROR(err)
+				return err
+			}
+			for _, d := range descendants {
+				if err := w.addRelevantTx(dbtx, &d.TxRecord, nil); err != nil {
+					log.ERROR(err)
+					return err
+				}
+			}
+			return nil
+		})
+		if dbErr != nil {
+			return nil, fmt.Errorf("unable to broadcast replacement: %v, "+
+				"unable to restore replaced tx: %v", err, dbErr)
+		}
+		return nil, err
+	}
+	return &BumpedTx{Tx: tx, OrigFee: origFee, Fee: fee}, nil
+}
+
+// TxReplacedBy returns the hash of the transaction that replaced an unmined
+// transaction of the wallet, or nil if it was not replaced.
+func (w *Wallet) TxReplacedBy(txHash *chainhash.Hash) (replacedBy *chainhash.Hash,
+	err error) {
+	err = walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		replacedBy = w.TxStore.ReplacedBy(txmgrNs, txHash)
+		return nil
+	})
+	return
+}
+
+// unminedDescendants returns the unmined transactions of the wallet spending
+// outputs of a transaction, directly or through one another, in dependency
+// order along with the total of their fees.
+func (w *Wallet) unminedDescendants(txmgrNs walletdb.ReadBucket,
+	txHash *chainhash.Hash) (descendants []*wtxmgr.TxDetails,
+	fees util.Amount, err error) {
+	unmined, err := w.TxStore.UnminedTxs(txmgrNs)
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	// parents come before their children so one pass finds all of them
+	spent := map[chainhash.Hash]struct{}{*txHash: {}}
+	for _, tx := range unmined {
+		var child bool
+		for _, txIn := range tx.TxIn {
+			if _, ok := spent[txIn.PreviousOutPoint.Hash]; ok {
+				child = true
+				break
+			}
+		}
+		if !child {
+			continue
+		}
+		hash := tx.TxHash()
+		spent[hash] = struct{}{}
+		var details *wtxmgr.TxDetails
+		if details, err = w.TxStore.TxDetails(txmgrNs, &hash); err != nil {
+			log.ERROR(err)
+			return
+		}
+		if details == nil || len(details.Debits) != len(tx.TxIn) {
+			return nil, 0, ErrBumpForeignDescendants
+		}
+		for _, debit := range details.Debits {
+			fees += debit.Amount
+		}
+		for _, txOut := range tx.TxOut {
+			fees -= util.Amount(txOut.Value)
+		}
+		descendants = append(descendants, details)
+	}
+	return
+}
+
+// payForParent creates and publishes a transaction spending the change output
+// of an unconfirmed transaction to the same account with a fee that brings
+// the fee rate of both together to feeSatPerKb.
+func (w *Wallet) payForParent(details *wtxmgr.TxDetails, change int,
+	origFee, feeSatPerKb util.Amount) (*BumpedTx, error) {
+	parentOut := details.MsgTx.TxOut[change]
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&details.Hash, uint32(change)),
+		nil, nil))
+	err := walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		addrmgrNs := dbtx.ReadWriteBucket(waddrmgrNamespaceKey)
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+			parentOut.PkScript, w.chainParams)
+		if err != nil || len(addrs) != 1 {
+			return ErrBumpNoChange
+		}
+		_, account, err := w.Manager.AddrAccount(addrmgrNs, addrs[0])
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		addr, err := w.newChangeAddress(addrmgrNs, account)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		tx.AddTxOut(wire.NewTxOut(0, pkScript))
+		return nil
+	})
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	var size int
+	if txscript.IsPayToWitnessPubKeyHash(parentOut.PkScript) {
+		size = txsizes.EstimateVirtualSize(0, 1, 0, tx.TxOut, false)
+	} else {
+		size = txsizes.EstimateSerializeSize(1, tx.TxOut, false)
+	}
+	fee := txrules.FeeForSerializeSize(feeSatPerKb,
+		size+details.MsgTx.SerializeSize()) - origFee
+	minFee := txrules.FeeForSerializeSize(txrules.DefaultRelayFeePerKb, size)
+	if fee < minFee {
+		fee = minFee
+	}
+	tx.TxOut[0].Value = parentOut.Value - int64(fee)
+	if tx.TxOut[0].Value < 0 || txrules.IsDustOutput(tx.TxOut[0],
+		txrules.DefaultRelayFeePerKb) {
+		return nil, ErrBumpChangeTooSmall
+	}
+	err = w.signBumpTx(tx, [][]byte{parentOut.PkScript},
+		[]util.Amount{util.Amount(parentOut.Value)})
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	if _, err := w.publishTransaction(tx, nil); err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	return &BumpedTx{Tx: tx, CPFP: true, OrigFee: origFee, Fee: fee}, nil
+}
+
+// signBumpTx signs every input of a transaction spending outputs of the
+// wallet and checks the result.
+func (w *Wallet) signBumpTx(tx *wire.MsgTx, prevScripts [][]byte,
+	inputValues []util.Amount) error {
+	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+		addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+		return txauthor.AddAllInputScripts(tx, prevScripts, inputValues,
+			secretSource{w.Manager, addrmgrNs})
+	})
+	if err != nil {
+		log.ERROR(err)
+		return err
+	}
+	return validateMsgTx(tx, prevScripts, inputValues)
+}
+
+// replaceableSequence is the highest sequence number of an input which
+// signals that its transaction can be replaced by a transaction paying a higher
+// fee as described by BIP125.
+const replaceableSequence = wire.MaxTxInSequenceNum - 2
+
+// signalsReplacement returns whether a transaction signals that it can be
+// replaced by one of its inputs having a sequence number no higher than
+// replaceableSequence.
+func signalsReplacement(tx *wire.MsgTx) bool {
+	for _, txIn := range tx.TxIn {
+		if txIn.Sequence <= replaceableSequence {
+			return true
+		}
+	}
+	return false
+}
@@ -16,6 +16,7 @@ func BackEnds() []string {
 	return []string{
 		"bitcoind",
 		"pod",
+		"node",
 		"neutrino",
 	}
 }
@@ -0,0 +1,573 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/p9c/pod/cmd/node/rpc"
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	tm "github.com/p9c/pod/pkg/chain/tx/mgr"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+	wm "github.com/p9c/pod/pkg/wallet/addrmgr"
+)
+
+var (
+	// ErrNodeClientShuttingDown is an error returned when we attempt
+	// to receive a notification for a specific item and the node client
+	// is in the middle of shutting down.
+	ErrNodeClientShuttingDown = errors.New("client is shutting down")
+)
+
+// NodeClient is a chain client for a node running in the same process as the
+// wallet. It reads the chain straight from the node's BlockChain and receives
+// block and mempool notifications from the notification manager of the node's
+// RPC server, so there is no RPC connection to set up and no credentials or
+// certificates are needed.
+type NodeClient struct {
+	started int32 // To be used atomically.
+	stopped int32 // To be used atomically.
+	// chainParams are the parameters of the current chain this client is
+	// active under.
+	chainParams *netparams.Params
+	// server is the RPC server of the node, used for its notifications and
+	// to send transactions the way sendrawtransaction does.
+	server *rpc.Server
+	chain  *blockchain.BlockChain
+	// listener receives the notifications of the node into nodeQueue, which
+	// holds them while a rescan runs.
+	listener  *rpc.WSListener
+	nodeQueue *ConcurrentQueue
+	// bestBlock is the last block the client has sent a notification for.
+	bestBlockMtx sync.RWMutex
+	bestBlock    wm.BlockStamp
+	// notifyBlocks signals whether the client is sending block
+	// notifications to the caller.
+	notifyBlocks uint32
+	// rescanRequests is used to run rescans in the notification handler so
+	// they are in order with the notifications of the node.
+	rescanRequests chan *nodeRescan
+	// watchMtx guards the addresses and outpoints the client sends
+	// notifications of transactions for.
+	watchMtx         sync.RWMutex
+	watchedAddresses map[string]struct{}
+	watchedOutPoints map[wire.OutPoint]struct{}
+	// notificationQueue is a concurrent unbounded queue that handles
+	// dispatching notifications to the subscriber of this client.
+	notificationQueue *ConcurrentQueue
+	quit              chan struct{}
+	wg                sync.WaitGroup
+}
+
+// nodeRescan is a request to rescan the chain from a block.
+type nodeRescan struct {
+	start chainhash.Hash
+	err   chan error
+}
+
+// Compile time check to ensure NodeClient satisfies the chain.Interface
+// interface.
+var _ Interface = (*NodeClient)(nil)
+
+// NewNodeClient creates a chain client for the node of the RPC server, which
+// must be running in the same process and on the same network. The client
+// does nothing until it is started.
+func NewNodeClient(chainParams *netparams.Params,
+	server *rpc.Server) *NodeClient {
+	quit := make(chan struct{})
+	nodeQueue := NewConcurrentQueue(20)
+	return &NodeClient{
+		chainParams: chainParams,
+		server:      server,
+		chain:       server.Cfg.Chain,
+		listener: &rpc.WSListener{
+			Notifications: nodeQueue.ChanIn(),
+			Quit:          quit,
+		},
+		nodeQueue:         nodeQueue,
+		rescanRequests:    make(chan *nodeRescan),
+		watchedAddresses:  make(map[string]struct{}),
+		watchedOutPoints:  make(map[wire.OutPoint]struct{}),
+		notificationQueue: NewConcurrentQueue(20),
+		quit:              quit,
+	}
+}
+
+// BackEnd returns the name of the driver.
+func (c *NodeClient) BackEnd() string {
+	return "node"
+}
+
+// Start subscribes the client to the notifications of the node and starts
+// the goroutine that handles them.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *NodeClient) Start() error {
+	if !atomic.CompareAndSwapInt32(&c.started, 0, 1) {
+		return nil
+	}
+	if c.server.Cfg.ChainParams.Net != c.chainParams.Net {
+		return errors.New("mismatched networks")
+	}
+	c.notificationQueue.Start()
+	c.nodeQueue.Start()
+	c.notificationQueue.ChanIn() <- ClientConnected{}
+	// The listener is registered before the best block is read so no block
+	// after it can be missed, earlier ones are sent again at worst.
+	c.server.NtfnMgr.RegisterListener(c.listener)
+	best := c.chain.BestSnapshot()
+	header, err := c.chain.HeaderByHash(&best.Hash)
+	if err != nil {
+		log.ERROR(err)
+		return err
+	}
+	c.bestBlockMtx.Lock()
+	c.bestBlock = wm.BlockStamp{
+		Hash:      best.Hash,
+		Height:    best.Height,
+		Timestamp: header.Timestamp,
+	}
+	c.bestBlockMtx.Unlock()
+	c.wg.Add(1)
+	go c.handler()
+	return nil
+}
+
+// Stop unsubscribes the client from the notifications of the node and stops
+// its goroutines.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *NodeClient) Stop() {
+	if !atomic.CompareAndSwapInt32(&c.stopped, 0, 1) {
+		return
+	}
+	close(c.quit)
+	c.server.NtfnMgr.UnregisterListener(c.listener)
+	c.nodeQueue.Stop()
+	c.notificationQueue.Stop()
+}
+
+// WaitForShutdown blocks until the client has been stopped and its handler
+// has exited.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *NodeClient) WaitForShutdown() {
+	c.wg.Wait()
+}
+
+// GetBestBlock returns the hash and height of the best block of the node.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *NodeClient) GetBestBlock() (*chainhash.Hash, int32, error) {
+	best := c.chain.BestSnapshot()
+	return &best.Hash, best.Height, nil
+}
+
+// GetBlock returns the block of the main chain with the hash.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *NodeClient) GetBlock(hash *chainhash.Hash) (*wire.MsgBlock, error) {
+	block, err := c.chain.BlockByHash(hash)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	return block.MsgBlock(), nil
+}
+
+// GetBlockHash returns the hash of the block of the main chain at the height.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *NodeClient) GetBlockHash(height int64) (*chainhash.Hash, error) {
+	return c.chain.BlockHashByHeight(int32(height))
+}
+
+// GetBlockHeader returns the header of the block with the hash.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *NodeClient) GetBlockHeader(
+	hash *chainhash.Hash) (*wire.BlockHeader, error) {
+	header, err := c.chain.HeaderByHash(hash)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	return &header, nil
+}
+
+// GetBlockHeight returns the height of the block of the main chain with the
+// hash.
+func (c *NodeClient) GetBlockHeight(hash *chainhash.Hash) (int32, error) {
+	return c.chain.BlockHeightByHash(hash)
+}
+
+// IsCurrent returns whether the node believes it has caught up with the
+// rest of the network.
+func (c *NodeClient) IsCurrent() bool {
+	return c.chain.IsCurrent()
+}
+
+// BlockStamp returns the latest block notified by the client.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *NodeClient) BlockStamp() (*wm.BlockStamp, error) {
+	c.bestBlockMtx.RLock()
+	bestBlock := c.bestBlock
+	c.bestBlockMtx.RUnlock()
+	return &bestBlock, nil
+}
+
+// FilterBlocks scans the blocks contained in the FilterBlocksRequest for any
+// addresses of interest. Each block is read from the node and filtered in
+// turn, returning a FilterBlocksResponse for the first block containing a
+// matching address. If no matches are found in the range of blocks requested,
+// the returned response will be nil.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *NodeClient) FilterBlocks(
+	req *FilterBlocksRequest) (*FilterBlocksResponse, error) {
+	blockFilterer := NewBlockFilterer(c.chainParams, req)
+	for i, block := range req.Blocks {
+		rawBlock, err := c.GetBlock(&block.Hash)
+		if err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+		if !blockFilterer.FilterBlock(rawBlock) {
+			continue
+		}
+		resp := &FilterBlocksResponse{
+			BatchIndex:         uint32(i),
+			BlockMeta:          block,
+			FoundExternalAddrs: blockFilterer.FoundExternal,
+			FoundInternalAddrs: blockFilterer.FoundInternal,
+			FoundOutPoints:     blockFilterer.FoundOutPoints,
+			RelevantTxns:       blockFilterer.RelevantTxns,
+		}
+		return resp, nil
+	}
+	// No addresses were found for this range.
+	return nil, nil
+}
+
+// SendRawTransaction adds a transaction to the mempool of the node and
+// relays it the same way the sendrawtransaction command does.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *NodeClient) SendRawTransaction(tx *wire.MsgTx,
+	allowHighFees bool) (*chainhash.Hash, error) {
+	var buf bytes.Buffer
+	buf.Grow(tx.SerializeSize())
+	if err := tx.Serialize(&buf); err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	cmd := btcjson.NewSendRawTransactionCmd(hex.EncodeToString(buf.Bytes()),
+		&allowHighFees)
+	result, err := rpc.HandleSendRawTransaction(c.server, cmd, c.quit)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	return chainhash.NewHashFromStr(result.(string))
+}
+
+// RawRequest runs a command of the node's RPC server in this process, for
+// passing through the commands the wallet doesn't handle itself.
+func (c *NodeClient) RawRequest(method string,
+	params []json.RawMessage) (json.RawMessage, error) {
+	cmd := rpc.ParseCmd(&btcjson.Request{
+		Jsonrpc: "1.0",
+		Method:  method,
+		Params:  params,
+	})
+	if cmd.Err != nil {
+		return nil, cmd.Err
+	}
+	result, err := c.server.StandardCmdResult(cmd, c.quit)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+// Rescan scans the chain from the block with the hash to the best block for
+// transactions paying to the addresses or spending the outpoints, which are
+// watched from then on. It returns once the rescan has finished.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *NodeClient) Rescan(startHash *chainhash.Hash, addrs []util.Address,
+	outPoints map[wire.OutPoint]util.Address) error {
+	if startHash == nil {
+		return errors.New("rescan requires a starting block hash")
+	}
+	c.watchMtx.Lock()
+	for _, addr := range addrs {
+		c.watchedAddresses[addr.String()] = struct{}{}
+	}
+	for op := range outPoints {
+		c.watchedOutPoints[op] = struct{}{}
+	}
+	c.watchMtx.Unlock()
+	r := &nodeRescan{start: *startHash, err: make(chan error, 1)}
+	select {
+	case c.rescanRequests <- r:
+	case <-c.quit:
+		return ErrNodeClientShuttingDown
+	}
+	select {
+	case err := <-r.err:
+		return err
+	case <-c.quit:
+		return ErrNodeClientShuttingDown
+	}
+}
+
+// NotifyReceived adds addresses to watch for transactions paying to them.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *NodeClient) NotifyReceived(addrs []util.Address) error {
+	c.watchMtx.Lock()
+	for _, addr := range addrs {
+		c.watchedAddresses[addr.String()] = struct{}{}
+	}
+	c.watchMtx.Unlock()
+	return nil
+}
+
+// NotifyBlocks allows the client to send block connected and disconnected
+// notifications.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *NodeClient) NotifyBlocks() error {
+	atomic.StoreUint32(&c.notifyBlocks, 1)
+	return nil
+}
+
+// shouldNotifyBlocks determines whether the client should send block
+// notifications to the caller.
+func (c *NodeClient) shouldNotifyBlocks() bool {
+	return atomic.LoadUint32(&c.notifyBlocks) == 1
+}
+
+// Notifications returns a channel to retrieve notifications from.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *NodeClient) Notifications() <-chan interface{} {
+	return c.notificationQueue.ChanOut()
+}
+
+// notify queues a notification for the caller.
+func (c *NodeClient) notify(n interface{}) {
+	select {
+	case c.notificationQueue.ChanIn() <- n:
+	case <-c.quit:
+	}
+}
+
+// handler handles the notifications of the node and the rescans in the order
+// they arrive.
+//
+// NOTE: This must be called as a goroutine.
+func (c *NodeClient) handler() {
+	defer c.wg.Done()
+	for {
+		select {
+		case n := <-c.nodeQueue.ChanOut():
+			c.handleNodeNotification(n)
+		case r := <-c.rescanRequests:
+			r.err <- c.rescan(r.start)
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// handleNodeNotification sends the notifications of a block or mempool
+// transaction of the node the caller needs.
+func (c *NodeClient) handleNodeNotification(n interface{}) {
+	switch n := n.(type) {
+	case *rpc.NotificationBlockConnected:
+		block := (*util.Block)(n)
+		meta := blockMeta(block)
+		c.filterBlock(block, meta)
+		c.bestBlockMtx.Lock()
+		c.bestBlock = wm.BlockStamp{
+			Hash:      meta.Hash,
+			Height:    meta.Height,
+			Timestamp: meta.Time,
+		}
+		c.bestBlockMtx.Unlock()
+		if c.shouldNotifyBlocks() {
+			c.notify(BlockConnected(*meta))
+		}
+	case *rpc.NotificationBlockDisconnected:
+		block := (*util.Block)(n)
+		meta := blockMeta(block)
+		prevHash := block.MsgBlock().Header.PrevBlock
+		prevHeader, err := c.chain.HeaderByHash(&prevHash)
+		if err != nil {
+			log.ERROR(err)
+			return
+		}
+		c.bestBlockMtx.Lock()
+		c.bestBlock = wm.BlockStamp{
+			Hash:      prevHash,
+			Height:    meta.Height - 1,
+			Timestamp: prevHeader.Timestamp,
+		}
+		c.bestBlockMtx.Unlock()
+		if c.shouldNotifyBlocks() {
+			c.notify(BlockDisconnected(*meta))
+		}
+	case *rpc.NotificationTxAcceptedByMempool:
+		c.filterTx(n.Tx, nil)
+	default:
+		log.WARNF("received unexpected notification type %T", n)
+	}
+}
+
+// rescan sends notifications of the watched transactions in the blocks from
+// the start block to the best block, with progress notifications along the
+// way and one when it is finished.
+func (c *NodeClient) rescan(start chainhash.Hash) error {
+	height, err := c.chain.BlockHeightByHash(&start)
+	if err != nil {
+		log.ERROR(err)
+		return err
+	}
+	// A ticker is used to wait at least 10 seconds between progress
+	// notifications, the same as the rescan of the node's RPC server.
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	var last *util.Block
+	for height <= c.chain.BestSnapshot().Height {
+		select {
+		case <-c.quit:
+			return ErrNodeClientShuttingDown
+		default:
+		}
+		hash, err := c.chain.BlockHashByHeight(height)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		block, err := c.chain.BlockByHash(hash)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		if last != nil &&
+			block.MsgBlock().Header.PrevBlock != *last.Hash() {
+			// The chain was reorganized since the last block, so the
+			// block at its height is scanned again. The notifications of
+			// the reorganization are handled after the rescan.
+			height = last.Height()
+			last = nil
+			continue
+		}
+		c.filterBlock(block, blockMeta(block))
+		last = block
+		height++
+		select {
+		case <-ticker.C:
+			c.notify(&RescanProgress{
+				Hash:   last.Hash(),
+				Height: last.Height(),
+				Time:   last.MsgBlock().Header.Timestamp,
+			})
+		default:
+		}
+	}
+	if last == nil {
+		return errors.New("rescan did not scan any blocks")
+	}
+	c.notify(&RescanFinished{
+		Hash:   last.Hash(),
+		Height: last.Height(),
+		Time:   last.MsgBlock().Header.Timestamp,
+	})
+	return nil
+}
+
+// filterBlock sends a notification for each transaction of a block that is
+// relevant to the caller.
+func (c *NodeClient) filterBlock(block *util.Block, meta *tm.BlockMeta) {
+	for _, tx := range block.Transactions() {
+		c.filterTx(tx, meta)
+	}
+}
+
+// filterTx sends a notification of a transaction if it pays to a watched
+// address or spends a watched outpoint. The outputs paying to watched
+// addresses are watched from then on so spending them is noticed as well.
+func (c *NodeClient) filterTx(tx *util.Tx, block *tm.BlockMeta) {
+	msgTx := tx.MsgTx()
+	var isRelevant bool
+	c.watchMtx.Lock()
+	for i, out := range msgTx.TxOut {
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+			out.PkScript, c.chainParams,
+		)
+		if err != nil {
+			log.DEBUGF(
+				"unable to parse output script in %s:%d: %v",
+				tx.Hash(), i, err,
+			)
+			continue
+		}
+		for _, addr := range addrs {
+			if _, ok := c.watchedAddresses[addr.String()]; ok {
+				isRelevant = true
+				op := wire.OutPoint{Hash: *tx.Hash(), Index: uint32(i)}
+				c.watchedOutPoints[op] = struct{}{}
+			}
+		}
+	}
+	if !isRelevant {
+		for _, in := range msgTx.TxIn {
+			if _, ok := c.watchedOutPoints[in.PreviousOutPoint]; ok {
+				isRelevant = true
+				break
+			}
+		}
+	}
+	c.watchMtx.Unlock()
+	if !isRelevant {
+		return
+	}
+	received := time.Now()
+	if block != nil {
+		received = block.Time
+	}
+	rec, err := tm.NewTxRecordFromMsgTx(msgTx, received)
+	if err != nil {
+		log.ERROR(
+			"cannot create transaction record for relevant tx:", err,
+		)
+		return
+	}
+	c.notify(RelevantTx{TxRecord: rec, Block: block})
+}
+
+// blockMeta returns the height, hash and time of a block of the main chain.
+func blockMeta(block *util.Block) *tm.BlockMeta {
+	return &tm.BlockMeta{
+		Block: tm.Block{
+			Hash:   *block.Hash(),
+			Height: block.Height(),
+		},
+		Time: block.MsgBlock().Header.Timestamp,
+	}
+}
@@ -0,0 +1,237 @@
+package chain
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/cmd/node/rpc"
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	database "github.com/p9c/pod/pkg/db"
+	_ "github.com/p9c/pod/pkg/db/ffldb"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// newTestNodeClient returns a client for a node whose RPC server only has a
+// regression test chain holding the genesis block, along with a function
+// removing its database which the caller must invoke when done. The client
+// is not started, only its notification queue is.
+func newTestNodeClient(t *testing.T, dbName string) (*NodeClient, func()) {
+	params := &netparams.RegressionTestParams
+	dbPath := filepath.Join(os.TempDir(), dbName)
+	_ = os.RemoveAll(dbPath)
+	db, err := database.Create("ffldb", dbPath, params.Net)
+	if err != nil {
+		t.Fatalf("error creating db: %v", err)
+	}
+	teardown := func() {
+		db.Close()
+		os.RemoveAll(dbPath)
+	}
+	chain, err := blockchain.New(&blockchain.Config{
+		DB:          db,
+		ChainParams: params,
+		TimeSource:  blockchain.NewMedianTime(),
+	})
+	if err != nil {
+		teardown()
+		t.Fatalf("failed to create chain instance: %v", err)
+	}
+	server := &rpc.Server{Cfg: rpc.ServerConfig{
+		Chain:       chain,
+		ChainParams: params,
+		DB:          db,
+	}}
+	c := NewNodeClient(params, server)
+	c.notificationQueue.Start()
+	return c, func() {
+		c.notificationQueue.Stop()
+		teardown()
+	}
+}
+
+// nextNotification returns the next notification the client sends.
+func nextNotification(t *testing.T, c *NodeClient) interface{} {
+	t.Helper()
+	select {
+	case n := <-c.Notifications():
+		return n
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a notification")
+	}
+	return nil
+}
+
+// testBlock returns a block at the height on top of the previous block with
+// the transactions, which is only used for its notifications and so isn't
+// valid or in the chain.
+func testBlock(prev *chainhash.Hash, height int32,
+	txs ...*wire.MsgTx) *util.Block {
+	block := util.NewBlock(&wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Version:   1,
+			PrevBlock: *prev,
+			Timestamp: time.Unix(1600000000, 0),
+		},
+		Transactions: txs,
+	})
+	block.SetHeight(height)
+	return block
+}
+
+func TestNodeClientBlockNotifications(t *testing.T) {
+	c, teardown := newTestNodeClient(t, "nodeclientblocks")
+	defer teardown()
+	genesis, _, err := c.GetBestBlock()
+	if err != nil {
+		t.Fatalf("GetBestBlock: unexpected error: %v", err)
+	}
+	genesisHeader, err := c.GetBlockHeader(genesis)
+	if err != nil {
+		t.Fatalf("GetBlockHeader: unexpected error: %v", err)
+	}
+	block := testBlock(genesis, 1)
+	// The best block is kept without block notifications being sent.
+	c.handleNodeNotification((*rpc.NotificationBlockConnected)(block))
+	stamp, err := c.BlockStamp()
+	if err != nil {
+		t.Fatalf("BlockStamp: unexpected error: %v", err)
+	}
+	if stamp.Hash != *block.Hash() || stamp.Height != 1 ||
+		!stamp.Timestamp.Equal(block.MsgBlock().Header.Timestamp) {
+		t.Errorf("block stamp %+v after the block was connected", stamp)
+	}
+	if err = c.NotifyBlocks(); err != nil {
+		t.Fatalf("NotifyBlocks: unexpected error: %v", err)
+	}
+	c.handleNodeNotification((*rpc.NotificationBlockDisconnected)(block))
+	disconnected, ok := nextNotification(t, c).(BlockDisconnected)
+	if !ok {
+		t.Fatalf("first notification is not the disconnected block")
+	}
+	if disconnected.Hash != *block.Hash() || disconnected.Height != 1 {
+		t.Errorf("disconnected block %+v", disconnected)
+	}
+	stamp, _ = c.BlockStamp()
+	if stamp.Hash != *genesis || stamp.Height != 0 ||
+		!stamp.Timestamp.Equal(genesisHeader.Timestamp) {
+		t.Errorf("block stamp %+v after the block was disconnected", stamp)
+	}
+	c.handleNodeNotification((*rpc.NotificationBlockConnected)(block))
+	connected, ok := nextNotification(t, c).(BlockConnected)
+	if !ok {
+		t.Fatalf("notification is not the connected block")
+	}
+	if connected.Hash != *block.Hash() || connected.Height != 1 ||
+		!connected.Time.Equal(block.MsgBlock().Header.Timestamp) {
+		t.Errorf("connected block %+v", connected)
+	}
+}
+
+func TestNodeClientRelevantTx(t *testing.T) {
+	c, teardown := newTestNodeClient(t, "nodeclientrelevanttx")
+	defer teardown()
+	addr, err := util.NewAddressPubKeyHash(make([]byte, 20), c.chainParams)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+	unrelated := wire.NewMsgTx(1)
+	unrelated.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Index: 0}})
+	unrelated.AddTxOut(&wire.TxOut{Value: 1000,
+		PkScript: []byte{txscript.OP_TRUE}})
+	c.handleNodeNotification(&rpc.NotificationTxAcceptedByMempool{
+		IsNew: true, Tx: util.NewTx(unrelated)})
+	if err = c.NotifyReceived([]util.Address{addr}); err != nil {
+		t.Fatalf("NotifyReceived: unexpected error: %v", err)
+	}
+	// A mempool transaction paying to a watched address is relevant and not
+	// in a block.
+	pay := wire.NewMsgTx(1)
+	pay.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Index: 1}})
+	pay.AddTxOut(&wire.TxOut{Value: 1000, PkScript: script})
+	c.handleNodeNotification(&rpc.NotificationTxAcceptedByMempool{
+		IsNew: true, Tx: util.NewTx(pay)})
+	relevant, ok := nextNotification(t, c).(RelevantTx)
+	if !ok {
+		t.Fatalf("first notification is not a relevant transaction")
+	}
+	if relevant.TxRecord.Hash != pay.TxHash() || relevant.Block != nil {
+		t.Errorf("relevant mempool transaction %v in block %v",
+			relevant.TxRecord.Hash, relevant.Block)
+	}
+	// Spending the output paying to the watched address is relevant too,
+	// and is notified with the block it is in.
+	spend := wire.NewMsgTx(1)
+	spend.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{
+		Hash: pay.TxHash(), Index: 0}})
+	spend.AddTxOut(&wire.TxOut{Value: 900,
+		PkScript: []byte{txscript.OP_TRUE}})
+	genesis, _, _ := c.GetBestBlock()
+	block := testBlock(genesis, 1, unrelated, spend)
+	c.handleNodeNotification((*rpc.NotificationBlockConnected)(block))
+	relevant, ok = nextNotification(t, c).(RelevantTx)
+	if !ok {
+		t.Fatalf("notification is not a relevant transaction")
+	}
+	if relevant.TxRecord.Hash != spend.TxHash() || relevant.Block == nil ||
+		relevant.Block.Hash != *block.Hash() || relevant.Block.Height != 1 {
+		t.Errorf("relevant transaction %v in block %v",
+			relevant.TxRecord.Hash, relevant.Block)
+	}
+}
+
+func TestNodeClientRawRequest(t *testing.T) {
+	c, teardown := newTestNodeClient(t, "nodeclientrawrequest")
+	defer teardown()
+	genesis, _, _ := c.GetBestBlock()
+	genesisJSON, _ := json.Marshal(genesis.String())
+	tests := []struct {
+		name   string
+		method string
+		params []json.RawMessage
+		result string
+		code   btcjson.RPCErrorCode
+	}{
+		{name: "no params", method: "getblockcount", result: "0"},
+		{name: "params", method: "getblockhash",
+			params: []json.RawMessage{json.RawMessage("0")},
+			result: string(genesisJSON)},
+		{name: "unknown method", method: "nosuchmethod",
+			code: btcjson.ErrRPCMethodNotFound.Code},
+		{name: "invalid params", method: "getblockhash",
+			params: []json.RawMessage{json.RawMessage(`"zero"`)},
+			code:   btcjson.ErrRPCInvalidParams.Code},
+		{name: "handler error", method: "getblockhash",
+			params: []json.RawMessage{json.RawMessage("5")},
+			code:   btcjson.ErrRPCOutOfRange},
+	}
+	for _, test := range tests {
+		result, err := c.RawRequest(test.method, test.params)
+		if test.code != 0 {
+			rpcErr, ok := err.(*btcjson.RPCError)
+			if !ok || rpcErr.Code != test.code {
+				t.Errorf("%s: got error %v, want code %d", test.name, err,
+					test.code)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if string(result) != test.result {
+			t.Errorf("%s: got %s, want %s", test.name, result, test.result)
+		}
+	}
+}
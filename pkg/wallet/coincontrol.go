@@ -0,0 +1,358 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txauthor "github.com/p9c/pod/pkg/chain/tx/author"
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	txrules "github.com/p9c/pod/pkg/chain/tx/rules"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	txsizes "github.com/p9c/pod/pkg/chain/tx/sizes"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/wallet/coinset"
+	walletdb "github.com/p9c/pod/pkg/wallet/db"
+)
+
+// CoinSelectionStrategy is how the outputs a transaction spends are chosen
+// from the eligible outputs of an account.
+type CoinSelectionStrategy int
+
+const (
+	// CoinSelectionLargestFirst spends the largest outputs first until the
+	// transaction outputs and fee are paid for.  This is the default.
+	CoinSelectionLargestFirst CoinSelectionStrategy = iota
+	// CoinSelectionBranchAndBound searches for outputs paying for the
+	// transaction outputs and fee closely enough that no change is needed,
+	// and spends the largest outputs first if there are none.
+	CoinSelectionBranchAndBound
+	// CoinSelectionNoAddressReuse spends all outputs paying to an address
+	// together, so no later transaction spending the rest of them links the
+	// address to this one.
+	CoinSelectionNoAddressReuse
+)
+
+// coinSelectionStrategyNames are the names of the coin selection strategies
+// as they are given over RPC.
+var coinSelectionStrategyNames = map[CoinSelectionStrategy]string{
+	CoinSelectionLargestFirst:   "largestfirst",
+	CoinSelectionBranchAndBound: "branchandbound",
+	CoinSelectionNoAddressReuse: "noaddressreuse",
+}
+
+// ErrUnknownCoinSelectionStrategy describes the error condition of a coin
+// selection strategy name that is not one of the strategies.
+var ErrUnknownCoinSelectionStrategy = errors.New("unknown coin selection strategy")
+
+// String returns the name of the coin selection strategy.
+func (s CoinSelectionStrategy) String() string {
+	if name, ok := coinSelectionStrategyNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("CoinSelectionStrategy(%d)", int(s))
+}
+
+// ParseCoinSelectionStrategy returns the coin selection strategy with the
+// name, which is one of largestfirst, branchandbound and noaddressreuse.
+func ParseCoinSelectionStrategy(name string) (CoinSelectionStrategy, error) {
+	for s, n := range coinSelectionStrategyNames {
+		if n == name {
+			return s, nil
+		}
+	}
+	return 0, ErrUnknownCoinSelectionStrategy
+}
+
+// CoinControl holds the options of which outputs a transaction spends and
+// where its change goes.  A nil *CoinControl, like the zero value, chooses
+// outputs of the account largest first and sends change to a new change
+// address of the account, without signalling that the transaction can be
+// replaced.  Locked outputs are never added to those given.
+type CoinControl struct {
+	// Inputs are outputs of the wallet that are always spent.
+	Inputs []wire.OutPoint
+	// InputsOnly is whether only Inputs are spent, without adding other
+	// outputs of the account when they are not enough.
+	InputsOnly bool
+	// Exclude are outputs that are never added to Inputs.
+	Exclude []wire.OutPoint
+	// ExcludeLabeled is whether outputs with a label, or paying to an address
+	// with a label, are never added to Inputs.
+	ExcludeLabeled bool
+	// Strategy is how outputs are added to Inputs.
+	Strategy CoinSelectionStrategy
+	// ChangeAddress is where change goes instead of a new change address of
+	// the account, if it is not nil.
+	ChangeAddress util.Address
+	// MaxInputs is the most inputs the transaction may have, Inputs
+	// included, if it is not zero.
+	MaxInputs int
+	// Replaceable is whether the transaction signals that it can be replaced
+	// by one paying a higher fee as described by BIP125.
+	Replaceable bool
+}
+
+// sequence returns the sequence number of the inputs of the transaction.
+func (cc *CoinControl) sequence() uint32 {
+	if cc != nil && cc.Replaceable {
+		return replaceableSequence
+	}
+	return wire.MaxTxInSequenceNum
+}
+
+// creditCoin is a coinset.Coin for an unspent output of the wallet.  Its value
+// is the amount of the output less the fee of spending it, so that a
+// selection of coins also pays for its own inputs.
+type creditCoin struct {
+	credit   *wtxmgr.Credit
+	fee      util.Amount
+	numConfs int64
+}
+
+func (c *creditCoin) Hash() *chainhash.Hash { return &c.credit.OutPoint.Hash }
+func (c *creditCoin) Index() uint32         { return c.credit.OutPoint.Index }
+func (c *creditCoin) Value() util.Amount    { return c.credit.Amount - c.fee }
+func (c *creditCoin) PkScript() []byte      { return c.credit.PkScript }
+func (c *creditCoin) NumConfs() int64       { return c.numConfs }
+func (c *creditCoin) ValueAge() int64       { return c.numConfs * int64(c.credit.Amount) }
+
+// inputFee returns the most fee spending an output with the script adds to a
+// transaction, estimated the same way as txauthor does.
+func inputFee(pkScript []byte, feeSatPerKb util.Amount) util.Amount {
+	var size int
+	switch {
+	case txscript.IsPayToScriptHash(pkScript):
+		size = txsizes.RedeemNestedP2WPKHInputSize +
+			(txsizes.RedeemP2WPKHInputWitnessWeight+3)/4
+	case txscript.IsPayToWitnessPubKeyHash(pkScript):
+		size = txsizes.RedeemP2WPKHInputSize +
+			(txsizes.RedeemP2WPKHInputWitnessWeight+3)/4
+	default:
+		size = txsizes.RedeemP2PKHInputSize
+	}
+	// the fee of the whole transaction is rounded down, one more atom makes
+	// up for each input
+	return feeSatPerKb*util.Amount(size)/1000 + 1
+}
+
+// selectorInputSource returns an input source spending the credits the
+// selector chooses for each target, with the sequence number.  If the selector
+// finds no selection the fallback input source is used instead, when there is
+// one.
+func selectorInputSource(selector coinset.CoinSelector,
+	eligible []wtxmgr.Credit, feeSatPerKb util.Amount, height int32,
+	sequence uint32, fallback txauthor.InputSource) txauthor.InputSource {
+	coins := make([]coinset.Coin, 0, len(eligible))
+	byOutPoint := make(map[wire.OutPoint]*wtxmgr.Credit, len(eligible))
+	for i := range eligible {
+		c := &creditCoin{
+			credit:   &eligible[i],
+			fee:      inputFee(eligible[i].PkScript, feeSatPerKb),
+			numConfs: int64(confirms(eligible[i].Height, height)),
+		}
+		// outputs worth less than spending them are left alone
+		if c.Value() <= 0 {
+			continue
+		}
+		coins = append(coins, c)
+		byOutPoint[eligible[i].OutPoint] = &eligible[i]
+	}
+	var lastTarget util.Amount
+	return func(target util.Amount) (util.Amount, []*wire.TxIn,
+		[]util.Amount, [][]byte, error) {
+		if target <= 0 {
+			return 0, nil, nil, nil, nil
+		}
+		// Targets only go up so a selection that turned out not to pay
+		// the fee is not chosen again.
+		if target <= lastTarget {
+			target = lastTarget + 1
+		}
+		lastTarget = target
+		selected, err := selector.CoinSelect(target, coins)
+		if err == coinset.ErrCoinsNoSelectionAvailable {
+			if fallback != nil {
+				return fallback(target)
+			}
+			return 0, nil, nil, nil, nil
+		}
+		if err != nil {
+			log.ERROR(err)
+			return 0, nil, nil, nil, err
+		}
+		var total util.Amount
+		var inputs []*wire.TxIn
+		var values []util.Amount
+		var scripts [][]byte
+		for _, coin := range selected.Coins() {
+			c := byOutPoint[wire.OutPoint{Hash: *coin.Hash(),
+				Index: coin.Index()}]
+			in := wire.NewTxIn(&c.OutPoint, nil, nil)
+			in.Sequence = sequence
+			total += c.Amount
+			inputs = append(inputs, in)
+			values = append(values, c.Amount)
+			scripts = append(scripts, c.PkScript)
+		}
+		return total, inputs, values, scripts, nil
+	}
+}
+
+// strategyInputSource returns the input source choosing from the eligible
+// credits with the strategy, spending at most maxInputs of them if it is not
+// negative, with inputs having the sequence number.
+func strategyInputSource(strategy CoinSelectionStrategy,
+	eligible []wtxmgr.Credit, maxInputs int, feeSatPerKb util.Amount,
+	height int32, sequence uint32) (txauthor.InputSource, error) {
+	if maxInputs < 0 {
+		maxInputs = len(eligible)
+	}
+	switch strategy {
+	case CoinSelectionLargestFirst:
+		// the largest ones are spent first, so only those are needed
+		sort.Sort(sort.Reverse(byAmount(eligible)))
+		if len(eligible) > maxInputs {
+			eligible = eligible[:maxInputs]
+		}
+		return makeInputSource(eligible, sequence), nil
+	case CoinSelectionBranchAndBound:
+		// a change output is only worth creating if it is worth more than
+		// creating and later spending it costs
+		costOfChange := txrules.FeeForSerializeSize(feeSatPerKb,
+			txsizes.P2WPKHOutputSize) + inputFee(nil, feeSatPerKb)
+		fallback, _ := strategyInputSource(CoinSelectionLargestFirst,
+			append([]wtxmgr.Credit{}, eligible...), maxInputs, feeSatPerKb,
+			height, sequence)
+		return selectorInputSource(coinset.BranchAndBoundCoinSelector{
+			MaxInputs:    maxInputs,
+			CostOfChange: costOfChange,
+		}, eligible, feeSatPerKb, height, sequence, fallback), nil
+	case CoinSelectionNoAddressReuse:
+		return selectorInputSource(coinset.AddressGroupCoinSelector{
+			MaxInputs: maxInputs,
+		}, eligible, feeSatPerKb, height, sequence, nil), nil
+	}
+	return nil, ErrUnknownCoinSelectionStrategy
+}
+
+// coinControlInputSource returns the input source of a transaction spending
+// outputs of the account, those given by the coin control options and those
+// they choose from the eligible ones, which may be nil for the default choice.
+// Outputs that are given must be of the account and, if they are from a
+// coinbase, mature at the height.
+func (w *Wallet) coinControlInputSource(dbtx walletdb.ReadTx, cc *CoinControl,
+	account uint32, eligible []wtxmgr.Credit, feeSatPerKb util.Amount,
+	height int32) (txauthor.InputSource, error) {
+	if cc == nil {
+		cc = &CoinControl{}
+	}
+	sequence := cc.sequence()
+	addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+	txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+	excluded := make(map[wire.OutPoint]struct{}, len(cc.Exclude))
+	for _, op := range cc.Exclude {
+		excluded[op] = struct{}{}
+	}
+	var selTotal util.Amount
+	var selInputs []*wire.TxIn
+	var selValues []util.Amount
+	var selScripts [][]byte
+	spent := make(map[wire.OutPoint]struct{}, len(cc.Inputs))
+	if len(cc.Inputs) != 0 {
+		unspent, err := w.TxStore.UnspentOutputs(txmgrNs)
+		if err != nil {
+			log.ERROR(err)
+			return nil, err
+		}
+		credits := make(map[wire.OutPoint]*wtxmgr.Credit, len(unspent))
+		for i := range unspent {
+			credits[unspent[i].OutPoint] = &unspent[i]
+		}
+		for i := range cc.Inputs {
+			c, ok := credits[cc.Inputs[i]]
+			if !ok {
+				return nil, fmt.Errorf("%v is not an unspent output of the"+
+					" wallet", cc.Inputs[i])
+			}
+			if _, ok := spent[c.OutPoint]; ok {
+				return nil, fmt.Errorf("%v is selected more than once",
+					c.OutPoint)
+			}
+			if _, ok := excluded[c.OutPoint]; ok {
+				return nil, fmt.Errorf("%v is both selected and excluded",
+					c.OutPoint)
+			}
+			if !w.creditInAccount(addrmgrNs, c, account) {
+				return nil, fmt.Errorf("%v is not an output of account %d",
+					c.OutPoint, account)
+			}
+			if c.FromCoinBase && !confirmed(
+				int32(w.chainParams.CoinbaseMaturity), c.Height, height) {
+				return nil, fmt.Errorf("%v is from a coinbase that is not"+
+					" mature yet", c.OutPoint)
+			}
+			spent[c.OutPoint] = struct{}{}
+			in := wire.NewTxIn(&c.OutPoint, nil, nil)
+			in.Sequence = sequence
+			selTotal += c.Amount
+			selInputs = append(selInputs, in)
+			selValues = append(selValues, c.Amount)
+			selScripts = append(selScripts, c.PkScript)
+		}
+	}
+	maxInputs := -1
+	if cc.MaxInputs > 0 {
+		maxInputs = cc.MaxInputs - len(selInputs)
+		if maxInputs < 0 {
+			return nil, fmt.Errorf("%d outputs are selected but at most %d"+
+				" may be spent", len(selInputs), cc.MaxInputs)
+		}
+	}
+	var rest []wtxmgr.Credit
+	if !cc.InputsOnly {
+		for i := range eligible {
+			op := eligible[i].OutPoint
+			if _, ok := spent[op]; ok {
+				continue
+			}
+			if _, ok := excluded[op]; ok {
+				continue
+			}
+			if cc.ExcludeLabeled {
+				var addr util.Address
+				_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+					eligible[i].PkScript, w.chainParams)
+				if err == nil && len(addrs) == 1 {
+					addr = addrs[0]
+				}
+				if fetchOutputLabel(dbtx, &op, addr) != "" {
+					continue
+				}
+			}
+			rest = append(rest, eligible[i])
+		}
+	}
+	restSource, err := strategyInputSource(cc.Strategy, rest, maxInputs,
+		feeSatPerKb, height, sequence)
+	if err != nil {
+		return nil, err
+	}
+	if len(selInputs) == 0 {
+		return restSource, nil
+	}
+	return func(target util.Amount) (util.Amount, []*wire.TxIn,
+		[]util.Amount, [][]byte, error) {
+		total, inputs, values, scripts, err := restSource(target - selTotal)
+		if err != nil {
+			return 0, nil, nil, nil, err
+		}
+		return selTotal + total,
+			append(append([]*wire.TxIn{}, selInputs...), inputs...),
+			append(append([]util.Amount{}, selValues...), values...),
+			append(append([][]byte{}, selScripts...), scripts...), nil
+	}, nil
+}
@@ -0,0 +1,318 @@
+package wallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+	walletdb "github.com/p9c/pod/pkg/wallet/db"
+	_ "github.com/p9c/pod/pkg/wallet/db/bdb"
+)
+
+// TestParseCoinSelectionStrategy ensures strategies are parsed back from their
+// names.
+func TestParseCoinSelectionStrategy(t *testing.T) {
+	for _, s := range []CoinSelectionStrategy{CoinSelectionLargestFirst,
+		CoinSelectionBranchAndBound, CoinSelectionNoAddressReuse} {
+		got, err := ParseCoinSelectionStrategy(s.String())
+		if err != nil || got != s {
+			t.Errorf("%v parsed as %v: %v", s, got, err)
+		}
+	}
+	if _, err := ParseCoinSelectionStrategy("smallestfirst"); err !=
+		ErrUnknownCoinSelectionStrategy {
+		t.Errorf("unknown strategy parsed: %v", err)
+	}
+}
+
+// TestStrategyInputSource ensures each strategy spends the outputs it is
+// expected to.  With no fee rate spending an output costs one atom, so the
+// values below are one more than what they are worth to a selection.
+func TestStrategyInputSource(t *testing.T) {
+	credit := func(index uint32, amount util.Amount,
+		pkScript byte) wtxmgr.Credit {
+		return wtxmgr.Credit{
+			OutPoint: wire.OutPoint{Index: index},
+			Amount:   amount,
+			PkScript: []byte{pkScript},
+		}
+	}
+	tests := []struct {
+		name      string
+		strategy  CoinSelectionStrategy
+		maxInputs int
+		target    util.Amount
+		want      []uint32
+	}{
+		{"largest first", CoinSelectionLargestFirst, -1, 120000, []uint32{0, 1}},
+		{"largest first limited", CoinSelectionLargestFirst, 1, 120000, []uint32{0}},
+		{"branch and bound single", CoinSelectionBranchAndBound, -1, 100000, []uint32{0}},
+		{"branch and bound pair", CoinSelectionBranchAndBound, -1, 65000, []uint32{2, 3}},
+		{"branch and bound fallback", CoinSelectionBranchAndBound, -1, 110000, []uint32{0, 1}},
+		{"no address reuse", CoinSelectionNoAddressReuse, -1, 45000, []uint32{0, 2}},
+		{"no address reuse limited", CoinSelectionNoAddressReuse, 1, 45000, []uint32{1}},
+	}
+	for _, test := range tests {
+		eligible := []wtxmgr.Credit{
+			credit(0, 100001, 'a'),
+			credit(1, 60001, 'b'),
+			credit(2, 40001, 'a'),
+			credit(3, 25001, 'c'),
+		}
+		source, err := strategyInputSource(test.strategy, eligible,
+			test.maxInputs, 0, 100, wire.MaxTxInSequenceNum)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		_, inputs, _, _, err := source(test.target)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if len(inputs) != len(test.want) {
+			t.Errorf("%s: spent %d outputs, want %d", test.name,
+				len(inputs), len(test.want))
+			continue
+		}
+		for i, in := range inputs {
+			if in.PreviousOutPoint.Index != test.want[i] {
+				t.Errorf("%s: input %d spends output %d, want %d",
+					test.name, i, in.PreviousOutPoint.Index, test.want[i])
+			}
+			if in.Sequence != wire.MaxTxInSequenceNum {
+				t.Errorf("%s: input %d has sequence %d, want %d", test.name,
+					i, in.Sequence, wire.MaxTxInSequenceNum)
+			}
+		}
+	}
+}
+
+// TestCoinControlSequence ensures transactions only signal that they can be
+// replaced when asked to.
+func TestCoinControlSequence(t *testing.T) {
+	var cc *CoinControl
+	if seq := cc.sequence(); seq != wire.MaxTxInSequenceNum {
+		t.Errorf("nil coin control gives sequence %d, want %d", seq,
+			wire.MaxTxInSequenceNum)
+	}
+	if seq := (&CoinControl{}).sequence(); seq != wire.MaxTxInSequenceNum {
+		t.Errorf("default coin control gives sequence %d, want %d", seq,
+			wire.MaxTxInSequenceNum)
+	}
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(&wire.TxIn{Sequence: (&CoinControl{Replaceable: true}).sequence()})
+	if !signalsReplacement(tx) {
+		t.Error("replaceable coin control does not signal replacement")
+	}
+}
+
+var (
+	testPubPass  = []byte("public")
+	testPrivPass = []byte("private")
+)
+
+// testWallet returns an unlocked simnet wallet in a temporary directory, along
+// with a function removing it which the caller must invoke when done.  The
+// wallet is not started.
+func testWallet(t *testing.T) (*Wallet, func()) {
+	dir, err := ioutil.TempDir("", "coincontrol")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	db, err := walletdb.Create("bdb", filepath.Join(dir, WalletDbName))
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("unable to create db: %v", err)
+	}
+	teardown := func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+	seed := make([]byte, 32)
+	params := &netparams.SimNetParams
+	if err = Create(db, testPubPass, testPrivPass, seed, params,
+		time.Now()); err != nil {
+		teardown()
+		t.Fatalf("unable to create wallet: %v", err)
+	}
+	w, err := Open(db, testPubPass, nil, params, 0)
+	if err != nil {
+		teardown()
+		t.Fatalf("unable to open wallet: %v", err)
+	}
+	err = walletdb.View(db, func(tx walletdb.ReadTx) error {
+		return w.Manager.Unlock(tx.ReadBucket(waddrmgrNamespaceKey),
+			testPrivPass)
+	})
+	if err != nil {
+		teardown()
+		t.Fatalf("unable to unlock wallet: %v", err)
+	}
+	return w, teardown
+}
+
+// addTestTx records the transaction mined at the height, and its outputs as
+// credits of the wallet.
+func addTestTx(t *testing.T, w *Wallet, tx *wire.MsgTx, height int32) {
+	rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
+	if err != nil {
+		t.Fatalf("unable to create tx record: %v", err)
+	}
+	block := &wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{Hash: chainhash.Hash{byte(height)},
+			Height: height},
+		Time: time.Now(),
+	}
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		ns := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+		if err := w.TxStore.InsertTx(ns, rec, block); err != nil {
+			return err
+		}
+		for i := range tx.TxOut {
+			err := w.TxStore.AddCredit(ns, rec, block, uint32(i), false)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to add tx: %v", err)
+	}
+}
+
+// TestCoinControlInputSource ensures the outputs given by the coin control
+// options are spent before those chosen from the eligible ones, and that
+// outputs which may not be given are refused.
+func TestCoinControlInputSource(t *testing.T) {
+	w, teardown := testWallet(t)
+	defer teardown()
+	scope := waddrmgr.KeyScopeBIP0044
+	other, err := w.NextAccount(scope, "other")
+	if err != nil {
+		t.Fatalf("unable to create account: %v", err)
+	}
+	script := func(account uint32) []byte {
+		addr, err := w.NewAddress(account, scope, true)
+		if err != nil {
+			t.Fatalf("unable to create address: %v", err)
+		}
+		pkScript, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			t.Fatalf("unable to create script: %v", err)
+		}
+		return pkScript
+	}
+	// outputs a, b and c of the default account are mined at height 10
+	pay := wire.NewMsgTx(wire.TxVersion)
+	pay.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Index: 7}})
+	pay.AddTxOut(wire.NewTxOut(1e8, script(waddrmgr.DefaultAccountNum)))
+	pay.AddTxOut(wire.NewTxOut(2e8, script(waddrmgr.DefaultAccountNum)))
+	pay.AddTxOut(wire.NewTxOut(3e8, script(waddrmgr.DefaultAccountNum)))
+	addTestTx(t, w, pay, 10)
+	a := wire.OutPoint{Hash: pay.TxHash(), Index: 0}
+	b := wire.OutPoint{Hash: pay.TxHash(), Index: 1}
+	c := wire.OutPoint{Hash: pay.TxHash(), Index: 2}
+	// d is of the other account
+	payOther := wire.NewMsgTx(wire.TxVersion)
+	payOther.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Index: 8}})
+	payOther.AddTxOut(wire.NewTxOut(1e8, script(other)))
+	addTestTx(t, w, payOther, 10)
+	d := wire.OutPoint{Hash: payOther.TxHash(), Index: 0}
+	// e is from a coinbase mined at height 50, which is not mature at 100
+	coinbase := wire.NewMsgTx(wire.TxVersion)
+	coinbase.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Index: wire.MaxPrevOutIndex},
+		SignatureScript:  []byte{0x01, 0x32},
+	})
+	coinbase.AddTxOut(wire.NewTxOut(1e8, script(waddrmgr.DefaultAccountNum)))
+	addTestTx(t, w, coinbase, 50)
+	e := wire.OutPoint{Hash: coinbase.TxHash(), Index: 0}
+	tests := []struct {
+		name   string
+		cc     *CoinControl
+		target util.Amount
+		want   []wire.OutPoint
+		fails  bool
+	}{
+		{name: "no options", target: 4e8, want: []wire.OutPoint{c, b}},
+		{name: "inputs spent first", cc: &CoinControl{
+			Inputs: []wire.OutPoint{a}}, target: 4e8,
+			want: []wire.OutPoint{a, c}},
+		{name: "excluded", cc: &CoinControl{
+			Exclude: []wire.OutPoint{c}}, target: 4e8,
+			want: []wire.OutPoint{b, a}},
+		{name: "inputs only", cc: &CoinControl{
+			Inputs: []wire.OutPoint{a}, InputsOnly: true}, target: 4e8,
+			want: []wire.OutPoint{a}},
+		{name: "max inputs", cc: &CoinControl{
+			Inputs: []wire.OutPoint{a}, MaxInputs: 2}, target: 6e8,
+			want: []wire.OutPoint{a, c}},
+		{name: "max inputs below inputs", cc: &CoinControl{
+			Inputs: []wire.OutPoint{a, b}, MaxInputs: 1}, fails: true},
+		{name: "another account", cc: &CoinControl{
+			Inputs: []wire.OutPoint{d}}, fails: true},
+		{name: "immature coinbase", cc: &CoinControl{
+			Inputs: []wire.OutPoint{e}}, fails: true},
+		{name: "duplicate", cc: &CoinControl{
+			Inputs: []wire.OutPoint{a, b, a}}, fails: true},
+		{name: "selected and excluded", cc: &CoinControl{
+			Inputs: []wire.OutPoint{a}, Exclude: []wire.OutPoint{a}},
+			fails: true},
+		{name: "not an output of the wallet", cc: &CoinControl{
+			Inputs: []wire.OutPoint{{Index: 7}}}, fails: true},
+	}
+	for _, test := range tests {
+		err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+			unspent, err := w.TxStore.UnspentOutputs(
+				dbtx.ReadBucket(wtxmgrNamespaceKey))
+			if err != nil {
+				return err
+			}
+			var eligible []wtxmgr.Credit
+			for i := range unspent {
+				if unspent[i].Hash == pay.TxHash() {
+					eligible = append(eligible, unspent[i])
+				}
+			}
+			source, err := w.coinControlInputSource(dbtx, test.cc,
+				waddrmgr.DefaultAccountNum, eligible, 0, 100)
+			if test.fails {
+				if err == nil {
+					t.Errorf("%s: no error", test.name)
+				}
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			_, inputs, _, _, err := source(test.target)
+			if err != nil {
+				return err
+			}
+			if len(inputs) != len(test.want) {
+				t.Errorf("%s: spent %d outputs, want %d", test.name,
+					len(inputs), len(test.want))
+				return nil
+			}
+			for i, in := range inputs {
+				if in.PreviousOutPoint != test.want[i] {
+					t.Errorf("%s: input %d spends %v, want %v", test.name,
+						i, in.PreviousOutPoint, test.want[i])
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+	}
+}
@@ -267,6 +267,119 @@ func (s MinPriorityCoinSelector) CoinSelect(targetValue util.Amount, coins []Coi
 	return nil, ErrCoinsNoSelectionAvailable
 }
 
+// DefaultBranchAndBoundTries is the number of combinations of coins a
+// BranchAndBoundCoinSelector explores when its MaxTries is not set.
+const DefaultBranchAndBoundTries = 100000
+
+// BranchAndBoundCoinSelector is a CoinSelector that searches for a selection of coins whose total value is at least targetValue and at most targetValue plus CostOfChange, so that no change output is worth creating. Of the selections found it returns the one with the least value in excess of targetValue. The search is depth first over the coins ordered by value, largest first, and gives up after MaxTries combinations have been explored.
+type BranchAndBoundCoinSelector struct {
+	MaxInputs    int
+	CostOfChange util.Amount
+	MaxTries     int
+}
+
+// CoinSelect will attempt to select coins using the algorithm described in the BranchAndBoundCoinSelector struct.
+func (s BranchAndBoundCoinSelector) CoinSelect(targetValue util.Amount, coins []Coin) (Coins, error) {
+	sortedCoins := make([]Coin, 0, len(coins))
+	for _, coin := range coins {
+		if coin.Value() > 0 {
+			sortedCoins = append(sortedCoins, coin)
+		}
+	}
+	sort.Sort(sort.Reverse(byAmount(sortedCoins)))
+	// remaining[i] is the total value of the coins from index i on, which
+	// bounds what a branch can still add.
+	remaining := make([]util.Amount, len(sortedCoins)+1)
+	for i := len(sortedCoins) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + sortedCoins[i].Value()
+	}
+	maxTries := s.MaxTries
+	if maxTries <= 0 {
+		maxTries = DefaultBranchAndBoundTries
+	}
+	var best, selected []int
+	bestExcess := util.Amount(-1)
+	tries := 0
+	var search func(i int, total util.Amount)
+	search = func(i int, total util.Amount) {
+		if tries >= maxTries || total > targetValue+s.CostOfChange {
+			return
+		}
+		tries++
+		if total >= targetValue {
+			if excess := total - targetValue; bestExcess < 0 || excess < bestExcess {
+				bestExcess = excess
+				best = append(best[:0], selected...)
+			}
+			return
+		}
+		if i == len(sortedCoins) || total+remaining[i] < targetValue ||
+			len(selected) >= s.MaxInputs {
+			return
+		}
+		selected = append(selected, i)
+		search(i+1, total+sortedCoins[i].Value())
+		selected = selected[:len(selected)-1]
+		if bestExcess == 0 {
+			return
+		}
+		// Leaving out a coin, every selection with a following coin of the
+		// same value instead was already explored.
+		next := i + 1
+		for next < len(sortedCoins) &&
+			sortedCoins[next].Value() == sortedCoins[i].Value() {
+			next++
+		}
+		search(next, total)
+	}
+	search(0, 0)
+	if bestExcess < 0 {
+		return nil, ErrCoinsNoSelectionAvailable
+	}
+	cs := NewCoinSet(nil)
+	for _, i := range best {
+		cs.PushCoin(sortedCoins[i])
+	}
+	return cs, nil
+}
+
+// AddressGroupCoinSelector is a CoinSelector that groups the coins paying to the same output script and only selects whole groups, so an address is never spent partly, which would link the transaction spending the rest of it to this one. Groups with the greatest total value are selected first to link as few addresses as possible.
+type AddressGroupCoinSelector struct {
+	MaxInputs       int
+	MinChangeAmount util.Amount
+}
+
+// CoinSelect will attempt to select coins using the algorithm described in the AddressGroupCoinSelector struct.
+func (s AddressGroupCoinSelector) CoinSelect(targetValue util.Amount, coins []Coin) (Coins, error) {
+	var groups []*CoinSet
+	groupOf := make(map[string]*CoinSet)
+	for _, coin := range coins {
+		group, ok := groupOf[string(coin.PkScript())]
+		if !ok {
+			group = NewCoinSet(nil)
+			groupOf[string(coin.PkScript())] = group
+			groups = append(groups, group)
+		}
+		group.PushCoin(coin)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].TotalValue() > groups[j].TotalValue()
+	})
+	cs := NewCoinSet(nil)
+	for _, group := range groups {
+		if cs.Num()+group.Num() > s.MaxInputs {
+			continue
+		}
+		for _, coin := range group.Coins() {
+			cs.PushCoin(coin)
+		}
+		if satisfiesTargetValue(targetValue, s.MinChangeAmount, cs.TotalValue()) {
+			return cs, nil
+		}
+	}
+	return nil, ErrCoinsNoSelectionAvailable
+}
+
 type byValueAge []Coin
 
 func (a byValueAge) Len() int           { return len(a) }
@@ -19,12 +19,13 @@ type TestCoin struct {
 	TxIndex    uint32
 	TxValue    util.Amount
 	TxNumConfs int64
+	TxPkScript []byte
 }
 
 func (c *TestCoin) Hash() *chainhash.Hash { return c.TxHash }
 func (c *TestCoin) Index() uint32         { return c.TxIndex }
 func (c *TestCoin) Value() util.Amount    { return c.TxValue }
-func (c *TestCoin) PkScript() []byte      { return nil }
+func (c *TestCoin) PkScript() []byte      { return c.TxPkScript }
 func (c *TestCoin) NumConfs() int64       { return c.TxNumConfs }
 func (c *TestCoin) ValueAge() int64       { return int64(c.TxValue) * c.TxNumConfs }
 func NewCoin(index int64, value util.Amount, numConfs int64) coinset.Coin {
@@ -212,6 +213,56 @@ func TestMinPrioritySelector(t *testing.T) {
 	testCoinSelector(minPriorityTests, t)
 }
 
+var branchAndBoundSelectors = []coinset.BranchAndBoundCoinSelector{
+	{MaxInputs: 10, CostOfChange: 1000000},
+	{MaxInputs: 1, CostOfChange: 1000000},
+}
+var branchAndBoundTests = []coinSelectTest{
+	{branchAndBoundSelectors[0], coins, 35000000, []coinset.Coin{coins[3], coins[1]}, nil},
+	{branchAndBoundSelectors[0], coins, 34500000, []coinset.Coin{coins[3], coins[1]}, nil},
+	{branchAndBoundSelectors[0], coins, 60000000, []coinset.Coin{coins[2], coins[1]}, nil},
+	{branchAndBoundSelectors[0], coins, 185000000, []coinset.Coin{coins[0], coins[2], coins[3], coins[1]}, nil},
+	{branchAndBoundSelectors[0], coins, 40000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+	{branchAndBoundSelectors[0], coins, 200000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+	{branchAndBoundSelectors[1], coins, 49500000, []coinset.Coin{coins[2]}, nil},
+	{branchAndBoundSelectors[1], coins, 35000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+}
+
+func TestBranchAndBoundSelector(t *testing.T) {
+	testCoinSelector(branchAndBoundTests, t)
+}
+
+func newScriptCoin(index int64, value util.Amount, pkScript []byte) coinset.Coin {
+	c := NewCoin(index, value, 1).(*TestCoin)
+	c.TxPkScript = pkScript
+	return c
+}
+
+var scriptCoins = []coinset.Coin{
+	newScriptCoin(5, 30000000, []byte{0xa}),
+	newScriptCoin(6, 40000000, []byte{0xb}),
+	newScriptCoin(7, 20000000, []byte{0xa}),
+	newScriptCoin(8, 5000000, []byte{0xc}),
+}
+var addressGroupSelectors = []coinset.AddressGroupCoinSelector{
+	{MaxInputs: 10, MinChangeAmount: 10000},
+	{MaxInputs: 2, MinChangeAmount: 10000},
+	{MaxInputs: 1, MinChangeAmount: 10000},
+}
+var addressGroupTests = []coinSelectTest{
+	{addressGroupSelectors[0], scriptCoins, 45000000, []coinset.Coin{scriptCoins[0], scriptCoins[2]}, nil},
+	{addressGroupSelectors[0], scriptCoins, 60000000, []coinset.Coin{scriptCoins[0], scriptCoins[2], scriptCoins[1]}, nil},
+	{addressGroupSelectors[0], scriptCoins, 95000000, []coinset.Coin{scriptCoins[0], scriptCoins[2], scriptCoins[1], scriptCoins[3]}, nil},
+	{addressGroupSelectors[0], scriptCoins, 96000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+	{addressGroupSelectors[1], scriptCoins, 35000000, []coinset.Coin{scriptCoins[0], scriptCoins[2]}, nil},
+	{addressGroupSelectors[1], scriptCoins, 60000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+	{addressGroupSelectors[2], scriptCoins, 35000000, []coinset.Coin{scriptCoins[1]}, nil},
+}
+
+func TestAddressGroupSelector(t *testing.T) {
+	testCoinSelector(addressGroupTests, t)
+}
+
 var (
 	// should be two outpoints, with 1st one having 0.035DUO value.
 	testSimpleCoinNumConfs            = int64(1)
@@ -24,7 +24,8 @@ type byAmount []wtxmgr.Credit
 func (s byAmount) Len() int           { return len(s) }
 func (s byAmount) Less(i, j int) bool { return s[i].Amount < s[j].Amount }
 func (s byAmount) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
-func makeInputSource(eligible []wtxmgr.Credit) txauthor.InputSource {
+func makeInputSource(eligible []wtxmgr.Credit,
+	sequence uint32) txauthor.InputSource {
 	// Pick largest outputs first.  This is only done for compatibility with
 	// previous tx creation code, not because it's a good idea.
 	sort.Sort(sort.Reverse(byAmount(eligible)))
@@ -40,6 +41,7 @@ func makeInputSource(eligible []wtxmgr.Credit) txauthor.InputSource {
 			nextCredit := &eligible[0]
 			eligible = eligible[1:]
 			nextInput := wire.NewTxIn(&nextCredit.OutPoint, nil, nil)
+			nextInput.Sequence = sequence
 			currentTotal += nextCredit.Amount
 			currentInputs = append(currentInputs, nextInput)
 			currentScripts = append(currentScripts, nextCredit.PkScript)
@@ -92,11 +94,13 @@ func (s secretSource) GetScript(addr util.Address) ([]byte, error) {
 
 // txToOutputs creates a signed transaction which includes each output from
 // outputs.  Previous outputs to reedeem are chosen from the passed account's
-// UTXO set and minconf policy. An additional output may be added to return
-// change to the wallet.  An appropriate fee is included based on the wallet's
-// current relay fee.  The wallet must be unlocked to create the transaction.
+// UTXO set and minconf policy as the coin control options, which may be nil,
+// direct. An additional output may be added to return change to the wallet.
+// An appropriate fee is included based on the wallet's current relay fee.  The
+// wallet must be unlocked to create the transaction.
 func (w *Wallet) txToOutputs(outputs []*wire.TxOut, account uint32,
-	minconf int32, feeSatPerKb util.Amount) (tx *txauthor.AuthoredTx, err error) {
+	minconf int32, feeSatPerKb util.Amount,
+	coinControl *CoinControl) (tx *txauthor.AuthoredTx, err error) {
 	chainClient, err := w.requireChainClient()
 	if err != nil {
 		log.ERROR(err)
@@ -115,8 +119,16 @@ func (w *Wallet) txToOutputs(outputs []*wire.TxOut, account uint32,
 			log.ERROR(err)
 			return err
 		}
-		inputSource := makeInputSource(eligible)
+		inputSource, err := w.coinControlInputSource(dbtx, coinControl,
+			account, eligible, feeSatPerKb, bs.Height)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
 		changeSource := func() ([]byte, error) {
+			if coinControl != nil && coinControl.ChangeAddress != nil {
+				return txscript.PayToAddrScript(coinControl.ChangeAddress)
+			}
 			// Derive the change output script.  As a hack to allow
 			// spending from the imported account, change addresses
 			// are created from account 0.
@@ -200,16 +212,7 @@ func (w *Wallet) findEligibleOutputs(dbtx walletdb.ReadTx, account uint32, minco
 		}
 		// Only include the output if it is associated with the passed
 		// account.
-		//
-		// TODO: Handle multisig outputs by determining if enough of the
-		// addresses are controlled.
-		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
-			output.PkScript, w.chainParams)
-		if err != nil || len(addrs) != 1 {
-			continue
-		}
-		_, addrAcct, err := w.Manager.AddrAccount(addrmgrNs, addrs[0])
-		if err != nil || addrAcct != account {
+		if !w.creditInAccount(addrmgrNs, output, account) {
 			continue
 		}
 		eligible = append(eligible, *output)
@@ -217,6 +220,22 @@ func (w *Wallet) findEligibleOutputs(dbtx walletdb.ReadTx, account uint32, minco
 	return eligible, nil
 }
 
+// creditInAccount returns whether an output pays to an address of the
+// account.
+//
+// TODO: Handle multisig outputs by determining if enough of the
+// addresses are controlled.
+func (w *Wallet) creditInAccount(addrmgrNs walletdb.ReadBucket,
+	output *wtxmgr.Credit, account uint32) bool {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+		output.PkScript, w.chainParams)
+	if err != nil || len(addrs) != 1 {
+		return false
+	}
+	_, addrAcct, err := w.Manager.AddrAccount(addrmgrNs, addrs[0])
+	return err == nil && addrAcct == account
+}
+
 // validateMsgTx verifies transaction input scripts for tx.  All previous output
 // scripts from outputs redeemed by the transaction, in the same order they are
 // spent, must be passed in the prevScripts slice.
@@ -0,0 +1,403 @@
+package wallet
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/util"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+	walletdb "github.com/p9c/pod/pkg/wallet/db"
+)
+
+// birthdayMargin is how far before a birthday a rescan starts, as block
+// timestamps are not always in order
+const birthdayMargin = time.Hour * 2
+
+// DumpedKey is a private key in a wallet dump along with where it came from
+type DumpedKey struct {
+	WIF     string
+	Address string
+	// Birthday is the earliest time the key could have been used
+	Birthday time.Time
+	// Label is the name of the account the key is in
+	Label string
+	// Derived is set for keys derived from the wallet seed, which have a
+	// scope and path, and unset for imported keys
+	Derived bool
+	Scope   waddrmgr.KeyScope
+	Path    waddrmgr.DerivationPath
+}
+
+// String returns the key as a line of a wallet dump, in the format of the
+// reference implementation: the key, its birthday and labels, then the
+// address and derivation path after a comment mark
+func (k *DumpedKey) String() string {
+	s := fmt.Sprintf("%s %s label=%s", k.WIF,
+		k.Birthday.UTC().Format(time.RFC3339), url.PathEscape(k.Label))
+	if k.Derived && k.Path.Branch == waddrmgr.InternalBranch {
+		s += " change=1"
+	}
+	s += " # addr=" + k.Address
+	if k.Derived {
+		s += " hdkeypath=" + k.HDKeyPath()
+	}
+	return s
+}
+
+// HDKeyPath returns the full derivation path of a derived key
+func (k *DumpedKey) HDKeyPath() string {
+	return fmt.Sprintf("%s/%d'/%d/%d", k.Scope.String(), k.Path.Account,
+		k.Path.Branch, k.Path.Index)
+}
+
+// ParseDumpedKey reads a line of a wallet dump, comments and blank lines
+// return nil
+func ParseDumpedKey(line string) (k *DumpedKey, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return
+	}
+	var comment string
+	if i := strings.Index(line, "#"); i >= 0 {
+		line, comment = line[:i], line[i+1:]
+	}
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("missing key or time in %q", line)
+	}
+	k = &DumpedKey{WIF: fields[0]}
+	if k.Birthday, err = time.Parse(time.RFC3339, fields[1]); err != nil {
+		return nil, err
+	}
+	for _, f := range append(fields[2:], strings.Fields(comment)...) {
+		i := strings.Index(f, "=")
+		if i < 0 {
+			continue
+		}
+		switch key, value := f[:i], f[i+1:]; key {
+		case "label":
+			if k.Label, err = url.PathUnescape(value); err != nil {
+				return nil, err
+			}
+		case "addr":
+			k.Address = value
+		case "hdkeypath":
+			if k.Scope, k.Path, err = parseHDKeyPath(value); err != nil {
+				return nil, err
+			}
+			k.Derived = true
+		}
+	}
+	return
+}
+
+// parseHDKeyPath reads a derivation path of the form
+// m/purpose'/coin'/account'/branch/index
+func parseHDKeyPath(s string) (scope waddrmgr.KeyScope,
+	path waddrmgr.DerivationPath, err error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 6 || parts[0] != "m" {
+		err = fmt.Errorf("invalid derivation path %q", s)
+		return
+	}
+	var n [5]uint32
+	for i, p := range parts[1:] {
+		hardened := strings.HasSuffix(p, "'")
+		if hardened != (i < 3) {
+			err = fmt.Errorf("invalid derivation path %q", s)
+			return
+		}
+		var v uint64
+		if v, err = strconv.ParseUint(strings.TrimSuffix(p, "'"), 10,
+			31); err != nil {
+			return
+		}
+		n[i] = uint32(v)
+	}
+	scope = waddrmgr.KeyScope{Purpose: n[0], Coin: n[1]}
+	path = waddrmgr.DerivationPath{Account: n[2], Branch: n[3], Index: n[4]}
+	return
+}
+
+// DumpKeys returns the private keys of all of the active addresses of the
+// wallet with their derivation paths and account names. The wallet must be
+// unlocked.
+func (w *Wallet) DumpKeys() (keys []DumpedKey, err error) {
+	birthday := w.Manager.Birthday()
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		return w.Manager.ForEachActiveAddress(addrmgrNs,
+			func(addr util.Address) error {
+				ma, err := w.Manager.Address(addrmgrNs, addr)
+				if err != nil {
+					log.ERROR(err)
+					return err
+				}
+				// Only those addresses with keys needed.
+				pka, ok := ma.(waddrmgr.ManagedPubKeyAddress)
+				if !ok {
+					return nil
+				}
+				wif, err := pka.ExportPrivKey()
+				if err != nil {
+					log.ERROR(err)
+					return err
+				}
+				k := DumpedKey{
+					WIF:      wif.String(),
+					Address:  addr.EncodeAddress(),
+					Birthday: birthday,
+				}
+				k.Scope, k.Path, k.Derived = pka.DerivationInfo()
+				manager, account, err := w.Manager.AddrAccount(addrmgrNs, addr)
+				if err != nil {
+					log.ERROR(err)
+					return err
+				}
+				k.Label, err = manager.AccountName(addrmgrNs, account)
+				if err != nil {
+					log.ERROR(err)
+					return err
+				}
+				keys = append(keys, k)
+				return nil
+			})
+	})
+	if err != nil {
+		return nil, err
+	}
+	// keep the dump in derivation order so it can be read and compared
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, b := &keys[i], &keys[j]
+		switch {
+		case a.Derived != b.Derived:
+			return a.Derived
+		case a.Scope != b.Scope:
+			return a.Scope.Purpose < b.Scope.Purpose ||
+				(a.Scope.Purpose == b.Scope.Purpose &&
+					a.Scope.Coin < b.Scope.Coin)
+		case a.Path.Account != b.Path.Account:
+			return a.Path.Account < b.Path.Account
+		case a.Path.Branch != b.Path.Branch:
+			return a.Path.Branch < b.Path.Branch
+		}
+		return a.Path.Index < b.Path.Index
+	})
+	return
+}
+
+// WriteDump writes all of the private keys of the wallet as text that can be
+// read back with ImportDump, in the format of the reference implementation.
+// The wallet must be unlocked.
+func (w *Wallet) WriteDump(out io.Writer) (err error) {
+	keys, err := w.DumpKeys()
+	if err != nil {
+		return
+	}
+	synced := w.Manager.SyncedTo()
+	bw := bufio.NewWriter(out)
+	_, _ = fmt.Fprintf(bw, "# Wallet dump created by pod\n")
+	_, _ = fmt.Fprintf(bw, "# * Created on %s\n",
+		time.Now().UTC().Format(time.RFC3339))
+	_, _ = fmt.Fprintf(bw, "# * Network %s\n", w.chainParams.Name)
+	_, _ = fmt.Fprintf(bw, "# * Best block at time of backup was %d (%s)\n",
+		synced.Height, synced.Hash)
+	_, _ = fmt.Fprintf(bw, "# * Wallet birthday %s\n\n",
+		w.Manager.Birthday().UTC().Format(time.RFC3339))
+	for i := range keys {
+		_, _ = fmt.Fprintln(bw, keys[i].String())
+	}
+	_, _ = fmt.Fprintf(bw, "\n# End of dump\n")
+	return bw.Flush()
+}
+
+// ImportDump imports the private keys from a wallet dump that are not
+// already in the wallet and starts a rescan for all of the keys of the dump
+// from the earliest of their birthdays. Every line is checked before any key
+// is imported, so a bad dump leaves the wallet as it was. Keys from the dump
+// are imported into the imported account as the wallet can only derive keys
+// from its own seed, the name of the account a key was in is kept as the label
+// of its address. It returns the number of keys imported.
+func (w *Wallet) ImportDump(in io.Reader) (imported int, err error) {
+	type dumpedWIF struct {
+		*DumpedKey
+		wif  *util.WIF
+		addr util.Address
+	}
+	var keys []dumpedWIF
+	scanner := bufio.NewScanner(in)
+	for line := 1; scanner.Scan(); line++ {
+		var k *DumpedKey
+		if k, err = ParseDumpedKey(scanner.Text()); err != nil {
+			return 0, fmt.Errorf("line %d: %v", line, err)
+		}
+		if k == nil {
+			continue
+		}
+		var wif *util.WIF
+		if wif, err = util.DecodeWIF(k.WIF); err != nil {
+			return 0, fmt.Errorf("line %d: %v", line, err)
+		}
+		if !wif.IsForNet(w.chainParams) {
+			return 0, fmt.Errorf("line %d: key for %s is not for %s", line,
+				k.Address, w.chainParams.Name)
+		}
+		var addr util.Address
+		if addr, err = util.NewAddressPubKeyHash(
+			util.Hash160(wif.SerializePubKey()), w.chainParams); err != nil {
+			return 0, fmt.Errorf("line %d: %v", line, err)
+		}
+		keys = append(keys, dumpedWIF{DumpedKey: k, wif: wif, addr: addr})
+	}
+	if err = scanner.Err(); err != nil {
+		log.ERROR(err)
+		return
+	}
+	if len(keys) == 0 {
+		return
+	}
+	// keys the wallet already has are rescanned as well, as they may be left
+	// over from an earlier import that stopped before its rescan
+	addrs := make([]util.Address, 0, len(keys))
+	var birthday time.Time
+	for _, k := range keys {
+		_, err = w.ImportPrivateKey(waddrmgr.KeyScopeBIP0044, k.wif, nil,
+			false)
+		switch {
+		case err == nil:
+			imported++
+		case waddrmgr.IsError(err, waddrmgr.ErrDuplicateAddress):
+		default:
+			log.ERROR(err)
+			return
+		}
+		if err = w.labelDumpedAddress(k.addr, k.Label); err != nil {
+			log.ERROR(err)
+			return
+		}
+		addrs = append(addrs, k.addr)
+		if birthday.IsZero() || k.Birthday.Before(birthday) {
+			birthday = k.Birthday
+		}
+	}
+	bs, err := w.birthdayBlock(birthday)
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	log.INFO("imported", imported, "keys from wallet dump, rescanning",
+		len(addrs), "addresses from height", bs.Height)
+	_ = w.SubmitRescan(&RescanJob{Addrs: addrs, BlockStamp: *bs})
+	return
+}
+
+// labelDumpedAddress labels an address with the account name a wallet dump
+// gave for its key, unless the address already has a label or the key was
+// already in the imported account
+func (w *Wallet) labelDumpedAddress(addr util.Address, label string) error {
+	if label == "" || label == waddrmgr.ImportedAddrAccountName {
+		return nil
+	}
+	current, err := w.AddressLabel(addr)
+	if err != nil || current != "" {
+		return err
+	}
+	return w.SetAddressLabel(addr, label)
+}
+
+// birthdayBlock returns the last block in the main chain before the birthday
+// less a margin, or the genesis block if there is no birthday
+func (w *Wallet) birthdayBlock(birthday time.Time) (bs *waddrmgr.BlockStamp,
+	err error) {
+	bs = &waddrmgr.BlockStamp{Hash: *w.chainParams.GenesisHash}
+	if birthday.IsZero() {
+		return
+	}
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		return
+	}
+	_, best, err := chainClient.GetBestBlock()
+	if err != nil {
+		return
+	}
+	target := birthday.Add(-birthdayMargin)
+	// binary search for the first block at or after the target
+	low, high := int32(0), best
+	for low < high {
+		mid := low + (high-low)/2
+		hash, e := chainClient.GetBlockHash(int64(mid))
+		if e != nil {
+			return nil, e
+		}
+		header, e := chainClient.GetBlockHeader(hash)
+		if e != nil {
+			return nil, e
+		}
+		if header.Timestamp.Before(target) {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	if low > 0 {
+		low--
+	}
+	hash, err := chainClient.GetBlockHash(int64(low))
+	if err != nil {
+		return
+	}
+	return &waddrmgr.BlockStamp{Hash: *hash, Height: low}, nil
+}
+
+// Backup writes a copy of the wallet database to the path, which may be a
+// directory in which case the file is named the same as the wallet's. The
+// copy is written to a temporary file first so a failed backup doesn't
+// replace a good one.
+func (w *Wallet) Backup(path string) (err error) {
+	if path == "" {
+		return errors.New("no backup destination given")
+	}
+	if fi, e := os.Stat(path); e == nil && fi.IsDir() {
+		path = filepath.Join(path, WalletDbName)
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".backup-")
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+	if err = w.db.Copy(tmp); err != nil {
+		log.ERROR(err)
+		_ = tmp.Close()
+		return
+	}
+	if err = tmp.Sync(); err != nil {
+		log.ERROR(err)
+		_ = tmp.Close()
+		return
+	}
+	if err = tmp.Close(); err != nil {
+		log.ERROR(err)
+		return
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		log.ERROR(err)
+	}
+	return
+}
@@ -0,0 +1,59 @@
+package wallet_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/wallet"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+)
+
+// TestDumpedKeyRoundTrip ensures keys written as lines of a wallet dump are
+// read back the same.
+func TestDumpedKeyRoundTrip(t *testing.T) {
+	birthday := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	keys := []wallet.DumpedKey{
+		{
+			WIF:      "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ",
+			Address:  "1GAehh7TsJAHuUAeKZcXf5CnwuGuGgyX2S",
+			Birthday: birthday,
+			Label:    "savings account",
+			Derived:  true,
+			Scope:    waddrmgr.KeyScopeBIP0044,
+			Path: waddrmgr.DerivationPath{
+				Account: 1, Branch: waddrmgr.InternalBranch, Index: 7,
+			},
+		},
+		{
+			WIF:      "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ",
+			Address:  "1GAehh7TsJAHuUAeKZcXf5CnwuGuGgyX2S",
+			Birthday: birthday,
+			Label:    waddrmgr.ImportedAddrAccountName,
+		},
+	}
+	for i := range keys {
+		line := keys[i].String()
+		got, err := wallet.ParseDumpedKey(line)
+		if err != nil {
+			t.Fatalf("%q: %v", line, err)
+		}
+		if *got != keys[i] {
+			t.Errorf("%q read as %+v, want %+v", line, *got, keys[i])
+		}
+	}
+	for _, line := range []string{"", "# a comment", "   "} {
+		if k, err := wallet.ParseDumpedKey(line); k != nil || err != nil {
+			t.Errorf("%q read as %v, %v", line, k, err)
+		}
+	}
+	for _, line := range []string{
+		"5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ",
+		"5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ yesterday",
+		"5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ " +
+			"2019-06-01T12:00:00Z # hdkeypath=m/44/0'/0'/0/1",
+	} {
+		if _, err := wallet.ParseDumpedKey(line); err == nil {
+			t.Errorf("%q was accepted", line)
+		}
+	}
+}
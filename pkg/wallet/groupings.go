@@ -0,0 +1,150 @@
+package wallet
+
+import (
+	"sort"
+
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/util"
+	walletdb "github.com/p9c/pod/pkg/wallet/db"
+)
+
+// GroupedAddress is an address in an address grouping with its balance and
+// the name of the account it is in
+type GroupedAddress struct {
+	Address util.Address
+	Balance util.Amount
+	Account string
+}
+
+// AddressGroupings returns the addresses of the wallet grouped by common
+// ownership, which is made public when they are spent from together as inputs
+// of a transaction or when one receives the change of a transaction spending
+// from another. Addresses that have never been linked are in a group of
+// their own.
+func (w *Wallet) AddressGroupings() (groups [][]GroupedAddress, err error) {
+	// parents is a disjoint set of addresses, each group is the addresses
+	// that lead to the same root
+	parents := make(map[string]string)
+	addrs := make(map[string]util.Address)
+	var find func(a string) string
+	find = func(a string) string {
+		p, ok := parents[a]
+		if !ok || p == a {
+			parents[a] = a
+			return a
+		}
+		root := find(p)
+		parents[a] = root
+		return root
+	}
+	union := func(a, b string) {
+		parents[find(a)] = find(b)
+	}
+	// add returns the address paid to by a script if it is in the wallet
+	add := func(pkScript []byte) (string, bool) {
+		_, outAddrs, _, err := txscript.ExtractPkScriptAddrs(pkScript,
+			w.chainParams)
+		if err != nil || len(outAddrs) != 1 {
+			return "", false
+		}
+		a := outAddrs[0].EncodeAddress()
+		addrs[a] = outAddrs[0]
+		find(a)
+		return a, true
+	}
+	balances := make(map[string]util.Amount)
+	accounts := make(map[string]string)
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		err := w.TxStore.RangeTransactions(txmgrNs, 0, -1,
+			func(details []wtxmgr.TxDetails) (bool, error) {
+				for i := range details {
+					d := &details[i]
+					var linked []string
+					if len(d.Debits) > 0 {
+						var block *wtxmgr.Block
+						if d.Block.Height != -1 {
+							block = &d.Block.Block
+						}
+						pkScripts, err := w.TxStore.PreviousPkScripts(txmgrNs,
+							&d.TxRecord, block)
+						if err != nil {
+							log.ERROR(err)
+							return false, err
+						}
+						for _, pkScript := range pkScripts {
+							if a, ok := add(pkScript); ok {
+								linked = append(linked, a)
+							}
+						}
+					}
+					for _, c := range d.Credits {
+						a, ok := add(d.MsgTx.TxOut[c.Index].PkScript)
+						if ok && c.Change && len(linked) > 0 {
+							linked = append(linked, a)
+						}
+					}
+					for j := 1; j < len(linked); j++ {
+						union(linked[0], linked[j])
+					}
+				}
+				return false, nil
+			})
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		unspent, err := w.TxStore.UnspentOutputs(txmgrNs)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		for i := range unspent {
+			if a, ok := add(unspent[i].PkScript); ok {
+				balances[a] += unspent[i].Amount
+			}
+		}
+		for a, addr := range addrs {
+			manager, account, err := w.Manager.AddrAccount(addrmgrNs, addr)
+			if err != nil {
+				// outputs to addresses that aren't the wallet's are not
+				// grouped
+				delete(addrs, a)
+				continue
+			}
+			if accounts[a], err = manager.AccountName(addrmgrNs,
+				account); err != nil {
+				log.ERROR(err)
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	byRoot := make(map[string][]GroupedAddress)
+	for a, addr := range addrs {
+		root := find(a)
+		byRoot[root] = append(byRoot[root], GroupedAddress{
+			Address: addr,
+			Balance: balances[a],
+			Account: accounts[a],
+		})
+	}
+	for _, group := range byRoot {
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].Address.EncodeAddress() <
+				group[j].Address.EncodeAddress()
+		})
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i][0].Address.EncodeAddress() <
+			groups[j][0].Address.EncodeAddress()
+	})
+	return
+}
@@ -0,0 +1,49 @@
+package wallet
+
+import (
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	"github.com/p9c/pod/pkg/log"
+	walletdb "github.com/p9c/pod/pkg/wallet/db"
+)
+
+// CalculateWalletBalances sums the amounts of all unspent transaction outputs
+// of the wallet into total, spendable and immature coinbase reward balances,
+// like CalculateAccountBalances does for a single account.
+func (w *Wallet) CalculateWalletBalances(confirms int32) (bals Balances,
+	err error) {
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		syncBlock := w.Manager.SyncedTo()
+		unspent, err := w.TxStore.UnspentOutputs(txmgrNs)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		for i := range unspent {
+			output := &unspent[i]
+			bals.Total += output.Amount
+			if output.FromCoinBase && !confirmed(int32(w.chainParams.CoinbaseMaturity),
+				output.Height, syncBlock.Height) {
+				bals.ImmatureReward += output.Amount
+			} else if confirmed(confirms, output.Height, syncBlock.Height) {
+				bals.Spendable += output.Amount
+			}
+		}
+		return nil
+	})
+	return
+}
+
+// TransactionCount returns the number of mined and unmined transactions
+// relevant to the wallet
+func (w *Wallet) TransactionCount() (count int, err error) {
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		return w.TxStore.RangeTransactions(txmgrNs, 0, -1,
+			func(details []wtxmgr.TxDetails) (bool, error) {
+				count += len(details)
+				return false, nil
+			})
+	})
+	return
+}
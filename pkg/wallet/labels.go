@@ -0,0 +1,231 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/util"
+	walletdb "github.com/p9c/pod/pkg/wallet/db"
+)
+
+// Buckets of the labels namespace, keyed by transaction hash, encoded address
+// and outpoint.
+var (
+	txLabelsBucketKey       = []byte("tx")
+	addrLabelsBucketKey     = []byte("addr")
+	outPointLabelsBucketKey = []byte("outpoint")
+)
+
+// ErrLabelsNamespace describes an error where the labels namespace of a
+// wallet database cannot be found.
+var ErrLabelsNamespace = errors.New("missing labels namespace")
+
+// TxLabel is the comment saved with a transaction, and who it was sent to,
+// like the comment and commentto arguments of the send commands.
+type TxLabel struct {
+	Comment   string
+	CommentTo string
+}
+
+// createLabelsNamespace creates the labels namespace and its buckets if the
+// wallet database does not have them yet, which is the case for wallets
+// created before labels were saved.
+func createLabelsNamespace(tx walletdb.ReadWriteTx) error {
+	ns := tx.ReadWriteBucket(wlabelsNamespaceKey)
+	if ns == nil {
+		var err error
+		ns, err = tx.CreateTopLevelBucket(wlabelsNamespaceKey)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+	}
+	for _, key := range [][]byte{txLabelsBucketKey, addrLabelsBucketKey,
+		outPointLabelsBucketKey} {
+		if _, err := ns.CreateBucketIfNotExists(key); err != nil {
+			log.ERROR(err)
+			return err
+		}
+	}
+	return nil
+}
+
+// labelsBucket returns a bucket of the labels namespace for writing.
+func labelsBucket(tx walletdb.ReadWriteTx,
+	key []byte) (walletdb.ReadWriteBucket, error) {
+	ns := tx.ReadWriteBucket(wlabelsNamespaceKey)
+	if ns == nil {
+		return nil, ErrLabelsNamespace
+	}
+	b := ns.NestedReadWriteBucket(key)
+	if b == nil {
+		return nil, ErrLabelsNamespace
+	}
+	return b, nil
+}
+
+// fetchLabel returns the label saved under a key of a bucket of the labels
+// namespace, or nil if there is none.
+func fetchLabel(tx walletdb.ReadTx, bucket, key []byte) []byte {
+	ns := tx.ReadBucket(wlabelsNamespaceKey)
+	if ns == nil {
+		return nil
+	}
+	b := ns.NestedReadBucket(bucket)
+	if b == nil {
+		return nil
+	}
+	return b.Get(key)
+}
+
+// putLabel saves a label under a key of a bucket of the labels namespace, or
+// deletes it if the label is empty.
+func putLabel(tx walletdb.ReadWriteTx, bucket, key, label []byte) error {
+	b, err := labelsBucket(tx, bucket)
+	if err != nil {
+		log.ERROR(err)
+		return err
+	}
+	if len(label) == 0 {
+		return b.Delete(key)
+	}
+	return b.Put(key, label)
+}
+
+// outPointLabelKey returns the key of an outpoint in the outpoint labels
+// bucket, the transaction hash followed by the little endian output index.
+func outPointLabelKey(op *wire.OutPoint) []byte {
+	k := make([]byte, chainhash.HashSize+4)
+	copy(k, op.Hash[:])
+	binary.LittleEndian.PutUint32(k[chainhash.HashSize:], op.Index)
+	return k
+}
+
+// serializeTxLabel encodes the comment and the recipient of a transaction as
+// two variable length strings. An empty label is encoded as nothing so it is
+// deleted when saved.
+func serializeTxLabel(label *TxLabel) []byte {
+	if label == nil || (label.Comment == "" && label.CommentTo == "") {
+		return nil
+	}
+	var buf bytes.Buffer
+	// Writing to a bytes.Buffer does not fail.
+	_ = wire.WriteVarString(&buf, 0, label.Comment)
+	_ = wire.WriteVarString(&buf, 0, label.CommentTo)
+	return buf.Bytes()
+}
+
+// deserializeTxLabel decodes a transaction label written by serializeTxLabel.
+func deserializeTxLabel(v []byte) (*TxLabel, error) {
+	r := bytes.NewReader(v)
+	comment, err := wire.ReadVarString(r, 0)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	commentTo, err := wire.ReadVarString(r, 0)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	return &TxLabel{Comment: comment, CommentTo: commentTo}, nil
+}
+
+// putTxLabel saves the label of a transaction in a database transaction.
+func putTxLabel(tx walletdb.ReadWriteTx, hash *chainhash.Hash,
+	label *TxLabel) error {
+	return putLabel(tx, txLabelsBucketKey, hash[:], serializeTxLabel(label))
+}
+
+// fetchTxLabel returns the label of a transaction, or nil if it has none.
+func fetchTxLabel(tx walletdb.ReadTx, hash *chainhash.Hash) *TxLabel {
+	v := fetchLabel(tx, txLabelsBucketKey, hash[:])
+	if v == nil {
+		return nil
+	}
+	label, err := deserializeTxLabel(v)
+	if err != nil {
+		log.ERROR("cannot read label of transaction", hash, err)
+		return nil
+	}
+	return label
+}
+
+// fetchOutputLabel returns the label of a transaction output, which is the
+// label of the outpoint if it has one and otherwise the label of the address
+// it pays to, if any.
+func fetchOutputLabel(tx walletdb.ReadTx, op *wire.OutPoint,
+	addr util.Address) string {
+	if v := fetchLabel(tx, outPointLabelsBucketKey,
+		outPointLabelKey(op)); v != nil {
+		return string(v)
+	}
+	if addr == nil {
+		return ""
+	}
+	return string(fetchLabel(tx, addrLabelsBucketKey,
+		[]byte(addr.EncodeAddress())))
+}
+
+// SetTxLabel saves the comment and recipient of a transaction, replacing any
+// it had before. Both being empty removes the label.
+func (w *Wallet) SetTxLabel(hash *chainhash.Hash, label *TxLabel) error {
+	return walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		return putTxLabel(tx, hash, label)
+	})
+}
+
+// TxLabel returns the comment and recipient saved with a transaction, or nil
+// if it has none.
+func (w *Wallet) TxLabel(hash *chainhash.Hash) (label *TxLabel, err error) {
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		label = fetchTxLabel(tx, hash)
+		return nil
+	})
+	return
+}
+
+// SetAddressLabel saves a label for an address, which is shown for the
+// transaction outputs paying to it. An empty label removes it.
+func (w *Wallet) SetAddressLabel(addr util.Address, label string) error {
+	return walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		return putLabel(tx, addrLabelsBucketKey,
+			[]byte(addr.EncodeAddress()), []byte(label))
+	})
+}
+
+// AddressLabel returns the label of an address, or the empty string if it
+// has none.
+func (w *Wallet) AddressLabel(addr util.Address) (label string, err error) {
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		label = string(fetchLabel(tx, addrLabelsBucketKey,
+			[]byte(addr.EncodeAddress())))
+		return nil
+	})
+	return
+}
+
+// SetOutPointLabel saves a label for a transaction output, which is shown
+// instead of the label of the address it pays to. An empty label removes it.
+func (w *Wallet) SetOutPointLabel(op *wire.OutPoint, label string) error {
+	return walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		return putLabel(tx, outPointLabelsBucketKey, outPointLabelKey(op),
+			[]byte(label))
+	})
+}
+
+// OutputLabel returns the label of a transaction output, which is the label
+// of the outpoint if it has one and otherwise that of the address it pays to.
+// The address may be nil for outputs that don't pay to a single address.
+func (w *Wallet) OutputLabel(op *wire.OutPoint,
+	addr util.Address) (label string, err error) {
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		label = fetchOutputLabel(tx, op, addr)
+		return nil
+	})
+	return
+}
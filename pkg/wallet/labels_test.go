@@ -0,0 +1,52 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// TestTxLabelSerialization ensures transaction labels are read back as they
+// were written and that empty labels are written as nothing.
+func TestTxLabelSerialization(t *testing.T) {
+	labels := []TxLabel{
+		{Comment: "invoice 42", CommentTo: "supplier"},
+		{Comment: "invoice 42"},
+		{CommentTo: "supplier"},
+	}
+	for i := range labels {
+		v := serializeTxLabel(&labels[i])
+		got, err := deserializeTxLabel(v)
+		if err != nil {
+			t.Fatalf("%+v: %v", labels[i], err)
+		}
+		if *got != labels[i] {
+			t.Errorf("%+v read as %+v", labels[i], *got)
+		}
+	}
+	if v := serializeTxLabel(&TxLabel{}); v != nil {
+		t.Errorf("empty label written as %x", v)
+	}
+	if v := serializeTxLabel(nil); v != nil {
+		t.Errorf("nil label written as %x", v)
+	}
+	if _, err := deserializeTxLabel([]byte{5, 'a'}); err == nil {
+		t.Error("truncated label was read")
+	}
+}
+
+// TestOutPointLabelKey ensures outpoints of the same transaction have
+// different keys.
+func TestOutPointLabelKey(t *testing.T) {
+	op := wire.OutPoint{Index: 1}
+	op.Hash[0] = 0xaa
+	k1 := outPointLabelKey(&op)
+	op.Index = 256
+	k2 := outPointLabelKey(&op)
+	if len(k1) != 36 || k1[0] != 0xaa || k1[32] != 1 {
+		t.Errorf("unexpected key %x", k1)
+	}
+	if string(k1) == string(k2) {
+		t.Errorf("outputs 1 and 256 have the same key %x", k1)
+	}
+}
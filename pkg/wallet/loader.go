@@ -4,6 +4,8 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -20,6 +22,11 @@ import (
 // methods and services which require the wallet when the wallet is loaded by
 // another subsystem.
 //
+// Besides the wallet at its database path, which is the default wallet, the
+// loader loads and unloads wallets by name at runtime.  Each named wallet has a
+// database of its own in a directory of its name under the NamedWalletsDir
+// directory next to the default wallet's database.
+//
 // Loader is safe for concurrent access.
 type Loader struct {
 	Callbacks      []func(*Wallet)
@@ -30,11 +37,22 @@ type Loader struct {
 	Loaded         bool
 	DB             walletdb.DB
 	Mutex          sync.Mutex
+	named          map[string]*namedWallet
+	namedCallbacks []func(string, *Wallet)
+}
+
+// namedWallet is a wallet loaded by name and its database.
+type namedWallet struct {
+	wallet *Wallet
+	db     walletdb.DB
 }
 
 const (
 	// WalletDbName is
 	WalletDbName = "wallet.db"
+	// NamedWalletsDir is the name of the directory the directories of named
+	// wallets are kept in.
+	NamedWalletsDir = "wallets"
 )
 
 var (
@@ -47,7 +65,14 @@ var (
 	// ErrNotLoaded describes the error condition of attempting to close a
 	// loaded wallet when a wallet has not been loaded.
 	ErrNotLoaded = errors.New("wallet is not loaded")
+	// ErrNotExists describes the error condition of attempting to open a
+	// named wallet that has not been created.
+	ErrNotExists = errors.New("wallet does not exist")
 	errNoConsole = errors.New("db upgrade requires console access for additional input")
+	// ErrInvalidWalletName describes the error condition of naming a wallet
+	// with a name that can't be used as the name of its directory.
+	ErrInvalidWalletName = errors.New("wallet names may only contain " +
+		"letters, digits, '-', '_' and '.' and may not start with '.'")
 )
 
 // CreateNewWallet creates a new wallet using the provided public and private passphrases.  The seed is optional.  If non-nil, addresses are derived from this seed.  If nil, a secure random seed is generated.
@@ -237,6 +262,248 @@ func (ld *Loader) onLoaded(db walletdb.DB) {
 	ld.Callbacks = nil // not needed anymore
 }
 
+// CheckWalletName returns ErrInvalidWalletName if the name can't be used for a
+// named wallet.  The empty name is that of the default wallet.
+func CheckWalletName(name string) error {
+	if name == "" || strings.HasPrefix(name, ".") {
+		return ErrInvalidWalletName
+	}
+	for _, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9',
+			c == '-', c == '_', c == '.':
+		default:
+			return ErrInvalidWalletName
+		}
+	}
+	return nil
+}
+
+// NamedWalletPath returns the path of the database of the wallet with the
+// name.
+func (ld *Loader) NamedWalletPath(name string) string {
+	return filepath.Join(filepath.Dir(ld.DDDirPath), NamedWalletsDir, name,
+		WalletDbName)
+}
+
+// NamedWalletExists returns whether the database of the wallet with the name
+// exists.  The empty name is that of the default wallet.
+func (ld *Loader) NamedWalletExists(name string) (bool, error) {
+	if name == "" {
+		return ld.WalletExists()
+	}
+	if err := CheckWalletName(name); err != nil {
+		return false, err
+	}
+	return fileExists(ld.NamedWalletPath(name))
+}
+
+// CreateNamedWallet creates a new wallet with the name in the same way as
+// CreateNewWallet and loads it.  The empty name is that of the default wallet.
+func (ld *Loader) CreateNamedWallet(name string, pubPassphrase,
+	privPassphrase, seed []byte, bday time.Time) (*Wallet, error) {
+	if name == "" {
+		return ld.CreateNewWallet(pubPassphrase, privPassphrase, seed, bday,
+			false)
+	}
+	if err := CheckWalletName(name); err != nil {
+		return nil, err
+	}
+	ld.Mutex.Lock()
+	if _, ok := ld.named[name]; ok {
+		ld.Mutex.Unlock()
+		return nil, ErrLoaded
+	}
+	dbPath := ld.NamedWalletPath(name)
+	exists, err := fileExists(dbPath)
+	if err != nil {
+		ld.Mutex.Unlock()
+		log.ERROR(err)
+		return nil, err
+	}
+	if exists {
+		ld.Mutex.Unlock()
+		return nil, ErrExists
+	}
+	if err = os.MkdirAll(filepath.Dir(dbPath), 0700); err != nil {
+		ld.Mutex.Unlock()
+		log.ERROR(err)
+		return nil, err
+	}
+	db, err := walletdb.Create("bdb", dbPath)
+	if err != nil {
+		ld.Mutex.Unlock()
+		log.ERROR(err)
+		return nil, err
+	}
+	err = Create(db, pubPassphrase, privPassphrase, seed, ld.ChainParams, bday)
+	if err != nil {
+		ld.Mutex.Unlock()
+		log.ERROR(err)
+		if e := db.Close(); e != nil {
+			log.WARN("error closing database:", e)
+		}
+		return nil, err
+	}
+	return ld.startNamedWallet(name, db, pubPassphrase)
+}
+
+// OpenNamedWallet opens the existing wallet with the name and the public
+// passphrase and loads it.  Unlike OpenExistingWallet, a database upgrade that
+// needs input from the console fails.  The empty name is that of the default
+// wallet.
+func (ld *Loader) OpenNamedWallet(name string, pubPassphrase []byte) (*Wallet,
+	error) {
+	if name == "" {
+		return ld.OpenExistingWallet(pubPassphrase, false)
+	}
+	if err := CheckWalletName(name); err != nil {
+		return nil, err
+	}
+	ld.Mutex.Lock()
+	if _, ok := ld.named[name]; ok {
+		ld.Mutex.Unlock()
+		return nil, ErrLoaded
+	}
+	dbPath := ld.NamedWalletPath(name)
+	exists, err := fileExists(dbPath)
+	if err != nil {
+		ld.Mutex.Unlock()
+		log.ERROR(err)
+		return nil, err
+	}
+	if !exists {
+		ld.Mutex.Unlock()
+		return nil, ErrNotExists
+	}
+	db, err := walletdb.Open("bdb", dbPath)
+	if err != nil {
+		ld.Mutex.Unlock()
+		log.ERROR(err)
+		return nil, err
+	}
+	return ld.startNamedWallet(name, db, pubPassphrase)
+}
+
+// startNamedWallet opens and starts the wallet with the name from its database
+// and runs the callbacks added with RunAfterNamedLoad for it.  The mutex must be
+// locked and is unlocked before the callbacks run.
+func (ld *Loader) startNamedWallet(name string, db walletdb.DB,
+	pubPassphrase []byte) (*Wallet, error) {
+	cbs := &waddrmgr.OpenCallbacks{
+		ObtainSeed:        noConsole,
+		ObtainPrivatePass: noConsole,
+	}
+	w, err := Open(db, pubPassphrase, cbs, ld.ChainParams, ld.RecoveryWindow)
+	if err != nil {
+		ld.Mutex.Unlock()
+		log.ERROR(err)
+		if e := db.Close(); e != nil {
+			log.WARN("error closing database:", e)
+		}
+		return nil, err
+	}
+	w.Start()
+	if ld.named == nil {
+		ld.named = make(map[string]*namedWallet)
+	}
+	ld.named[name] = &namedWallet{wallet: w, db: db}
+	callbacks := ld.namedCallbacks
+	ld.Mutex.Unlock()
+	log.INFO("loaded wallet", name)
+	for _, fn := range callbacks {
+		fn(name, w)
+	}
+	return w, nil
+}
+
+// NamedWallet returns the loaded wallet with the name, if any, and whether it
+// is loaded.  The empty name is that of the default wallet.
+func (ld *Loader) NamedWallet(name string) (*Wallet, bool) {
+	if name == "" {
+		return ld.LoadedWallet()
+	}
+	ld.Mutex.Lock()
+	nw, ok := ld.named[name]
+	ld.Mutex.Unlock()
+	if !ok {
+		return nil, false
+	}
+	return nw.wallet, true
+}
+
+// LoadedWalletNames returns the names of the loaded wallets in order, starting
+// with the empty name of the default wallet if it is loaded.
+func (ld *Loader) LoadedWalletNames() []string {
+	ld.Mutex.Lock()
+	names := make([]string, 0, len(ld.named)+1)
+	for name := range ld.named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if ld.Wallet != nil {
+		names = append([]string{""}, names...)
+	}
+	ld.Mutex.Unlock()
+	return names
+}
+
+// RunAfterNamedLoad adds a function to be executed for each wallet the loader
+// loads by name, including those loaded already.  Unlike RunAfterLoad, the
+// function is kept for the wallets loaded later on.
+func (ld *Loader) RunAfterNamedLoad(fn func(name string, w *Wallet)) {
+	ld.Mutex.Lock()
+	ld.namedCallbacks = append(ld.namedCallbacks, fn)
+	loaded := make(map[string]*Wallet, len(ld.named))
+	for name, nw := range ld.named {
+		loaded[name] = nw.wallet
+	}
+	ld.Mutex.Unlock()
+	for name, w := range loaded {
+		fn(name, w)
+	}
+}
+
+// UnloadNamedWallet stops the wallet with the name and closes its database.
+// This returns ErrNotLoaded if no wallet with the name is loaded.  The empty
+// name is that of the default wallet.
+func (ld *Loader) UnloadNamedWallet(name string) error {
+	if name == "" {
+		return ld.UnloadWallet()
+	}
+	ld.Mutex.Lock()
+	nw, ok := ld.named[name]
+	delete(ld.named, name)
+	ld.Mutex.Unlock()
+	if !ok {
+		return ErrNotLoaded
+	}
+	nw.wallet.Stop()
+	nw.wallet.WaitForShutdown()
+	if err := nw.db.Close(); err != nil {
+		log.ERROR(err)
+		return err
+	}
+	log.INFO("unloaded wallet", name)
+	return nil
+}
+
+// UnloadNamedWallets unloads all wallets loaded by name.
+func (ld *Loader) UnloadNamedWallets() {
+	ld.Mutex.Lock()
+	names := make([]string, 0, len(ld.named))
+	for name := range ld.named {
+		names = append(names, name)
+	}
+	ld.Mutex.Unlock()
+	for _, name := range names {
+		err := ld.UnloadNamedWallet(name)
+		if err != nil && err != ErrNotLoaded {
+			log.ERROR("failed to close wallet", name, ":", err)
+		}
+	}
+}
+
 // NewLoader constructs a Loader with an optional recovery window. If the
 // recovery window is non-zero, the wallet will attempt to recovery addresses
 // starting from the last SyncedTo height.
@@ -0,0 +1,33 @@
+package wallet
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestCheckWalletName ensures only names usable as a directory name are
+// accepted for named wallets.
+func TestCheckWalletName(t *testing.T) {
+	for _, name := range []string{"hot", "treasury-2", "mining_pool", "a.b"} {
+		if err := CheckWalletName(name); err != nil {
+			t.Errorf("%q rejected: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", ".", "..", ".hidden", "a/b", `a\b`,
+		"with space", "naïve"} {
+		if err := CheckWalletName(name); err != ErrInvalidWalletName {
+			t.Errorf("%q accepted", name)
+		}
+	}
+}
+
+// TestNamedWalletPath ensures named wallets are kept next to the default
+// wallet.
+func TestNamedWalletPath(t *testing.T) {
+	netDir := filepath.Join("data", "mainnet")
+	ld := NewLoader(nil, filepath.Join(netDir, WalletDbName), 0)
+	want := filepath.Join(netDir, NamedWalletsDir, "hot", WalletDbName)
+	if got := ld.NamedWalletPath("hot"); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
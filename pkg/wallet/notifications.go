@@ -3,10 +3,12 @@ package wallet
 import (
 	"bytes"
 	"sync"
+	"time"
 
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
 	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
 	"github.com/p9c/pod/pkg/log"
 	"github.com/p9c/pod/pkg/util"
 	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
@@ -65,10 +67,40 @@ type NotificationServer struct {
 	currentTxNtfn  *TransactionNotifications // coalesce this since wallet does not add mined txs together
 	spentness      map[uint32][]chan *SpentnessNotifications
 	accountClients []chan *AccountNotification
+	rescanClients  []chan *RescanNotification
 	mu             sync.Mutex // Only protects registered client channels
 	wallet         *Wallet    // smells like hacks
 }
 
+// RescanNotification reports the progress of a rescan through the blocks of
+// the main chain.  Finished is set when the rescan reached the block, which is
+// the last block scanned.  Jobs are the IDs of the rescan jobs the rescan is
+// for, more than one when jobs waiting for a rescan were merged.
+type RescanNotification struct {
+	Hash     *chainhash.Hash
+	Height   int32
+	Time     time.Time
+	Finished bool
+	Jobs     []uint64
+}
+
+// ForJob returns whether the notification is about the rescan of the job
+// with the ID.
+func (n *RescanNotification) ForJob(id uint64) bool {
+	for _, job := range n.Jobs {
+		if job == id {
+			return true
+		}
+	}
+	return false
+}
+
+// RescanNotificationsClient receives RescanNotifications over the channel C.
+type RescanNotificationsClient struct {
+	C      chan *RescanNotification
+	server *NotificationServer
+}
+
 // SpentnessNotifications is a notification that is fired for transaction
 // outputs controlled by some account's keys.  The notification may be about a
 // newly added unspent transaction output or that a previously unspent output is
@@ -123,7 +155,8 @@ type TransactionNotificationsClient struct {
 }
 
 // TransactionSummary contains a transaction relevant to the wallet and marks
-// which inputs and outputs were relevant.
+// which inputs and outputs were relevant.  The Comment and CommentTo fields
+// are the label saved with the transaction, if any.
 type TransactionSummary struct {
 	Hash        *chainhash.Hash
 	Transaction []byte
@@ -131,6 +164,8 @@ type TransactionSummary struct {
 	MyOutputs   []TransactionSummaryOutput
 	Fee         util.Amount
 	Timestamp   int64
+	Comment     string
+	CommentTo   string
 }
 
 // TransactionSummaryInput describes a transaction input that is relevant to the
@@ -145,11 +180,13 @@ type TransactionSummaryInput struct {
 
 // TransactionSummaryOutput describes wallet properties of a transaction output
 // controlled by the wallet.  The Index field marks the transaction output index
-// of the transaction (not included here).
+// of the transaction (not included here).  The Label field is the label of the
+// outpoint, or of the address it pays to if the outpoint has none.
 type TransactionSummaryOutput struct {
 	Index    uint32
 	Account  uint32
 	Internal bool
+	Label    string
 }
 
 // Done deregisters the client from the server and drains any remaining
@@ -190,6 +227,44 @@ func (s *NotificationServer) AccountNotifications() AccountNotificationsClient {
 	}
 }
 
+// Done deregisters the client from the server and drains any remaining
+// messages.  It must be called exactly once when the client is finished
+// receiving notifications.
+func (c *RescanNotificationsClient) Done() {
+	go func() {
+		for range c.C {
+		}
+	}()
+	go func() {
+		s := c.server
+		s.mu.Lock()
+		clients := s.rescanClients
+		for i, ch := range clients {
+			if c.C == ch {
+				clients[i] = clients[len(clients)-1]
+				s.rescanClients = clients[:len(clients)-1]
+				close(ch)
+				break
+			}
+		}
+		s.mu.Unlock()
+	}()
+}
+
+// RescanNotifications returns a client for receiving RescanNotifications over
+// a channel.  The channel is unbuffered.  When finished, the client's Done
+// method should be called to disassociate the client from the server.
+func (s *NotificationServer) RescanNotifications() RescanNotificationsClient {
+	c := make(chan *RescanNotification)
+	s.mu.Lock()
+	s.rescanClients = append(s.rescanClients, c)
+	s.mu.Unlock()
+	return RescanNotificationsClient{
+		C:      c,
+		server: s,
+	}
+}
+
 // AccountSpentnessNotifications registers a client for spentness changes of
 // outputs controlled by the account.
 func (s *NotificationServer) AccountSpentnessNotifications(account uint32) SpentnessNotificationsClient {
@@ -238,6 +313,25 @@ func (s *NotificationServer) notifyAccountProperties(props *waddrmgr.AccountProp
 		c <- n
 	}
 }
+func (s *NotificationServer) notifyRescanProgress(hash *chainhash.Hash,
+	height int32, t time.Time, jobs []uint64, finished bool) {
+	defer s.mu.Unlock()
+	s.mu.Lock()
+	clients := s.rescanClients
+	if len(clients) == 0 {
+		return
+	}
+	n := &RescanNotification{
+		Hash:     hash,
+		Height:   height,
+		Time:     t,
+		Finished: finished,
+		Jobs:     jobs,
+	}
+	for _, c := range clients {
+		c <- n
+	}
+}
 func (s *NotificationServer) notifyAttachedBlock(dbtx walletdb.ReadTx, block *wtxmgr.BlockMeta) {
 	if s.currentTxNtfn == nil {
 		s.currentTxNtfn = &TransactionNotifications{}
@@ -567,14 +661,22 @@ func makeTxSummary(dbtx walletdb.ReadTx, w *Wallet, details *wtxmgr.TxDetails) T
 			continue
 		}
 		acct, internal := lookupOutputChain(dbtx, w, details, details.Credits[credIndex])
+		var addr util.Address
+		_, addrs, _, _ := txscript.ExtractPkScriptAddrs(
+			details.MsgTx.TxOut[i].PkScript, w.chainParams)
+		if len(addrs) == 1 {
+			addr = addrs[0]
+		}
 		output := TransactionSummaryOutput{
 			Index:    uint32(i),
 			Account:  acct,
 			Internal: internal,
+			Label: fetchOutputLabel(dbtx,
+				wire.NewOutPoint(&details.Hash, uint32(i)), addr),
 		}
 		outputs = append(outputs, output)
 	}
-	return TransactionSummary{
+	summary := TransactionSummary{
 		Hash:        &details.Hash,
 		Transaction: serializedTx,
 		MyInputs:    inputs,
@@ -582,6 +684,11 @@ func makeTxSummary(dbtx walletdb.ReadTx, w *Wallet, details *wtxmgr.TxDetails) T
 		Fee:         fee,
 		Timestamp:   details.Received.Unix(),
 	}
+	if label := fetchTxLabel(dbtx, &details.Hash); label != nil {
+		summary.Comment = label.Comment
+		summary.CommentTo = label.CommentTo
+	}
+	return summary
 }
 func newNotificationServer(wallet *Wallet) *NotificationServer {
 	return &NotificationServer{
@@ -0,0 +1,293 @@
+package wallet
+
+import (
+	"bytes"
+	"fmt"
+
+	txauthor "github.com/p9c/pod/pkg/chain/tx/author"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/psbt"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+	walletdb "github.com/p9c/pod/pkg/wallet/db"
+)
+
+// FundPsbt creates a PSBT paying to the outputs with inputs from the account
+// chosen the same way as for a transaction the wallet sends itself, as the
+// coin control options direct if they are not nil. Change goes to a new change
+// address of the account unless the options give another. The PSBT has the
+// outputs spent by its inputs filled in but is not signed, so the wallet
+// doesn't need to be unlocked. It returns the index of the change output,
+// which is -1 if there is none, and the fee.
+func (w *Wallet) FundPsbt(account uint32, outputs []*wire.TxOut,
+	lockTime uint32, minconf int32, feeSatPerKb util.Amount,
+	coinControl *CoinControl, lockUnspents bool) (
+	p *psbt.Packet, changeIndex int, fee util.Amount, err error) {
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	var tx *txauthor.AuthoredTx
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		addrmgrNs := dbtx.ReadWriteBucket(waddrmgrNamespaceKey)
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		bs, err := chainClient.BlockStamp()
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		eligible, err := w.findEligibleOutputs(dbtx, account, minconf, bs)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		inputSource, err := w.coinControlInputSource(dbtx, coinControl,
+			account, eligible, feeSatPerKb, bs.Height)
+		if err != nil {
+			return err
+		}
+		changeSource := func() ([]byte, error) {
+			if coinControl != nil && coinControl.ChangeAddress != nil {
+				return txscript.PayToAddrScript(coinControl.ChangeAddress)
+			}
+			// change from the imported account goes to the default
+			// account as it is when the wallet sends
+			changeAccount := account
+			if account == waddrmgr.ImportedAddrAccount {
+				changeAccount = 0
+			}
+			addr, err := w.newChangeAddress(addrmgrNs, changeAccount)
+			if err != nil {
+				log.ERROR(err)
+				return nil, err
+			}
+			return txscript.PayToAddrScript(addr)
+		}
+		tx, err = txauthor.NewUnsignedTransaction(outputs, feeSatPerKb,
+			inputSource, changeSource)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		if tx.ChangeIndex >= 0 {
+			tx.RandomizeChangePosition()
+		}
+		tx.Tx.LockTime = lockTime
+		for _, in := range tx.Tx.TxIn {
+			// the lock time is only enforced if an input is not final,
+			// which replaceable inputs already are not
+			if lockTime != 0 && in.Sequence == wire.MaxTxInSequenceNum {
+				in.Sequence = wire.MaxTxInSequenceNum - 1
+			}
+		}
+		if p, err = psbt.NewFromUnsignedTx(tx.Tx); err != nil {
+			log.ERROR(err)
+			return err
+		}
+		return w.updatePsbt(addrmgrNs, txmgrNs, p)
+	})
+	if err != nil {
+		log.ERROR(err)
+		return nil, -1, 0, err
+	}
+	if lockUnspents {
+		for _, in := range tx.Tx.TxIn {
+			w.LockOutpoint(in.PreviousOutPoint)
+		}
+	}
+	fee = tx.TotalInput
+	for _, out := range tx.Tx.TxOut {
+		fee -= util.Amount(out.Value)
+	}
+	return p, tx.ChangeIndex, fee, nil
+}
+
+// updatePsbt fills in the outputs spent by inputs of a PSBT that are from
+// transactions in the wallet and the redeem scripts of pay to script hash
+// outputs the wallet has the scripts of
+func (w *Wallet) updatePsbt(addrmgrNs, txmgrNs walletdb.ReadBucket,
+	p *psbt.Packet) error {
+	secrets := secretSource{w.Manager, addrmgrNs}
+	for i, txIn := range p.UnsignedTx.TxIn {
+		in := &p.Inputs[i]
+		if in.FinalScriptSig != nil || in.FinalScriptWitness != nil {
+			continue
+		}
+		if in.NonWitnessUtxo == nil {
+			op := txIn.PreviousOutPoint
+			details, err := w.TxStore.TxDetails(txmgrNs, &op.Hash)
+			if err != nil {
+				log.ERROR(err)
+				return err
+			}
+			if details != nil && op.Index < uint32(len(details.MsgTx.TxOut)) {
+				in.NonWitnessUtxo = details.MsgTx.Copy()
+			}
+		}
+		spent := p.SpentOutput(i)
+		if spent == nil {
+			continue
+		}
+		script := spent.PkScript
+		if txscript.IsPayToScriptHash(script) && in.RedeemScript == nil {
+			_, addrs, _, err := txscript.ExtractPkScriptAddrs(script,
+				w.chainParams)
+			if err == nil && len(addrs) == 1 {
+				// scripts the wallet doesn't have are left for others
+				// to add
+				if redeemScript, err := secrets.GetScript(addrs[0]); err == nil {
+					in.RedeemScript = redeemScript
+				}
+			}
+		}
+		if in.RedeemScript != nil {
+			script = in.RedeemScript
+		}
+		if in.WitnessUtxo == nil && (txscript.IsPayToWitnessPubKeyHash(script) ||
+			txscript.IsPayToWitnessScriptHash(script)) {
+			in.WitnessUtxo = wire.NewTxOut(spent.Value, spent.PkScript)
+		}
+	}
+	return nil
+}
+
+// SignPsbt adds what the wallet knows about the inputs of a PSBT, signs the
+// inputs the wallet has keys for if sign is set, and finalizes the inputs that
+// have all of the signatures they need. Signatures use the hash type, which
+// must match the one given in an input if there is one. Keys of other signers
+// are skipped, so the PSBT may still need their signatures. It returns whether
+// all of the inputs are finalized so the transaction can be extracted.
+func (w *Wallet) SignPsbt(p *psbt.Packet, hashType txscript.SigHashType,
+	sign bool) (complete bool, err error) {
+	u, err := psbt.NewUpdater(p)
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	err = walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+		addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		if err := w.updatePsbt(addrmgrNs, txmgrNs, p); err != nil {
+			return err
+		}
+		if !sign {
+			return nil
+		}
+		sigHashes := txscript.NewTxSigHashes(p.UnsignedTx)
+		for i := range p.Inputs {
+			if err := w.signPsbtInput(addrmgrNs, u, i, hashType,
+				sigHashes); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.ERROR(err)
+		return
+	}
+	return psbt.MaybeFinalizeAll(p)
+}
+
+// signPsbtInput adds a signature for each key of the wallet that can sign the
+// input
+func (w *Wallet) signPsbtInput(addrmgrNs walletdb.ReadBucket, u *psbt.Updater,
+	i int, hashType txscript.SigHashType,
+	sigHashes *txscript.TxSigHashes) error {
+	p := u.Upsbt
+	in := &p.Inputs[i]
+	spent := p.SpentOutput(i)
+	if in.FinalScriptSig != nil || in.FinalScriptWitness != nil ||
+		spent == nil {
+		return nil
+	}
+	script := spent.PkScript
+	if txscript.IsPayToScriptHash(script) {
+		if in.RedeemScript == nil {
+			return nil
+		}
+		script = in.RedeemScript
+	}
+	// keyScript is the script the keys are found in and signScript is the
+	// one the signature commits to
+	keyScript, signScript, witness := script, script, false
+	switch {
+	case txscript.IsPayToWitnessScriptHash(script):
+		if in.WitnessScript == nil {
+			return nil
+		}
+		keyScript, signScript, witness = in.WitnessScript, in.WitnessScript,
+			true
+	case txscript.IsPayToWitnessPubKeyHash(script):
+		// a witness public key hash is signed as if it were a pay to public
+		// key hash output
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(script,
+			w.chainParams)
+		if err != nil || len(addrs) != 1 {
+			return nil
+		}
+		pkh, err := util.NewAddressPubKeyHash(addrs[0].ScriptAddress(),
+			w.chainParams)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		if signScript, err = txscript.PayToAddrScript(pkh); err != nil {
+			log.ERROR(err)
+			return err
+		}
+		witness = true
+	}
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(keyScript, w.chainParams)
+	if err != nil {
+		return nil
+	}
+	secrets := secretSource{w.Manager, addrmgrNs}
+next:
+	for _, addr := range addrs {
+		key, compressed, err := secrets.GetKey(addr)
+		if waddrmgr.IsError(err, waddrmgr.ErrLocked) {
+			return err
+		}
+		if err != nil {
+			// keys of other signers
+			continue
+		}
+		var pubKey []byte
+		_, isPubKey := addr.(*util.AddressPubKey)
+		switch {
+		case isPubKey:
+			// the key must be serialized as it is in the script
+			pubKey = addr.ScriptAddress()
+		case compressed:
+			pubKey = key.PubKey().SerializeCompressed()
+		default:
+			pubKey = key.PubKey().SerializeUncompressed()
+		}
+		for _, ps := range in.PartialSigs {
+			if bytes.Equal(ps.PubKey, pubKey) {
+				continue next
+			}
+		}
+		var sig []byte
+		if witness {
+			sig, err = txscript.RawTxInWitnessSignature(p.UnsignedTx,
+				sigHashes, i, spent.Value, signScript, hashType, key)
+		} else {
+			sig, err = txscript.RawTxInSignature(p.UnsignedTx, i, signScript,
+				hashType, key)
+		}
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		if err = u.Sign(i, sig, pubKey, nil, nil); err != nil {
+			log.ERROR(err)
+			return fmt.Errorf("input %d: %v", i, err)
+		}
+	}
+	return nil
+}
@@ -77,7 +77,7 @@ func (rm *RecoveryManager) Resurrect(ns walletdb.ReadBucket,
 		// deriving each address and adding it to the external branch
 		// recovery state's set of addresses to look for.
 		for i := uint32(0); i < externalCount; i++ {
-			keyPath := externalKeyPath(i)
+			keyPath := externalKeyPath(waddrmgr.DefaultAccountNum, i)
 			addr, err := scopedMgr.DeriveFromKeyPath(ns, keyPath)
 			if err != nil && err != hdkeychain.ErrInvalidChild {
 				return err
@@ -94,7 +94,7 @@ func (rm *RecoveryManager) Resurrect(ns walletdb.ReadBucket,
 		// deriving each address and adding it to the internal branch
 		// recovery state's set of addresses to look for.
 		for i := uint32(0); i < internalCount; i++ {
-			keyPath := internalKeyPath(i)
+			keyPath := internalKeyPath(waddrmgr.DefaultAccountNum, i)
 			addr, err := scopedMgr.DeriveFromKeyPath(ns, keyPath)
 			if err != nil && err != hdkeychain.ErrInvalidChild {
 				return err
@@ -1,6 +1,8 @@
 package wallet
 
 import (
+	"sync/atomic"
+
 	tm "github.com/p9c/pod/pkg/chain/tx/mgr"
 	txs "github.com/p9c/pod/pkg/chain/tx/script"
 	"github.com/p9c/pod/pkg/chain/wire"
@@ -8,19 +10,27 @@ import (
 	"github.com/p9c/pod/pkg/util"
 	wm "github.com/p9c/pod/pkg/wallet/addrmgr"
 	"github.com/p9c/pod/pkg/wallet/chain"
+	walletdb "github.com/p9c/pod/pkg/wallet/db"
 )
 
+// lastRescanJob is the ID of the last rescan job submitted, it is used
+// atomically.
+var lastRescanJob uint64
+
 // RescanProgressMsg reports the current progress made by a rescan for a
-// set of wallet addresses.
+// set of wallet addresses, and the IDs of the jobs the rescan is for.
 type RescanProgressMsg struct {
 	Addresses    []util.Address
+	Jobs         []uint64
 	Notification *chain.RescanProgress
 }
 
 // RescanFinishedMsg reports the addresses that were rescanned when a
-// rescanfinished message was received rescanning a batch of addresses.
+// rescanfinished message was received rescanning a batch of addresses, and the
+// IDs of the jobs the rescan was for.
 type RescanFinishedMsg struct {
 	Addresses    []util.Address
+	Jobs         []uint64
 	Notification *chain.RescanFinished
 }
 
@@ -35,6 +45,9 @@ type RescanJob struct {
 	OutPoints   map[wire.OutPoint]util.Address
 	BlockStamp  wm.BlockStamp
 	err         chan error
+	// id tells the notifications of the rescan of the job apart from those
+	// of other rescans.
+	id uint64
 }
 
 // rescanBatch is a collection of one or more RescanJobs that were merged
@@ -45,6 +58,7 @@ type rescanBatch struct {
 	outpoints   map[wire.OutPoint]util.Address
 	bs          wm.BlockStamp
 	errChans    []chan error
+	jobs        []uint64
 }
 
 // SubmitRescan submits a RescanJob to the RescanManager.  A channel is
@@ -53,6 +67,7 @@ type rescanBatch struct {
 func (w *Wallet) SubmitRescan(job *RescanJob) <-chan error {
 	errChan := make(chan error, 1)
 	job.err = errChan
+	job.id = atomic.AddUint64(&lastRescanJob, 1)
 	w.rescanAddJob <- job
 	return errChan
 }
@@ -65,6 +80,7 @@ func (job *RescanJob) batch() *rescanBatch {
 		outpoints:   job.OutPoints,
 		bs:          job.BlockStamp,
 		errChans:    []chan error{job.err},
+		jobs:        []uint64{job.id},
 	}
 }
 
@@ -83,6 +99,7 @@ func (b *rescanBatch) merge(job *RescanJob) {
 		b.bs = job.BlockStamp
 	}
 	b.errChans = append(b.errChans, job.err)
+	b.jobs = append(b.jobs, job.id)
 }
 
 // done iterates through all error channels, duplicating sending the error
@@ -129,6 +146,7 @@ out:
 				}
 				w.rescanProgress <- &RescanProgressMsg{
 					Addresses:    curBatch.addrs,
+					Jobs:         curBatch.jobs,
 					Notification: n,
 				}
 			case *chain.RescanFinished:
@@ -140,6 +158,7 @@ out:
 				}
 				w.rescanFinished <- &RescanFinishedMsg{
 					Addresses:    curBatch.addrs,
+					Jobs:         curBatch.jobs,
 					Notification: n,
 				}
 				curBatch, nextBatch = nextBatch, nil
@@ -170,6 +189,8 @@ out:
 				"rescanned through block %v (height %d)",
 				n.Hash, n.Height,
 			)
+			w.NtfnServer.notifyRescanProgress(n.Hash, n.Height, n.Time,
+				msg.Jobs, false)
 		case msg := <-w.rescanFinished:
 			n := msg.Notification
 			addrs := msg.Addresses
@@ -178,6 +199,8 @@ out:
 				"finished rescan for %d %s (synced to block %s, height %d)",
 				len(addrs), noun, n.Hash, n.Height,
 			)
+			w.NtfnServer.notifyRescanProgress(n.Hash, n.Height, n.Time,
+				msg.Jobs, true)
 			go w.resendUnminedTxs()
 		case <-quit:
 			break out
@@ -232,6 +255,58 @@ func (w *Wallet) Rescan(addrs []util.Address, unspent []tm.Credit) error {
 	return w.rescanWithTarget(addrs, unspent, nil)
 }
 
+// SubmitRescanFromHeight submits a rescan of all active addresses and unspent
+// outputs of the wallet starting at the block at the height.  The ID of the
+// job is returned to pick out the RescanNotifications of the rescan and, as
+// with SubmitRescan, a channel with the final error of the rescan.
+func (w *Wallet) SubmitRescanFromHeight(height int32) (uint64, <-chan error,
+	error) {
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		log.ERROR(err)
+		return 0, nil, err
+	}
+	hash, err := chainClient.GetBlockHash(int64(height))
+	if err != nil {
+		log.ERROR(err)
+		return 0, nil, err
+	}
+	var (
+		addrs   []util.Address
+		unspent []tm.Credit
+	)
+	err = walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+		var err error
+		addrs, unspent, err = w.activeData(dbtx)
+		return err
+	})
+	if err != nil {
+		log.ERROR(err)
+		return 0, nil, err
+	}
+	outpoints := make(map[wire.OutPoint]util.Address, len(unspent))
+	for _, output := range unspent {
+		_, outputAddrs, _, err := txs.ExtractPkScriptAddrs(
+			output.PkScript, w.chainParams,
+		)
+		if err != nil {
+			log.ERROR(err)
+			return 0, nil, err
+		}
+		outpoints[output.OutPoint] = outputAddrs[0]
+	}
+	job := &RescanJob{
+		Addrs:     addrs,
+		OutPoints: outpoints,
+		BlockStamp: wm.BlockStamp{
+			Hash:   *hash,
+			Height: height,
+		},
+	}
+	errChan := w.SubmitRescan(job)
+	return job.id, errChan, nil
+}
+
 // rescanWithTarget performs a rescan starting at the optional startStamp. If
 // none is provided, the rescan will begin from the manager's sync tip.
 func (w *Wallet) rescanWithTarget(addrs []util.Address,
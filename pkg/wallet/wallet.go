@@ -57,6 +57,7 @@ var ErrNotSynced = errors.New("wallet is not synchronized with the chain server"
 var (
 	waddrmgrNamespaceKey = []byte("waddrmgr")
 	wtxmgrNamespaceKey   = []byte("wtxmgr")
+	wlabelsNamespaceKey  = []byte("wlabels")
 )
 
 // Wallet is a structure containing all the components for a
@@ -424,6 +425,8 @@ func (w *Wallet) syncWithChain() error {
 				switch c := chainClient.(type) {
 				case *chain.NeutrinoClient:
 					return c.CS.IsCurrent()
+				case *chain.NodeClient:
+					return c.IsCurrent()
 				}
 				return bestHeight >= checkHeight
 			}
@@ -686,10 +689,11 @@ func (w *Wallet) recoverDefaultScopes(
 	}
 	return w.recoverScopedAddresses(
 		chainClient, tx, ns, batch, recoveryState, scopedMgrs,
+		waddrmgr.DefaultAccountNum,
 	)
 }
 
-// recoverAccountAddresses scans a range of blocks in attempts to recover any
+// recoverScopedAddresses scans a range of blocks in attempts to recover any
 // previously used addresses for a particular account derivation path. At a high
 // level, the algorithm works as follows:
 //  1) Ensure internal and external branch horizons are fully expanded.
@@ -705,7 +709,8 @@ func (w *Wallet) recoverScopedAddresses(
 	ns walletdb.ReadWriteBucket,
 	batch []wtxmgr.BlockMeta,
 	recoveryState *RecoveryState,
-	scopedMgrs map[waddrmgr.KeyScope]*waddrmgr.ScopedKeyManager) error {
+	scopedMgrs map[waddrmgr.KeyScope]*waddrmgr.ScopedKeyManager,
+	account uint32) error {
 	// If there are no blocks in the batch, we are done.
 	if len(batch) == 0 {
 		return nil
@@ -717,7 +722,7 @@ func (w *Wallet) recoverScopedAddresses(
 expandHorizons:
 	for scope, scopedMgr := range scopedMgrs {
 		scopeState := recoveryState.StateForScope(scope)
-		err := expandScopeHorizons(ns, scopedMgr, scopeState)
+		err := expandScopeHorizons(ns, scopedMgr, scopeState, account)
 		if err != nil {
 			log.ERROR(err)
 			return err
@@ -752,7 +757,9 @@ expandHorizons:
 	// last-found index of either will result in the horizons being expanded
 	// upon the next iteration. Any found addresses are also marked used
 	// using the scoped key manager.
-	err = extendFoundAddresses(ns, filterResp, scopedMgrs, recoveryState)
+	err = extendFoundAddresses(
+		ns, filterResp, scopedMgrs, recoveryState, account,
+	)
 	if err != nil {
 		log.ERROR(err)
 		return err
@@ -798,14 +805,14 @@ expandHorizons:
 // proper number of valid child keys.
 func expandScopeHorizons(ns walletdb.ReadWriteBucket,
 	scopedMgr *waddrmgr.ScopedKeyManager,
-	scopeState *ScopeRecoveryState) error {
+	scopeState *ScopeRecoveryState, account uint32) error {
 	// Compute the current external horizon and the number of addresses we
 	// must derive to ensure we maintain a sufficient recovery window for
 	// the external branch.
 	exHorizon, exWindow := scopeState.ExternalBranch.ExtendHorizon()
 	count, childIndex := uint32(0), exHorizon
 	for count < exWindow {
-		keyPath := externalKeyPath(childIndex)
+		keyPath := externalKeyPath(account, childIndex)
 		addr, err := scopedMgr.DeriveFromKeyPath(ns, keyPath)
 		switch {
 		case err == hdkeychain.ErrInvalidChild:
@@ -831,7 +838,7 @@ func expandScopeHorizons(ns walletdb.ReadWriteBucket,
 	inHorizon, inWindow := scopeState.InternalBranch.ExtendHorizon()
 	count, childIndex = 0, inHorizon
 	for count < inWindow {
-		keyPath := internalKeyPath(childIndex)
+		keyPath := internalKeyPath(account, childIndex)
 		addr, err := scopedMgr.DeriveFromKeyPath(ns, keyPath)
 		switch {
 		case err == hdkeychain.ErrInvalidChild:
@@ -854,19 +861,21 @@ func expandScopeHorizons(ns walletdb.ReadWriteBucket,
 	return nil
 }
 
-// externalKeyPath returns the relative external derivation path /0/0/index.
-func externalKeyPath(index uint32) waddrmgr.DerivationPath {
+// externalKeyPath returns the relative external derivation path
+// /account/0/index.
+func externalKeyPath(account, index uint32) waddrmgr.DerivationPath {
 	return waddrmgr.DerivationPath{
-		Account: waddrmgr.DefaultAccountNum,
+		Account: account,
 		Branch:  waddrmgr.ExternalBranch,
 		Index:   index,
 	}
 }
 
-// internalKeyPath returns the relative internal derivation path /0/1/index.
-func internalKeyPath(index uint32) waddrmgr.DerivationPath {
+// internalKeyPath returns the relative internal derivation path
+// /account/1/index.
+func internalKeyPath(account, index uint32) waddrmgr.DerivationPath {
 	return waddrmgr.DerivationPath{
-		Account: waddrmgr.DefaultAccountNum,
+		Account: account,
 		Branch:  waddrmgr.InternalBranch,
 		Index:   index,
 	}
@@ -911,7 +920,7 @@ func newFilterBlocksRequest(batch []wtxmgr.BlockMeta,
 func extendFoundAddresses(ns walletdb.ReadWriteBucket,
 	filterResp *chain.FilterBlocksResponse,
 	scopedMgrs map[waddrmgr.KeyScope]*waddrmgr.ScopedKeyManager,
-	recoveryState *RecoveryState) error {
+	recoveryState *RecoveryState, account uint32) error {
 	// Mark all recovered external addresses as used. This will be done only
 	// for scopes that reported a non-zero number of external addresses in
 	// this block.
@@ -933,7 +942,7 @@ func extendFoundAddresses(ns walletdb.ReadWriteBucket,
 			exLastFound--
 		}
 		err := scopedMgr.ExtendExternalAddresses(
-			ns, waddrmgr.DefaultAccountNum, exLastFound,
+			ns, account, exLastFound,
 		)
 		if err != nil {
 			log.ERROR(err)
@@ -972,7 +981,7 @@ func extendFoundAddresses(ns walletdb.ReadWriteBucket,
 			inLastFound--
 		}
 		err := scopedMgr.ExtendInternalAddresses(
-			ns, waddrmgr.DefaultAccountNum, inLastFound,
+			ns, account, inLastFound,
 		)
 		if err != nil {
 			log.ERROR(err)
@@ -1035,6 +1044,7 @@ type (
 		outputs     []*wire.TxOut
 		minconf     int32
 		feeSatPerKB util.Amount
+		coinControl *CoinControl
 		resp        chan createTxResponse
 	}
 	createTxResponse struct {
@@ -1066,7 +1076,7 @@ out:
 				continue
 			}
 			tx, err := w.txToOutputs(txr.outputs, txr.account,
-				txr.minconf, txr.feeSatPerKB)
+				txr.minconf, txr.feeSatPerKB, txr.coinControl)
 			heldUnlock.release()
 			txr.resp <- createTxResponse{tx, err}
 		case <-quit:
@@ -1079,16 +1089,19 @@ out:
 // CreateSimpleTx creates a new signed transaction spending unspent P2PKH
 // outputs with at laest minconf confirmations spending to any number of
 // address/amount pairs.  Change and an appropriate transaction fee are
-// automatically included, if necessary.  All transaction creation through this
-// function is serialized to prevent the creation of many transactions which
-// spend the same outputs.
+// automatically included, if necessary.  The coin control options, if not nil,
+// direct which outputs are spent and where change goes.  All transaction
+// creation through this function is serialized to prevent the creation of many
+// transactions which spend the same outputs.
 func (w *Wallet) CreateSimpleTx(account uint32, outputs []*wire.TxOut,
-	minconf int32, satPerKb util.Amount) (*txauthor.AuthoredTx, error) {
+	minconf int32, satPerKb util.Amount,
+	coinControl *CoinControl) (*txauthor.AuthoredTx, error) {
 	req := createTxRequest{
 		account:     account,
 		outputs:     outputs,
 		minconf:     minconf,
 		feeSatPerKB: satPerKb,
+		coinControl: coinControl,
 		resp:        make(chan createTxResponse),
 	}
 	w.createTxRequests <- req
@@ -1512,6 +1525,28 @@ func (w *Wallet) PrivKeyForAddress(a util.Address) (*ec.PrivateKey, error) {
 	return privKey, err
 }
 
+// SignMessage signs a message with the private key of a P2PKH or P2PK
+// address, returning the compact signature the verifymessage command recovers
+// the address from.
+func (w *Wallet) SignMessage(a util.Address, message string) ([]byte, error) {
+	privKey, err := w.PrivKeyForAddress(a)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = wire.WriteVarString(&buf, 0, "Bitcoin Signed Message:\n"); err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	if err = wire.WriteVarString(&buf, 0, message); err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	messageHash := chainhash.DoubleHashB(buf.Bytes())
+	return ec.SignCompact(ec.S256(), privKey, messageHash, true)
+}
+
 // HaveAddress returns whether the wallet is the owner of the address a.
 func (w *Wallet) HaveAddress(a util.Address) (bool, error) {
 	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
@@ -1754,6 +1789,10 @@ func listTransactions(tx walletdb.ReadTx, details *wtxmgr.TxDetails, addrMgr *wa
 		// is calculated.
 		feeF64 = (outputTotal - debitTotal).ToDUO()
 	}
+	var comment, commentTo string
+	if label := fetchTxLabel(tx, &details.Hash); label != nil {
+		comment, commentTo = label.Comment, label.CommentTo
+	}
 outputs:
 	for i, output := range details.MsgTx.TxOut {
 		// Determine if this output is a credit, and if so, determine
@@ -1773,9 +1812,11 @@ outputs:
 		}
 		var address string
 		var accountName string
+		var outputAddr util.Address
 		_, addrs, _, _ := txscript.ExtractPkScriptAddrs(output.PkScript, net)
 		if len(addrs) == 1 {
 			addr := addrs[0]
+			outputAddr = addr
 			address = addr.EncodeAddress()
 			mgr, account, err := addrMgr.AddrAccount(addrmgrNs, addrs[0])
 			if err == nil {
@@ -1807,6 +1848,10 @@ outputs:
 			WalletConflicts: []string{},
 			Time:            received,
 			TimeReceived:    received,
+			Comment:         comment,
+			To:              commentTo,
+			Label: fetchOutputLabel(tx, wire.NewOutPoint(&details.Hash,
+				uint32(i)), outputAddr),
 		}
 		// Add a received/generated/immature result if this is a credit.
 		// If the output was spent, create a second result under the
@@ -2039,6 +2084,13 @@ func (w *Wallet) GetTransactions(startBlock, endBlock *BlockIdentifier, cancel <
 					log.ERROR(err)
 					return nil, err
 				}
+			case *chain.NodeClient:
+				var err error
+				start, err = client.GetBlockHeight(startBlock.hash)
+				if err != nil {
+					log.ERROR(err)
+					return nil, err
+				}
 			}
 		}
 	}
@@ -2059,6 +2111,13 @@ func (w *Wallet) GetTransactions(startBlock, endBlock *BlockIdentifier, cancel <
 					log.ERROR(err)
 					return nil, err
 				}
+			case *chain.NodeClient:
+				var err error
+				end, err = client.GetBlockHeight(endBlock.hash)
+				if err != nil {
+					log.ERROR(err)
+					return nil, err
+				}
 			}
 		}
 	}
@@ -2973,9 +3032,12 @@ func (w *Wallet) TotalReceivedForAddr(addr util.Address, minConf int32) (util.Am
 }
 
 // SendOutputs creates and sends payment transactions. It returns the
-// transaction hash upon success.
+// transaction hash upon success. The coin control options, if not nil, direct
+// which outputs are spent and where change goes. If label is not nil it is
+// saved with the transaction.
 func (w *Wallet) SendOutputs(outputs []*wire.TxOut, account uint32,
-	minconf int32, satPerKb util.Amount) (*chainhash.Hash, error) {
+	minconf int32, satPerKb util.Amount, coinControl *CoinControl,
+	label *TxLabel) (*chainhash.Hash, error) {
 	// Ensure the outputs to be created adhere to the network's consensus
 	// rules.
 	for _, output := range outputs {
@@ -2987,12 +3049,13 @@ func (w *Wallet) SendOutputs(outputs []*wire.TxOut, account uint32,
 	// transaction will be added to the database in order to ensure that we
 	// continue to re-broadcast the transaction upon restarts until it has
 	// been confirmed.
-	createdTx, err := w.CreateSimpleTx(account, outputs, minconf, satPerKb)
+	createdTx, err := w.CreateSimpleTx(account, outputs, minconf, satPerKb,
+		coinControl)
 	if err != nil {
 		log.ERROR(err)
 		return nil, err
 	}
-	return w.publishTransaction(createdTx.Tx)
+	return w.publishTransaction(createdTx.Tx, label)
 }
 
 // SignatureError records the underlying error when validating a transaction
@@ -3134,7 +3197,7 @@ func (w *Wallet) SignTransaction(tx *wire.MsgTx, hashType txscript.SigHashType,
 // This function is unstable and will be removed once syncing code is moved out
 // of the wallet.
 func (w *Wallet) PublishTransaction(tx *wire.MsgTx) error {
-	_, err := w.publishTransaction(tx)
+	_, err := w.publishTransaction(tx, nil)
 	return err
 }
 
@@ -3142,8 +3205,11 @@ func (w *Wallet) PublishTransaction(tx *wire.MsgTx) error {
 // contains the primary logic required for publishing a transaction, updating
 // the relevant database state, and finally possible removing the transaction
 // from the database (along with cleaning up all inputs used, and outputs
-// created) if the transaction is rejected by the back end.
-func (w *Wallet) publishTransaction(tx *wire.MsgTx) (*chainhash.Hash, error) {
+// created) if the transaction is rejected by the back end. The label, if not
+// nil, is saved with the transaction before it is added so the notification
+// of the new transaction includes it.
+func (w *Wallet) publishTransaction(tx *wire.MsgTx,
+	label *TxLabel) (*chainhash.Hash, error) {
 	server, err := w.requireChainClient()
 	if err != nil {
 		log.ERROR(err)
@@ -3159,6 +3225,13 @@ func (w *Wallet) publishTransaction(tx *wire.MsgTx) (*chainhash.Hash, error) {
 		return nil, err
 	}
 	err = walletdb.Update(w.db, func(dbTx walletdb.ReadWriteTx) error {
+		if label != nil {
+			err := putTxLabel(dbTx, &txRec.Hash, label)
+			if err != nil {
+				log.ERROR(err)
+				return err
+			}
+		}
 		return w.addRelevantTx(dbTx, txRec, nil)
 	})
 	if err != nil {
@@ -3188,6 +3261,13 @@ func (w *Wallet) publishTransaction(tx *wire.MsgTx) (*chainhash.Hash, error) {
 		// accurate.
 		dbErr := walletdb.Update(w.db, func(dbTx walletdb.ReadWriteTx) error {
 			txmgrNs := dbTx.ReadWriteBucket(wtxmgrNamespaceKey)
+			if label != nil {
+				err := putTxLabel(dbTx, &txRec.Hash, nil)
+				if err != nil {
+					log.ERROR(err)
+					return err
+				}
+			}
 			return w.TxStore.RemoveUnminedTx(txmgrNs, txRec)
 		})
 		if dbErr != nil {
@@ -3253,7 +3333,12 @@ func Create(db walletdb.DB, pubPass, privPass, seed []byte, params *netparams.Pa
 			log.ERROR(err)
 			return err
 		}
-		return wtxmgr.Create(txmgrNs)
+		err = wtxmgr.Create(txmgrNs)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		return createLabelsNamespace(tx)
 	})
 }
 
@@ -3293,6 +3378,12 @@ func Open(db walletdb.DB, pubPass []byte, cbs *waddrmgr.OpenCallbacks,
 		log.ERROR(err)
 		return nil, err
 	}
+	// Wallets created before labels were saved don't have their namespace.
+	err = walletdb.Update(db, createLabelsNamespace)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
 	// Open database abstraction instances
 	var (
 		addrMgr *waddrmgr.Manager
@@ -0,0 +1,210 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/p9c/pod/pkg/log"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+	walletdb "github.com/p9c/pod/pkg/wallet/db"
+)
+
+const (
+	// accountPubKeyDepth is the depth of an account extended key, which is
+	// derived from the master key at m/purpose'/coin'/account'.
+	accountPubKeyDepth = 3
+	// DefaultGapLimit is the number of unused addresses in a row after
+	// which address discovery of a watch-only account stops, as in BIP0044.
+	DefaultGapLimit = 20
+)
+
+// Errors returned when an extended public key can't be imported as a
+// watch-only account.
+var (
+	ErrXpubPrivate = errors.New("extended key is private, give the " +
+		"extended public key of the account")
+	ErrXpubNotAccount = errors.New("extended public key is not an " +
+		"account key at depth 3 (m/purpose'/coin'/account')")
+	ErrXpubWrongNet = errors.New("extended public key is for a different " +
+		"network")
+)
+
+// ImportAccountWatchingOnly creates a watch-only account from the extended
+// public key of an account in a cold storage wallet. The account tracks the
+// addresses derived from the key and its outputs can be spent with FundPsbt,
+// but the PSBTs have to be signed by the wallet holding the private key.
+//
+// When rescan is true the chain is searched from the block stamp, or the
+// genesis block if it is nil, for addresses of the account that were used
+// using the same recovery as a restored seed, so the search continues until
+// gapLimit addresses in a row are unused, DefaultGapLimit if it is zero.
+// The addresses found are then rescanned for in the background.
+func (w *Wallet) ImportAccountWatchingOnly(scope waddrmgr.KeyScope,
+	name string, xpub *hdkeychain.ExtendedKey, bs *waddrmgr.BlockStamp,
+	rescan bool, gapLimit uint32) (*waddrmgr.AccountProperties, error) {
+	switch {
+	case xpub.IsPrivate():
+		return nil, ErrXpubPrivate
+	case xpub.Depth() != accountPubKeyDepth:
+		return nil, ErrXpubNotAccount
+	case !xpub.IsForNet(w.chainParams):
+		return nil, ErrXpubWrongNet
+	}
+	manager, err := w.Manager.FetchScopedKeyManager(scope)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	// The starting block for the account is the genesis block unless
+	// otherwise specified.
+	var newBirthday time.Time
+	if bs == nil {
+		bs = &waddrmgr.BlockStamp{
+			Hash:   *w.chainParams.GenesisHash,
+			Height: 0,
+		}
+	} else {
+		// Only update the new birthday time from default value if we
+		// actually have timestamp info in the header.
+		header, err := w.chainClient.GetBlockHeader(&bs.Hash)
+		if err == nil {
+			newBirthday = header.Timestamp
+		}
+	}
+	var props *waddrmgr.AccountProperties
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		account, err := manager.NewAccountWatchingOnly(addrmgrNs, name, xpub)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		props, err = manager.AccountProperties(addrmgrNs, account)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		if !rescan {
+			return nil
+		}
+		return w.Manager.SetBirthday(addrmgrNs, newBirthday)
+	})
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	log.INFOF("imported watch-only account %q in scope %v", name, &scope)
+	w.NtfnServer.notifyAccountProperties(props)
+	if !rescan {
+		return props, nil
+	}
+	if gapLimit == 0 {
+		gapLimit = DefaultGapLimit
+	}
+	err = w.discoverAccountAddresses(
+		manager, props.AccountNumber, bs.Height, gapLimit,
+	)
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	var addrs []util.Address
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		props, err = manager.AccountProperties(addrmgrNs, props.AccountNumber)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		return manager.ForEachAccountAddress(addrmgrNs, props.AccountNumber,
+			func(maddr waddrmgr.ManagedAddress) error {
+				addrs = append(addrs, maddr.Address())
+				return nil
+			})
+	})
+	if err != nil {
+		log.ERROR(err)
+		return nil, err
+	}
+	w.NtfnServer.notifyAccountProperties(props)
+	if len(addrs) == 0 {
+		return props, nil
+	}
+	// Rescan for the discovered addresses so spends of their outputs are
+	// found and the chain client watches them from now on. Do not block on
+	// finishing the rescan, it is logged elsewhere.
+	_ = w.SubmitRescan(&RescanJob{
+		Addrs:      addrs,
+		BlockStamp: *bs,
+	})
+	return props, nil
+}
+
+// discoverAccountAddresses searches the blocks from startHeight to the best
+// block for used addresses of an account with a recovery window of gapLimit,
+// extending the account through the last used address of each branch and
+// recording the transactions found.
+func (w *Wallet) discoverAccountAddresses(
+	scopedMgr *waddrmgr.ScopedKeyManager, account uint32, startHeight int32,
+	gapLimit uint32) error {
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		log.ERROR(err)
+		return err
+	}
+	_, bestHeight, err := chainClient.GetBestBlock()
+	if err != nil {
+		log.ERROR(err)
+		return err
+	}
+	log.INFOF(
+		"discovering addresses of account %d from height %d to %d with a gap limit of %d",
+		account, startHeight, bestHeight, gapLimit,
+	)
+	scopedMgrs := map[waddrmgr.KeyScope]*waddrmgr.ScopedKeyManager{
+		scopedMgr.Scope(): scopedMgr,
+	}
+	recoveryMgr := NewRecoveryManager(
+		gapLimit, recoveryBatchSize, w.chainParams,
+	)
+	recoverBatch := func() error {
+		err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			return w.recoverScopedAddresses(
+				chainClient, tx, ns, recoveryMgr.BlockBatch(),
+				recoveryMgr.State(), scopedMgrs, account,
+			)
+		})
+		recoveryMgr.ResetBlockBatch()
+		return err
+	}
+	for height := startHeight; height <= bestHeight; height++ {
+		select {
+		case <-w.quitChan():
+			return fmt.Errorf("wallet shutting down")
+		default:
+		}
+		hash, err := chainClient.GetBlockHash(int64(height))
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		header, err := chainClient.GetBlockHeader(hash)
+		if err != nil {
+			log.ERROR(err)
+			return err
+		}
+		recoveryMgr.AddToBlockBatch(hash, height, header.Timestamp)
+		if height%recoveryBatchSize == 0 {
+			if err := recoverBatch(); err != nil {
+				log.ERROR(err)
+				return err
+			}
+		}
+	}
+	// Search the blocks that did not fill a whole batch.
+	return recoverBatch()
+}